@@ -0,0 +1,1535 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: api/proto/admin.proto
+
+package adminv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListReviewQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                                    // Optional, "pending_review" or "risk_hold". Empty means both
+	Chain         string                 `protobuf:"bytes,2,opt,name=chain,proto3" json:"chain,omitempty"`                                      // Optional, e.g., "ETH", "BTC"
+	MinAmount     string                 `protobuf:"bytes,3,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`             // Optional, decimal string, inclusive
+	MaxAmount     string                 `protobuf:"bytes,4,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`             // Optional, decimal string, inclusive
+	MinRiskScore  int32                  `protobuf:"varint,5,opt,name=min_risk_score,json=minRiskScore,proto3" json:"min_risk_score,omitempty"` // Optional, inclusive, 0 means no lower bound
+	MaxRiskScore  int32                  `protobuf:"varint,6,opt,name=max_risk_score,json=maxRiskScore,proto3" json:"max_risk_score,omitempty"` // Optional, inclusive, 0 means no upper bound
+	Assignee      string                 `protobuf:"bytes,7,opt,name=assignee,proto3" json:"assignee,omitempty"`                                // Optional, "mine" or "unassigned". Empty means all
+	Cursor        string                 `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`                                    // next_cursor from the previous page, empty for the first page
+	Limit         int32                  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`                                     // Default 50, max 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewQueueRequest) Reset() {
+	*x = ListReviewQueueRequest{}
+	mi := &file_api_proto_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewQueueRequest) ProtoMessage() {}
+
+func (x *ListReviewQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewQueueRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewQueueRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListReviewQueueRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListReviewQueueRequest) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *ListReviewQueueRequest) GetMinAmount() string {
+	if x != nil {
+		return x.MinAmount
+	}
+	return ""
+}
+
+func (x *ListReviewQueueRequest) GetMaxAmount() string {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return ""
+}
+
+func (x *ListReviewQueueRequest) GetMinRiskScore() int32 {
+	if x != nil {
+		return x.MinRiskScore
+	}
+	return 0
+}
+
+func (x *ListReviewQueueRequest) GetMaxRiskScore() int32 {
+	if x != nil {
+		return x.MaxRiskScore
+	}
+	return 0
+}
+
+func (x *ListReviewQueueRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *ListReviewQueueRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListReviewQueueRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListReviewQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Withdrawal          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty when there are no more pages
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewQueueResponse) Reset() {
+	*x = ListReviewQueueResponse{}
+	mi := &file_api_proto_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewQueueResponse) ProtoMessage() {}
+
+func (x *ListReviewQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewQueueResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewQueueResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListReviewQueueResponse) GetItems() []*Withdrawal {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListReviewQueueResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type Withdrawal struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId            int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Chain             string                 `protobuf:"bytes,3,opt,name=chain,proto3" json:"chain,omitempty"`
+	ToAddress         string                 `protobuf:"bytes,4,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	Amount            string                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"` // String for precision
+	Status            string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	RiskScore         int32                  `protobuf:"varint,7,opt,name=risk_score,json=riskScore,proto3" json:"risk_score,omitempty"`
+	RiskReasons       []string               `protobuf:"bytes,8,rep,name=risk_reasons,json=riskReasons,proto3" json:"risk_reasons,omitempty"`
+	RequiredApprovals int32                  `protobuf:"varint,9,opt,name=required_approvals,json=requiredApprovals,proto3" json:"required_approvals,omitempty"`
+	CurrentApprovals  int32                  `protobuf:"varint,10,opt,name=current_approvals,json=currentApprovals,proto3" json:"current_approvals,omitempty"`
+	AssigneeId        int64                  `protobuf:"varint,11,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`               // 0 if unclaimed or the claim expired
+	ClaimExpiresAt    int64                  `protobuf:"varint,12,opt,name=claim_expires_at,json=claimExpiresAt,proto3" json:"claim_expires_at,omitempty"` // Unix seconds, 0 if unclaimed
+	TxHash            string                 `protobuf:"bytes,13,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	ExecuteAfter      int64                  `protobuf:"varint,14,opt,name=execute_after,json=executeAfter,proto3" json:"execute_after,omitempty"` // Unix seconds, 0 if none
+	CreatedAt         int64                  `protobuf:"varint,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`          // Unix seconds
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Withdrawal) Reset() {
+	*x = Withdrawal{}
+	mi := &file_api_proto_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Withdrawal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Withdrawal) ProtoMessage() {}
+
+func (x *Withdrawal) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Withdrawal.ProtoReflect.Descriptor instead.
+func (*Withdrawal) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Withdrawal) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetToAddress() string {
+	if x != nil {
+		return x.ToAddress
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetRiskScore() int32 {
+	if x != nil {
+		return x.RiskScore
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetRiskReasons() []string {
+	if x != nil {
+		return x.RiskReasons
+	}
+	return nil
+}
+
+func (x *Withdrawal) GetRequiredApprovals() int32 {
+	if x != nil {
+		return x.RequiredApprovals
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetCurrentApprovals() int32 {
+	if x != nil {
+		return x.CurrentApprovals
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetAssigneeId() int64 {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetClaimExpiresAt() int64 {
+	if x != nil {
+		return x.ClaimExpiresAt
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetExecuteAfter() int64 {
+	if x != nil {
+		return x.ExecuteAfter
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type GetWithdrawalDetailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId  int64                  `protobuf:"varint,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWithdrawalDetailRequest) Reset() {
+	*x = GetWithdrawalDetailRequest{}
+	mi := &file_api_proto_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWithdrawalDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWithdrawalDetailRequest) ProtoMessage() {}
+
+func (x *GetWithdrawalDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWithdrawalDetailRequest.ProtoReflect.Descriptor instead.
+func (*GetWithdrawalDetailRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetWithdrawalDetailRequest) GetWithdrawalId() int64 {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return 0
+}
+
+type GetWithdrawalDetailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Withdrawal    *Withdrawal            `protobuf:"bytes,1,opt,name=withdrawal,proto3" json:"withdrawal,omitempty"`
+	Reviews       []*Review              `protobuf:"bytes,2,rep,name=reviews,proto3" json:"reviews,omitempty"` // Oldest first
+	Activity      *UserActivity          `protobuf:"bytes,3,opt,name=activity,proto3" json:"activity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWithdrawalDetailResponse) Reset() {
+	*x = GetWithdrawalDetailResponse{}
+	mi := &file_api_proto_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWithdrawalDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWithdrawalDetailResponse) ProtoMessage() {}
+
+func (x *GetWithdrawalDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWithdrawalDetailResponse.ProtoReflect.Descriptor instead.
+func (*GetWithdrawalDetailResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetWithdrawalDetailResponse) GetWithdrawal() *Withdrawal {
+	if x != nil {
+		return x.Withdrawal
+	}
+	return nil
+}
+
+func (x *GetWithdrawalDetailResponse) GetReviews() []*Review {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+func (x *GetWithdrawalDetailResponse) GetActivity() *UserActivity {
+	if x != nil {
+		return x.Activity
+	}
+	return nil
+}
+
+type Review struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminId       int64                  `protobuf:"varint,1,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"`
+	AdminUsername string                 `protobuf:"bytes,2,opt,name=admin_username,json=adminUsername,proto3" json:"admin_username,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"` // "approve" or "reject"
+	Remark        string                 `protobuf:"bytes,4,opt,name=remark,proto3" json:"remark,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Review) Reset() {
+	*x = Review{}
+	mi := &file_api_proto_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Review) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Review) ProtoMessage() {}
+
+func (x *Review) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Review.ProtoReflect.Descriptor instead.
+func (*Review) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Review) GetAdminId() int64 {
+	if x != nil {
+		return x.AdminId
+	}
+	return 0
+}
+
+func (x *Review) GetAdminUsername() string {
+	if x != nil {
+		return x.AdminUsername
+	}
+	return ""
+}
+
+func (x *Review) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Review) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+func (x *Review) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type UserActivity struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username          string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email             string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	RegisteredAt      int64                  `protobuf:"varint,4,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`                                              // Unix seconds
+	PasswordChangedAt int64                  `protobuf:"varint,5,opt,name=password_changed_at,json=passwordChangedAt,proto3" json:"password_changed_at,omitempty"`                             // Unix seconds, 0 if never changed
+	Balances          map[string]*Balance    `protobuf:"bytes,6,rep,name=balances,proto3" json:"balances,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Currency -> balance
+	RecentDeposits    []*Deposit             `protobuf:"bytes,7,rep,name=recent_deposits,json=recentDeposits,proto3" json:"recent_deposits,omitempty"`                                         // Newest first
+	RecentWithdrawals []*Withdrawal          `protobuf:"bytes,8,rep,name=recent_withdrawals,json=recentWithdrawals,proto3" json:"recent_withdrawals,omitempty"`                                // Newest first, excluding the one under review
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UserActivity) Reset() {
+	*x = UserActivity{}
+	mi := &file_api_proto_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserActivity) ProtoMessage() {}
+
+func (x *UserActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserActivity.ProtoReflect.Descriptor instead.
+func (*UserActivity) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UserActivity) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserActivity) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserActivity) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserActivity) GetRegisteredAt() int64 {
+	if x != nil {
+		return x.RegisteredAt
+	}
+	return 0
+}
+
+func (x *UserActivity) GetPasswordChangedAt() int64 {
+	if x != nil {
+		return x.PasswordChangedAt
+	}
+	return 0
+}
+
+func (x *UserActivity) GetBalances() map[string]*Balance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+func (x *UserActivity) GetRecentDeposits() []*Deposit {
+	if x != nil {
+		return x.RecentDeposits
+	}
+	return nil
+}
+
+func (x *UserActivity) GetRecentWithdrawals() []*Withdrawal {
+	if x != nil {
+		return x.RecentWithdrawals
+	}
+	return nil
+}
+
+type Balance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         string                 `protobuf:"bytes,1,opt,name=total,proto3" json:"total,omitempty"`
+	Locked        string                 `protobuf:"bytes,2,opt,name=locked,proto3" json:"locked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Balance) Reset() {
+	*x = Balance{}
+	mi := &file_api_proto_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Balance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balance) ProtoMessage() {}
+
+func (x *Balance) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balance.ProtoReflect.Descriptor instead.
+func (*Balance) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Balance) GetTotal() string {
+	if x != nil {
+		return x.Total
+	}
+	return ""
+}
+
+func (x *Balance) GetLocked() string {
+	if x != nil {
+		return x.Locked
+	}
+	return ""
+}
+
+type Deposit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Chain         string                 `protobuf:"bytes,2,opt,name=chain,proto3" json:"chain,omitempty"`
+	FromAddress   string                 `protobuf:"bytes,3,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"`
+	Amount        string                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	TxHash        string                 `protobuf:"bytes,6,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Deposit) Reset() {
+	*x = Deposit{}
+	mi := &file_api_proto_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Deposit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deposit) ProtoMessage() {}
+
+func (x *Deposit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deposit.ProtoReflect.Descriptor instead.
+func (*Deposit) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Deposit) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Deposit) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *Deposit) GetFromAddress() string {
+	if x != nil {
+		return x.FromAddress
+	}
+	return ""
+}
+
+func (x *Deposit) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Deposit) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Deposit) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Deposit) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ReviewWithdrawalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId  int64                  `protobuf:"varint,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"` // "approve" or "reject"
+	Remark        string                 `protobuf:"bytes,3,opt,name=remark,proto3" json:"remark,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewWithdrawalRequest) Reset() {
+	*x = ReviewWithdrawalRequest{}
+	mi := &file_api_proto_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewWithdrawalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewWithdrawalRequest) ProtoMessage() {}
+
+func (x *ReviewWithdrawalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewWithdrawalRequest.ProtoReflect.Descriptor instead.
+func (*ReviewWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReviewWithdrawalRequest) GetWithdrawalId() int64 {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return 0
+}
+
+func (x *ReviewWithdrawalRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ReviewWithdrawalRequest) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+type ReviewWithdrawalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Withdrawal    *Withdrawal            `protobuf:"bytes,1,opt,name=withdrawal,proto3" json:"withdrawal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewWithdrawalResponse) Reset() {
+	*x = ReviewWithdrawalResponse{}
+	mi := &file_api_proto_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewWithdrawalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewWithdrawalResponse) ProtoMessage() {}
+
+func (x *ReviewWithdrawalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewWithdrawalResponse.ProtoReflect.Descriptor instead.
+func (*ReviewWithdrawalResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReviewWithdrawalResponse) GetWithdrawal() *Withdrawal {
+	if x != nil {
+		return x.Withdrawal
+	}
+	return nil
+}
+
+type BulkReviewWithdrawalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalIds []int64                `protobuf:"varint,1,rep,packed,name=withdrawal_ids,json=withdrawalIds,proto3" json:"withdrawal_ids,omitempty"` // 1 to 100 withdrawals
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`                                            // "approve" or "reject"
+	Remark        string                 `protobuf:"bytes,3,opt,name=remark,proto3" json:"remark,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkReviewWithdrawalsRequest) Reset() {
+	*x = BulkReviewWithdrawalsRequest{}
+	mi := &file_api_proto_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkReviewWithdrawalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkReviewWithdrawalsRequest) ProtoMessage() {}
+
+func (x *BulkReviewWithdrawalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkReviewWithdrawalsRequest.ProtoReflect.Descriptor instead.
+func (*BulkReviewWithdrawalsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BulkReviewWithdrawalsRequest) GetWithdrawalIds() []int64 {
+	if x != nil {
+		return x.WithdrawalIds
+	}
+	return nil
+}
+
+func (x *BulkReviewWithdrawalsRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *BulkReviewWithdrawalsRequest) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+type BulkReviewWithdrawalsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BulkReviewResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // One per distinct withdrawal id, in request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkReviewWithdrawalsResponse) Reset() {
+	*x = BulkReviewWithdrawalsResponse{}
+	mi := &file_api_proto_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkReviewWithdrawalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkReviewWithdrawalsResponse) ProtoMessage() {}
+
+func (x *BulkReviewWithdrawalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkReviewWithdrawalsResponse.ProtoReflect.Descriptor instead.
+func (*BulkReviewWithdrawalsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BulkReviewWithdrawalsResponse) GetResults() []*BulkReviewResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BulkReviewResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId  int64                  `protobuf:"varint,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Code          int32                  `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"` // Business error code when success is false
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkReviewResult) Reset() {
+	*x = BulkReviewResult{}
+	mi := &file_api_proto_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkReviewResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkReviewResult) ProtoMessage() {}
+
+func (x *BulkReviewResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkReviewResult.ProtoReflect.Descriptor instead.
+func (*BulkReviewResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BulkReviewResult) GetWithdrawalId() int64 {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return 0
+}
+
+func (x *BulkReviewResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BulkReviewResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BulkReviewResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ClaimWithdrawalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId  int64                  `protobuf:"varint,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimWithdrawalRequest) Reset() {
+	*x = ClaimWithdrawalRequest{}
+	mi := &file_api_proto_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimWithdrawalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimWithdrawalRequest) ProtoMessage() {}
+
+func (x *ClaimWithdrawalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimWithdrawalRequest.ProtoReflect.Descriptor instead.
+func (*ClaimWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ClaimWithdrawalRequest) GetWithdrawalId() int64 {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return 0
+}
+
+type ClaimWithdrawalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Withdrawal    *Withdrawal            `protobuf:"bytes,1,opt,name=withdrawal,proto3" json:"withdrawal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimWithdrawalResponse) Reset() {
+	*x = ClaimWithdrawalResponse{}
+	mi := &file_api_proto_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimWithdrawalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimWithdrawalResponse) ProtoMessage() {}
+
+func (x *ClaimWithdrawalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimWithdrawalResponse.ProtoReflect.Descriptor instead.
+func (*ClaimWithdrawalResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ClaimWithdrawalResponse) GetWithdrawal() *Withdrawal {
+	if x != nil {
+		return x.Withdrawal
+	}
+	return nil
+}
+
+type ReleaseWithdrawalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId  int64                  `protobuf:"varint,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseWithdrawalRequest) Reset() {
+	*x = ReleaseWithdrawalRequest{}
+	mi := &file_api_proto_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseWithdrawalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseWithdrawalRequest) ProtoMessage() {}
+
+func (x *ReleaseWithdrawalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseWithdrawalRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReleaseWithdrawalRequest) GetWithdrawalId() int64 {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return 0
+}
+
+type ReleaseWithdrawalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Withdrawal    *Withdrawal            `protobuf:"bytes,1,opt,name=withdrawal,proto3" json:"withdrawal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseWithdrawalResponse) Reset() {
+	*x = ReleaseWithdrawalResponse{}
+	mi := &file_api_proto_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseWithdrawalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseWithdrawalResponse) ProtoMessage() {}
+
+func (x *ReleaseWithdrawalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseWithdrawalResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseWithdrawalResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReleaseWithdrawalResponse) GetWithdrawal() *Withdrawal {
+	if x != nil {
+		return x.Withdrawal
+	}
+	return nil
+}
+
+type ReassignWithdrawalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId  int64                  `protobuf:"varint,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	AssigneeId    int64                  `protobuf:"varint,2,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"` // Admin to assign, 0 to unassign
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignWithdrawalRequest) Reset() {
+	*x = ReassignWithdrawalRequest{}
+	mi := &file_api_proto_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignWithdrawalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignWithdrawalRequest) ProtoMessage() {}
+
+func (x *ReassignWithdrawalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignWithdrawalRequest.ProtoReflect.Descriptor instead.
+func (*ReassignWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReassignWithdrawalRequest) GetWithdrawalId() int64 {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return 0
+}
+
+func (x *ReassignWithdrawalRequest) GetAssigneeId() int64 {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return 0
+}
+
+type ReassignWithdrawalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Withdrawal    *Withdrawal            `protobuf:"bytes,1,opt,name=withdrawal,proto3" json:"withdrawal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignWithdrawalResponse) Reset() {
+	*x = ReassignWithdrawalResponse{}
+	mi := &file_api_proto_admin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignWithdrawalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignWithdrawalResponse) ProtoMessage() {}
+
+func (x *ReassignWithdrawalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_admin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignWithdrawalResponse.ProtoReflect.Descriptor instead.
+func (*ReassignWithdrawalResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReassignWithdrawalResponse) GetWithdrawal() *Withdrawal {
+	if x != nil {
+		return x.Withdrawal
+	}
+	return nil
+}
+
+var File_api_proto_admin_proto protoreflect.FileDescriptor
+
+const file_api_proto_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x15api/proto/admin.proto\x12\badmin.v1\"\x9a\x02\n" +
+	"\x16ListReviewQueueRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05chain\x18\x02 \x01(\tR\x05chain\x12\x1d\n" +
+	"\n" +
+	"min_amount\x18\x03 \x01(\tR\tminAmount\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\x04 \x01(\tR\tmaxAmount\x12$\n" +
+	"\x0emin_risk_score\x18\x05 \x01(\x05R\fminRiskScore\x12$\n" +
+	"\x0emax_risk_score\x18\x06 \x01(\x05R\fmaxRiskScore\x12\x1a\n" +
+	"\bassignee\x18\a \x01(\tR\bassignee\x12\x16\n" +
+	"\x06cursor\x18\b \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limit\"f\n" +
+	"\x17ListReviewQueueResponse\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.admin.v1.WithdrawalR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xe0\x03\n" +
+	"\n" +
+	"Withdrawal\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05chain\x18\x03 \x01(\tR\x05chain\x12\x1d\n" +
+	"\n" +
+	"to_address\x18\x04 \x01(\tR\ttoAddress\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"risk_score\x18\a \x01(\x05R\triskScore\x12!\n" +
+	"\frisk_reasons\x18\b \x03(\tR\vriskReasons\x12-\n" +
+	"\x12required_approvals\x18\t \x01(\x05R\x11requiredApprovals\x12+\n" +
+	"\x11current_approvals\x18\n" +
+	" \x01(\x05R\x10currentApprovals\x12\x1f\n" +
+	"\vassignee_id\x18\v \x01(\x03R\n" +
+	"assigneeId\x12(\n" +
+	"\x10claim_expires_at\x18\f \x01(\x03R\x0eclaimExpiresAt\x12\x17\n" +
+	"\atx_hash\x18\r \x01(\tR\x06txHash\x12#\n" +
+	"\rexecute_after\x18\x0e \x01(\x03R\fexecuteAfter\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\x03R\tcreatedAt\"A\n" +
+	"\x1aGetWithdrawalDetailRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\x03R\fwithdrawalId\"\xb3\x01\n" +
+	"\x1bGetWithdrawalDetailResponse\x124\n" +
+	"\n" +
+	"withdrawal\x18\x01 \x01(\v2\x14.admin.v1.WithdrawalR\n" +
+	"withdrawal\x12*\n" +
+	"\areviews\x18\x02 \x03(\v2\x10.admin.v1.ReviewR\areviews\x122\n" +
+	"\bactivity\x18\x03 \x01(\v2\x16.admin.v1.UserActivityR\bactivity\"\x99\x01\n" +
+	"\x06Review\x12\x19\n" +
+	"\badmin_id\x18\x01 \x01(\x03R\aadminId\x12%\n" +
+	"\x0eadmin_username\x18\x02 \x01(\tR\radminUsername\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06remark\x18\x04 \x01(\tR\x06remark\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"\xc1\x03\n" +
+	"\fUserActivity\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12#\n" +
+	"\rregistered_at\x18\x04 \x01(\x03R\fregisteredAt\x12.\n" +
+	"\x13password_changed_at\x18\x05 \x01(\x03R\x11passwordChangedAt\x12@\n" +
+	"\bbalances\x18\x06 \x03(\v2$.admin.v1.UserActivity.BalancesEntryR\bbalances\x12:\n" +
+	"\x0frecent_deposits\x18\a \x03(\v2\x11.admin.v1.DepositR\x0erecentDeposits\x12C\n" +
+	"\x12recent_withdrawals\x18\b \x03(\v2\x14.admin.v1.WithdrawalR\x11recentWithdrawals\x1aN\n" +
+	"\rBalancesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12'\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.admin.v1.BalanceR\x05value:\x028\x01\"7\n" +
+	"\aBalance\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\tR\x05total\x12\x16\n" +
+	"\x06locked\x18\x02 \x01(\tR\x06locked\"\xba\x01\n" +
+	"\aDeposit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05chain\x18\x02 \x01(\tR\x05chain\x12!\n" +
+	"\ffrom_address\x18\x03 \x01(\tR\vfromAddress\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\tR\x06amount\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x17\n" +
+	"\atx_hash\x18\x06 \x01(\tR\x06txHash\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\"n\n" +
+	"\x17ReviewWithdrawalRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\x03R\fwithdrawalId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x16\n" +
+	"\x06remark\x18\x03 \x01(\tR\x06remark\"P\n" +
+	"\x18ReviewWithdrawalResponse\x124\n" +
+	"\n" +
+	"withdrawal\x18\x01 \x01(\v2\x14.admin.v1.WithdrawalR\n" +
+	"withdrawal\"u\n" +
+	"\x1cBulkReviewWithdrawalsRequest\x12%\n" +
+	"\x0ewithdrawal_ids\x18\x01 \x03(\x03R\rwithdrawalIds\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x16\n" +
+	"\x06remark\x18\x03 \x01(\tR\x06remark\"U\n" +
+	"\x1dBulkReviewWithdrawalsResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.admin.v1.BulkReviewResultR\aresults\"\x7f\n" +
+	"\x10BulkReviewResult\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\x03R\fwithdrawalId\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"=\n" +
+	"\x16ClaimWithdrawalRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\x03R\fwithdrawalId\"O\n" +
+	"\x17ClaimWithdrawalResponse\x124\n" +
+	"\n" +
+	"withdrawal\x18\x01 \x01(\v2\x14.admin.v1.WithdrawalR\n" +
+	"withdrawal\"?\n" +
+	"\x18ReleaseWithdrawalRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\x03R\fwithdrawalId\"Q\n" +
+	"\x19ReleaseWithdrawalResponse\x124\n" +
+	"\n" +
+	"withdrawal\x18\x01 \x01(\v2\x14.admin.v1.WithdrawalR\n" +
+	"withdrawal\"a\n" +
+	"\x19ReassignWithdrawalRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\x03R\fwithdrawalId\x12\x1f\n" +
+	"\vassignee_id\x18\x02 \x01(\x03R\n" +
+	"assigneeId\"R\n" +
+	"\x1aReassignWithdrawalResponse\x124\n" +
+	"\n" +
+	"withdrawal\x18\x01 \x01(\v2\x14.admin.v1.WithdrawalR\n" +
+	"withdrawal2\xa6\x05\n" +
+	"\fAdminService\x12V\n" +
+	"\x0fListReviewQueue\x12 .admin.v1.ListReviewQueueRequest\x1a!.admin.v1.ListReviewQueueResponse\x12b\n" +
+	"\x13GetWithdrawalDetail\x12$.admin.v1.GetWithdrawalDetailRequest\x1a%.admin.v1.GetWithdrawalDetailResponse\x12Y\n" +
+	"\x10ReviewWithdrawal\x12!.admin.v1.ReviewWithdrawalRequest\x1a\".admin.v1.ReviewWithdrawalResponse\x12h\n" +
+	"\x15BulkReviewWithdrawals\x12&.admin.v1.BulkReviewWithdrawalsRequest\x1a'.admin.v1.BulkReviewWithdrawalsResponse\x12V\n" +
+	"\x0fClaimWithdrawal\x12 .admin.v1.ClaimWithdrawalRequest\x1a!.admin.v1.ClaimWithdrawalResponse\x12\\\n" +
+	"\x11ReleaseWithdrawal\x12\".admin.v1.ReleaseWithdrawalRequest\x1a#.admin.v1.ReleaseWithdrawalResponse\x12_\n" +
+	"\x12ReassignWithdrawal\x12#.admin.v1.ReassignWithdrawalRequest\x1a$.admin.v1.ReassignWithdrawalResponseB1Z/github.com/wallet-core/api/gen/admin/v1;adminv1b\x06proto3"
+
+var (
+	file_api_proto_admin_proto_rawDescOnce sync.Once
+	file_api_proto_admin_proto_rawDescData []byte
+)
+
+func file_api_proto_admin_proto_rawDescGZIP() []byte {
+	file_api_proto_admin_proto_rawDescOnce.Do(func() {
+		file_api_proto_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_admin_proto_rawDesc), len(file_api_proto_admin_proto_rawDesc)))
+	})
+	return file_api_proto_admin_proto_rawDescData
+}
+
+var file_api_proto_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_api_proto_admin_proto_goTypes = []any{
+	(*ListReviewQueueRequest)(nil),        // 0: admin.v1.ListReviewQueueRequest
+	(*ListReviewQueueResponse)(nil),       // 1: admin.v1.ListReviewQueueResponse
+	(*Withdrawal)(nil),                    // 2: admin.v1.Withdrawal
+	(*GetWithdrawalDetailRequest)(nil),    // 3: admin.v1.GetWithdrawalDetailRequest
+	(*GetWithdrawalDetailResponse)(nil),   // 4: admin.v1.GetWithdrawalDetailResponse
+	(*Review)(nil),                        // 5: admin.v1.Review
+	(*UserActivity)(nil),                  // 6: admin.v1.UserActivity
+	(*Balance)(nil),                       // 7: admin.v1.Balance
+	(*Deposit)(nil),                       // 8: admin.v1.Deposit
+	(*ReviewWithdrawalRequest)(nil),       // 9: admin.v1.ReviewWithdrawalRequest
+	(*ReviewWithdrawalResponse)(nil),      // 10: admin.v1.ReviewWithdrawalResponse
+	(*BulkReviewWithdrawalsRequest)(nil),  // 11: admin.v1.BulkReviewWithdrawalsRequest
+	(*BulkReviewWithdrawalsResponse)(nil), // 12: admin.v1.BulkReviewWithdrawalsResponse
+	(*BulkReviewResult)(nil),              // 13: admin.v1.BulkReviewResult
+	(*ClaimWithdrawalRequest)(nil),        // 14: admin.v1.ClaimWithdrawalRequest
+	(*ClaimWithdrawalResponse)(nil),       // 15: admin.v1.ClaimWithdrawalResponse
+	(*ReleaseWithdrawalRequest)(nil),      // 16: admin.v1.ReleaseWithdrawalRequest
+	(*ReleaseWithdrawalResponse)(nil),     // 17: admin.v1.ReleaseWithdrawalResponse
+	(*ReassignWithdrawalRequest)(nil),     // 18: admin.v1.ReassignWithdrawalRequest
+	(*ReassignWithdrawalResponse)(nil),    // 19: admin.v1.ReassignWithdrawalResponse
+	nil,                                   // 20: admin.v1.UserActivity.BalancesEntry
+}
+var file_api_proto_admin_proto_depIdxs = []int32{
+	2,  // 0: admin.v1.ListReviewQueueResponse.items:type_name -> admin.v1.Withdrawal
+	2,  // 1: admin.v1.GetWithdrawalDetailResponse.withdrawal:type_name -> admin.v1.Withdrawal
+	5,  // 2: admin.v1.GetWithdrawalDetailResponse.reviews:type_name -> admin.v1.Review
+	6,  // 3: admin.v1.GetWithdrawalDetailResponse.activity:type_name -> admin.v1.UserActivity
+	20, // 4: admin.v1.UserActivity.balances:type_name -> admin.v1.UserActivity.BalancesEntry
+	8,  // 5: admin.v1.UserActivity.recent_deposits:type_name -> admin.v1.Deposit
+	2,  // 6: admin.v1.UserActivity.recent_withdrawals:type_name -> admin.v1.Withdrawal
+	2,  // 7: admin.v1.ReviewWithdrawalResponse.withdrawal:type_name -> admin.v1.Withdrawal
+	13, // 8: admin.v1.BulkReviewWithdrawalsResponse.results:type_name -> admin.v1.BulkReviewResult
+	2,  // 9: admin.v1.ClaimWithdrawalResponse.withdrawal:type_name -> admin.v1.Withdrawal
+	2,  // 10: admin.v1.ReleaseWithdrawalResponse.withdrawal:type_name -> admin.v1.Withdrawal
+	2,  // 11: admin.v1.ReassignWithdrawalResponse.withdrawal:type_name -> admin.v1.Withdrawal
+	7,  // 12: admin.v1.UserActivity.BalancesEntry.value:type_name -> admin.v1.Balance
+	0,  // 13: admin.v1.AdminService.ListReviewQueue:input_type -> admin.v1.ListReviewQueueRequest
+	3,  // 14: admin.v1.AdminService.GetWithdrawalDetail:input_type -> admin.v1.GetWithdrawalDetailRequest
+	9,  // 15: admin.v1.AdminService.ReviewWithdrawal:input_type -> admin.v1.ReviewWithdrawalRequest
+	11, // 16: admin.v1.AdminService.BulkReviewWithdrawals:input_type -> admin.v1.BulkReviewWithdrawalsRequest
+	14, // 17: admin.v1.AdminService.ClaimWithdrawal:input_type -> admin.v1.ClaimWithdrawalRequest
+	16, // 18: admin.v1.AdminService.ReleaseWithdrawal:input_type -> admin.v1.ReleaseWithdrawalRequest
+	18, // 19: admin.v1.AdminService.ReassignWithdrawal:input_type -> admin.v1.ReassignWithdrawalRequest
+	1,  // 20: admin.v1.AdminService.ListReviewQueue:output_type -> admin.v1.ListReviewQueueResponse
+	4,  // 21: admin.v1.AdminService.GetWithdrawalDetail:output_type -> admin.v1.GetWithdrawalDetailResponse
+	10, // 22: admin.v1.AdminService.ReviewWithdrawal:output_type -> admin.v1.ReviewWithdrawalResponse
+	12, // 23: admin.v1.AdminService.BulkReviewWithdrawals:output_type -> admin.v1.BulkReviewWithdrawalsResponse
+	15, // 24: admin.v1.AdminService.ClaimWithdrawal:output_type -> admin.v1.ClaimWithdrawalResponse
+	17, // 25: admin.v1.AdminService.ReleaseWithdrawal:output_type -> admin.v1.ReleaseWithdrawalResponse
+	19, // 26: admin.v1.AdminService.ReassignWithdrawal:output_type -> admin.v1.ReassignWithdrawalResponse
+	20, // [20:27] is the sub-list for method output_type
+	13, // [13:20] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_admin_proto_init() }
+func file_api_proto_admin_proto_init() {
+	if File_api_proto_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_admin_proto_rawDesc), len(file_api_proto_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_admin_proto_goTypes,
+		DependencyIndexes: file_api_proto_admin_proto_depIdxs,
+		MessageInfos:      file_api_proto_admin_proto_msgTypes,
+	}.Build()
+	File_api_proto_admin_proto = out.File
+	file_api_proto_admin_proto_goTypes = nil
+	file_api_proto_admin_proto_depIdxs = nil
+}
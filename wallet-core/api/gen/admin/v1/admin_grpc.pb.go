@@ -0,0 +1,365 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             v5.29.3
+// source: api/proto/admin.proto
+
+package adminv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AdminService_ListReviewQueue_FullMethodName       = "/admin.v1.AdminService/ListReviewQueue"
+	AdminService_GetWithdrawalDetail_FullMethodName   = "/admin.v1.AdminService/GetWithdrawalDetail"
+	AdminService_ReviewWithdrawal_FullMethodName      = "/admin.v1.AdminService/ReviewWithdrawal"
+	AdminService_BulkReviewWithdrawals_FullMethodName = "/admin.v1.AdminService/BulkReviewWithdrawals"
+	AdminService_ClaimWithdrawal_FullMethodName       = "/admin.v1.AdminService/ClaimWithdrawal"
+	AdminService_ReleaseWithdrawal_FullMethodName     = "/admin.v1.AdminService/ReleaseWithdrawal"
+	AdminService_ReassignWithdrawal_FullMethodName    = "/admin.v1.AdminService/ReassignWithdrawal"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService is the back-office API for withdrawal review.
+// Every call needs an admin session token (authorization: Bearer <token> from
+// POST /api/v1/admin/auth/login); end-user access tokens are rejected.
+type AdminServiceClient interface {
+	// Review queue (pending_review and risk_hold), highest risk score first
+	ListReviewQueue(ctx context.Context, in *ListReviewQueueRequest, opts ...grpc.CallOption) (*ListReviewQueueResponse, error)
+	GetWithdrawalDetail(ctx context.Context, in *GetWithdrawalDetailRequest, opts ...grpc.CallOption) (*GetWithdrawalDetailResponse, error)
+	// Review
+	ReviewWithdrawal(ctx context.Context, in *ReviewWithdrawalRequest, opts ...grpc.CallOption) (*ReviewWithdrawalResponse, error)
+	BulkReviewWithdrawals(ctx context.Context, in *BulkReviewWithdrawalsRequest, opts ...grpc.CallOption) (*BulkReviewWithdrawalsResponse, error)
+	// Claims keep reviewers from working on the same withdrawal.
+	// A claim expires after 30 minutes without a review.
+	ClaimWithdrawal(ctx context.Context, in *ClaimWithdrawalRequest, opts ...grpc.CallOption) (*ClaimWithdrawalResponse, error)
+	ReleaseWithdrawal(ctx context.Context, in *ReleaseWithdrawalRequest, opts ...grpc.CallOption) (*ReleaseWithdrawalResponse, error)
+	ReassignWithdrawal(ctx context.Context, in *ReassignWithdrawalRequest, opts ...grpc.CallOption) (*ReassignWithdrawalResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ListReviewQueue(ctx context.Context, in *ListReviewQueueRequest, opts ...grpc.CallOption) (*ListReviewQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReviewQueueResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListReviewQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetWithdrawalDetail(ctx context.Context, in *GetWithdrawalDetailRequest, opts ...grpc.CallOption) (*GetWithdrawalDetailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWithdrawalDetailResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetWithdrawalDetail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ReviewWithdrawal(ctx context.Context, in *ReviewWithdrawalRequest, opts ...grpc.CallOption) (*ReviewWithdrawalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewWithdrawalResponse)
+	err := c.cc.Invoke(ctx, AdminService_ReviewWithdrawal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) BulkReviewWithdrawals(ctx context.Context, in *BulkReviewWithdrawalsRequest, opts ...grpc.CallOption) (*BulkReviewWithdrawalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkReviewWithdrawalsResponse)
+	err := c.cc.Invoke(ctx, AdminService_BulkReviewWithdrawals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClaimWithdrawal(ctx context.Context, in *ClaimWithdrawalRequest, opts ...grpc.CallOption) (*ClaimWithdrawalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimWithdrawalResponse)
+	err := c.cc.Invoke(ctx, AdminService_ClaimWithdrawal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ReleaseWithdrawal(ctx context.Context, in *ReleaseWithdrawalRequest, opts ...grpc.CallOption) (*ReleaseWithdrawalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseWithdrawalResponse)
+	err := c.cc.Invoke(ctx, AdminService_ReleaseWithdrawal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ReassignWithdrawal(ctx context.Context, in *ReassignWithdrawalRequest, opts ...grpc.CallOption) (*ReassignWithdrawalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReassignWithdrawalResponse)
+	err := c.cc.Invoke(ctx, AdminService_ReassignWithdrawal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService is the back-office API for withdrawal review.
+// Every call needs an admin session token (authorization: Bearer <token> from
+// POST /api/v1/admin/auth/login); end-user access tokens are rejected.
+type AdminServiceServer interface {
+	// Review queue (pending_review and risk_hold), highest risk score first
+	ListReviewQueue(context.Context, *ListReviewQueueRequest) (*ListReviewQueueResponse, error)
+	GetWithdrawalDetail(context.Context, *GetWithdrawalDetailRequest) (*GetWithdrawalDetailResponse, error)
+	// Review
+	ReviewWithdrawal(context.Context, *ReviewWithdrawalRequest) (*ReviewWithdrawalResponse, error)
+	BulkReviewWithdrawals(context.Context, *BulkReviewWithdrawalsRequest) (*BulkReviewWithdrawalsResponse, error)
+	// Claims keep reviewers from working on the same withdrawal.
+	// A claim expires after 30 minutes without a review.
+	ClaimWithdrawal(context.Context, *ClaimWithdrawalRequest) (*ClaimWithdrawalResponse, error)
+	ReleaseWithdrawal(context.Context, *ReleaseWithdrawalRequest) (*ReleaseWithdrawalResponse, error)
+	ReassignWithdrawal(context.Context, *ReassignWithdrawalRequest) (*ReassignWithdrawalResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) ListReviewQueue(context.Context, *ListReviewQueueRequest) (*ListReviewQueueResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListReviewQueue not implemented")
+}
+func (UnimplementedAdminServiceServer) GetWithdrawalDetail(context.Context, *GetWithdrawalDetailRequest) (*GetWithdrawalDetailResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWithdrawalDetail not implemented")
+}
+func (UnimplementedAdminServiceServer) ReviewWithdrawal(context.Context, *ReviewWithdrawalRequest) (*ReviewWithdrawalResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReviewWithdrawal not implemented")
+}
+func (UnimplementedAdminServiceServer) BulkReviewWithdrawals(context.Context, *BulkReviewWithdrawalsRequest) (*BulkReviewWithdrawalsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BulkReviewWithdrawals not implemented")
+}
+func (UnimplementedAdminServiceServer) ClaimWithdrawal(context.Context, *ClaimWithdrawalRequest) (*ClaimWithdrawalResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClaimWithdrawal not implemented")
+}
+func (UnimplementedAdminServiceServer) ReleaseWithdrawal(context.Context, *ReleaseWithdrawalRequest) (*ReleaseWithdrawalResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseWithdrawal not implemented")
+}
+func (UnimplementedAdminServiceServer) ReassignWithdrawal(context.Context, *ReassignWithdrawalRequest) (*ReassignWithdrawalResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReassignWithdrawal not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call panics, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_ListReviewQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListReviewQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListReviewQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListReviewQueue(ctx, req.(*ListReviewQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetWithdrawalDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWithdrawalDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetWithdrawalDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetWithdrawalDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetWithdrawalDetail(ctx, req.(*GetWithdrawalDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ReviewWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewWithdrawalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ReviewWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ReviewWithdrawal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ReviewWithdrawal(ctx, req.(*ReviewWithdrawalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_BulkReviewWithdrawals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkReviewWithdrawalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).BulkReviewWithdrawals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_BulkReviewWithdrawals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).BulkReviewWithdrawals(ctx, req.(*BulkReviewWithdrawalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ClaimWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimWithdrawalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClaimWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ClaimWithdrawal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClaimWithdrawal(ctx, req.(*ClaimWithdrawalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ReleaseWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseWithdrawalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ReleaseWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ReleaseWithdrawal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ReleaseWithdrawal(ctx, req.(*ReleaseWithdrawalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ReassignWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReassignWithdrawalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ReassignWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ReassignWithdrawal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ReassignWithdrawal(ctx, req.(*ReassignWithdrawalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListReviewQueue",
+			Handler:    _AdminService_ListReviewQueue_Handler,
+		},
+		{
+			MethodName: "GetWithdrawalDetail",
+			Handler:    _AdminService_GetWithdrawalDetail_Handler,
+		},
+		{
+			MethodName: "ReviewWithdrawal",
+			Handler:    _AdminService_ReviewWithdrawal_Handler,
+		},
+		{
+			MethodName: "BulkReviewWithdrawals",
+			Handler:    _AdminService_BulkReviewWithdrawals_Handler,
+		},
+		{
+			MethodName: "ClaimWithdrawal",
+			Handler:    _AdminService_ClaimWithdrawal_Handler,
+		},
+		{
+			MethodName: "ReleaseWithdrawal",
+			Handler:    _AdminService_ReleaseWithdrawal_Handler,
+		},
+		{
+			MethodName: "ReassignWithdrawal",
+			Handler:    _AdminService_ReassignWithdrawal_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/admin.proto",
+}
@@ -0,0 +1,81 @@
+// Hand-written request validation, checked by interceptor.Validate before the handler runs.
+// Not generated: keep this file when regenerating admin.pb.go.
+
+package adminv1
+
+import "errors"
+
+func (r *ListReviewQueueRequest) Validate() error {
+	switch r.GetStatus() {
+	case "", "pending_review", "risk_hold":
+	default:
+		return errors.New(`status must be "pending_review" or "risk_hold"`)
+	}
+	switch r.GetAssignee() {
+	case "", "mine", "unassigned":
+	default:
+		return errors.New(`assignee must be "mine" or "unassigned"`)
+	}
+	if r.GetLimit() < 0 || r.GetLimit() > 100 {
+		return errors.New("limit must be between 0 and 100")
+	}
+	if r.GetMinRiskScore() < 0 || r.GetMaxRiskScore() < 0 {
+		return errors.New("risk scores must not be negative")
+	}
+	return nil
+}
+
+func (r *GetWithdrawalDetailRequest) Validate() error {
+	return validateWithdrawalID(r.GetWithdrawalId())
+}
+
+func (r *ReviewWithdrawalRequest) Validate() error {
+	if err := validateWithdrawalID(r.GetWithdrawalId()); err != nil {
+		return err
+	}
+	return validateAction(r.GetAction())
+}
+
+func (r *BulkReviewWithdrawalsRequest) Validate() error {
+	if n := len(r.GetWithdrawalIds()); n == 0 || n > 100 {
+		return errors.New("withdrawal_ids must contain 1 to 100 ids")
+	}
+	for _, id := range r.GetWithdrawalIds() {
+		if err := validateWithdrawalID(id); err != nil {
+			return err
+		}
+	}
+	return validateAction(r.GetAction())
+}
+
+func (r *ClaimWithdrawalRequest) Validate() error {
+	return validateWithdrawalID(r.GetWithdrawalId())
+}
+
+func (r *ReleaseWithdrawalRequest) Validate() error {
+	return validateWithdrawalID(r.GetWithdrawalId())
+}
+
+func (r *ReassignWithdrawalRequest) Validate() error {
+	if err := validateWithdrawalID(r.GetWithdrawalId()); err != nil {
+		return err
+	}
+	if r.GetAssigneeId() < 0 {
+		return errors.New("assignee_id must not be negative")
+	}
+	return nil
+}
+
+func validateWithdrawalID(id int64) error {
+	if id <= 0 {
+		return errors.New("withdrawal_id is required")
+	}
+	return nil
+}
+
+func validateAction(action string) error {
+	if action != "approve" && action != "reject" {
+		return errors.New(`action must be "approve" or "reject"`)
+	}
+	return nil
+}
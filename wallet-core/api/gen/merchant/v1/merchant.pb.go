@@ -0,0 +1,1024 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: api/proto/merchant.proto
+
+package merchantv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateInvoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // Unique per merchant, at most 64 characters
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`                                // Currency the invoice is paid in, e.g., "ETH", "BTC"
+	Amount        string                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`                                    // Decimal string in currency. Set either amount or price_amount
+	PriceCurrency string                 `protobuf:"bytes,5,opt,name=price_currency,json=priceCurrency,proto3" json:"price_currency,omitempty"` // Fiat currency for fiat-priced invoices, e.g., "USD"
+	PriceAmount   string                 `protobuf:"bytes,6,opt,name=price_amount,json=priceAmount,proto3" json:"price_amount,omitempty"`       // Decimal string in price_currency, converted at the current rate
+	AddressMode   string                 `protobuf:"bytes,7,opt,name=address_mode,json=addressMode,proto3" json:"address_mode,omitempty"`       // "dedicated" (default) or "shared"
+	TtlSeconds    int64                  `protobuf:"varint,8,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`         // 0 means the configured default
+	RedirectUrl   string                 `protobuf:"bytes,9,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`       // Where the hosted checkout sends the payer when done
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvoiceRequest) Reset() {
+	*x = CreateInvoiceRequest{}
+	mi := &file_api_proto_merchant_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceRequest) ProtoMessage() {}
+
+func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_merchant_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_merchant_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateInvoiceRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetPriceCurrency() string {
+	if x != nil {
+		return x.PriceCurrency
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetPriceAmount() string {
+	if x != nil {
+		return x.PriceAmount
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetAddressMode() string {
+	if x != nil {
+		return x.AddressMode
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *CreateInvoiceRequest) GetRedirectUrl() string {
+	if x != nil {
+		return x.RedirectUrl
+	}
+	return ""
+}
+
+type CreateInvoiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoice       *Invoice               `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvoiceResponse) Reset() {
+	*x = CreateInvoiceResponse{}
+	mi := &file_api_proto_merchant_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceResponse) ProtoMessage() {}
+
+func (x *CreateInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_merchant_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_merchant_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateInvoiceResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+type GetInvoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Public id (inv_...)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	mi := &file_api_proto_merchant_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceRequest) ProtoMessage() {}
+
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_merchant_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_merchant_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetInvoiceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetInvoiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoice       *Invoice               `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	Payments      []*Payment             `protobuf:"bytes,2,rep,name=payments,proto3" json:"payments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceResponse) Reset() {
+	*x = GetInvoiceResponse{}
+	mi := &file_api_proto_merchant_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceResponse) ProtoMessage() {}
+
+func (x *GetInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_merchant_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*GetInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_merchant_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetInvoiceResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+func (x *GetInvoiceResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+type ListInvoicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                      // Optional: pending, underpaid, paid, overpaid or expired
+	BeforeId      int64                  `protobuf:"varint,2,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"` // Cursor: internal id of the last invoice on the previous page
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                       // Default 20, max 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvoicesRequest) Reset() {
+	*x = ListInvoicesRequest{}
+	mi := &file_api_proto_merchant_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvoicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvoicesRequest) ProtoMessage() {}
+
+func (x *ListInvoicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_merchant_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvoicesRequest.ProtoReflect.Descriptor instead.
+func (*ListInvoicesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_merchant_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListInvoicesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListInvoicesRequest) GetBeforeId() int64 {
+	if x != nil {
+		return x.BeforeId
+	}
+	return 0
+}
+
+func (x *ListInvoicesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListInvoicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Invoice             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvoicesResponse) Reset() {
+	*x = ListInvoicesResponse{}
+	mi := &file_api_proto_merchant_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvoicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvoicesResponse) ProtoMessage() {}
+
+func (x *ListInvoicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_merchant_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvoicesResponse.ProtoReflect.Descriptor instead.
+func (*ListInvoicesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_merchant_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListInvoicesResponse) GetItems() []*Invoice {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GetCheckoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Public id (inv_...)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCheckoutRequest) Reset() {
+	*x = GetCheckoutRequest{}
+	mi := &file_api_proto_merchant_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCheckoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheckoutRequest) ProtoMessage() {}
+
+func (x *GetCheckoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_merchant_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheckoutRequest.ProtoReflect.Descriptor instead.
+func (*GetCheckoutRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_merchant_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCheckoutRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCheckoutResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Merchant         string                 `protobuf:"bytes,2,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	Description      string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Currency         string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount           string                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	PaidAmount       string                 `protobuf:"bytes,6,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	RemainingAmount  string                 `protobuf:"bytes,7,opt,name=remaining_amount,json=remainingAmount,proto3" json:"remaining_amount,omitempty"`
+	PriceCurrency    string                 `protobuf:"bytes,8,opt,name=price_currency,json=priceCurrency,proto3" json:"price_currency,omitempty"`
+	PriceAmount      string                 `protobuf:"bytes,9,opt,name=price_amount,json=priceAmount,proto3" json:"price_amount,omitempty"`
+	ExchangeRate     string                 `protobuf:"bytes,10,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty"`
+	Address          string                 `protobuf:"bytes,11,opt,name=address,proto3" json:"address,omitempty"`
+	ExactAmount      bool                   `protobuf:"varint,12,opt,name=exact_amount,json=exactAmount,proto3" json:"exact_amount,omitempty"` // Shared address: pay the exact amount in one transaction
+	PaymentUri       string                 `protobuf:"bytes,13,opt,name=payment_uri,json=paymentUri,proto3" json:"payment_uri,omitempty"`     // EIP-681 / BIP-21 URI for the remaining amount, empty when nothing is due
+	Status           string                 `protobuf:"bytes,14,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiresAt        int64                  `protobuf:"varint,15,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix seconds
+	SecondsRemaining int64                  `protobuf:"varint,16,opt,name=seconds_remaining,json=secondsRemaining,proto3" json:"seconds_remaining,omitempty"`
+	PaidAt           int64                  `protobuf:"varint,17,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"` // Unix seconds, 0 if not paid
+	RedirectUrl      string                 `protobuf:"bytes,18,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	CheckoutUrl      string                 `protobuf:"bytes,19,opt,name=checkout_url,json=checkoutUrl,proto3" json:"checkout_url,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetCheckoutResponse) Reset() {
+	*x = GetCheckoutResponse{}
+	mi := &file_api_proto_merchant_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCheckoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheckoutResponse) ProtoMessage() {}
+
+func (x *GetCheckoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_merchant_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheckoutResponse.ProtoReflect.Descriptor instead.
+func (*GetCheckoutResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_merchant_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCheckoutResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetMerchant() string {
+	if x != nil {
+		return x.Merchant
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetPaidAmount() string {
+	if x != nil {
+		return x.PaidAmount
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetRemainingAmount() string {
+	if x != nil {
+		return x.RemainingAmount
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetPriceCurrency() string {
+	if x != nil {
+		return x.PriceCurrency
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetPriceAmount() string {
+	if x != nil {
+		return x.PriceAmount
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetExchangeRate() string {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetExactAmount() bool {
+	if x != nil {
+		return x.ExactAmount
+	}
+	return false
+}
+
+func (x *GetCheckoutResponse) GetPaymentUri() string {
+	if x != nil {
+		return x.PaymentUri
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *GetCheckoutResponse) GetSecondsRemaining() int64 {
+	if x != nil {
+		return x.SecondsRemaining
+	}
+	return 0
+}
+
+func (x *GetCheckoutResponse) GetPaidAt() int64 {
+	if x != nil {
+		return x.PaidAt
+	}
+	return 0
+}
+
+func (x *GetCheckoutResponse) GetRedirectUrl() string {
+	if x != nil {
+		return x.RedirectUrl
+	}
+	return ""
+}
+
+func (x *GetCheckoutResponse) GetCheckoutUrl() string {
+	if x != nil {
+		return x.CheckoutUrl
+	}
+	return ""
+}
+
+type Invoice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // Internal id, used as the list cursor
+	PublicId      string                 `protobuf:"bytes,2,opt,name=public_id,json=publicId,proto3" json:"public_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount        string                 `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"` // Amount due, decimal string
+	PaidAmount    string                 `protobuf:"bytes,7,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	PriceCurrency string                 `protobuf:"bytes,8,opt,name=price_currency,json=priceCurrency,proto3" json:"price_currency,omitempty"`
+	PriceAmount   string                 `protobuf:"bytes,9,opt,name=price_amount,json=priceAmount,proto3" json:"price_amount,omitempty"`
+	ExchangeRate  string                 `protobuf:"bytes,10,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty"` // 1 currency = exchange_rate price_currency, locked at creation
+	AddressMode   string                 `protobuf:"bytes,11,opt,name=address_mode,json=addressMode,proto3" json:"address_mode,omitempty"`
+	Address       string                 `protobuf:"bytes,12,opt,name=address,proto3" json:"address,omitempty"`
+	Status        string                 `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"` // pending, underpaid, paid, overpaid or expired
+	RedirectUrl   string                 `protobuf:"bytes,14,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	CheckoutUrl   string                 `protobuf:"bytes,15,opt,name=checkout_url,json=checkoutUrl,proto3" json:"checkout_url,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,16,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix seconds
+	PaidAt        int64                  `protobuf:"varint,17,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"`          // Unix seconds, 0 if not paid
+	CreatedAt     int64                  `protobuf:"varint,18,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invoice) Reset() {
+	*x = Invoice{}
+	mi := &file_api_proto_merchant_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invoice) ProtoMessage() {}
+
+func (x *Invoice) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_merchant_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
+func (*Invoice) Descriptor() ([]byte, []int) {
+	return file_api_proto_merchant_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Invoice) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Invoice) GetPublicId() string {
+	if x != nil {
+		return x.PublicId
+	}
+	return ""
+}
+
+func (x *Invoice) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Invoice) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Invoice) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Invoice) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Invoice) GetPaidAmount() string {
+	if x != nil {
+		return x.PaidAmount
+	}
+	return ""
+}
+
+func (x *Invoice) GetPriceCurrency() string {
+	if x != nil {
+		return x.PriceCurrency
+	}
+	return ""
+}
+
+func (x *Invoice) GetPriceAmount() string {
+	if x != nil {
+		return x.PriceAmount
+	}
+	return ""
+}
+
+func (x *Invoice) GetExchangeRate() string {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return ""
+}
+
+func (x *Invoice) GetAddressMode() string {
+	if x != nil {
+		return x.AddressMode
+	}
+	return ""
+}
+
+func (x *Invoice) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Invoice) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Invoice) GetRedirectUrl() string {
+	if x != nil {
+		return x.RedirectUrl
+	}
+	return ""
+}
+
+func (x *Invoice) GetCheckoutUrl() string {
+	if x != nil {
+		return x.CheckoutUrl
+	}
+	return ""
+}
+
+func (x *Invoice) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Invoice) GetPaidAt() int64 {
+	if x != nil {
+		return x.PaidAt
+	}
+	return 0
+}
+
+func (x *Invoice) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type Payment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DepositId     int64                  `protobuf:"varint,1,opt,name=deposit_id,json=depositId,proto3" json:"deposit_id,omitempty"`
+	TxHash        string                 `protobuf:"bytes,2,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Late          bool                   `protobuf:"varint,4,opt,name=late,proto3" json:"late,omitempty"`                            // Arrived after the invoice expired
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_api_proto_merchant_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_merchant_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_api_proto_merchant_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Payment) GetDepositId() int64 {
+	if x != nil {
+		return x.DepositId
+	}
+	return 0
+}
+
+func (x *Payment) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Payment) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Payment) GetLate() bool {
+	if x != nil {
+		return x.Late
+	}
+	return false
+}
+
+func (x *Payment) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+var File_api_proto_merchant_proto protoreflect.FileDescriptor
+
+const file_api_proto_merchant_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/proto/merchant.proto\x12\vmerchant.v1\"\xb8\x02\n" +
+	"\x14CreateInvoiceRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\tR\x06amount\x12%\n" +
+	"\x0eprice_currency\x18\x05 \x01(\tR\rpriceCurrency\x12!\n" +
+	"\fprice_amount\x18\x06 \x01(\tR\vpriceAmount\x12!\n" +
+	"\faddress_mode\x18\a \x01(\tR\vaddressMode\x12\x1f\n" +
+	"\vttl_seconds\x18\b \x01(\x03R\n" +
+	"ttlSeconds\x12!\n" +
+	"\fredirect_url\x18\t \x01(\tR\vredirectUrl\"G\n" +
+	"\x15CreateInvoiceResponse\x12.\n" +
+	"\ainvoice\x18\x01 \x01(\v2\x14.merchant.v1.InvoiceR\ainvoice\"#\n" +
+	"\x11GetInvoiceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"v\n" +
+	"\x12GetInvoiceResponse\x12.\n" +
+	"\ainvoice\x18\x01 \x01(\v2\x14.merchant.v1.InvoiceR\ainvoice\x120\n" +
+	"\bpayments\x18\x02 \x03(\v2\x14.merchant.v1.PaymentR\bpayments\"`\n" +
+	"\x13ListInvoicesRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1b\n" +
+	"\tbefore_id\x18\x02 \x01(\x03R\bbeforeId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"B\n" +
+	"\x14ListInvoicesResponse\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.merchant.v1.InvoiceR\x05items\"$\n" +
+	"\x12GetCheckoutRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xf3\x04\n" +
+	"\x13GetCheckoutResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bmerchant\x18\x02 \x01(\tR\bmerchant\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12\x1f\n" +
+	"\vpaid_amount\x18\x06 \x01(\tR\n" +
+	"paidAmount\x12)\n" +
+	"\x10remaining_amount\x18\a \x01(\tR\x0fremainingAmount\x12%\n" +
+	"\x0eprice_currency\x18\b \x01(\tR\rpriceCurrency\x12!\n" +
+	"\fprice_amount\x18\t \x01(\tR\vpriceAmount\x12#\n" +
+	"\rexchange_rate\x18\n" +
+	" \x01(\tR\fexchangeRate\x12\x18\n" +
+	"\aaddress\x18\v \x01(\tR\aaddress\x12!\n" +
+	"\fexact_amount\x18\f \x01(\bR\vexactAmount\x12\x1f\n" +
+	"\vpayment_uri\x18\r \x01(\tR\n" +
+	"paymentUri\x12\x16\n" +
+	"\x06status\x18\x0e \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x0f \x01(\x03R\texpiresAt\x12+\n" +
+	"\x11seconds_remaining\x18\x10 \x01(\x03R\x10secondsRemaining\x12\x17\n" +
+	"\apaid_at\x18\x11 \x01(\x03R\x06paidAt\x12!\n" +
+	"\fredirect_url\x18\x12 \x01(\tR\vredirectUrl\x12!\n" +
+	"\fcheckout_url\x18\x13 \x01(\tR\vcheckoutUrl\"\xa9\x04\n" +
+	"\aInvoice\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\tpublic_id\x18\x02 \x01(\tR\bpublicId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\tR\x06amount\x12\x1f\n" +
+	"\vpaid_amount\x18\a \x01(\tR\n" +
+	"paidAmount\x12%\n" +
+	"\x0eprice_currency\x18\b \x01(\tR\rpriceCurrency\x12!\n" +
+	"\fprice_amount\x18\t \x01(\tR\vpriceAmount\x12#\n" +
+	"\rexchange_rate\x18\n" +
+	" \x01(\tR\fexchangeRate\x12!\n" +
+	"\faddress_mode\x18\v \x01(\tR\vaddressMode\x12\x18\n" +
+	"\aaddress\x18\f \x01(\tR\aaddress\x12\x16\n" +
+	"\x06status\x18\r \x01(\tR\x06status\x12!\n" +
+	"\fredirect_url\x18\x0e \x01(\tR\vredirectUrl\x12!\n" +
+	"\fcheckout_url\x18\x0f \x01(\tR\vcheckoutUrl\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x10 \x01(\x03R\texpiresAt\x12\x17\n" +
+	"\apaid_at\x18\x11 \x01(\x03R\x06paidAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x12 \x01(\x03R\tcreatedAt\"\x8c\x01\n" +
+	"\aPayment\x12\x1d\n" +
+	"\n" +
+	"deposit_id\x18\x01 \x01(\x03R\tdepositId\x12\x17\n" +
+	"\atx_hash\x18\x02 \x01(\tR\x06txHash\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12\x12\n" +
+	"\x04late\x18\x04 \x01(\bR\x04late\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt2\xde\x02\n" +
+	"\x0eInvoiceService\x12V\n" +
+	"\rCreateInvoice\x12!.merchant.v1.CreateInvoiceRequest\x1a\".merchant.v1.CreateInvoiceResponse\x12M\n" +
+	"\n" +
+	"GetInvoice\x12\x1e.merchant.v1.GetInvoiceRequest\x1a\x1f.merchant.v1.GetInvoiceResponse\x12S\n" +
+	"\fListInvoices\x12 .merchant.v1.ListInvoicesRequest\x1a!.merchant.v1.ListInvoicesResponse\x12P\n" +
+	"\vGetCheckout\x12\x1f.merchant.v1.GetCheckoutRequest\x1a .merchant.v1.GetCheckoutResponseB7Z5github.com/wallet-core/api/gen/merchant/v1;merchantv1b\x06proto3"
+
+var (
+	file_api_proto_merchant_proto_rawDescOnce sync.Once
+	file_api_proto_merchant_proto_rawDescData []byte
+)
+
+func file_api_proto_merchant_proto_rawDescGZIP() []byte {
+	file_api_proto_merchant_proto_rawDescOnce.Do(func() {
+		file_api_proto_merchant_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_merchant_proto_rawDesc), len(file_api_proto_merchant_proto_rawDesc)))
+	})
+	return file_api_proto_merchant_proto_rawDescData
+}
+
+var file_api_proto_merchant_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_proto_merchant_proto_goTypes = []any{
+	(*CreateInvoiceRequest)(nil),  // 0: merchant.v1.CreateInvoiceRequest
+	(*CreateInvoiceResponse)(nil), // 1: merchant.v1.CreateInvoiceResponse
+	(*GetInvoiceRequest)(nil),     // 2: merchant.v1.GetInvoiceRequest
+	(*GetInvoiceResponse)(nil),    // 3: merchant.v1.GetInvoiceResponse
+	(*ListInvoicesRequest)(nil),   // 4: merchant.v1.ListInvoicesRequest
+	(*ListInvoicesResponse)(nil),  // 5: merchant.v1.ListInvoicesResponse
+	(*GetCheckoutRequest)(nil),    // 6: merchant.v1.GetCheckoutRequest
+	(*GetCheckoutResponse)(nil),   // 7: merchant.v1.GetCheckoutResponse
+	(*Invoice)(nil),               // 8: merchant.v1.Invoice
+	(*Payment)(nil),               // 9: merchant.v1.Payment
+}
+var file_api_proto_merchant_proto_depIdxs = []int32{
+	8, // 0: merchant.v1.CreateInvoiceResponse.invoice:type_name -> merchant.v1.Invoice
+	8, // 1: merchant.v1.GetInvoiceResponse.invoice:type_name -> merchant.v1.Invoice
+	9, // 2: merchant.v1.GetInvoiceResponse.payments:type_name -> merchant.v1.Payment
+	8, // 3: merchant.v1.ListInvoicesResponse.items:type_name -> merchant.v1.Invoice
+	0, // 4: merchant.v1.InvoiceService.CreateInvoice:input_type -> merchant.v1.CreateInvoiceRequest
+	2, // 5: merchant.v1.InvoiceService.GetInvoice:input_type -> merchant.v1.GetInvoiceRequest
+	4, // 6: merchant.v1.InvoiceService.ListInvoices:input_type -> merchant.v1.ListInvoicesRequest
+	6, // 7: merchant.v1.InvoiceService.GetCheckout:input_type -> merchant.v1.GetCheckoutRequest
+	1, // 8: merchant.v1.InvoiceService.CreateInvoice:output_type -> merchant.v1.CreateInvoiceResponse
+	3, // 9: merchant.v1.InvoiceService.GetInvoice:output_type -> merchant.v1.GetInvoiceResponse
+	5, // 10: merchant.v1.InvoiceService.ListInvoices:output_type -> merchant.v1.ListInvoicesResponse
+	7, // 11: merchant.v1.InvoiceService.GetCheckout:output_type -> merchant.v1.GetCheckoutResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_merchant_proto_init() }
+func file_api_proto_merchant_proto_init() {
+	if File_api_proto_merchant_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_merchant_proto_rawDesc), len(file_api_proto_merchant_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_merchant_proto_goTypes,
+		DependencyIndexes: file_api_proto_merchant_proto_depIdxs,
+		MessageInfos:      file_api_proto_merchant_proto_msgTypes,
+	}.Build()
+	File_api_proto_merchant_proto = out.File
+	file_api_proto_merchant_proto_goTypes = nil
+	file_api_proto_merchant_proto_depIdxs = nil
+}
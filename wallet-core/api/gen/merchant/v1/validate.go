@@ -0,0 +1,69 @@
+// Hand-written request validation, checked by interceptor.Validate before the handler runs.
+// Not generated: keep this file when regenerating merchant.pb.go.
+
+package merchantv1
+
+import (
+	"errors"
+	"strings"
+)
+
+func (r *CreateInvoiceRequest) Validate() error {
+	if r.GetOrderId() == "" || len(r.GetOrderId()) > 64 {
+		return errors.New("order_id is required and must be at most 64 characters")
+	}
+	if len(r.GetDescription()) > 255 {
+		return errors.New("description must be at most 255 characters")
+	}
+	if r.GetCurrency() == "" {
+		return errors.New("currency is required")
+	}
+	if (r.GetAmount() == "") == (r.GetPriceAmount() == "") {
+		return errors.New("set either amount or price_amount")
+	}
+	if r.GetPriceAmount() != "" && len(r.GetPriceCurrency()) != 3 {
+		return errors.New("price_currency must be a 3-letter currency code")
+	}
+	switch r.GetAddressMode() {
+	case "", "dedicated", "shared":
+	default:
+		return errors.New(`address_mode must be "dedicated" or "shared"`)
+	}
+	if r.GetTtlSeconds() < 0 || (r.GetTtlSeconds() > 0 && r.GetTtlSeconds() < 60) {
+		return errors.New("ttl_seconds must be at least 60")
+	}
+	if len(r.GetRedirectUrl()) > 512 {
+		return errors.New("redirect_url must be at most 512 characters")
+	}
+	return nil
+}
+
+func (r *GetInvoiceRequest) Validate() error {
+	return validatePublicID(r.GetId())
+}
+
+func (r *ListInvoicesRequest) Validate() error {
+	switch r.GetStatus() {
+	case "", "pending", "underpaid", "paid", "overpaid", "expired":
+	default:
+		return errors.New("status must be pending, underpaid, paid, overpaid or expired")
+	}
+	if r.GetBeforeId() < 0 {
+		return errors.New("before_id must not be negative")
+	}
+	if r.GetLimit() < 0 || r.GetLimit() > 100 {
+		return errors.New("limit must be between 0 and 100")
+	}
+	return nil
+}
+
+func (r *GetCheckoutRequest) Validate() error {
+	return validatePublicID(r.GetId())
+}
+
+func validatePublicID(id string) error {
+	if !strings.HasPrefix(id, "inv_") || len(id) > 40 {
+		return errors.New("id must be an invoice public id (inv_...)")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Hand-written request validation, checked by interceptor.Validate before the handler runs.
+// Not generated: keep this file when regenerating wallet.pb.go.
+
+package walletv1
+
+import "errors"
+
+func (r *CreateAddressRequest) Validate() error {
+	if r.GetCurrency() == "" {
+		return errors.New("currency is required")
+	}
+	return nil
+}
+
+func (r *CreateWithdrawalRequest) Validate() error {
+	if r.GetToAddress() == "" || r.GetAmount() == "" || r.GetCurrency() == "" {
+		return errors.New("to_address, amount and currency are required")
+	}
+	if r.GetExecuteAfter() < 0 {
+		return errors.New("execute_after must be a unix timestamp")
+	}
+	return nil
+}
+
+func (r *QuoteWithdrawalRequest) Validate() error {
+	if r.GetCurrency() == "" {
+		return errors.New("currency is required")
+	}
+	switch r.GetLevel() {
+	case "", "slow", "standard", "fast":
+	default:
+		return errors.New(`level must be "slow", "standard" or "fast"`)
+	}
+	return nil
+}
+
+func (r *ListTransactionsRequest) Validate() error {
+	if r.GetLimit() < 0 || r.GetLimit() > 100 {
+		return errors.New("limit must be between 0 and 100")
+	}
+	if r.GetStartTime() < 0 || r.GetEndTime() < 0 {
+		return errors.New("start_time and end_time must be unix timestamps")
+	}
+	if r.GetStartTime() > 0 && r.GetEndTime() > 0 && r.GetStartTime() >= r.GetEndTime() {
+		return errors.New("start_time must be before end_time")
+	}
+	return nil
+}
+
+func (r *WatchAccountEventsRequest) Validate() error {
+	for _, t := range r.GetTypes() {
+		switch t {
+		case "deposit.detected", "deposit.confirmed", "withdrawal.status", "balance.changed":
+		default:
+			return errors.New("unknown event type " + t)
+		}
+	}
+	return nil
+}
+
+func (r *ValidateAddressRequest) Validate() error {
+	if r.GetCurrency() == "" || r.GetAddress() == "" {
+		return errors.New("currency and address are required")
+	}
+	return nil
+}
+
+func (r *GetWithdrawalRequest) Validate() error {
+	if r.GetWithdrawalId() <= 0 {
+		return errors.New("withdrawal_id is required")
+	}
+	return nil
+}
+
+func (r *CancelWithdrawalRequest) Validate() error {
+	if r.GetWithdrawalId() <= 0 {
+		return errors.New("withdrawal_id is required")
+	}
+	return nil
+}
+
+func (r *GetDepositRequest) Validate() error {
+	if r.GetDepositId() <= 0 {
+		return errors.New("deposit_id is required")
+	}
+	return nil
+}
@@ -21,17 +21,73 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type CreateAddressRequest struct {
+// Money is an amount of a currency.
+// amount is a decimal string in whole coin units (e.g. "0.5" ETH), never a float
+// and never the smallest unit (Wei / satoshi).
+type Money struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        string                 `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
 	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"` // e.g., "ETH", "BTC"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_api_proto_wallet_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type CreateAddressRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in api/proto/wallet.proto.
+	UserId        int64  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Ignored, the user comes from the access token
+	Currency      string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`            // e.g., "ETH", "BTC"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
 func (x *CreateAddressRequest) Reset() {
 	*x = CreateAddressRequest{}
-	mi := &file_api_proto_wallet_proto_msgTypes[0]
+	mi := &file_api_proto_wallet_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -43,7 +99,7 @@ func (x *CreateAddressRequest) String() string {
 func (*CreateAddressRequest) ProtoMessage() {}
 
 func (x *CreateAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_wallet_proto_msgTypes[0]
+	mi := &file_api_proto_wallet_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -56,9 +112,10 @@ func (x *CreateAddressRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateAddressRequest.ProtoReflect.Descriptor instead.
 func (*CreateAddressRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_wallet_proto_rawDescGZIP(), []int{0}
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{1}
 }
 
+// Deprecated: Marked as deprecated in api/proto/wallet.proto.
 func (x *CreateAddressRequest) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
@@ -82,7 +139,7 @@ type CreateAddressResponse struct {
 
 func (x *CreateAddressResponse) Reset() {
 	*x = CreateAddressResponse{}
-	mi := &file_api_proto_wallet_proto_msgTypes[1]
+	mi := &file_api_proto_wallet_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -94,7 +151,7 @@ func (x *CreateAddressResponse) String() string {
 func (*CreateAddressResponse) ProtoMessage() {}
 
 func (x *CreateAddressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_wallet_proto_msgTypes[1]
+	mi := &file_api_proto_wallet_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -107,7 +164,7 @@ func (x *CreateAddressResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateAddressResponse.ProtoReflect.Descriptor instead.
 func (*CreateAddressResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_wallet_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateAddressResponse) GetAddress() string {
@@ -118,16 +175,17 @@ func (x *CreateAddressResponse) GetAddress() string {
 }
 
 type GetBalanceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"` // Optional, empty means all
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in api/proto/wallet.proto.
+	UserId        int64  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Ignored, the user comes from the access token
+	Currency      string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`            // Optional, empty means all
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetBalanceRequest) Reset() {
 	*x = GetBalanceRequest{}
-	mi := &file_api_proto_wallet_proto_msgTypes[2]
+	mi := &file_api_proto_wallet_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -139,7 +197,7 @@ func (x *GetBalanceRequest) String() string {
 func (*GetBalanceRequest) ProtoMessage() {}
 
 func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_wallet_proto_msgTypes[2]
+	mi := &file_api_proto_wallet_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -152,9 +210,10 @@ func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
 func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_wallet_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{3}
 }
 
+// Deprecated: Marked as deprecated in api/proto/wallet.proto.
 func (x *GetBalanceRequest) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
@@ -178,7 +237,7 @@ type GetBalanceResponse struct {
 
 func (x *GetBalanceResponse) Reset() {
 	*x = GetBalanceResponse{}
-	mi := &file_api_proto_wallet_proto_msgTypes[3]
+	mi := &file_api_proto_wallet_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -190,7 +249,7 @@ func (x *GetBalanceResponse) String() string {
 func (*GetBalanceResponse) ProtoMessage() {}
 
 func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_wallet_proto_msgTypes[3]
+	mi := &file_api_proto_wallet_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -203,7 +262,7 @@ func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
 func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_wallet_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetBalanceResponse) GetBalances() map[string]string {
@@ -214,18 +273,21 @@ func (x *GetBalanceResponse) GetBalances() map[string]string {
 }
 
 type CreateWithdrawalRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	ToAddress     string                 `protobuf:"bytes,2,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
-	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in api/proto/wallet.proto.
+	UserId        int64  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Ignored, the user comes from the access token
+	ToAddress     string `protobuf:"bytes,2,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	Amount        string `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	ExecuteAfter  int64  `protobuf:"varint,5,opt,name=execute_after,json=executeAfter,proto3" json:"execute_after,omitempty"` // Optional, unix seconds. Schedule the withdrawal for a future time
+	TotpCode      string `protobuf:"bytes,6,opt,name=totp_code,json=totpCode,proto3" json:"totp_code,omitempty"`              // Required once TOTP is enabled. Authenticator code or backup code
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateWithdrawalRequest) Reset() {
 	*x = CreateWithdrawalRequest{}
-	mi := &file_api_proto_wallet_proto_msgTypes[4]
+	mi := &file_api_proto_wallet_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -237,7 +299,7 @@ func (x *CreateWithdrawalRequest) String() string {
 func (*CreateWithdrawalRequest) ProtoMessage() {}
 
 func (x *CreateWithdrawalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_wallet_proto_msgTypes[4]
+	mi := &file_api_proto_wallet_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -250,9 +312,10 @@ func (x *CreateWithdrawalRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateWithdrawalRequest.ProtoReflect.Descriptor instead.
 func (*CreateWithdrawalRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_wallet_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{5}
 }
 
+// Deprecated: Marked as deprecated in api/proto/wallet.proto.
 func (x *CreateWithdrawalRequest) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
@@ -281,17 +344,33 @@ func (x *CreateWithdrawalRequest) GetCurrency() string {
 	return ""
 }
 
+func (x *CreateWithdrawalRequest) GetExecuteAfter() int64 {
+	if x != nil {
+		return x.ExecuteAfter
+	}
+	return 0
+}
+
+func (x *CreateWithdrawalRequest) GetTotpCode() string {
+	if x != nil {
+		return x.TotpCode
+	}
+	return ""
+}
+
 type CreateWithdrawalResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WithdrawalId  int64                  `protobuf:"varint,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "pending_review"
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                                  // "pending_review" or "risk_hold"; "completed" if the address belongs to another user and was settled internally
+	ExecuteAfter  int64                  `protobuf:"varint,3,opt,name=execute_after,json=executeAfter,proto3" json:"execute_after,omitempty"` // Unix seconds, 0 if the withdrawal executes right after approval
+	TransferId    int64                  `protobuf:"varint,4,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`       // Set when settled internally instead of on-chain
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateWithdrawalResponse) Reset() {
 	*x = CreateWithdrawalResponse{}
-	mi := &file_api_proto_wallet_proto_msgTypes[5]
+	mi := &file_api_proto_wallet_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -303,7 +382,7 @@ func (x *CreateWithdrawalResponse) String() string {
 func (*CreateWithdrawalResponse) ProtoMessage() {}
 
 func (x *CreateWithdrawalResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_wallet_proto_msgTypes[5]
+	mi := &file_api_proto_wallet_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -316,7 +395,7 @@ func (x *CreateWithdrawalResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateWithdrawalResponse.ProtoReflect.Descriptor instead.
 func (*CreateWithdrawalResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_wallet_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CreateWithdrawalResponse) GetWithdrawalId() int64 {
@@ -333,38 +412,2213 @@ func (x *CreateWithdrawalResponse) GetStatus() string {
 	return ""
 }
 
-var File_api_proto_wallet_proto protoreflect.FileDescriptor
+func (x *CreateWithdrawalResponse) GetExecuteAfter() int64 {
+	if x != nil {
+		return x.ExecuteAfter
+	}
+	return 0
+}
 
-const file_api_proto_wallet_proto_rawDesc = "" +
-	"\n" +
-	"\x16api/proto/wallet.proto\x12\twallet.v1\"K\n" +
-	"\x14CreateAddressRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
-	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"1\n" +
-	"\x15CreateAddressResponse\x12\x18\n" +
-	"\aaddress\x18\x01 \x01(\tR\aaddress\"H\n" +
-	"\x11GetBalanceRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1a\n" +
-	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\x9a\x01\n" +
-	"\x12GetBalanceResponse\x12G\n" +
-	"\bbalances\x18\x01 \x03(\v2+.wallet.v1.GetBalanceResponse.BalancesEntryR\bbalances\x1a;\n" +
-	"\rBalancesEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x85\x01\n" +
-	"\x17CreateWithdrawalRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1d\n" +
-	"\n" +
-	"to_address\x18\x02 \x01(\tR\ttoAddress\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\tR\x06amount\x12\x1a\n" +
-	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"W\n" +
-	"\x18CreateWithdrawalResponse\x12#\n" +
-	"\rwithdrawal_id\x18\x01 \x01(\x03R\fwithdrawalId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status2\x8b\x02\n" +
-	"\rWalletService\x12R\n" +
-	"\rCreateAddress\x12\x1f.wallet.v1.CreateAddressRequest\x1a .wallet.v1.CreateAddressResponse\x12I\n" +
+func (x *CreateWithdrawalResponse) GetTransferId() int64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+type QuoteWithdrawalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`                    // e.g., "ETH", "BTC"
+	ToAddress     string                 `protobuf:"bytes,2,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"` // Optional, used for gas estimation
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`                        // Optional
+	Level         string                 `protobuf:"bytes,4,opt,name=level,proto3" json:"level,omitempty"`                          // "slow", "standard" (default), "fast"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteWithdrawalRequest) Reset() {
+	*x = QuoteWithdrawalRequest{}
+	mi := &file_api_proto_wallet_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteWithdrawalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteWithdrawalRequest) ProtoMessage() {}
+
+func (x *QuoteWithdrawalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteWithdrawalRequest.ProtoReflect.Descriptor instead.
+func (*QuoteWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *QuoteWithdrawalRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *QuoteWithdrawalRequest) GetToAddress() string {
+	if x != nil {
+		return x.ToAddress
+	}
+	return ""
+}
+
+func (x *QuoteWithdrawalRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *QuoteWithdrawalRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+type QuoteWithdrawalResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Currency             string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Level                string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	NetworkFee           string                 `protobuf:"bytes,3,opt,name=network_fee,json=networkFee,proto3" json:"network_fee,omitempty"`                                     // Estimated network fee in coin units (ETH / BTC)
+	GasLimit             uint64                 `protobuf:"varint,4,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`                                          // EVM
+	GasPrice             string                 `protobuf:"bytes,5,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`                                           // EVM legacy, Wei
+	MaxFeePerGas         string                 `protobuf:"bytes,6,opt,name=max_fee_per_gas,json=maxFeePerGas,proto3" json:"max_fee_per_gas,omitempty"`                           // EVM EIP-1559, Wei
+	MaxPriorityFeePerGas string                 `protobuf:"bytes,7,opt,name=max_priority_fee_per_gas,json=maxPriorityFeePerGas,proto3" json:"max_priority_fee_per_gas,omitempty"` // EVM EIP-1559, Wei
+	FeeRate              int64                  `protobuf:"varint,8,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`                                             // BTC, sat/vB
+	Fallback             bool                   `protobuf:"varint,9,opt,name=fallback,proto3" json:"fallback,omitempty"`                                                          // true if the node was unreachable and a fallback value was used
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *QuoteWithdrawalResponse) Reset() {
+	*x = QuoteWithdrawalResponse{}
+	mi := &file_api_proto_wallet_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteWithdrawalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteWithdrawalResponse) ProtoMessage() {}
+
+func (x *QuoteWithdrawalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteWithdrawalResponse.ProtoReflect.Descriptor instead.
+func (*QuoteWithdrawalResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QuoteWithdrawalResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *QuoteWithdrawalResponse) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *QuoteWithdrawalResponse) GetNetworkFee() string {
+	if x != nil {
+		return x.NetworkFee
+	}
+	return ""
+}
+
+func (x *QuoteWithdrawalResponse) GetGasLimit() uint64 {
+	if x != nil {
+		return x.GasLimit
+	}
+	return 0
+}
+
+func (x *QuoteWithdrawalResponse) GetGasPrice() string {
+	if x != nil {
+		return x.GasPrice
+	}
+	return ""
+}
+
+func (x *QuoteWithdrawalResponse) GetMaxFeePerGas() string {
+	if x != nil {
+		return x.MaxFeePerGas
+	}
+	return ""
+}
+
+func (x *QuoteWithdrawalResponse) GetMaxPriorityFeePerGas() string {
+	if x != nil {
+		return x.MaxPriorityFeePerGas
+	}
+	return ""
+}
+
+func (x *QuoteWithdrawalResponse) GetFeeRate() int64 {
+	if x != nil {
+		return x.FeeRate
+	}
+	return 0
+}
+
+func (x *QuoteWithdrawalResponse) GetFallback() bool {
+	if x != nil {
+		return x.Fallback
+	}
+	return false
+}
+
+type ListTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`                     // Optional, e.g., "ETH", "BTC"
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                         // Optional, deposit / withdrawal status
+	TxHash        string                 `protobuf:"bytes,3,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`           // Optional, exact match
+	StartTime     int64                  `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // Optional, unix seconds, inclusive
+	EndTime       int64                  `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // Optional, unix seconds, exclusive
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`                         // next_cursor from the previous page, empty for the first page
+	Limit         int32                  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`                          // Default 20, max 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_api_proto_wallet_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListTransactionsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Transaction         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty when there are no more pages
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_api_proto_wallet_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListTransactionsResponse) GetItems() []*Transaction {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type Transaction struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Kind                  string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // "deposit" or "withdrawal"
+	Id                    int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Currency              string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	TxHash                string                 `protobuf:"bytes,4,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Amount                string                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"` // String for precision
+	Status                string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Address               string                 `protobuf:"bytes,7,opt,name=address,proto3" json:"address,omitempty"` // Counterparty: sender for deposits, recipient for withdrawals
+	Confirmations         uint64                 `protobuf:"varint,8,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	RequiredConfirmations uint64                 `protobuf:"varint,9,opt,name=required_confirmations,json=requiredConfirmations,proto3" json:"required_confirmations,omitempty"`
+	ExplorerUrl           string                 `protobuf:"bytes,10,opt,name=explorer_url,json=explorerUrl,proto3" json:"explorer_url,omitempty"`
+	CreatedAt             int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`       // Unix seconds
+	ConfirmedAt           int64                  `protobuf:"varint,12,opt,name=confirmed_at,json=confirmedAt,proto3" json:"confirmed_at,omitempty"` // Unix seconds, 0 if not confirmed yet
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_api_proto_wallet_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Transaction) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Transaction) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Transaction) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Transaction) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Transaction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Transaction) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Transaction) GetConfirmations() uint64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *Transaction) GetRequiredConfirmations() uint64 {
+	if x != nil {
+		return x.RequiredConfirmations
+	}
+	return 0
+}
+
+func (x *Transaction) GetExplorerUrl() string {
+	if x != nil {
+		return x.ExplorerUrl
+	}
+	return ""
+}
+
+func (x *Transaction) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Transaction) GetConfirmedAt() int64 {
+	if x != nil {
+		return x.ConfirmedAt
+	}
+	return 0
+}
+
+type WatchAccountEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LastEventId   string                 `protobuf:"bytes,1,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"` // Optional, resume after this event. Empty means new events only
+	Types         []string               `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`                                  // Optional, e.g., "deposit.confirmed". Empty means all types
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAccountEventsRequest) Reset() {
+	*x = WatchAccountEventsRequest{}
+	mi := &file_api_proto_wallet_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAccountEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAccountEventsRequest) ProtoMessage() {}
+
+func (x *WatchAccountEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAccountEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchAccountEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchAccountEventsRequest) GetLastEventId() string {
+	if x != nil {
+		return x.LastEventId
+	}
+	return ""
+}
+
+func (x *WatchAccountEventsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type AccountEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                 // Pass as last_event_id to resume after a reconnect
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                             // "deposit.detected", "deposit.confirmed", "withdrawal.status", "balance.changed", "transfer.sent", "transfer.received", "resync"
+	Data          string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`                             // JSON payload, depends on type
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountEvent) Reset() {
+	*x = AccountEvent{}
+	mi := &file_api_proto_wallet_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountEvent) ProtoMessage() {}
+
+func (x *AccountEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountEvent.ProtoReflect.Descriptor instead.
+func (*AccountEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AccountEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AccountEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AccountEvent) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *AccountEvent) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ListAddressesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"` // Optional, empty means all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressesRequest) Reset() {
+	*x = ListAddressesRequest{}
+	mi := &file_api_proto_wallet_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressesRequest) ProtoMessage() {}
+
+func (x *ListAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressesRequest.ProtoReflect.Descriptor instead.
+func (*ListAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListAddressesRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ListAddressesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addresses     []*DepositAddress      `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressesResponse) Reset() {
+	*x = ListAddressesResponse{}
+	mi := &file_api_proto_wallet_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressesResponse) ProtoMessage() {}
+
+func (x *ListAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressesResponse.ProtoReflect.Descriptor instead.
+func (*ListAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListAddressesResponse) GetAddresses() []*DepositAddress {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type DepositAddress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepositAddress) Reset() {
+	*x = DepositAddress{}
+	mi := &file_api_proto_wallet_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepositAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepositAddress) ProtoMessage() {}
+
+func (x *DepositAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepositAddress.ProtoReflect.Descriptor instead.
+func (*DepositAddress) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DepositAddress) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *DepositAddress) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *DepositAddress) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ValidateAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateAddressRequest) Reset() {
+	*x = ValidateAddressRequest{}
+	mi := &file_api_proto_wallet_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAddressRequest) ProtoMessage() {}
+
+func (x *ValidateAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAddressRequest.ProtoReflect.Descriptor instead.
+func (*ValidateAddressRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ValidateAddressRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ValidateAddressRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type ValidateAddressResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Valid             bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	NormalizedAddress string                 `protobuf:"bytes,2,opt,name=normalized_address,json=normalizedAddress,proto3" json:"normalized_address,omitempty"` // Canonical form (EIP-55 checksum for ETH), set when valid
+	Reason            string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                                                // Why the address is invalid
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ValidateAddressResponse) Reset() {
+	*x = ValidateAddressResponse{}
+	mi := &file_api_proto_wallet_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAddressResponse) ProtoMessage() {}
+
+func (x *ValidateAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAddressResponse.ProtoReflect.Descriptor instead.
+func (*ValidateAddressResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ValidateAddressResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateAddressResponse) GetNormalizedAddress() string {
+	if x != nil {
+		return x.NormalizedAddress
+	}
+	return ""
+}
+
+func (x *ValidateAddressResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetSupportedAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSupportedAssetsRequest) Reset() {
+	*x = GetSupportedAssetsRequest{}
+	mi := &file_api_proto_wallet_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSupportedAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSupportedAssetsRequest) ProtoMessage() {}
+
+func (x *GetSupportedAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSupportedAssetsRequest.ProtoReflect.Descriptor instead.
+func (*GetSupportedAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{19}
+}
+
+type GetSupportedAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assets        []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSupportedAssetsResponse) Reset() {
+	*x = GetSupportedAssetsResponse{}
+	mi := &file_api_proto_wallet_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSupportedAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSupportedAssetsResponse) ProtoMessage() {}
+
+func (x *GetSupportedAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSupportedAssetsResponse.ProtoReflect.Descriptor instead.
+func (*GetSupportedAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetSupportedAssetsResponse) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+type Asset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`                       // e.g., "ETH"
+	Chain         string                 `protobuf:"bytes,2,opt,name=chain,proto3" json:"chain,omitempty"`                             // Network name, e.g., "Ethereum"
+	Decimals      int32                  `protobuf:"varint,3,opt,name=decimals,proto3" json:"decimals,omitempty"`                      // Amounts may not have more decimal places than this
+	MinDeposit    *Money                 `protobuf:"bytes,4,opt,name=min_deposit,json=minDeposit,proto3" json:"min_deposit,omitempty"` // Smaller deposits are not credited
+	MinWithdrawal *Money                 `protobuf:"bytes,5,opt,name=min_withdrawal,json=minWithdrawal,proto3" json:"min_withdrawal,omitempty"`
+	Confirmations uint64                 `protobuf:"varint,6,opt,name=confirmations,proto3" json:"confirmations,omitempty"` // Confirmations required before a deposit is credited
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Asset) Reset() {
+	*x = Asset{}
+	mi := &file_api_proto_wallet_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Asset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Asset) ProtoMessage() {}
+
+func (x *Asset) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Asset.ProtoReflect.Descriptor instead.
+func (*Asset) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Asset) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Asset) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *Asset) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *Asset) GetMinDeposit() *Money {
+	if x != nil {
+		return x.MinDeposit
+	}
+	return nil
+}
+
+func (x *Asset) GetMinWithdrawal() *Money {
+	if x != nil {
+		return x.MinWithdrawal
+	}
+	return nil
+}
+
+func (x *Asset) GetConfirmations() uint64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+type GetWithdrawalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId  int64                  `protobuf:"varint,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWithdrawalRequest) Reset() {
+	*x = GetWithdrawalRequest{}
+	mi := &file_api_proto_wallet_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWithdrawalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWithdrawalRequest) ProtoMessage() {}
+
+func (x *GetWithdrawalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWithdrawalRequest.ProtoReflect.Descriptor instead.
+func (*GetWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetWithdrawalRequest) GetWithdrawalId() int64 {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return 0
+}
+
+type GetWithdrawalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Withdrawal    *Withdrawal            `protobuf:"bytes,1,opt,name=withdrawal,proto3" json:"withdrawal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWithdrawalResponse) Reset() {
+	*x = GetWithdrawalResponse{}
+	mi := &file_api_proto_wallet_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWithdrawalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWithdrawalResponse) ProtoMessage() {}
+
+func (x *GetWithdrawalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWithdrawalResponse.ProtoReflect.Descriptor instead.
+func (*GetWithdrawalResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetWithdrawalResponse) GetWithdrawal() *Withdrawal {
+	if x != nil {
+		return x.Withdrawal
+	}
+	return nil
+}
+
+type CancelWithdrawalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId  int64                  `protobuf:"varint,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelWithdrawalRequest) Reset() {
+	*x = CancelWithdrawalRequest{}
+	mi := &file_api_proto_wallet_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelWithdrawalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelWithdrawalRequest) ProtoMessage() {}
+
+func (x *CancelWithdrawalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelWithdrawalRequest.ProtoReflect.Descriptor instead.
+func (*CancelWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CancelWithdrawalRequest) GetWithdrawalId() int64 {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return 0
+}
+
+type CancelWithdrawalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Withdrawal    *Withdrawal            `protobuf:"bytes,1,opt,name=withdrawal,proto3" json:"withdrawal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelWithdrawalResponse) Reset() {
+	*x = CancelWithdrawalResponse{}
+	mi := &file_api_proto_wallet_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelWithdrawalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelWithdrawalResponse) ProtoMessage() {}
+
+func (x *CancelWithdrawalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelWithdrawalResponse.ProtoReflect.Descriptor instead.
+func (*CancelWithdrawalResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CancelWithdrawalResponse) GetWithdrawal() *Withdrawal {
+	if x != nil {
+		return x.Withdrawal
+	}
+	return nil
+}
+
+type Withdrawal struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ToAddress             string                 `protobuf:"bytes,2,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	Amount                *Money                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status                string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	TxHash                string                 `protobuf:"bytes,5,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Confirmations         uint64                 `protobuf:"varint,6,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	RequiredConfirmations uint64                 `protobuf:"varint,7,opt,name=required_confirmations,json=requiredConfirmations,proto3" json:"required_confirmations,omitempty"`
+	ExplorerUrl           string                 `protobuf:"bytes,8,opt,name=explorer_url,json=explorerUrl,proto3" json:"explorer_url,omitempty"`
+	NetworkFee            *Money                 `protobuf:"bytes,9,opt,name=network_fee,json=networkFee,proto3" json:"network_fee,omitempty"` // Set once broadcast (EVM only)
+	FailReason            string                 `protobuf:"bytes,10,opt,name=fail_reason,json=failReason,proto3" json:"fail_reason,omitempty"`
+	ExecuteAfter          int64                  `protobuf:"varint,11,opt,name=execute_after,json=executeAfter,proto3" json:"execute_after,omitempty"` // Unix seconds, 0 if none
+	CreatedAt             int64                  `protobuf:"varint,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`          // Unix seconds
+	ConfirmedAt           int64                  `protobuf:"varint,13,opt,name=confirmed_at,json=confirmedAt,proto3" json:"confirmed_at,omitempty"`    // Unix seconds, 0 if not confirmed yet
+	TransferId            int64                  `protobuf:"varint,14,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`       // Set when settled internally instead of on-chain
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Withdrawal) Reset() {
+	*x = Withdrawal{}
+	mi := &file_api_proto_wallet_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Withdrawal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Withdrawal) ProtoMessage() {}
+
+func (x *Withdrawal) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Withdrawal.ProtoReflect.Descriptor instead.
+func (*Withdrawal) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Withdrawal) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetToAddress() string {
+	if x != nil {
+		return x.ToAddress
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Withdrawal) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetConfirmations() uint64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetRequiredConfirmations() uint64 {
+	if x != nil {
+		return x.RequiredConfirmations
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetExplorerUrl() string {
+	if x != nil {
+		return x.ExplorerUrl
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetNetworkFee() *Money {
+	if x != nil {
+		return x.NetworkFee
+	}
+	return nil
+}
+
+func (x *Withdrawal) GetFailReason() string {
+	if x != nil {
+		return x.FailReason
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetExecuteAfter() int64 {
+	if x != nil {
+		return x.ExecuteAfter
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetConfirmedAt() int64 {
+	if x != nil {
+		return x.ConfirmedAt
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetTransferId() int64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+type GetDepositRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DepositId     int64                  `protobuf:"varint,1,opt,name=deposit_id,json=depositId,proto3" json:"deposit_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDepositRequest) Reset() {
+	*x = GetDepositRequest{}
+	mi := &file_api_proto_wallet_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDepositRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDepositRequest) ProtoMessage() {}
+
+func (x *GetDepositRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDepositRequest.ProtoReflect.Descriptor instead.
+func (*GetDepositRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetDepositRequest) GetDepositId() int64 {
+	if x != nil {
+		return x.DepositId
+	}
+	return 0
+}
+
+type GetDepositResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deposit       *Deposit               `protobuf:"bytes,1,opt,name=deposit,proto3" json:"deposit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDepositResponse) Reset() {
+	*x = GetDepositResponse{}
+	mi := &file_api_proto_wallet_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDepositResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDepositResponse) ProtoMessage() {}
+
+func (x *GetDepositResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDepositResponse.ProtoReflect.Descriptor instead.
+func (*GetDepositResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetDepositResponse) GetDeposit() *Deposit {
+	if x != nil {
+		return x.Deposit
+	}
+	return nil
+}
+
+type Deposit struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromAddress           string                 `protobuf:"bytes,2,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"`
+	Amount                *Money                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status                string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	TxHash                string                 `protobuf:"bytes,5,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Confirmations         uint64                 `protobuf:"varint,6,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	RequiredConfirmations uint64                 `protobuf:"varint,7,opt,name=required_confirmations,json=requiredConfirmations,proto3" json:"required_confirmations,omitempty"`
+	ExplorerUrl           string                 `protobuf:"bytes,8,opt,name=explorer_url,json=explorerUrl,proto3" json:"explorer_url,omitempty"`
+	BlockHeight           uint64                 `protobuf:"varint,9,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	CreatedAt             int64                  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`       // Unix seconds
+	ConfirmedAt           int64                  `protobuf:"varint,11,opt,name=confirmed_at,json=confirmedAt,proto3" json:"confirmed_at,omitempty"` // Unix seconds, 0 if not confirmed yet
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Deposit) Reset() {
+	*x = Deposit{}
+	mi := &file_api_proto_wallet_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Deposit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deposit) ProtoMessage() {}
+
+func (x *Deposit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deposit.ProtoReflect.Descriptor instead.
+func (*Deposit) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Deposit) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Deposit) GetFromAddress() string {
+	if x != nil {
+		return x.FromAddress
+	}
+	return ""
+}
+
+func (x *Deposit) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Deposit) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Deposit) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Deposit) GetConfirmations() uint64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *Deposit) GetRequiredConfirmations() uint64 {
+	if x != nil {
+		return x.RequiredConfirmations
+	}
+	return 0
+}
+
+func (x *Deposit) GetExplorerUrl() string {
+	if x != nil {
+		return x.ExplorerUrl
+	}
+	return ""
+}
+
+func (x *Deposit) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *Deposit) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Deposit) GetConfirmedAt() int64 {
+	if x != nil {
+		return x.ConfirmedAt
+	}
+	return 0
+}
+
+type CreateTransferRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	To             string                 `protobuf:"bytes,1,opt,name=to,proto3" json:"to,omitempty"` // Recipient username or email, must belong to the same tenant
+	Amount         string                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency       string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`                                   // e.g., "ETH", "BTC"
+	Memo           string                 `protobuf:"bytes,4,opt,name=memo,proto3" json:"memo,omitempty"`                                           // Optional, visible to both users
+	IdempotencyKey string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // Optional. Retrying with the same key returns the original transfer
+	TotpCode       string                 `protobuf:"bytes,6,opt,name=totp_code,json=totpCode,proto3" json:"totp_code,omitempty"`                   // Required once TOTP is enabled. Authenticator code or backup code
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateTransferRequest) Reset() {
+	*x = CreateTransferRequest{}
+	mi := &file_api_proto_wallet_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransferRequest) ProtoMessage() {}
+
+func (x *CreateTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransferRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransferRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateTransferRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetTotpCode() string {
+	if x != nil {
+		return x.TotpCode
+	}
+	return ""
+}
+
+type CreateTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfer      *Transfer              `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTransferResponse) Reset() {
+	*x = CreateTransferResponse{}
+	mi := &file_api_proto_wallet_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransferResponse) ProtoMessage() {}
+
+func (x *CreateTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransferResponse.ProtoReflect.Descriptor instead.
+func (*CreateTransferResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateTransferResponse) GetTransfer() *Transfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+type GetTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    int64                  `protobuf:"varint,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferRequest) Reset() {
+	*x = GetTransferRequest{}
+	mi := &file_api_proto_wallet_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferRequest) ProtoMessage() {}
+
+func (x *GetTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetTransferRequest) GetTransferId() int64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+type GetTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfer      *Transfer              `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferResponse) Reset() {
+	*x = GetTransferResponse{}
+	mi := &file_api_proto_wallet_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferResponse) ProtoMessage() {}
+
+func (x *GetTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferResponse.ProtoReflect.Descriptor instead.
+func (*GetTransferResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetTransferResponse) GetTransfer() *Transfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+type ListTransfersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`                  // Optional
+	BeforeId      int64                  `protobuf:"varint,2,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"` // id of the last transfer on the previous page, 0 for the first page
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                       // Default 20, max 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransfersRequest) Reset() {
+	*x = ListTransfersRequest{}
+	mi := &file_api_proto_wallet_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransfersRequest) ProtoMessage() {}
+
+func (x *ListTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransfersRequest.ProtoReflect.Descriptor instead.
+func (*ListTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListTransfersRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListTransfersRequest) GetBeforeId() int64 {
+	if x != nil {
+		return x.BeforeId
+	}
+	return 0
+}
+
+func (x *ListTransfersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTransfersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfers     []*Transfer            `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransfersResponse) Reset() {
+	*x = ListTransfersResponse{}
+	mi := &file_api_proto_wallet_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransfersResponse) ProtoMessage() {}
+
+func (x *ListTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransfersResponse.ProtoReflect.Descriptor instead.
+func (*ListTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListTransfersResponse) GetTransfers() []*Transfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+type Transfer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Direction     string                 `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"` // "out" or "in", from the caller's point of view
+	FromUserId    int64                  `protobuf:"varint,3,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`
+	ToUserId      int64                  `protobuf:"varint,4,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Memo          string                 `protobuf:"bytes,6,opt,name=memo,proto3" json:"memo,omitempty"`
+	Source        string                 `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`                                  // "transfer", or "withdrawal" when a withdrawal to a platform address was settled internally
+	WithdrawalId  int64                  `protobuf:"varint,8,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"` // Set when source is "withdrawal"
+	ToAddress     string                 `protobuf:"bytes,9,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`           // Set when source is "withdrawal"
+	CreatedAt     int64                  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`         // Unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transfer) Reset() {
+	*x = Transfer{}
+	mi := &file_api_proto_wallet_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transfer) ProtoMessage() {}
+
+func (x *Transfer) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wallet_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transfer.ProtoReflect.Descriptor instead.
+func (*Transfer) Descriptor() ([]byte, []int) {
+	return file_api_proto_wallet_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Transfer) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Transfer) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *Transfer) GetFromUserId() int64 {
+	if x != nil {
+		return x.FromUserId
+	}
+	return 0
+}
+
+func (x *Transfer) GetToUserId() int64 {
+	if x != nil {
+		return x.ToUserId
+	}
+	return 0
+}
+
+func (x *Transfer) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Transfer) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *Transfer) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Transfer) GetWithdrawalId() int64 {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return 0
+}
+
+func (x *Transfer) GetToAddress() string {
+	if x != nil {
+		return x.ToAddress
+	}
+	return ""
+}
+
+func (x *Transfer) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+var File_api_proto_wallet_proto protoreflect.FileDescriptor
+
+const file_api_proto_wallet_proto_rawDesc = "" +
+	"\n" +
+	"\x16api/proto/wallet.proto\x12\twallet.v1\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"O\n" +
+	"\x14CreateAddressRequest\x12\x1b\n" +
+	"\auser_id\x18\x01 \x01(\x03B\x02\x18\x01R\x06userId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"1\n" +
+	"\x15CreateAddressResponse\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"L\n" +
+	"\x11GetBalanceRequest\x12\x1b\n" +
+	"\auser_id\x18\x01 \x01(\x03B\x02\x18\x01R\x06userId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\x9a\x01\n" +
+	"\x12GetBalanceResponse\x12G\n" +
+	"\bbalances\x18\x01 \x03(\v2+.wallet.v1.GetBalanceResponse.BalancesEntryR\bbalances\x1a;\n" +
+	"\rBalancesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcb\x01\n" +
+	"\x17CreateWithdrawalRequest\x12\x1b\n" +
+	"\auser_id\x18\x01 \x01(\x03B\x02\x18\x01R\x06userId\x12\x1d\n" +
+	"\n" +
+	"to_address\x18\x02 \x01(\tR\ttoAddress\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12#\n" +
+	"\rexecute_after\x18\x05 \x01(\x03R\fexecuteAfter\x12\x1b\n" +
+	"\ttotp_code\x18\x06 \x01(\tR\btotpCode\"\x9d\x01\n" +
+	"\x18CreateWithdrawalResponse\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\x03R\fwithdrawalId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12#\n" +
+	"\rexecute_after\x18\x03 \x01(\x03R\fexecuteAfter\x12\x1f\n" +
+	"\vtransfer_id\x18\x04 \x01(\x03R\n" +
+	"transferId\"\x81\x01\n" +
+	"\x16QuoteWithdrawalRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"to_address\x18\x02 \x01(\tR\ttoAddress\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\tR\x05level\"\xbc\x02\n" +
+	"\x17QuoteWithdrawalResponse\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x1f\n" +
+	"\vnetwork_fee\x18\x03 \x01(\tR\n" +
+	"networkFee\x12\x1b\n" +
+	"\tgas_limit\x18\x04 \x01(\x04R\bgasLimit\x12\x1b\n" +
+	"\tgas_price\x18\x05 \x01(\tR\bgasPrice\x12%\n" +
+	"\x0fmax_fee_per_gas\x18\x06 \x01(\tR\fmaxFeePerGas\x126\n" +
+	"\x18max_priority_fee_per_gas\x18\a \x01(\tR\x14maxPriorityFeePerGas\x12\x19\n" +
+	"\bfee_rate\x18\b \x01(\x03R\afeeRate\x12\x1a\n" +
+	"\bfallback\x18\t \x01(\bR\bfallback\"\xce\x01\n" +
+	"\x17ListTransactionsRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x17\n" +
+	"\atx_hash\x18\x03 \x01(\tR\x06txHash\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x05 \x01(\x03R\aendTime\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\"i\n" +
+	"\x18ListTransactionsResponse\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.wallet.v1.TransactionR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xf2\x02\n" +
+	"\vTransaction\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x17\n" +
+	"\atx_hash\x18\x04 \x01(\tR\x06txHash\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x18\n" +
+	"\aaddress\x18\a \x01(\tR\aaddress\x12$\n" +
+	"\rconfirmations\x18\b \x01(\x04R\rconfirmations\x125\n" +
+	"\x16required_confirmations\x18\t \x01(\x04R\x15requiredConfirmations\x12!\n" +
+	"\fexplorer_url\x18\n" +
+	" \x01(\tR\vexplorerUrl\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12!\n" +
+	"\fconfirmed_at\x18\f \x01(\x03R\vconfirmedAt\"U\n" +
+	"\x19WatchAccountEventsRequest\x12\"\n" +
+	"\rlast_event_id\x18\x01 \x01(\tR\vlastEventId\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\"e\n" +
+	"\fAccountEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\"2\n" +
+	"\x14ListAddressesRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\"P\n" +
+	"\x15ListAddressesResponse\x127\n" +
+	"\taddresses\x18\x01 \x03(\v2\x19.wallet.v1.DepositAddressR\taddresses\"e\n" +
+	"\x0eDepositAddress\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\"N\n" +
+	"\x16ValidateAddressRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\"v\n" +
+	"\x17ValidateAddressResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12-\n" +
+	"\x12normalized_address\x18\x02 \x01(\tR\x11normalizedAddress\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x1b\n" +
+	"\x19GetSupportedAssetsRequest\"F\n" +
+	"\x1aGetSupportedAssetsResponse\x12(\n" +
+	"\x06assets\x18\x01 \x03(\v2\x10.wallet.v1.AssetR\x06assets\"\xe7\x01\n" +
+	"\x05Asset\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05chain\x18\x02 \x01(\tR\x05chain\x12\x1a\n" +
+	"\bdecimals\x18\x03 \x01(\x05R\bdecimals\x121\n" +
+	"\vmin_deposit\x18\x04 \x01(\v2\x10.wallet.v1.MoneyR\n" +
+	"minDeposit\x127\n" +
+	"\x0emin_withdrawal\x18\x05 \x01(\v2\x10.wallet.v1.MoneyR\rminWithdrawal\x12$\n" +
+	"\rconfirmations\x18\x06 \x01(\x04R\rconfirmations\";\n" +
+	"\x14GetWithdrawalRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\x03R\fwithdrawalId\"N\n" +
+	"\x15GetWithdrawalResponse\x125\n" +
+	"\n" +
+	"withdrawal\x18\x01 \x01(\v2\x15.wallet.v1.WithdrawalR\n" +
+	"withdrawal\">\n" +
+	"\x17CancelWithdrawalRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\x03R\fwithdrawalId\"Q\n" +
+	"\x18CancelWithdrawalResponse\x125\n" +
+	"\n" +
+	"withdrawal\x18\x01 \x01(\v2\x15.wallet.v1.WithdrawalR\n" +
+	"withdrawal\"\xf2\x03\n" +
+	"\n" +
+	"Withdrawal\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"to_address\x18\x02 \x01(\tR\ttoAddress\x12(\n" +
+	"\x06amount\x18\x03 \x01(\v2\x10.wallet.v1.MoneyR\x06amount\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x17\n" +
+	"\atx_hash\x18\x05 \x01(\tR\x06txHash\x12$\n" +
+	"\rconfirmations\x18\x06 \x01(\x04R\rconfirmations\x125\n" +
+	"\x16required_confirmations\x18\a \x01(\x04R\x15requiredConfirmations\x12!\n" +
+	"\fexplorer_url\x18\b \x01(\tR\vexplorerUrl\x121\n" +
+	"\vnetwork_fee\x18\t \x01(\v2\x10.wallet.v1.MoneyR\n" +
+	"networkFee\x12\x1f\n" +
+	"\vfail_reason\x18\n" +
+	" \x01(\tR\n" +
+	"failReason\x12#\n" +
+	"\rexecute_after\x18\v \x01(\x03R\fexecuteAfter\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\x03R\tcreatedAt\x12!\n" +
+	"\fconfirmed_at\x18\r \x01(\x03R\vconfirmedAt\x12\x1f\n" +
+	"\vtransfer_id\x18\x0e \x01(\x03R\n" +
+	"transferId\"2\n" +
+	"\x11GetDepositRequest\x12\x1d\n" +
+	"\n" +
+	"deposit_id\x18\x01 \x01(\x03R\tdepositId\"B\n" +
+	"\x12GetDepositResponse\x12,\n" +
+	"\adeposit\x18\x01 \x01(\v2\x12.wallet.v1.DepositR\adeposit\"\xfc\x02\n" +
+	"\aDeposit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
+	"\ffrom_address\x18\x02 \x01(\tR\vfromAddress\x12(\n" +
+	"\x06amount\x18\x03 \x01(\v2\x10.wallet.v1.MoneyR\x06amount\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x17\n" +
+	"\atx_hash\x18\x05 \x01(\tR\x06txHash\x12$\n" +
+	"\rconfirmations\x18\x06 \x01(\x04R\rconfirmations\x125\n" +
+	"\x16required_confirmations\x18\a \x01(\x04R\x15requiredConfirmations\x12!\n" +
+	"\fexplorer_url\x18\b \x01(\tR\vexplorerUrl\x12!\n" +
+	"\fblock_height\x18\t \x01(\x04R\vblockHeight\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt\x12!\n" +
+	"\fconfirmed_at\x18\v \x01(\x03R\vconfirmedAt\"\xb5\x01\n" +
+	"\x15CreateTransferRequest\x12\x0e\n" +
+	"\x02to\x18\x01 \x01(\tR\x02to\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04memo\x18\x04 \x01(\tR\x04memo\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\x12\x1b\n" +
+	"\ttotp_code\x18\x06 \x01(\tR\btotpCode\"I\n" +
+	"\x16CreateTransferResponse\x12/\n" +
+	"\btransfer\x18\x01 \x01(\v2\x13.wallet.v1.TransferR\btransfer\"5\n" +
+	"\x12GetTransferRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\x03R\n" +
+	"transferId\"F\n" +
+	"\x13GetTransferResponse\x12/\n" +
+	"\btransfer\x18\x01 \x01(\v2\x13.wallet.v1.TransferR\btransfer\"e\n" +
+	"\x14ListTransfersRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x1b\n" +
+	"\tbefore_id\x18\x02 \x01(\x03R\bbeforeId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"J\n" +
+	"\x15ListTransfersResponse\x121\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x13.wallet.v1.TransferR\ttransfers\"\xb1\x02\n" +
+	"\bTransfer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1c\n" +
+	"\tdirection\x18\x02 \x01(\tR\tdirection\x12 \n" +
+	"\ffrom_user_id\x18\x03 \x01(\x03R\n" +
+	"fromUserId\x12\x1c\n" +
+	"\n" +
+	"to_user_id\x18\x04 \x01(\x03R\btoUserId\x12(\n" +
+	"\x06amount\x18\x05 \x01(\v2\x10.wallet.v1.MoneyR\x06amount\x12\x12\n" +
+	"\x04memo\x18\x06 \x01(\tR\x04memo\x12\x16\n" +
+	"\x06source\x18\a \x01(\tR\x06source\x12#\n" +
+	"\rwithdrawal_id\x18\b \x01(\x03R\fwithdrawalId\x12\x1d\n" +
+	"\n" +
+	"to_address\x18\t \x01(\tR\ttoAddress\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt2\xd4\v\n" +
+	"\rWalletService\x12R\n" +
+	"\rCreateAddress\x12\x1f.wallet.v1.CreateAddressRequest\x1a .wallet.v1.CreateAddressResponse\x12R\n" +
+	"\rListAddresses\x12\x1f.wallet.v1.ListAddressesRequest\x1a .wallet.v1.ListAddressesResponse\x12X\n" +
+	"\x0fValidateAddress\x12!.wallet.v1.ValidateAddressRequest\x1a\".wallet.v1.ValidateAddressResponse\x12I\n" +
+	"\n" +
+	"GetBalance\x12\x1c.wallet.v1.GetBalanceRequest\x1a\x1d.wallet.v1.GetBalanceResponse\x12a\n" +
+	"\x12GetSupportedAssets\x12$.wallet.v1.GetSupportedAssetsRequest\x1a%.wallet.v1.GetSupportedAssetsResponse\x12[\n" +
+	"\x10CreateWithdrawal\x12\".wallet.v1.CreateWithdrawalRequest\x1a#.wallet.v1.CreateWithdrawalResponse\x12X\n" +
+	"\x0fQuoteWithdrawal\x12!.wallet.v1.QuoteWithdrawalRequest\x1a\".wallet.v1.QuoteWithdrawalResponse\x12R\n" +
+	"\rGetWithdrawal\x12\x1f.wallet.v1.GetWithdrawalRequest\x1a .wallet.v1.GetWithdrawalResponse\x12[\n" +
+	"\x10CancelWithdrawal\x12\".wallet.v1.CancelWithdrawalRequest\x1a#.wallet.v1.CancelWithdrawalResponse\x12I\n" +
 	"\n" +
-	"GetBalance\x12\x1c.wallet.v1.GetBalanceRequest\x1a\x1d.wallet.v1.GetBalanceResponse\x12[\n" +
-	"\x10CreateWithdrawal\x12\".wallet.v1.CreateWithdrawalRequest\x1a#.wallet.v1.CreateWithdrawalResponseB3Z1github.com/wallet-core/api/gen/wallet/v1;walletv1b\x06proto3"
+	"GetDeposit\x12\x1c.wallet.v1.GetDepositRequest\x1a\x1d.wallet.v1.GetDepositResponse\x12U\n" +
+	"\x0eCreateTransfer\x12 .wallet.v1.CreateTransferRequest\x1a!.wallet.v1.CreateTransferResponse\x12L\n" +
+	"\vGetTransfer\x12\x1d.wallet.v1.GetTransferRequest\x1a\x1e.wallet.v1.GetTransferResponse\x12R\n" +
+	"\rListTransfers\x12\x1f.wallet.v1.ListTransfersRequest\x1a .wallet.v1.ListTransfersResponse\x12W\n" +
+	"\fListDeposits\x12\".wallet.v1.ListTransactionsRequest\x1a#.wallet.v1.ListTransactionsResponse\x12Z\n" +
+	"\x0fListWithdrawals\x12\".wallet.v1.ListTransactionsRequest\x1a#.wallet.v1.ListTransactionsResponse\x12[\n" +
+	"\x10ListTransactions\x12\".wallet.v1.ListTransactionsRequest\x1a#.wallet.v1.ListTransactionsResponse\x12U\n" +
+	"\x12WatchAccountEvents\x12$.wallet.v1.WatchAccountEventsRequest\x1a\x17.wallet.v1.AccountEvent0\x01B3Z1github.com/wallet-core/api/gen/wallet/v1;walletv1b\x06proto3"
 
 var (
 	file_api_proto_wallet_proto_rawDescOnce sync.Once
@@ -378,29 +2632,103 @@ func file_api_proto_wallet_proto_rawDescGZIP() []byte {
 	return file_api_proto_wallet_proto_rawDescData
 }
 
-var file_api_proto_wallet_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_proto_wallet_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_api_proto_wallet_proto_goTypes = []any{
-	(*CreateAddressRequest)(nil),     // 0: wallet.v1.CreateAddressRequest
-	(*CreateAddressResponse)(nil),    // 1: wallet.v1.CreateAddressResponse
-	(*GetBalanceRequest)(nil),        // 2: wallet.v1.GetBalanceRequest
-	(*GetBalanceResponse)(nil),       // 3: wallet.v1.GetBalanceResponse
-	(*CreateWithdrawalRequest)(nil),  // 4: wallet.v1.CreateWithdrawalRequest
-	(*CreateWithdrawalResponse)(nil), // 5: wallet.v1.CreateWithdrawalResponse
-	nil,                              // 6: wallet.v1.GetBalanceResponse.BalancesEntry
+	(*Money)(nil),                      // 0: wallet.v1.Money
+	(*CreateAddressRequest)(nil),       // 1: wallet.v1.CreateAddressRequest
+	(*CreateAddressResponse)(nil),      // 2: wallet.v1.CreateAddressResponse
+	(*GetBalanceRequest)(nil),          // 3: wallet.v1.GetBalanceRequest
+	(*GetBalanceResponse)(nil),         // 4: wallet.v1.GetBalanceResponse
+	(*CreateWithdrawalRequest)(nil),    // 5: wallet.v1.CreateWithdrawalRequest
+	(*CreateWithdrawalResponse)(nil),   // 6: wallet.v1.CreateWithdrawalResponse
+	(*QuoteWithdrawalRequest)(nil),     // 7: wallet.v1.QuoteWithdrawalRequest
+	(*QuoteWithdrawalResponse)(nil),    // 8: wallet.v1.QuoteWithdrawalResponse
+	(*ListTransactionsRequest)(nil),    // 9: wallet.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),   // 10: wallet.v1.ListTransactionsResponse
+	(*Transaction)(nil),                // 11: wallet.v1.Transaction
+	(*WatchAccountEventsRequest)(nil),  // 12: wallet.v1.WatchAccountEventsRequest
+	(*AccountEvent)(nil),               // 13: wallet.v1.AccountEvent
+	(*ListAddressesRequest)(nil),       // 14: wallet.v1.ListAddressesRequest
+	(*ListAddressesResponse)(nil),      // 15: wallet.v1.ListAddressesResponse
+	(*DepositAddress)(nil),             // 16: wallet.v1.DepositAddress
+	(*ValidateAddressRequest)(nil),     // 17: wallet.v1.ValidateAddressRequest
+	(*ValidateAddressResponse)(nil),    // 18: wallet.v1.ValidateAddressResponse
+	(*GetSupportedAssetsRequest)(nil),  // 19: wallet.v1.GetSupportedAssetsRequest
+	(*GetSupportedAssetsResponse)(nil), // 20: wallet.v1.GetSupportedAssetsResponse
+	(*Asset)(nil),                      // 21: wallet.v1.Asset
+	(*GetWithdrawalRequest)(nil),       // 22: wallet.v1.GetWithdrawalRequest
+	(*GetWithdrawalResponse)(nil),      // 23: wallet.v1.GetWithdrawalResponse
+	(*CancelWithdrawalRequest)(nil),    // 24: wallet.v1.CancelWithdrawalRequest
+	(*CancelWithdrawalResponse)(nil),   // 25: wallet.v1.CancelWithdrawalResponse
+	(*Withdrawal)(nil),                 // 26: wallet.v1.Withdrawal
+	(*GetDepositRequest)(nil),          // 27: wallet.v1.GetDepositRequest
+	(*GetDepositResponse)(nil),         // 28: wallet.v1.GetDepositResponse
+	(*Deposit)(nil),                    // 29: wallet.v1.Deposit
+	(*CreateTransferRequest)(nil),      // 30: wallet.v1.CreateTransferRequest
+	(*CreateTransferResponse)(nil),     // 31: wallet.v1.CreateTransferResponse
+	(*GetTransferRequest)(nil),         // 32: wallet.v1.GetTransferRequest
+	(*GetTransferResponse)(nil),        // 33: wallet.v1.GetTransferResponse
+	(*ListTransfersRequest)(nil),       // 34: wallet.v1.ListTransfersRequest
+	(*ListTransfersResponse)(nil),      // 35: wallet.v1.ListTransfersResponse
+	(*Transfer)(nil),                   // 36: wallet.v1.Transfer
+	nil,                                // 37: wallet.v1.GetBalanceResponse.BalancesEntry
 }
 var file_api_proto_wallet_proto_depIdxs = []int32{
-	6, // 0: wallet.v1.GetBalanceResponse.balances:type_name -> wallet.v1.GetBalanceResponse.BalancesEntry
-	0, // 1: wallet.v1.WalletService.CreateAddress:input_type -> wallet.v1.CreateAddressRequest
-	2, // 2: wallet.v1.WalletService.GetBalance:input_type -> wallet.v1.GetBalanceRequest
-	4, // 3: wallet.v1.WalletService.CreateWithdrawal:input_type -> wallet.v1.CreateWithdrawalRequest
-	1, // 4: wallet.v1.WalletService.CreateAddress:output_type -> wallet.v1.CreateAddressResponse
-	3, // 5: wallet.v1.WalletService.GetBalance:output_type -> wallet.v1.GetBalanceResponse
-	5, // 6: wallet.v1.WalletService.CreateWithdrawal:output_type -> wallet.v1.CreateWithdrawalResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	37, // 0: wallet.v1.GetBalanceResponse.balances:type_name -> wallet.v1.GetBalanceResponse.BalancesEntry
+	11, // 1: wallet.v1.ListTransactionsResponse.items:type_name -> wallet.v1.Transaction
+	16, // 2: wallet.v1.ListAddressesResponse.addresses:type_name -> wallet.v1.DepositAddress
+	21, // 3: wallet.v1.GetSupportedAssetsResponse.assets:type_name -> wallet.v1.Asset
+	0,  // 4: wallet.v1.Asset.min_deposit:type_name -> wallet.v1.Money
+	0,  // 5: wallet.v1.Asset.min_withdrawal:type_name -> wallet.v1.Money
+	26, // 6: wallet.v1.GetWithdrawalResponse.withdrawal:type_name -> wallet.v1.Withdrawal
+	26, // 7: wallet.v1.CancelWithdrawalResponse.withdrawal:type_name -> wallet.v1.Withdrawal
+	0,  // 8: wallet.v1.Withdrawal.amount:type_name -> wallet.v1.Money
+	0,  // 9: wallet.v1.Withdrawal.network_fee:type_name -> wallet.v1.Money
+	29, // 10: wallet.v1.GetDepositResponse.deposit:type_name -> wallet.v1.Deposit
+	0,  // 11: wallet.v1.Deposit.amount:type_name -> wallet.v1.Money
+	36, // 12: wallet.v1.CreateTransferResponse.transfer:type_name -> wallet.v1.Transfer
+	36, // 13: wallet.v1.GetTransferResponse.transfer:type_name -> wallet.v1.Transfer
+	36, // 14: wallet.v1.ListTransfersResponse.transfers:type_name -> wallet.v1.Transfer
+	0,  // 15: wallet.v1.Transfer.amount:type_name -> wallet.v1.Money
+	1,  // 16: wallet.v1.WalletService.CreateAddress:input_type -> wallet.v1.CreateAddressRequest
+	14, // 17: wallet.v1.WalletService.ListAddresses:input_type -> wallet.v1.ListAddressesRequest
+	17, // 18: wallet.v1.WalletService.ValidateAddress:input_type -> wallet.v1.ValidateAddressRequest
+	3,  // 19: wallet.v1.WalletService.GetBalance:input_type -> wallet.v1.GetBalanceRequest
+	19, // 20: wallet.v1.WalletService.GetSupportedAssets:input_type -> wallet.v1.GetSupportedAssetsRequest
+	5,  // 21: wallet.v1.WalletService.CreateWithdrawal:input_type -> wallet.v1.CreateWithdrawalRequest
+	7,  // 22: wallet.v1.WalletService.QuoteWithdrawal:input_type -> wallet.v1.QuoteWithdrawalRequest
+	22, // 23: wallet.v1.WalletService.GetWithdrawal:input_type -> wallet.v1.GetWithdrawalRequest
+	24, // 24: wallet.v1.WalletService.CancelWithdrawal:input_type -> wallet.v1.CancelWithdrawalRequest
+	27, // 25: wallet.v1.WalletService.GetDeposit:input_type -> wallet.v1.GetDepositRequest
+	30, // 26: wallet.v1.WalletService.CreateTransfer:input_type -> wallet.v1.CreateTransferRequest
+	32, // 27: wallet.v1.WalletService.GetTransfer:input_type -> wallet.v1.GetTransferRequest
+	34, // 28: wallet.v1.WalletService.ListTransfers:input_type -> wallet.v1.ListTransfersRequest
+	9,  // 29: wallet.v1.WalletService.ListDeposits:input_type -> wallet.v1.ListTransactionsRequest
+	9,  // 30: wallet.v1.WalletService.ListWithdrawals:input_type -> wallet.v1.ListTransactionsRequest
+	9,  // 31: wallet.v1.WalletService.ListTransactions:input_type -> wallet.v1.ListTransactionsRequest
+	12, // 32: wallet.v1.WalletService.WatchAccountEvents:input_type -> wallet.v1.WatchAccountEventsRequest
+	2,  // 33: wallet.v1.WalletService.CreateAddress:output_type -> wallet.v1.CreateAddressResponse
+	15, // 34: wallet.v1.WalletService.ListAddresses:output_type -> wallet.v1.ListAddressesResponse
+	18, // 35: wallet.v1.WalletService.ValidateAddress:output_type -> wallet.v1.ValidateAddressResponse
+	4,  // 36: wallet.v1.WalletService.GetBalance:output_type -> wallet.v1.GetBalanceResponse
+	20, // 37: wallet.v1.WalletService.GetSupportedAssets:output_type -> wallet.v1.GetSupportedAssetsResponse
+	6,  // 38: wallet.v1.WalletService.CreateWithdrawal:output_type -> wallet.v1.CreateWithdrawalResponse
+	8,  // 39: wallet.v1.WalletService.QuoteWithdrawal:output_type -> wallet.v1.QuoteWithdrawalResponse
+	23, // 40: wallet.v1.WalletService.GetWithdrawal:output_type -> wallet.v1.GetWithdrawalResponse
+	25, // 41: wallet.v1.WalletService.CancelWithdrawal:output_type -> wallet.v1.CancelWithdrawalResponse
+	28, // 42: wallet.v1.WalletService.GetDeposit:output_type -> wallet.v1.GetDepositResponse
+	31, // 43: wallet.v1.WalletService.CreateTransfer:output_type -> wallet.v1.CreateTransferResponse
+	33, // 44: wallet.v1.WalletService.GetTransfer:output_type -> wallet.v1.GetTransferResponse
+	35, // 45: wallet.v1.WalletService.ListTransfers:output_type -> wallet.v1.ListTransfersResponse
+	10, // 46: wallet.v1.WalletService.ListDeposits:output_type -> wallet.v1.ListTransactionsResponse
+	10, // 47: wallet.v1.WalletService.ListWithdrawals:output_type -> wallet.v1.ListTransactionsResponse
+	10, // 48: wallet.v1.WalletService.ListTransactions:output_type -> wallet.v1.ListTransactionsResponse
+	13, // 49: wallet.v1.WalletService.WatchAccountEvents:output_type -> wallet.v1.AccountEvent
+	33, // [33:50] is the sub-list for method output_type
+	16, // [16:33] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_wallet_proto_init() }
@@ -414,7 +2742,7 @@ func file_api_proto_wallet_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_wallet_proto_rawDesc), len(file_api_proto_wallet_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -4,10 +4,16 @@ import (
 	"net/http"
 
 	"wallet-core/internal/gateway"
+	"wallet-core/internal/interceptor"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/service/auth"
 	"wallet-core/pkg/config"
+	"wallet-core/pkg/health"
 	"wallet-core/pkg/logger"
+	"wallet-core/pkg/ratelimit"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -25,9 +31,13 @@ func main() {
 	logger.Info("Starting Blockchain Gateway...", zap.String("port", config.Global.App.HttpPort))
 
 	// 2. Connect to gRPC Services
+	// Client interceptors add a default deadline, propagate the request ID, and log / measure every call.
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		interceptor.ClientDialOptions(config.Global.GRPC)...)
+
 	// In production, use Service Discovery (K8s Service Name)
 	// User Service
-	userConn, err := grpc.Dial("localhost:50053", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	userConn, err := grpc.Dial("localhost:50053", dialOpts...)
 	if err != nil {
 		logger.Fatal("Failed to connect to User Service", zap.Error(err))
 	}
@@ -36,7 +46,7 @@ func main() {
 	logger.Info("Connected to User Service at localhost:50053")
 
 	// Wallet Service
-	walletConn, err := grpc.Dial("localhost:50052", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	walletConn, err := grpc.Dial("localhost:50052", dialOpts...)
 	if err != nil {
 		logger.Fatal("Failed to connect to Wallet Service", zap.Error(err))
 	}
@@ -44,11 +54,43 @@ func main() {
 	walletClient := walletv1.NewWalletServiceClient(walletConn)
 	logger.Info("Connected to Wallet Service at localhost:50052")
 
+	// Access tokens are verified at the edge as well as in each service.
+	// Shares jwt_secret and Redis (token versions) with user-service.
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     config.Global.Redis.Addr,
+		Password: config.Global.Redis.Password,
+		DB:       config.Global.Redis.DB,
+	})
+	defer rdb.Close()
+	authSvc, err := auth.NewService(rdb, config.Global.Auth)
+	if err != nil {
+		logger.Fatal("Failed to init auth service", zap.Error(err))
+	}
+
+	// Rate limit buckets live in the same Redis, so limits hold across gateway replicas.
+	limiter, err := ratelimit.New(rdb, "gateway", config.Global.RateLimit)
+	if err != nil {
+		logger.Fatal("Failed to init rate limiter", zap.Error(err))
+	}
+
 	// 3. Init HTTP Server (Gin)
 	r := gin.Default()
+	// Only trust X-Forwarded-For from the configured proxies; the rate limiter and backends key on ClientIP.
+	if err := middleware.SetTrustedProxies(r, config.Global.App.TrustedProxies); err != nil {
+		logger.Fatal("Invalid app.trusted_proxies", zap.Error(err))
+	}
+	r.Use(middleware.RequestID())
 
 	// 4. Setup Routes
-	gateway.RegisterRoutes(r, userClient, walletClient)
+	// Probes: the gateway is ready once Redis and both upstream services report SERVING.
+	probes := health.NewRegistry("bc-gateway", config.Global.Health.Timeout)
+	probes.Register("redis", health.Readiness, health.Redis(rdb))
+	probes.Register("user_service", health.Readiness, health.GRPCUpstream(userConn, userv1.UserService_ServiceDesc.ServiceName))
+	probes.Register("wallet_service", health.Readiness, health.GRPCUpstream(walletConn, walletv1.WalletService_ServiceDesc.ServiceName))
+	r.GET("/livez", gin.WrapF(probes.LiveHandler()))
+	r.GET("/readyz", gin.WrapF(probes.ReadyHandler()))
+
+	gateway.RegisterRoutes(r, userClient, walletClient, authSvc, limiter)
 
 	// 5. Start Server
 	srv := &http.Server{
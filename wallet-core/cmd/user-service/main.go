@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
@@ -9,13 +10,21 @@ import (
 
 	userv1 "wallet-core/api/gen/user/v1"
 	"wallet-core/cmd/user-service/server"
+	"wallet-core/internal/interceptor"
+	"wallet-core/internal/service"
+	"wallet-core/internal/service/auth"
+	"wallet-core/internal/service/mfa"
 	"wallet-core/internal/service/user"
+	"wallet-core/internal/worker"
 	"wallet-core/pkg/config"
 	"wallet-core/pkg/database"
+	"wallet-core/pkg/health"
 	"wallet-core/pkg/logger"
+	"wallet-core/pkg/monitor"
+	"wallet-core/pkg/ratelimit"
 
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -42,18 +51,65 @@ func main() {
 		logger.Fatal("Failed to connect to database", zap.Error(err))
 	}
 
-	// 4. Init Service
-	svc := user.NewService(db)
+	// 4. Init Redis (MFA attempt counters, refresh tokens)
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     config.Global.Redis.Addr,
+		Password: config.Global.Redis.Password,
+		DB:       config.Global.Redis.DB,
+	})
+	defer rdb.Close()
 
-	// 5. Init gRPC Server
-	grpcServer := grpc.NewServer()
+	// 5. Init Service
+	mfaKeys, err := mfa.NewKeyManager(config.Global.MFA, config.Global.App.Env)
+	if err != nil {
+		logger.Fatal("Failed to init MFA key manager", zap.Error(err))
+	}
+	mfaSvc := mfa.NewService(db, rdb, mfaKeys, config.Global.MFA)
+	authSvc, err := auth.NewService(rdb, config.Global.Auth)
+	if err != nil {
+		logger.Fatal("Failed to init auth service", zap.Error(err))
+	}
+	// Verification / reset emails are sent by the wallet-server worker; this process only enqueues
+	taskClient := worker.NewClient(config.Global.Redis.Addr, config.Global.Redis.Password, config.Global.Redis.DB)
+	defer taskClient.Close()
+	svc := user.NewService(db, mfaSvc, authSvc, taskClient, config.Global.Account)
+	limiter, err := ratelimit.New(rdb, "user-service", config.Global.RateLimit)
+	if err != nil {
+		logger.Fatal("Failed to init rate limiter", zap.Error(err))
+	}
+
+	// Tenant codes (x-tenant) are resolved for register / login / password reset; no keys needed here
+	tenants := service.NewTenantService(db, nil, nil)
+
+	// 6. Init gRPC Server (tenant then auth run first so rate limits can key on the user;
+	// a signed-in caller's tenant comes from the token, overriding x-tenant)
+	grpcServer := interceptor.NewServer(config.Global.GRPC,
+		interceptor.Tenant(tenants),
+		interceptor.Auth(authSvc, server.PublicMethods...),
+		interceptor.RateLimit(limiter, server.RateLimitedMethods),
+	)
 	userServer := server.NewUserGRPCServer(svc)
 	userv1.RegisterUserServiceServer(grpcServer, userServer)
 
 	// Enable reflection for debugging (grpcurl)
 	reflection.Register(grpcServer)
 
-	// 6. Listen
+	// Health checks: grpc.health.v1 for k8s gRPC probes, /livez and /readyz on health.port
+	probes := health.NewRegistry("user-service", config.Global.Health.Timeout)
+	probes.Register("mfa_key", health.Liveness, health.KMSKey(mfaKeys, mfa.KeyID))
+	probes.Register("db", health.Readiness, health.DB(db))
+	probes.Register("redis", health.Readiness, health.Redis(rdb))
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	probes.RegisterGRPC(healthCtx, grpcServer, config.Global.Health.Interval)
+	if healthPort := config.Global.Health.Port; healthPort != "" {
+		go func() {
+			if err := health.Serve(":"+healthPort, probes); err != nil {
+				logger.Error("Health server stopped", zap.Error(err))
+			}
+		}()
+	}
+
+	// 7. Listen
 	// User service port: 50053 (as per plan)
 	port := ":50053"
 	lis, err := net.Listen("tcp", port)
@@ -63,7 +119,16 @@ func main() {
 
 	logger.Info("User Service listening on gRPC", zap.String("port", port))
 
-	// 7. Graceful Shutdown
+	// Prometheus RPC metrics (no HTTP server in this process, so expose on a separate port)
+	if metricsPort := config.Global.GRPC.MetricsPort; metricsPort != "" {
+		go func() {
+			if err := monitor.ServeMetrics(":" + metricsPort); err != nil {
+				logger.Error("Metrics server stopped", zap.Error(err))
+			}
+		}()
+	}
+
+	// 8. Graceful Shutdown
 	go func() {
 		if err := grpcServer.Serve(lis); err != nil {
 			logger.Fatal("Failed to serve gRPC", zap.Error(err))
@@ -75,6 +140,7 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down User Service...")
+	stopHealth()
 	grpcServer.GracefulStop()
 	logger.Info("User Service stopped")
 }
@@ -4,9 +4,33 @@ import (
 	"context"
 
 	userv1 "wallet-core/api/gen/user/v1"
+	"wallet-core/internal/interceptor"
 	"wallet-core/internal/service/user"
+	"wallet-core/pkg/health"
 )
 
+// PublicMethods 无需 Access Token 即可调用的方法
+// 其余方法的用户 ID 一律取自 Access Token，忽略请求中的 user_id；健康检查给探针用，不需要登录
+var PublicMethods = append([]string{
+	userv1.UserService_Register_FullMethodName,
+	userv1.UserService_Login_FullMethodName,
+	userv1.UserService_RefreshToken_FullMethodName,
+	userv1.UserService_Logout_FullMethodName,
+	userv1.UserService_VerifyEmail_FullMethodName,
+	userv1.UserService_RequestPasswordReset_FullMethodName,
+	userv1.UserService_ResetPassword_FullMethodName,
+}, health.GRPCMethods...)
+
+// RateLimitedMethods 需要限流的方法 -> 规则名 (见配置 ratelimit.rules)
+var RateLimitedMethods = map[string]string{
+	userv1.UserService_Register_FullMethodName:              "register",
+	userv1.UserService_Login_FullMethodName:                 "login",
+	userv1.UserService_SendVerificationEmail_FullMethodName: "account_email",
+	userv1.UserService_VerifyEmail_FullMethodName:           "account_email",
+	userv1.UserService_RequestPasswordReset_FullMethodName:  "account_email",
+	userv1.UserService_ResetPassword_FullMethodName:         "account_email",
+}
+
 // UserGRPCServer 实现 user.v1.UserServiceServer 接口
 type UserGRPCServer struct {
 	userv1.UnimplementedUserServiceServer
@@ -29,27 +53,129 @@ func (s *UserGRPCServer) Register(ctx context.Context, req *userv1.RegisterReque
 }
 
 func (s *UserGRPCServer) Login(ctx context.Context, req *userv1.LoginRequest) (*userv1.LoginResponse, error) {
-	token, userId, username, err := s.svc.Login(ctx, req.Email, req.Password)
+	tokens, username, err := s.svc.Login(ctx, req.Email, req.Password, req.TotpCode)
 	if err != nil {
 		return nil, err
 	}
 
 	return &userv1.LoginResponse{
-		Token:    token,
-		UserId:   userId,
-		Username: username,
+		Token:        tokens.AccessToken,
+		UserId:       int64(tokens.UserID),
+		Username:     username,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt.Unix(),
+	}, nil
+}
+
+func (s *UserGRPCServer) RefreshToken(ctx context.Context, req *userv1.RefreshTokenRequest) (*userv1.RefreshTokenResponse, error) {
+	tokens, err := s.svc.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.RefreshTokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt.Unix(),
 	}, nil
 }
 
+func (s *UserGRPCServer) Logout(ctx context.Context, req *userv1.LogoutRequest) (*userv1.LogoutResponse, error) {
+	if err := s.svc.Logout(ctx, req.RefreshToken); err != nil {
+		return nil, err
+	}
+	return &userv1.LogoutResponse{}, nil
+}
+
+func (s *UserGRPCServer) LogoutAll(ctx context.Context, req *userv1.LogoutAllRequest) (*userv1.LogoutAllResponse, error) {
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.LogoutAll(ctx, userID); err != nil {
+		return nil, err
+	}
+	return &userv1.LogoutAllResponse{}, nil
+}
+
 func (s *UserGRPCServer) GetUserInfo(ctx context.Context, req *userv1.GetUserInfoRequest) (*userv1.GetUserInfoResponse, error) {
-	u, err := s.svc.GetUserInfo(ctx, req.UserId)
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.svc.GetUserInfo(ctx, int64(userID))
 	if err != nil {
 		return nil, err
 	}
 
 	return &userv1.GetUserInfoResponse{
-		UserId:   int64(u.ID),
-		Username: u.Username,
-		Email:    u.Email,
+		UserId:        int64(u.ID),
+		Username:      u.Username,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerifiedAt != nil,
+		KycTier:       u.KYCTier,
 	}, nil
 }
+
+func (s *UserGRPCServer) EnrollTOTP(ctx context.Context, req *userv1.EnrollTOTPRequest) (*userv1.EnrollTOTPResponse, error) {
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secret, uri, err := s.svc.EnrollTOTP(ctx, int64(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.EnrollTOTPResponse{
+		Secret:          secret,
+		ProvisioningUri: uri,
+	}, nil
+}
+
+func (s *UserGRPCServer) ActivateTOTP(ctx context.Context, req *userv1.ActivateTOTPRequest) (*userv1.ActivateTOTPResponse, error) {
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	codes, err := s.svc.ActivateTOTP(ctx, int64(userID), req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.ActivateTOTPResponse{
+		BackupCodes: codes,
+	}, nil
+}
+
+func (s *UserGRPCServer) SendVerificationEmail(ctx context.Context, req *userv1.SendVerificationEmailRequest) (*userv1.SendVerificationEmailResponse, error) {
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.SendVerificationEmail(ctx, userID); err != nil {
+		return nil, err
+	}
+	return &userv1.SendVerificationEmailResponse{}, nil
+}
+
+func (s *UserGRPCServer) VerifyEmail(ctx context.Context, req *userv1.VerifyEmailRequest) (*userv1.VerifyEmailResponse, error) {
+	if err := s.svc.VerifyEmail(ctx, req.Token); err != nil {
+		return nil, err
+	}
+	return &userv1.VerifyEmailResponse{}, nil
+}
+
+func (s *UserGRPCServer) RequestPasswordReset(ctx context.Context, req *userv1.RequestPasswordResetRequest) (*userv1.RequestPasswordResetResponse, error) {
+	if err := s.svc.RequestPasswordReset(ctx, req.Email); err != nil {
+		return nil, err
+	}
+	return &userv1.RequestPasswordResetResponse{}, nil
+}
+
+func (s *UserGRPCServer) ResetPassword(ctx context.Context, req *userv1.ResetPasswordRequest) (*userv1.ResetPasswordResponse, error) {
+	if err := s.svc.ResetPassword(ctx, req.Token, req.NewPassword); err != nil {
+		return nil, err
+	}
+	return &userv1.ResetPasswordResponse{}, nil
+}
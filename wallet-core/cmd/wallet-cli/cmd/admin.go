@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"syscall"
+
+	"wallet-core/internal/service"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/database"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "后台管理员账号管理",
+}
+
+var adminCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "创建后台管理员 (用于初始化第一个 superadmin)",
+	Long: `直接写入数据库创建后台管理员，读取当前目录的 config.yaml / 环境变量连接数据库。
+创建成功后输出 TOTP 密钥和 otpauth URI (只显示这一次)，请交给管理员本人绑定验证器 App。
+之后的管理员可由 superadmin 通过 POST /api/v1/admin/admins 创建。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		username, _ := cmd.Flags().GetString("username")
+		email, _ := cmd.Flags().GetString("email")
+		role, _ := cmd.Flags().GetString("role")
+
+		// 1. 输入密码
+		fmt.Print("输入管理员密码: ")
+		bytePassword, err := term.ReadPassword(int(syscall.Stdin))
+		fmt.Println()
+		if err != nil {
+			fmt.Println("读取密码失败:", err)
+			os.Exit(1)
+		}
+		fmt.Print("确认密码: ")
+		bytePasswordConfirm, err := term.ReadPassword(int(syscall.Stdin))
+		fmt.Println()
+		if err != nil {
+			fmt.Println("读取密码失败:", err)
+			os.Exit(1)
+		}
+		if string(bytePassword) != string(bytePasswordConfirm) {
+			fmt.Println("两次输入的密码不一致！")
+			os.Exit(1)
+		}
+
+		// 2. 连接数据库 (与 wallet-server 共用配置和 MFA 加密密钥)
+		config.Init()
+		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Shanghai",
+			config.Global.DB.Host,
+			config.Global.DB.User,
+			config.Global.DB.Password,
+			config.Global.DB.Name,
+			config.Global.DB.Port,
+		)
+		db, err := database.ConnectPostgres(dsn)
+		if err != nil {
+			fmt.Printf("数据库连接失败: %v\n", err)
+			os.Exit(1)
+		}
+		keys, err := service.NewKeyManager(&config.Global)
+		if err != nil {
+			fmt.Printf("初始化 KMS 密钥失败: %v\n", err)
+			os.Exit(1)
+		}
+		adminAuth, err := service.NewAdminAuthService(db, nil, keys, config.Global.Admin, config.Global.MFA)
+		if err != nil {
+			fmt.Printf("初始化管理员服务失败: %v\n", err)
+			os.Exit(1)
+		}
+
+		// 3. 创建
+		admin, secret, uri, err := adminAuth.CreateAdmin(context.Background(), 0, username, email, string(bytePassword), role)
+		if err != nil {
+			fmt.Printf("❌ 创建失败: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("✅ 管理员已创建: id=%d username=%s role=%s\n", admin.ID, admin.Username, admin.Role)
+		fmt.Println("TOTP 密钥 (只显示这一次):", secret)
+		fmt.Println("otpauth URI:", uri)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(adminCmd)
+	adminCmd.AddCommand(adminCreateCmd)
+	adminCreateCmd.Flags().String("username", "", "管理员用户名")
+	adminCreateCmd.Flags().String("email", "", "管理员邮箱")
+	adminCreateCmd.Flags().String("role", "superadmin", "角色: reviewer | finance | ops | compliance | superadmin")
+	_ = adminCreateCmd.MarkFlagRequired("username")
+	_ = adminCreateCmd.MarkFlagRequired("email")
+}
@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
+	"wallet-core/internal/service/fee"
+	"wallet-core/pkg/cache"
+	"wallet-core/pkg/wallet/ethtx"
 	"wallet-core/pkg/wallet/types"
 
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 )
 
@@ -23,9 +29,44 @@ var buildTxCmd = &cobra.Command{
 		path, _ := cmd.Flags().GetString("path")
 		chainID, _ := cmd.Flags().GetInt64("chain-id")
 
+		txType, _ := cmd.Flags().GetString("tx-type")
+		rpcURL, _ := cmd.Flags().GetString("rpc")
+		feeLevel, _ := cmd.Flags().GetString("fee-level")
+
 		// 默认值
-		gasLimit := uint64(21000)
-		gasPrice := "20000000000" // 20 Gwei
+		gasLimit := fee.GasLimitTransfer
+		gasPrice, _ := cmd.Flags().GetString("gas-price")
+		maxFee, _ := cmd.Flags().GetString("max-fee")
+		priorityFee, _ := cmd.Flags().GetString("priority-fee")
+
+		if txType != ethtx.TxTypeLegacy && txType != ethtx.TxTypeDynamic {
+			fmt.Printf("不支持的交易类型: %s (legacy | dynamic)\n", txType)
+			os.Exit(1)
+		}
+
+		// 指定了 RPC 时，按链上数据估算手续费 (Legacy: eth_gasPrice; EIP-1559: eth_feeHistory)
+		if rpcURL != "" {
+			level, err := fee.ParseLevel(feeLevel)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			feeSvc, _ := fee.NewService(cache.NewMemoryCache(time.Minute, time.Minute), rpcURL)
+			estimate, err := feeSvc.EstimateTxType(context.Background(), "ETH", txType)
+			if err != nil {
+				fmt.Printf("估算手续费失败: %v\n", err)
+				os.Exit(1)
+			}
+			if estimate.Fallback {
+				fmt.Println("⚠️  RPC 不可用，使用兜底手续费")
+			}
+			fees := estimate.Quote(level).EthFees()
+			if fees.Dynamic {
+				maxFee, priorityFee = fees.GasFeeCap.String(), fees.GasTipCap.String()
+			} else {
+				gasPrice = fees.GasPrice.String()
+			}
+		}
 
 		tx := types.UnsignedTransaction{
 			Chain:          "ETH",
@@ -34,11 +75,17 @@ var buildTxCmd = &cobra.Command{
 			Amount:         amount,
 			Nonce:          nonce,
 			GasLimit:       gasLimit,
-			GasPrice:       gasPrice,
 			Data:           "",
 			DerivationPath: path,
 			ChainID:        chainID,
 		}
+		if txType == ethtx.TxTypeDynamic {
+			tx.Type = ethtypes.DynamicFeeTxType
+			tx.MaxFeePerGas = maxFee
+			tx.MaxPriorityFeePerGas = priorityFee
+		} else {
+			tx.GasPrice = gasPrice
+		}
 
 		outputFile, _ := cmd.Flags().GetString("output")
 		data, _ := json.MarshalIndent(tx, "", "  ")
@@ -61,6 +108,12 @@ func init() {
 	buildTxCmd.Flags().Uint64("nonce", 0, "Nonce")
 	buildTxCmd.Flags().String("path", "m/44'/60'/0'/0/0", "私钥派生路径")
 	buildTxCmd.Flags().Int64("chain-id", 1, "Chain ID (1=Mainnet, 11155111=Sepolia)")
+	buildTxCmd.Flags().String("tx-type", ethtx.TxTypeDynamic, "交易类型 (legacy | dynamic)")
+	buildTxCmd.Flags().String("gas-price", "20000000000", "Gas Price (Wei, legacy)")
+	buildTxCmd.Flags().String("max-fee", "30000000000", "Max Fee Per Gas (Wei, dynamic)")
+	buildTxCmd.Flags().String("priority-fee", "1000000000", "Max Priority Fee Per Gas (Wei, dynamic)")
+	buildTxCmd.Flags().String("rpc", "", "RPC 地址 (可选，指定后按链上数据估算手续费)")
+	buildTxCmd.Flags().String("fee-level", "standard", "手续费档位 (slow | standard | fast)，配合 --rpc 使用")
 	buildTxCmd.Flags().StringP("output", "o", "unsigned.json", "输出文件")
 
 	buildTxCmd.MarkFlagRequired("from")
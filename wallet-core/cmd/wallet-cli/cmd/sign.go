@@ -11,6 +11,7 @@ import (
 	"wallet-core/pkg/bip32"
 	"wallet-core/pkg/bip39"
 	"wallet-core/pkg/keystore"
+	"wallet-core/pkg/wallet/ethtx"
 	"wallet-core/pkg/wallet/types"
 
 	"github.com/btcsuite/btcd/chaincfg"
@@ -49,7 +50,14 @@ var signCmd = &cobra.Command{
 		fmt.Printf("To:         %s\n", unsignedTx.To)
 		fmt.Printf("Amount:     %s\n", unsignedTx.Amount)
 		fmt.Printf("Nonce:      %d\n", unsignedTx.Nonce)
-		fmt.Printf("GasPrice:   %s\n", unsignedTx.GasPrice)
+		if unsignedTx.Type == ethtypes.DynamicFeeTxType {
+			fmt.Printf("Type:       EIP-1559\n")
+			fmt.Printf("MaxFee:     %s\n", unsignedTx.MaxFeePerGas)
+			fmt.Printf("Priority:   %s\n", unsignedTx.MaxPriorityFeePerGas)
+		} else {
+			fmt.Printf("GasPrice:   %s\n", unsignedTx.GasPrice)
+		}
+		fmt.Printf("GasLimit:   %d\n", unsignedTx.GasLimit)
 		fmt.Printf("Path:       %s\n", unsignedTx.DerivationPath)
 		fmt.Println("============================================")
 
@@ -132,22 +140,25 @@ func init() {
 
 func signEthTx(privKey bip32.ExtendedKey, utx *types.UnsignedTransaction) (string, string, error) {
 	// Convert types
-	nonce := utx.Nonce
-
-	amountInt, _ := new(big.Int).SetString(utx.Amount, 10)
-	gasPriceInt, _ := new(big.Int).SetString(utx.GasPrice, 10)
+	amountInt, ok := new(big.Int).SetString(utx.Amount, 10)
+	if !ok {
+		return "", "", fmt.Errorf("invalid amount: %q", utx.Amount)
+	}
+	fees, err := unsignedTxFees(utx)
+	if err != nil {
+		return "", "", err
+	}
 
 	toAddr := common.HexToAddress(utx.To)
-
-	// New Transaction
-	var tx *ethtypes.Transaction
+	var data []byte
 	if len(utx.Data) > 0 {
-		data := common.FromHex(utx.Data)
-		tx = ethtypes.NewTransaction(nonce, toAddr, amountInt, utx.GasLimit, gasPriceInt, data)
-	} else {
-		tx = ethtypes.NewTransaction(nonce, toAddr, amountInt, utx.GasLimit, gasPriceInt, nil)
+		data = common.FromHex(utx.Data)
 	}
 
+	// New Transaction (Legacy or EIP-1559)
+	chainID := big.NewInt(utx.ChainID)
+	tx := ethtx.NewTx(chainID, utx.Nonce, toAddr, amountInt, utx.GasLimit, fees, data)
+
 	// Sign
 	pk, err := privKey.ECPrivKey()
 	if err != nil {
@@ -155,15 +166,12 @@ func signEthTx(privKey bip32.ExtendedKey, utx *types.UnsignedTransaction) (strin
 	}
 	ecdsaKey := pk.ToECDSA()
 
-	chainID := big.NewInt(utx.ChainID)
-	signer := ethtypes.NewEIP155Signer(chainID)
-
-	signedTx, err := ethtypes.SignTx(tx, signer, ecdsaKey)
+	signedTx, err := ethtypes.SignTx(tx, ethtx.Signer(chainID), ecdsaKey)
 	if err != nil {
 		return "", "", err
 	}
 
-	// Serialize (RLP Encoding)
+	// Serialize (RLP Encoding; type-2 transactions are prefixed with 0x02)
 	rawTxBytes, err := signedTx.MarshalBinary()
 	if err != nil {
 		return "", "", err
@@ -172,3 +180,30 @@ func signEthTx(privKey bip32.ExtendedKey, utx *types.UnsignedTransaction) (strin
 
 	return "0x" + rawTxHex, signedTx.Hash().Hex(), nil
 }
+
+// unsignedTxFees 从未签名交易中解析手续费参数
+func unsignedTxFees(utx *types.UnsignedTransaction) (ethtx.Fees, error) {
+	switch utx.Type {
+	case ethtypes.LegacyTxType:
+		gasPrice, ok := new(big.Int).SetString(utx.GasPrice, 10)
+		if !ok {
+			return ethtx.Fees{}, fmt.Errorf("invalid gas_price: %q", utx.GasPrice)
+		}
+		return ethtx.Fees{GasPrice: gasPrice}, nil
+	case ethtypes.DynamicFeeTxType:
+		maxFee, ok := new(big.Int).SetString(utx.MaxFeePerGas, 10)
+		if !ok {
+			return ethtx.Fees{}, fmt.Errorf("invalid max_fee_per_gas: %q", utx.MaxFeePerGas)
+		}
+		tip, ok := new(big.Int).SetString(utx.MaxPriorityFeePerGas, 10)
+		if !ok {
+			return ethtx.Fees{}, fmt.Errorf("invalid max_priority_fee_per_gas: %q", utx.MaxPriorityFeePerGas)
+		}
+		if tip.Cmp(maxFee) > 0 {
+			return ethtx.Fees{}, fmt.Errorf("max_priority_fee_per_gas (%s) 不能大于 max_fee_per_gas (%s)", tip, maxFee)
+		}
+		return ethtx.Fees{Dynamic: true, GasTipCap: tip, GasFeeCap: maxFee}, nil
+	default:
+		return ethtx.Fees{}, fmt.Errorf("unsupported tx type: %d", utx.Type)
+	}
+}
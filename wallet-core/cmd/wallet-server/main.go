@@ -6,23 +6,34 @@ import (
 	"os"
 	"time"
 
+	"wallet-core/internal/middleware"
 	"wallet-core/internal/model"
 	"wallet-core/internal/server"
 	"wallet-core/internal/service"
+	"wallet-core/internal/service/auth"
+	"wallet-core/internal/service/fee"
+	"wallet-core/internal/service/mfa"
 	"wallet-core/internal/service/mq"
 	"wallet-core/internal/service/observer"
+	"wallet-core/internal/service/risk"
+	"wallet-core/internal/service/screening"
 	"wallet-core/internal/worker"
+	"wallet-core/internal/worker/tasks"
 
 	"wallet-core/pkg/bip32"
 	"wallet-core/pkg/bip39"
 	"wallet-core/pkg/cache"
 	"wallet-core/pkg/config"
 	"wallet-core/pkg/database"
+	"wallet-core/pkg/health"
 	"wallet-core/pkg/keystore"
 	"wallet-core/pkg/logger"
+	"wallet-core/pkg/mail"
+	"wallet-core/pkg/ratelimit"
 	"wallet-core/pkg/validator"
 
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -44,6 +55,14 @@ import (
 
 // @host localhost:8080
 // @BasePath /api/v1
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+
+// @securityDefinitions.apikey AdminAuth
+// @in header
+// @name Authorization
 func main() {
 	// 0. 初始化 Config
 	config.Init()
@@ -171,8 +190,16 @@ func main() {
 	relayService := service.NewRelayService(db, producer)
 	go relayService.Start(context.Background())
 
+	// 9.1 制裁名单筛查 (充值隔离 / 提现拦截共用，名单定时重新加载)
+	screener, err := screening.NewScreener(db, config.Global.Screening)
+	if err != nil {
+		logger.Fatal("初始化制裁名单筛查失败", zap.Error(err))
+	}
+	go screener.Start(context.Background())
+
 	// 10. 启动区块扫描器
-	ethObserver := observer.NewEthObserver(db, producer, 3000, 5)
+	observerStartedAt := time.Now()
+	ethObserver := observer.NewEthObserver(db, producer, screener, 3000, 5)
 	go func() {
 		if err := ethObserver.Start(context.Background()); err != nil {
 			logger.Error("Observer 启动失败", zap.Error(err))
@@ -182,7 +209,13 @@ func main() {
 	// 11. 启动资金归集服务
 	hotWallet := config.Global.Wallet.HotWallet
 	rpcURL := config.Global.Wallet.RpcUrl
-	sweeper, err := service.NewSweeperService(db, consumer, rpcURL, masterKey, hotWallet, rdb)
+	// 11.1 手续费估算 (归集 / 提现广播 / 加速 共用，结果缓存在多级缓存中)
+	feeService, err := fee.NewService(multiCache, rpcURL)
+	if err != nil {
+		logger.Fatal("FeeService 初始化失败", zap.Error(err))
+	}
+
+	sweeper, err := service.NewSweeperService(db, consumer, rpcURL, masterKey, hotWallet, rdb, feeService)
 	if err != nil {
 		logger.Error("Sweeper 初始化失败", zap.Error(err))
 	} else {
@@ -193,13 +226,50 @@ func main() {
 		}()
 	}
 
-	// 11.2 [NEW] 启动提现广播服务 (MultiSig Broadcaster)
-	broadcaster, err := service.NewBroadcasterService(db, rpcURL, masterKey)
+	// 11.2 提现广播只由 broadcaster-worker 运行 (单实例)，这里的 Broadcaster 只用于加速 / 取消时签名
+	// wallet-server 有多个副本，各自轮询 pending_broadcast 会抢同一批提现
+	broadcaster, err := service.NewBroadcasterService(db, rpcURL, masterKey, hotWallet, feeService)
 	if err != nil {
 		logger.Error("Broadcaster 初始化失败", zap.Error(err))
 	} else {
-		// 在后台运行
-		go broadcaster.Start(context.Background())
+		// 11.2.1 卡单加速/取消 (复用 Broadcaster 的热钱包签名)
+		service.Replacement = service.NewReplacementService(db, broadcaster)
+		go service.Replacement.Start(context.Background())
+	}
+
+	// 11.2.2 提现风控引擎 (规则定时热加载)
+	riskEngine, err := risk.NewEngine(db, risk.NewSource(db, config.Global.Risk), config.Global.Risk.ReloadInterval)
+	if err != nil {
+		logger.Fatal("初始化风控引擎失败", zap.Error(err))
+	}
+	go riskEngine.Start(context.Background())
+	// 用户 TOTP、管理员 TOTP、商户 API 密钥、KYC 证件、Webhook 密钥各用独立的 KMS 密钥
+	mfaKeys, err := service.NewKeyManager(&config.Global)
+	if err != nil {
+		logger.Fatal("初始化 KMS 密钥失败", zap.Error(err))
+	}
+	mfaService := mfa.NewService(db, rdb, mfaKeys, config.Global.MFA)
+	service.Transfer = service.NewTransferService(db, mfaService, config.Global.Transfer)
+	service.Withdraw = service.NewWithdrawService(db, riskEngine, screener, mfaService, service.Transfer, &chaincfg.MainNetParams)
+
+	// 11.2.3 定时 / 时间锁提现 (Asynq 延时任务放行，定时扫描兜底)
+	// 锁定通知 / 执行前提醒由本进程的 Asynq Worker 发到用户邮箱
+	taskClient := worker.NewClient(config.Global.Redis.Addr, config.Global.Redis.Password, config.Global.Redis.DB)
+	defer taskClient.Close()
+	mailSender, err := mail.New(config.Global.Mail)
+	if err != nil {
+		logger.Fatal("Failed to init mail sender", zap.Error(err))
+	}
+	service.TimeLock = service.NewTimeLockService(db, taskClient, mailSender)
+	service.History = service.NewHistoryService(db)
+	go service.TimeLock.Start(context.Background())
+
+	// 11.3 启动交易确认跟踪 (提现 & 归集)
+	txTracker, err := service.NewTxTrackerService(db, rpcURL)
+	if err != nil {
+		logger.Error("TxTracker 初始化失败", zap.Error(err))
+	} else {
+		go txTracker.Start(context.Background())
 	}
 
 	// 11.5 启动定时任务服务 (Module 11)
@@ -213,10 +283,75 @@ func main() {
 
 	// 12. HTTP Router
 	// 路由注册已被合并进 server 包
-	r := server.NewHTTPRouter()
+	authSvc, err := auth.NewService(rdb, config.Global.Auth)
+	if err != nil {
+		logger.Fatal("初始化认证服务失败", zap.Error(err))
+	}
+	service.AdminAuth, err = service.NewAdminAuthService(db, rdb, mfaKeys, config.Global.Admin, config.Global.MFA)
+	if err != nil {
+		logger.Fatal("初始化管理员认证失败", zap.Error(err))
+	}
+	service.Merchant = service.NewMerchantService(db, rdb, mfaKeys, config.Global.Merchant)
+	service.KYC = service.NewKYCService(db, mfaKeys, config.Global.KYC)
+	// 租户管理: 未提供 xpub 的租户由主密钥派生托管 xpub (m/44'/coin'/租户 ID')
+	service.Tenant = service.NewTenantService(db, masterKey, &chaincfg.MainNetParams)
+
+	// 12.0 商户通知: 每个商户多个通知地址，由 Asynq 签名投递并指数退避重试；
+	// 结算账户的充值 / 提现事件由独立的消费者组转换为通知，定时扫描重新投递积压的通知
+	service.Webhook = service.NewWebhookService(db, mfaKeys, taskClient, config.Global.Invoice)
+	service.Webhook.ConsumeEvents(context.Background(), func(topic string) mq.Consumer {
+		if mqType == "kafka" {
+			return mq.NewKafkaConsumer(config.Global.Kafka.Brokers, "wallet_webhook_group")
+		}
+		return mq.NewRedisConsumer(rdb, "wallet_webhook", "webhook-0")
+	})
+	go service.Webhook.Start(context.Background())
+
+	// 12.1 商户收款账单: 独立的消费者组订阅充值消息匹配账单，定时扫描过期账单
+	service.Invoice = service.NewInvoiceService(db, addressService, service.Webhook,
+		service.ConfigRates(config.Global.Invoice.Rates), config.Global.Invoice)
+	var invoiceConsumer mq.Consumer
+	if mqType == "kafka" {
+		invoiceConsumer = mq.NewKafkaConsumer(config.Global.Kafka.Brokers, "wallet_invoice_group")
+	} else {
+		invoiceConsumer = mq.NewRedisConsumer(rdb, "wallet_invoice", "invoice-0")
+	}
+	go func() {
+		if err := service.Invoice.ConsumeDeposits(context.Background(), invoiceConsumer); err != nil {
+			logger.Error("账单充值消费出错", zap.Error(err))
+		}
+	}()
+	go service.Invoice.Start(context.Background())
+	limiter, err := ratelimit.New(rdb, "wallet-server", config.Global.RateLimit)
+	if err != nil {
+		logger.Fatal("初始化限流器失败", zap.Error(err))
+	}
+
+	// 12.2 健康检查: /livez 只看进程自身 (重启能恢复的问题)，/readyz 额外检查外部依赖
+	rpcClient, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		logger.Warn("健康检查 RPC 连接失败", zap.Error(err))
+	}
+	probes := health.NewRegistry("wallet-server", config.Global.Health.Timeout)
+	probes.Register("master_key", health.Liveness, health.KeyLoaded(func() bool { return masterKey != nil }))
+	for _, keyID := range service.KeyIDs {
+		probes.Register("kms_key:"+keyID, health.Liveness, health.KMSKey(mfaKeys, keyID))
+	}
+	probes.Register("observer", health.Liveness, health.Lag(ethObserver.LastProcessedAt, observerStartedAt, config.Global.Health.ObserverMaxLag))
+	probes.Register("db", health.Readiness, health.DB(db))
+	probes.Register("redis", health.Readiness, health.Redis(rdb))
+	probes.Register("mq", health.Readiness, health.MQ(mqType, config.Global.Kafka.Brokers, rdb))
+	probes.Register("rpc_node", health.Readiness, health.EthNode(rpcClient))
+
+	r := server.NewHTTPRouter(authSvc, service.AdminAuth, service.Merchant, limiter, probes)
+	if err := middleware.SetTrustedProxies(r, config.Global.App.TrustedProxies); err != nil {
+		logger.Fatal("app.trusted_proxies 配置无效", zap.Error(err))
+	}
 
-	// 13. gRPC Server
-	grpcServer := server.NewGRPCServer(addressService)
+	// 13. gRPC Server (grpc.health.v1 的状态跟随 /readyz，停机前置为 NOT_SERVING)
+	grpcServer := server.NewGRPCServer(addressService, service.AdminAuth, service.Merchant, service.Invoice)
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	probes.RegisterGRPC(healthCtx, grpcServer, config.Global.Health.Interval)
 
 	// 13.5 初始化并启动 Asynq Worker (Module 13)
 	// 在生产环境中，建议将 Worker 部署为独立进程
@@ -226,6 +361,14 @@ func main() {
 		config.Global.Redis.DB,
 		10, // Concurrency
 	)
+	workerServer.Handle(tasks.TypeWithdrawalRelease, service.TimeLock.HandleReleaseTask)
+	workerServer.Handle(tasks.TypeWithdrawalReminder, service.TimeLock.HandleReminderTask)
+	workerServer.Handle(tasks.TypeMerchantWebhook, service.Webhook.HandleTask)
+	workerServer.Handle(tasks.TypeWithdrawalLockNotice, service.TimeLock.HandleLockNoticeTask)
+	// 账户邮件由 user-service 投递，这里负责发送
+	accountMailer := tasks.NewAccountMailer(mailSender, config.Global.Account)
+	workerServer.Handle(tasks.TypeEmailVerification, accountMailer.HandleEmailVerificationTask)
+	workerServer.Handle(tasks.TypePasswordReset, accountMailer.HandlePasswordResetTask)
 	workerServer.Start()
 	defer workerServer.Stop()
 
@@ -240,6 +383,7 @@ func main() {
 	if err != nil {
 		logger.Fatal("应用启动失败", zap.Error(err))
 	}
+	app.BeforeShutdown(stopHealth)
 
 	// 运行 (阻塞)
 	app.Run()
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
@@ -10,18 +11,30 @@ import (
 
 	walletv1 "wallet-core/api/gen/wallet/v1"
 	"wallet-core/cmd/wallet-service/server"
+	"wallet-core/internal/interceptor"
 	"wallet-core/internal/service"
+	"wallet-core/internal/service/auth"
+	"wallet-core/internal/service/fee"
+	"wallet-core/internal/service/mfa"
 	"wallet-core/internal/service/mq"
+	"wallet-core/internal/service/push"
+	"wallet-core/internal/service/risk"
+	"wallet-core/internal/service/screening"
 	"wallet-core/internal/service/wallet"
+	"wallet-core/internal/worker"
 	"wallet-core/pkg/bip32"
 	"wallet-core/pkg/bip39"
 	"wallet-core/pkg/cache"
 	"wallet-core/pkg/config"
 	"wallet-core/pkg/database"
+	"wallet-core/pkg/health"
 	"wallet-core/pkg/keystore"
 	"wallet-core/pkg/logger"
+	"wallet-core/pkg/monitor"
+	"wallet-core/pkg/ratelimit"
 
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -91,16 +104,97 @@ func main() {
 		logger.Fatal("初始化 AddressService 失败", zap.Error(err))
 	}
 
-	svc := wallet.NewService(db, addrSvc, producer)
+	feeSvc, err := fee.NewService(c, config.Global.Wallet.RpcUrl)
+	if err != nil {
+		logger.Fatal("初始化 FeeService 失败", zap.Error(err))
+	}
+
+	riskEngine, err := risk.NewEngine(db, risk.NewSource(db, config.Global.Risk), config.Global.Risk.ReloadInterval)
+	if err != nil {
+		logger.Fatal("初始化风控引擎失败", zap.Error(err))
+	}
+	go riskEngine.Start(context.Background())
+
+	screener, err := screening.NewScreener(db, config.Global.Screening)
+	if err != nil {
+		logger.Fatal("初始化制裁名单筛查失败", zap.Error(err))
+	}
+	go screener.Start(context.Background())
 
-	// 9. 初始化 gRPC 服务器
-	grpcServer := grpc.NewServer()
-	walletServer := server.NewWalletGRPCServer(svc)
+	// 时间锁任务投递到 wallet-server 的 Asynq Worker (共用 Redis)，通知邮件也由它发送
+	taskClient := worker.NewClient(config.Global.Redis.Addr, config.Global.Redis.Password, config.Global.Redis.DB)
+	defer taskClient.Close()
+	timeLock := service.NewTimeLockService(db, taskClient, nil)
+
+	mfaKeys, err := mfa.NewKeyManager(config.Global.MFA, config.Global.App.Env)
+	if err != nil {
+		logger.Fatal("初始化 MFA 密钥失败", zap.Error(err))
+	}
+	mfaSvc := mfa.NewService(db, rdb, mfaKeys, config.Global.MFA)
+
+	transferSvc := service.NewTransferService(db, mfaSvc, config.Global.Transfer)
+	svc := wallet.NewService(db, addrSvc, producer, feeSvc, riskEngine, screener, timeLock, mfaSvc, transferSvc, netParams)
+
+	// 用户身份一律取自 Access Token (与 user-service 共用 jwt_secret 和 Redis)
+	authSvc, err := auth.NewService(rdb, config.Global.Auth)
+	if err != nil {
+		logger.Fatal("初始化认证服务失败", zap.Error(err))
+	}
+
+	limiter, err := ratelimit.New(rdb, "wallet-service", config.Global.RateLimit)
+	if err != nil {
+		logger.Fatal("初始化限流器失败", zap.Error(err))
+	}
+
+	// 账户事件推送: 消费 MQ 钱包事件写入用户事件流，WatchAccountEvents 从中读取
+	events := push.NewHub(rdb)
+	go events.Run(context.Background())
+	push.NewBridge(events, db).Start(context.Background(), func(topic string) mq.Consumer {
+		if config.Global.Redis.MQType == "kafka" {
+			return mq.NewKafkaConsumer(config.Global.Kafka.Brokers, "wallet_push_group")
+		}
+		hostname, _ := os.Hostname()
+		return mq.NewRedisConsumer(rdb, "wallet_push", "push-"+hostname)
+	})
+
+	// 9. 初始化 gRPC 服务器 (先认证再限流，按用户限流需要用户 ID)
+	grpcServer := interceptor.NewServerWithStream(config.Global.GRPC,
+		[]grpc.UnaryServerInterceptor{
+			interceptor.Auth(authSvc, server.PublicMethods...),
+			interceptor.RateLimit(limiter, server.RateLimitedMethods),
+		},
+		[]grpc.StreamServerInterceptor{
+			interceptor.StreamAuth(authSvc, server.PublicMethods...),
+		},
+	)
+	walletServer := server.NewWalletGRPCServer(svc, service.NewHistoryService(db), events)
 	walletv1.RegisterWalletServiceServer(grpcServer, walletServer)
 
 	// 启用反射 (grpcurl 调试用)
 	reflection.Register(grpcServer)
 
+	// 健康检查: grpc.health.v1 供 k8s gRPC 探针使用，/livez /readyz 在 health.port 上暴露
+	rpcClient, err := ethclient.Dial(config.Global.Wallet.RpcUrl)
+	if err != nil {
+		logger.Warn("健康检查 RPC 连接失败", zap.Error(err))
+	}
+	probes := health.NewRegistry("wallet-service", config.Global.Health.Timeout)
+	probes.Register("master_key", health.Liveness, health.KeyLoaded(func() bool { return masterKey != nil }))
+	probes.Register("mfa_key", health.Liveness, health.KMSKey(mfaKeys, mfa.KeyID))
+	probes.Register("db", health.Readiness, health.DB(db))
+	probes.Register("redis", health.Readiness, health.Redis(rdb))
+	probes.Register("mq", health.Readiness, health.MQ(config.Global.Redis.MQType, config.Global.Kafka.Brokers, rdb))
+	probes.Register("rpc_node", health.Readiness, health.EthNode(rpcClient))
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	probes.RegisterGRPC(healthCtx, grpcServer, config.Global.Health.Interval)
+	if healthPort := config.Global.Health.Port; healthPort != "" {
+		go func() {
+			if err := health.Serve(":"+healthPort, probes); err != nil {
+				logger.Error("健康检查服务异常退出", zap.Error(err))
+			}
+		}()
+	}
+
 	// 10. 监听端口
 	// Wallet service port: 50052
 	port := ":50052"
@@ -111,6 +205,15 @@ func main() {
 
 	logger.Info("钱包服务 (Wallet Service) 已启动 gRPC 监听", zap.String("port", port))
 
+	// Prometheus RPC 指标 (本进程没有 HTTP 服务，单独开端口暴露)
+	if metricsPort := config.Global.GRPC.MetricsPort; metricsPort != "" {
+		go func() {
+			if err := monitor.ServeMetrics(":" + metricsPort); err != nil {
+				logger.Error("指标服务异常退出", zap.Error(err))
+			}
+		}()
+	}
+
 	// 11. 优雅停机
 	go func() {
 		if err := grpcServer.Serve(lis); err != nil {
@@ -123,6 +226,7 @@ func main() {
 	<-quit
 
 	logger.Info("正在关闭钱包服务...")
+	stopHealth()
 	grpcServer.GracefulStop()
 	logger.Info("钱包服务已停止")
 }
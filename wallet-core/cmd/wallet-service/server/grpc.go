@@ -2,23 +2,55 @@ package server
 
 import (
 	"context"
+	"time"
 
 	walletv1 "wallet-core/api/gen/wallet/v1"
+	"wallet-core/internal/interceptor"
+	"wallet-core/internal/model"
+	"wallet-core/internal/service"
+	"wallet-core/internal/service/push"
 	"wallet-core/internal/service/wallet"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/health"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
 )
 
+// PublicMethods 无需 Access Token 即可调用的方法 (报价 / 币种 / 地址校验不涉及用户数据，健康检查给探针用)
+var PublicMethods = append([]string{
+	walletv1.WalletService_QuoteWithdrawal_FullMethodName,
+	walletv1.WalletService_GetSupportedAssets_FullMethodName,
+	walletv1.WalletService_ValidateAddress_FullMethodName,
+}, health.GRPCMethods...)
+
+// RateLimitedMethods 需要限流的方法 -> 规则名 (见配置 ratelimit.rules)
+var RateLimitedMethods = map[string]string{
+	walletv1.WalletService_CreateAddress_FullMethodName:    "address_create",
+	walletv1.WalletService_CreateWithdrawal_FullMethodName: "withdraw",
+	walletv1.WalletService_CreateTransfer_FullMethodName:   "transfer",
+}
+
 // WalletGRPCServer 实现 wallet.v1.WalletServiceServer 接口
 type WalletGRPCServer struct {
 	walletv1.UnimplementedWalletServiceServer
-	svc *wallet.Service
+	svc     *wallet.Service
+	history *service.HistoryService
+	events  *push.Hub
 }
 
-func NewWalletGRPCServer(svc *wallet.Service) *WalletGRPCServer {
-	return &WalletGRPCServer{svc: svc}
+func NewWalletGRPCServer(svc *wallet.Service, history *service.HistoryService, events *push.Hub) *WalletGRPCServer {
+	return &WalletGRPCServer{svc: svc, history: history, events: events}
 }
 
 func (s *WalletGRPCServer) CreateAddress(ctx context.Context, req *walletv1.CreateAddressRequest) (*walletv1.CreateAddressResponse, error) {
-	addr, err := s.svc.CreateAddress(ctx, req.UserId, req.Currency)
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := s.svc.CreateAddress(ctx, int64(userID), req.Currency)
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +61,12 @@ func (s *WalletGRPCServer) CreateAddress(ctx context.Context, req *walletv1.Crea
 }
 
 func (s *WalletGRPCServer) GetBalance(ctx context.Context, req *walletv1.GetBalanceRequest) (*walletv1.GetBalanceResponse, error) {
-	balances, err := s.svc.GetBalance(ctx, req.UserId, req.Currency)
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := s.svc.GetBalance(ctx, int64(userID), req.Currency)
 	if err != nil {
 		return nil, err
 	}
@@ -40,13 +77,352 @@ func (s *WalletGRPCServer) GetBalance(ctx context.Context, req *walletv1.GetBala
 }
 
 func (s *WalletGRPCServer) CreateWithdrawal(ctx context.Context, req *walletv1.CreateWithdrawalRequest) (*walletv1.CreateWithdrawalResponse, error) {
-	id, err := s.svc.CreateWithdrawal(ctx, req.UserId, req.ToAddress, req.Amount, req.Currency)
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var executeAfter *time.Time
+	if req.ExecuteAfter > 0 {
+		t := time.Unix(req.ExecuteAfter, 0)
+		executeAfter = &t
+	}
+
+	w, err := s.svc.CreateWithdrawal(ctx, int64(userID), req.ToAddress, req.Amount, req.Currency, executeAfter, req.TotpCode)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &walletv1.CreateWithdrawalResponse{
+		WithdrawalId: int64(w.ID),
+		Status:       w.Status,
+		TransferId:   int64(w.TransferID),
+	}
+	if w.ExecuteAfter != nil {
+		resp.ExecuteAfter = w.ExecuteAfter.Unix()
+	}
+	return resp, nil
+}
+
+func (s *WalletGRPCServer) QuoteWithdrawal(ctx context.Context, req *walletv1.QuoteWithdrawalRequest) (*walletv1.QuoteWithdrawalResponse, error) {
+	quote, err := s.svc.QuoteWithdrawal(ctx, req.Currency, req.ToAddress, req.Amount, req.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &walletv1.QuoteWithdrawalResponse{
+		Currency:   quote.Currency,
+		Level:      string(quote.Level),
+		NetworkFee: quote.NetworkFee.String(),
+		GasLimit:   quote.GasLimit,
+		FeeRate:    quote.Fees.FeeRate,
+		Fallback:   quote.Fallback,
+	}
+	if quote.Fees.GasPrice != nil {
+		resp.GasPrice = quote.Fees.GasPrice.String()
+	}
+	if quote.Fees.GasFeeCap != nil {
+		resp.MaxFeePerGas = quote.Fees.GasFeeCap.String()
+		resp.MaxPriorityFeePerGas = quote.Fees.GasTipCap.String()
+	}
+	return resp, nil
+}
+
+func (s *WalletGRPCServer) ListDeposits(ctx context.Context, req *walletv1.ListTransactionsRequest) (*walletv1.ListTransactionsResponse, error) {
+	return s.listHistory(ctx, req, s.history.ListDeposits)
+}
+
+func (s *WalletGRPCServer) ListWithdrawals(ctx context.Context, req *walletv1.ListTransactionsRequest) (*walletv1.ListTransactionsResponse, error) {
+	return s.listHistory(ctx, req, s.history.ListWithdrawals)
+}
+
+func (s *WalletGRPCServer) ListTransactions(ctx context.Context, req *walletv1.ListTransactionsRequest) (*walletv1.ListTransactionsResponse, error) {
+	return s.listHistory(ctx, req, s.history.ListTransactions)
+}
+
+type historyLister func(ctx context.Context, userID uint64, f service.HistoryFilter) (*service.HistoryPage, error)
+
+func (s *WalletGRPCServer) listHistory(ctx context.Context, req *walletv1.ListTransactionsRequest, list historyLister) (*walletv1.ListTransactionsResponse, error) {
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f := service.HistoryFilter{
+		Currency: req.Currency,
+		Status:   req.Status,
+		TxHash:   req.TxHash,
+		Cursor:   req.Cursor,
+		Limit:    int(req.Limit),
+	}
+	if req.StartTime > 0 {
+		t := time.Unix(req.StartTime, 0)
+		f.Since = &t
+	}
+	if req.EndTime > 0 {
+		t := time.Unix(req.EndTime, 0)
+		f.Until = &t
+	}
+
+	page, err := list(ctx, userID, f)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &walletv1.ListTransactionsResponse{
+		Items:      make([]*walletv1.Transaction, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+	for _, it := range page.Items {
+		tx := &walletv1.Transaction{
+			Kind:                  it.Kind,
+			Id:                    int64(it.ID),
+			Currency:              it.Currency,
+			TxHash:                it.TxHash,
+			Amount:                it.Amount.String(),
+			Status:                it.Status,
+			Address:               it.Address,
+			Confirmations:         it.Confirmations,
+			RequiredConfirmations: it.RequiredConfirmations,
+			ExplorerUrl:           it.ExplorerURL,
+			CreatedAt:             it.CreatedAt.Unix(),
+		}
+		if it.ConfirmedAt != nil {
+			tx.ConfirmedAt = it.ConfirmedAt.Unix()
+		}
+		resp.Items = append(resp.Items, tx)
+	}
+	return resp, nil
+}
+
+// WatchAccountEvents 推送当前用户的账户事件，直到客户端断开
+// 订阅成功后立即发送 header，调用方据此区分 "订阅失败" 和 "暂时没有事件"
+func (s *WalletGRPCServer) WatchAccountEvents(req *walletv1.WatchAccountEventsRequest, stream grpc.ServerStreamingServer[walletv1.AccountEvent]) error {
+	ctx := stream.Context()
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return err
+	}
+	events, err := s.events.Subscribe(ctx, userID, req.LastEventId, req.Types...)
+	if err != nil {
+		return err
+	}
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	for e := range events {
+		if err := stream.Send(&walletv1.AccountEvent{
+			Id:        e.ID,
+			Type:      e.Type,
+			Data:      string(e.Data),
+			CreatedAt: e.CreatedAt.UnixMilli(),
+		}); err != nil {
+			return err
+		}
+	}
+	// channel 关闭: 客户端断开 (ctx 取消) 或读取事件流失败
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errno.ErrUnavailable
+}
+
+func (s *WalletGRPCServer) ListAddresses(ctx context.Context, req *walletv1.ListAddressesRequest) (*walletv1.ListAddressesResponse, error) {
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := s.svc.ListAddresses(ctx, int64(userID), req.Currency)
 	if err != nil {
 		return nil, err
 	}
 
-	return &walletv1.CreateWithdrawalResponse{
-		WithdrawalId: id,
-		Status:       "pending_review",
+	resp := &walletv1.ListAddressesResponse{Addresses: make([]*walletv1.DepositAddress, 0, len(addrs))}
+	for _, a := range addrs {
+		resp.Addresses = append(resp.Addresses, &walletv1.DepositAddress{
+			Currency:  a.Chain,
+			Address:   a.Address,
+			CreatedAt: a.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+func (s *WalletGRPCServer) ValidateAddress(ctx context.Context, req *walletv1.ValidateAddressRequest) (*walletv1.ValidateAddressResponse, error) {
+	check, err := s.svc.ValidateAddress(req.Currency, req.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &walletv1.ValidateAddressResponse{
+		Valid:             check.Valid,
+		NormalizedAddress: check.Normalized,
+		Reason:            check.Reason,
 	}, nil
 }
+
+func (s *WalletGRPCServer) GetSupportedAssets(ctx context.Context, req *walletv1.GetSupportedAssetsRequest) (*walletv1.GetSupportedAssetsResponse, error) {
+	assets := service.SupportedAssets()
+	resp := &walletv1.GetSupportedAssetsResponse{Assets: make([]*walletv1.Asset, 0, len(assets))}
+	for _, a := range assets {
+		resp.Assets = append(resp.Assets, &walletv1.Asset{
+			Currency:      a.Currency,
+			Chain:         a.Name,
+			Decimals:      a.Decimals,
+			MinDeposit:    money(a.MinDeposit, a.Currency),
+			MinWithdrawal: money(a.MinWithdrawal, a.Currency),
+			Confirmations: a.Confirmations,
+		})
+	}
+	return resp, nil
+}
+
+func (s *WalletGRPCServer) GetWithdrawal(ctx context.Context, req *walletv1.GetWithdrawalRequest) (*walletv1.GetWithdrawalResponse, error) {
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := s.svc.GetWithdrawal(ctx, int64(userID), uint64(req.WithdrawalId))
+	if err != nil {
+		return nil, err
+	}
+	return &walletv1.GetWithdrawalResponse{Withdrawal: toWithdrawal(w)}, nil
+}
+
+func (s *WalletGRPCServer) CancelWithdrawal(ctx context.Context, req *walletv1.CancelWithdrawalRequest) (*walletv1.CancelWithdrawalResponse, error) {
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := s.svc.CancelWithdrawal(ctx, int64(userID), uint64(req.WithdrawalId))
+	if err != nil {
+		return nil, err
+	}
+	return &walletv1.CancelWithdrawalResponse{Withdrawal: toWithdrawal(w)}, nil
+}
+
+func (s *WalletGRPCServer) GetDeposit(ctx context.Context, req *walletv1.GetDepositRequest) (*walletv1.GetDepositResponse, error) {
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := s.svc.GetDeposit(ctx, int64(userID), uint64(req.DepositId))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &walletv1.Deposit{
+		Id:                    int64(d.ID),
+		FromAddress:           d.FromAddress,
+		Amount:                money(d.Amount, d.Chain),
+		Status:                d.Status,
+		TxHash:                d.TxHash,
+		Confirmations:         d.Confirmations,
+		RequiredConfirmations: config.Chain(d.Chain).Confirmations,
+		ExplorerUrl:           service.ExplorerTxURL(d.Chain, d.TxHash),
+		BlockHeight:           d.BlockHeight,
+		CreatedAt:             d.CreatedAt.Unix(),
+	}
+	if d.ConfirmedAt != nil {
+		resp.ConfirmedAt = d.ConfirmedAt.Unix()
+	}
+	return &walletv1.GetDepositResponse{Deposit: resp}, nil
+}
+
+func toWithdrawal(w *model.Withdrawal) *walletv1.Withdrawal {
+	resp := &walletv1.Withdrawal{
+		Id:                    int64(w.ID),
+		ToAddress:             w.ToAddress,
+		Amount:                money(w.Amount, w.Chain),
+		Status:                w.Status,
+		TxHash:                w.TxHash,
+		Confirmations:         w.Confirmations,
+		RequiredConfirmations: config.Chain(w.Chain).Confirmations,
+		ExplorerUrl:           service.ExplorerTxURL(w.Chain, w.TxHash),
+		FailReason:            w.FailReason,
+		CreatedAt:             w.CreatedAt.Unix(),
+		TransferId:            int64(w.TransferID),
+	}
+	// GasFee 以 Wei 记录，只有 EVM 链有
+	if w.Chain == "ETH" && w.GasFee.IsPositive() {
+		resp.NetworkFee = money(w.GasFee.Shift(-18), w.Chain)
+	}
+	if w.ExecuteAfter != nil {
+		resp.ExecuteAfter = w.ExecuteAfter.Unix()
+	}
+	if w.ConfirmedAt != nil {
+		resp.ConfirmedAt = w.ConfirmedAt.Unix()
+	}
+	return resp
+}
+
+func (s *WalletGRPCServer) CreateTransfer(ctx context.Context, req *walletv1.CreateTransferRequest) (*walletv1.CreateTransferResponse, error) {
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.svc.CreateTransfer(ctx, int64(userID), req.To, req.Amount, req.Currency, req.Memo, req.IdempotencyKey, req.TotpCode)
+	if err != nil {
+		return nil, err
+	}
+	return &walletv1.CreateTransferResponse{Transfer: toTransfer(t, userID)}, nil
+}
+
+func (s *WalletGRPCServer) GetTransfer(ctx context.Context, req *walletv1.GetTransferRequest) (*walletv1.GetTransferResponse, error) {
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.svc.GetTransfer(ctx, int64(userID), uint64(req.TransferId))
+	if err != nil {
+		return nil, err
+	}
+	return &walletv1.GetTransferResponse{Transfer: toTransfer(t, userID)}, nil
+}
+
+func (s *WalletGRPCServer) ListTransfers(ctx context.Context, req *walletv1.ListTransfersRequest) (*walletv1.ListTransfersResponse, error) {
+	userID, err := interceptor.UserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.svc.ListTransfers(ctx, int64(userID), req.Currency, uint64(req.BeforeId), int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	resp := &walletv1.ListTransfersResponse{Transfers: make([]*walletv1.Transfer, 0, len(list))}
+	for i := range list {
+		resp.Transfers = append(resp.Transfers, toTransfer(&list[i], userID))
+	}
+	return resp, nil
+}
+
+// toTransfer 转换为 proto，direction 以调用方视角区分转出 / 转入
+func toTransfer(t *model.Transfer, userID uint64) *walletv1.Transfer {
+	direction := "out"
+	if t.ToUserID == userID {
+		direction = "in"
+	}
+	return &walletv1.Transfer{
+		Id:           int64(t.ID),
+		Direction:    direction,
+		FromUserId:   int64(t.FromUserID),
+		ToUserId:     int64(t.ToUserID),
+		Amount:       money(t.Amount, t.Currency),
+		Memo:         t.Memo,
+		Source:       t.Source,
+		WithdrawalId: int64(t.WithdrawalID),
+		ToAddress:    t.ToAddress,
+		CreatedAt:    t.CreatedAt.Unix(),
+	}
+}
+
+func money(amount decimal.Decimal, currency string) *walletv1.Money {
+	return &walletv1.Money{Amount: amount.String(), Currency: currency}
+}
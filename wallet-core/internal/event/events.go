@@ -1,5 +1,29 @@
 package event
 
+// Topics
+const (
+	TopicDeposit             = "wallet_events_deposit"
+	TopicWithdrawal          = "wallet_events_withdrawal"
+	TopicWithdrawalConfirmed = "wallet_events_withdrawal_confirmed"
+	TopicWithdrawalFailed    = "wallet_events_withdrawal_failed"
+	TopicCollectionConfirmed = "wallet_events_collection_confirmed"
+	TopicKYCTierChanged      = "wallet_events_kyc_tier_changed"
+	TopicTransfer            = "wallet_events_transfer"
+)
+
+// DepositEvent 充值入库事件 (隔离的充值不发送)
+// Topic: wallet_events_deposit
+type DepositEvent struct {
+	DepositID     uint64 `json:"deposit_id"`
+	TenantID      uint64 `json:"tenant_id"`
+	UserID        uint64 `json:"user_id"`
+	Chain         string `json:"chain"`
+	TxHash        string `json:"tx_hash"`
+	Amount        string `json:"amount"` // Decimal string
+	Status        string `json:"status"` // pending: 已检测到，等待确认; confirmed: 已入账
+	Confirmations uint64 `json:"confirmations"`
+}
+
 // WithdrawalCreatedEvent 提现创建事件
 // Topic: wallet_events_withdrawal
 type WithdrawalCreatedEvent struct {
@@ -8,4 +32,65 @@ type WithdrawalCreatedEvent struct {
 	ToAddress    string `json:"to_address"`
 	Amount       string `json:"amount"` // Decimal string
 	Chain        string `json:"chain"`
+	Status       string `json:"status"` // pending_review / risk_hold
+}
+
+// WithdrawalConfirmedEvent 提现交易达到确认数
+// Topic: wallet_events_withdrawal_confirmed
+type WithdrawalConfirmedEvent struct {
+	WithdrawalID  uint64 `json:"withdrawal_id"`
+	UserID        uint64 `json:"user_id"`
+	Chain         string `json:"chain"`
+	TxHash        string `json:"tx_hash"`
+	Amount        string `json:"amount"`
+	BlockNumber   uint64 `json:"block_number"`
+	Confirmations uint64 `json:"confirmations"`
+	GasLimit      uint64 `json:"gas_limit"` // 预估
+	GasUsed       uint64 `json:"gas_used"`  // 实际
+	GasFee        string `json:"gas_fee"`   // 实际 Gas 费 (Wei)
+}
+
+// WithdrawalFailedEvent 提现交易链上失败 (revert) 或被替换/丢弃
+// Topic: wallet_events_withdrawal_failed
+type WithdrawalFailedEvent struct {
+	WithdrawalID uint64 `json:"withdrawal_id"`
+	UserID       uint64 `json:"user_id"`
+	Chain        string `json:"chain"`
+	TxHash       string `json:"tx_hash"`
+	Amount       string `json:"amount"`
+	Reason       string `json:"reason"`
+}
+
+// CollectionConfirmedEvent 归集交易达到确认数
+// Topic: wallet_events_collection_confirmed
+type CollectionConfirmedEvent struct {
+	CollectionID uint   `json:"collection_id"`
+	DepositID    uint   `json:"deposit_id"`
+	TxHash       string `json:"tx_hash"`
+	Amount       string `json:"amount"` // Wei
+	GasLimit     uint64 `json:"gas_limit"`
+	GasUsed      uint64 `json:"gas_used"`
+	GasFee       string `json:"gas_fee"`
+}
+
+// KYCTierChangedEvent 用户实名认证等级变更 (审核通过 / 管理员调整)
+// Topic: wallet_events_kyc_tier_changed
+type KYCTierChangedEvent struct {
+	UserID        uint64 `json:"user_id"`
+	OldTier       string `json:"old_tier"`
+	NewTier       string `json:"new_tier"`
+	ApplicationID uint64 `json:"application_id,omitempty"` // 审核申请触发时为申请 ID，管理员直接调整时为 0
+	AdminID       uint64 `json:"admin_id"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// TransferEvent 站内转账完成 (两边余额已在同一事务内变更)
+// Topic: wallet_events_transfer
+type TransferEvent struct {
+	TransferID   uint64 `json:"transfer_id"`
+	FromUserID   uint64 `json:"from_user_id"`
+	ToUserID     uint64 `json:"to_user_id"`
+	Currency     string `json:"currency"`
+	Amount       string `json:"amount"`
+	WithdrawalID uint64 `json:"withdrawal_id,omitempty"` // 由提现转为站内结算时非 0
 }
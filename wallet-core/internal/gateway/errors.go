@@ -0,0 +1,77 @@
+package gateway
+
+import (
+	"net/http"
+
+	"wallet-core/internal/handler/response"
+	"wallet-core/pkg/errno"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// writeError translates a backend gRPC error (or a local errno.Errno) into the
+// matching HTTP status and the standard response envelope, so REST clients see the
+// same business codes as gRPC clients.
+func writeError(c *gin.Context, err error) {
+	st := status.Convert(err)
+	e, ok := errno.FromGRPC(err)
+	if !ok {
+		e = errnoFromCode(st)
+	}
+	c.JSON(httpStatus(st.Code()), response.Response{
+		Code:    e.Code,
+		Message: e.Message,
+		Data:    gin.H{},
+	})
+}
+
+// errnoFromCode picks a generic business error for statuses that carry no errno detail
+// (e.g. raised by grpc itself). Internal messages are not passed through.
+func errnoFromCode(st *status.Status) errno.Errno {
+	switch st.Code() {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return errno.ErrBind.WithMessage(st.Message())
+	case codes.Unauthenticated:
+		return errno.ErrUnauthorized
+	case codes.PermissionDenied:
+		return errno.ErrPermissionDenied
+	case codes.ResourceExhausted:
+		return errno.ErrTooManyRequests
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return errno.ErrUnavailable
+	default:
+		return errno.InternalServerError
+	}
+}
+
+// httpStatus maps a gRPC code to an HTTP status (same table as grpc-gateway)
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499 // client closed request
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,47 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"wallet-core/internal/handler/response"
+	"wallet-core/pkg/errno"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWriteError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name   string
+		err    error
+		status int
+		code   int
+	}{
+		{"business error from backend", status.ErrorProto(status.Convert(errno.ErrUserAlreadyExist).Proto()), http.StatusConflict, errno.ErrUserAlreadyExist.Code},
+		{"local bind error", errno.ErrBind.WithMessage("bad json"), http.StatusBadRequest, errno.ErrBind.Code},
+		{"plain status", status.Error(codes.Unauthenticated, "no token"), http.StatusUnauthorized, errno.ErrUnauthorized.Code},
+		{"backend down", status.Error(codes.Unavailable, "connection refused"), http.StatusServiceUnavailable, errno.ErrUnavailable.Code},
+		{"unknown error", errors.New("boom"), http.StatusInternalServerError, errno.InternalServerError.Code},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			writeError(c, tc.err)
+
+			assert.Equal(t, tc.status, w.Code)
+			var body response.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tc.code, body.Code)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	walletv1 "wallet-core/api/gen/wallet/v1"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// heartbeatInterval keeps idle connections open through proxies and load balancers.
+const heartbeatInterval = 15 * time.Second
+
+// Browsers cannot set headers on EventSource / WebSocket, so these endpoints also accept
+// ?access_token=. Since the token never comes from a cookie, cross-origin pages cannot
+// ride on a user's session and any origin is allowed.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// accountEvent is the JSON shape sent to clients. data is the raw JSON payload.
+type accountEvent struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt int64           `json:"created_at"` // Unix milliseconds
+}
+
+func toAccountEvent(e *walletv1.AccountEvent) accountEvent {
+	data := json.RawMessage(e.Data)
+	if !json.Valid(data) {
+		data = json.RawMessage("{}")
+	}
+	return accountEvent{ID: e.Id, Type: e.Type, Data: data, CreatedAt: e.CreatedAt}
+}
+
+// watchEvents opens WatchAccountEvents for the caller.
+// Resume point: Last-Event-ID header (sent by EventSource on reconnect) or ?last_event_id=.
+// Filter: ?types=deposit.confirmed,balance.changed
+// Errors before the stream is established (auth, bad arguments) are returned so the
+// handler can still answer with a normal error response.
+func (h *WalletHandler) watchEvents(c *gin.Context) (grpc.ServerStreamingClient[walletv1.AccountEvent], context.CancelFunc, error) {
+	req := &walletv1.WatchAccountEventsRequest{
+		LastEventId: c.GetHeader("Last-Event-ID"),
+	}
+	if req.LastEventId == "" {
+		req.LastEventId = c.Query("last_event_id")
+	}
+	if types := c.Query("types"); types != "" {
+		req.Types = strings.Split(types, ",")
+	}
+
+	// No deadline: the stream lives as long as the client connection.
+	ctx, cancel := context.WithCancel(outgoingContext(c, c.Request.Context()))
+	stream, err := h.client.WatchAccountEvents(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	// The service sends headers once subscribed; nil headers mean the call failed
+	// and the status is reported by Recv.
+	if md, _ := stream.Header(); md == nil {
+		_, err := stream.Recv()
+		cancel()
+		if err == nil || err == io.EOF {
+			err = errno.ErrUnavailable
+		}
+		return nil, nil, err
+	}
+	return stream, cancel, nil
+}
+
+// recvEvents forwards events from the stream to a channel until the stream ends.
+func recvEvents(stream grpc.ServerStreamingClient[walletv1.AccountEvent]) <-chan *walletv1.AccountEvent {
+	ch := make(chan *walletv1.AccountEvent)
+	go func() {
+		defer close(ch)
+		for {
+			e, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && stream.Context().Err() == nil {
+					logger.Warn("account event stream ended", zap.Error(err))
+				}
+				return
+			}
+			select {
+			case ch <- e:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// EventsSSE streams account events as Server-Sent Events.
+func (h *WalletHandler) EventsSSE(c *gin.Context) {
+	stream, cancel, err := h.watchEvents(c)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // disable nginx buffering
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	events := recvEvents(stream)
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if _, err := c.Writer.WriteString(formatSSE(e)); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-ticker.C:
+			if _, err := c.Writer.WriteString(": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// formatSSE renders one event: "id" lets EventSource resume, "event" lets clients
+// addEventListener by type.
+func formatSSE(e *walletv1.AccountEvent) string {
+	data, _ := json.Marshal(toAccountEvent(e))
+	return fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", e.Id, e.Type, data)
+}
+
+// EventsWebSocket streams account events over a WebSocket, one JSON message per event.
+// The connection is server-to-client only; client messages are read and discarded
+// so that close frames and pongs are processed.
+func (h *WalletHandler) EventsWebSocket(c *gin.Context) {
+	stream, cancel, err := h.watchEvents(c)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	defer cancel()
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return // Upgrade has already written the HTTP error
+	}
+	defer conn.Close()
+
+	closed := make(chan struct{})
+	conn.SetReadDeadline(time.Now().Add(2 * heartbeatInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * heartbeatInterval))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	events := recvEvents(stream)
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case e, ok := <-events:
+			if !ok {
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "event stream closed"),
+					time.Now().Add(time.Second))
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(heartbeatInterval))
+			if err := conn.WriteJSON(toAccountEvent(e)); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				return
+			}
+		}
+	}
+}
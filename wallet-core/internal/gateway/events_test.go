@@ -0,0 +1,23 @@
+package gateway
+
+import (
+	"testing"
+
+	walletv1 "wallet-core/api/gen/wallet/v1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSSE(t *testing.T) {
+	got := formatSSE(&walletv1.AccountEvent{
+		Id:        "1700000000000-0",
+		Type:      "deposit.confirmed",
+		Data:      `{"deposit_id":1}`,
+		CreatedAt: 1700000000000,
+	})
+	assert.Equal(t, "id: 1700000000000-0\nevent: deposit.confirmed\n"+
+		`data: {"id":"1700000000000-0","type":"deposit.confirmed","data":{"deposit_id":1},"created_at":1700000000000}`+"\n\n", got)
+
+	// 非法 JSON 不会破坏 SSE 帧
+	assert.Contains(t, formatSSE(&walletv1.AccountEvent{Id: "1-0", Type: "resync", Data: "not json\n"}), `"data":{}`)
+}
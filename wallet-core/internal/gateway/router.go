@@ -8,25 +8,84 @@ import (
 
 	userv1 "wallet-core/api/gen/user/v1"
 	walletv1 "wallet-core/api/gen/wallet/v1"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/service/auth"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/ratelimit"
+	"wallet-core/pkg/tenant"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // RegisterRoutes registers all HTTP routes for the gateway
-func RegisterRoutes(r *gin.Engine, userClient userv1.UserServiceClient, walletClient walletv1.WalletServiceClient) {
+// Routes under the authenticated group require "Authorization: Bearer <access token>".
+// The token is checked here and forwarded to the backend services, which resolve
+// the user from it; a user_id supplied by the client is never trusted.
+// Sensitive routes are rate limited by the rules in config ratelimit.rules.
+// Failures use the response.Response envelope with the backend's errno code (see writeError).
+func RegisterRoutes(r *gin.Engine, userClient userv1.UserServiceClient, walletClient walletv1.WalletServiceClient, authSvc *auth.Service, limiter *ratelimit.Limiter) {
 	api := r.Group("/v1")
+	authed := api.Group("", middleware.JWTAuth(authSvc))
 
 	// User Routes
 	userHandler := &UserHandler{client: userClient}
-	api.POST("/user/register", userHandler.Register)
-	api.POST("/user/login", userHandler.Login)
-	api.GET("/user/profile", userHandler.GetProfile)
+	api.POST("/user/register", middleware.RateLimit(limiter, "register"), userHandler.Register)
+	api.POST("/user/login", middleware.RateLimit(limiter, "login"), userHandler.Login)
+	api.POST("/user/token/refresh", userHandler.RefreshToken)
+	api.POST("/user/logout", userHandler.Logout)
+	authed.POST("/user/logout/all", userHandler.LogoutAll)
+	authed.GET("/user/profile", userHandler.GetProfile)
+	authed.POST("/user/mfa/totp/enroll", userHandler.EnrollTOTP)
+	authed.POST("/user/mfa/totp/activate", userHandler.ActivateTOTP)
+	authed.POST("/user/email/verification", middleware.RateLimit(limiter, "account_email"), userHandler.SendVerificationEmail)
+	api.POST("/user/email/verify", middleware.RateLimit(limiter, "account_email"), userHandler.VerifyEmail)
+	api.POST("/user/password/forgot", middleware.RateLimit(limiter, "account_email"), userHandler.RequestPasswordReset)
+	api.POST("/user/password/reset", middleware.RateLimit(limiter, "account_email"), userHandler.ResetPassword)
 
 	// Wallet Routes
 	walletHandler := &WalletHandler{client: walletClient}
-	api.POST("/wallet/address", walletHandler.CreateAddress)
-	api.GET("/wallet/balance", walletHandler.GetBalance)
-	api.POST("/wallet/withdraw", walletHandler.CreateWithdrawal)
+	authed.POST("/wallet/address", middleware.RateLimit(limiter, "address_create"), walletHandler.CreateAddress)
+	authed.GET("/wallet/balance", walletHandler.GetBalance)
+	authed.GET("/wallet/addresses", walletHandler.ListAddresses)
+	api.GET("/wallet/address/validate", walletHandler.ValidateAddress)
+	api.GET("/wallet/assets", walletHandler.GetSupportedAssets)
+	authed.POST("/wallet/withdraw", middleware.RateLimit(limiter, "withdraw"), walletHandler.CreateWithdrawal)
+	api.GET("/wallet/withdraw/quote", walletHandler.QuoteWithdrawal)
+	authed.POST("/wallet/withdraw/:id/cancel", walletHandler.CancelWithdrawal)
+	authed.GET("/wallet/deposits", walletHandler.ListDeposits)
+	authed.GET("/wallet/deposits/:id", walletHandler.GetDeposit)
+	authed.GET("/wallet/withdrawals", walletHandler.ListWithdrawals)
+	authed.GET("/wallet/withdrawals/:id", walletHandler.GetWithdrawal)
+	authed.GET("/wallet/transactions", walletHandler.ListTransactions)
+	authed.POST("/wallet/transfers", middleware.RateLimit(limiter, "transfer"), walletHandler.CreateTransfer)
+	authed.GET("/wallet/transfers", walletHandler.ListTransfers)
+	authed.GET("/wallet/transfers/:id", walletHandler.GetTransfer)
+
+	// Account event push (see events.go)
+	events := api.Group("/wallet/events", middleware.TokenFromQuery("access_token"), middleware.JWTAuth(authSvc))
+	events.GET("", walletHandler.EventsSSE)
+	events.GET("/ws", walletHandler.EventsWebSocket)
+}
+
+// rpcContext returns the context for a backend call, forwarding the caller's access token,
+// client IP (backend services rate limit anonymous calls by IP; ClientIP only honours X-Forwarded-For
+// from app.trusted_proxies, and backends only honour x-forwarded-for from grpc.trusted_forwarders) and X-Tenant header
+// (the tenant code used by register / login / password reset; signed-in calls use the tenant in the token)
+func rpcContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(outgoingContext(c, c.Request.Context()), 5*time.Second)
+}
+
+func outgoingContext(c *gin.Context, ctx context.Context) context.Context {
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-forwarded-for", c.ClientIP())
+	if token := c.GetHeader("Authorization"); token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	}
+	if code := c.GetHeader(tenant.Header); code != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, tenant.MetadataKey, code)
+	}
+	return ctx
 }
 
 type UserHandler struct {
@@ -36,16 +95,16 @@ type UserHandler struct {
 func (h *UserHandler) Register(c *gin.Context) {
 	var req userv1.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, errno.ErrBind.WithMessage(err.Error()))
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := rpcContext(c)
 	defer cancel()
 
 	resp, err := h.client.Register(ctx, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -55,16 +114,67 @@ func (h *UserHandler) Register(c *gin.Context) {
 func (h *UserHandler) Login(c *gin.Context) {
 	var req userv1.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, errno.ErrBind.WithMessage(err.Error()))
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := rpcContext(c)
 	defer cancel()
 
 	resp, err := h.client.Login(ctx, &req)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *UserHandler) RefreshToken(c *gin.Context) {
+	var req userv1.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, errno.ErrBind.WithMessage(err.Error()))
+		return
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.RefreshToken(ctx, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *UserHandler) Logout(c *gin.Context) {
+	var req userv1.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, errno.ErrBind.WithMessage(err.Error()))
+		return
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.Logout(ctx, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *UserHandler) LogoutAll(c *gin.Context) {
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.LogoutAll(ctx, &userv1.LogoutAllRequest{})
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
@@ -72,24 +182,114 @@ func (h *UserHandler) Login(c *gin.Context) {
 }
 
 func (h *UserHandler) GetProfile(c *gin.Context) {
-	userIDStr := c.Query("user_id")
-	if userIDStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.GetUserInfo(ctx, &userv1.GetUserInfoRequest{})
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
-	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *UserHandler) EnrollTOTP(c *gin.Context) {
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.EnrollTOTP(ctx, &userv1.EnrollTOTPRequest{})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id must be a valid number"})
+		writeError(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *UserHandler) ActivateTOTP(c *gin.Context) {
+	var req userv1.ActivateTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, errno.ErrBind.WithMessage(err.Error()))
+		return
+	}
+
+	ctx, cancel := rpcContext(c)
 	defer cancel()
 
-	resp, err := h.client.GetUserInfo(ctx, &userv1.GetUserInfoRequest{UserId: userID})
+	resp, err := h.client.ActivateTOTP(ctx, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *UserHandler) SendVerificationEmail(c *gin.Context) {
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.SendVerificationEmail(ctx, &userv1.SendVerificationEmailRequest{})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	var req userv1.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, errno.ErrBind.WithMessage(err.Error()))
+		return
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.VerifyEmail(ctx, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *UserHandler) RequestPasswordReset(c *gin.Context) {
+	var req userv1.RequestPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, errno.ErrBind.WithMessage(err.Error()))
+		return
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.RequestPasswordReset(ctx, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var req userv1.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, errno.ErrBind.WithMessage(err.Error()))
+		return
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.ResetPassword(ctx, &req)
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
@@ -103,63 +303,315 @@ type WalletHandler struct {
 func (h *WalletHandler) CreateAddress(c *gin.Context) {
 	var req walletv1.CreateAddressRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, errno.ErrBind.WithMessage(err.Error()))
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := rpcContext(c)
 	defer cancel()
 
 	resp, err := h.client.CreateAddress(ctx, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, resp)
 }
 
 func (h *WalletHandler) GetBalance(c *gin.Context) {
-	userIDStr := c.Query("user_id")
-	if userIDStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.GetBalance(ctx, &walletv1.GetBalanceRequest{
+		Currency: c.Query("currency"),
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WalletHandler) CreateWithdrawal(c *gin.Context) {
+	var req walletv1.CreateWithdrawalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, errno.ErrBind.WithMessage(err.Error()))
+		return
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.CreateWithdrawal(ctx, &req)
+	if err != nil {
+		writeError(c, err)
 		return
 	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateTransfer sends funds to another user of the same tenant off-chain.
+// The idempotency key may also be passed in the Idempotency-Key header.
+func (h *WalletHandler) CreateTransfer(c *gin.Context) {
+	var req walletv1.CreateTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, errno.ErrBind.WithMessage(err.Error()))
+		return
+	}
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = c.GetHeader("Idempotency-Key")
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.CreateTransfer(ctx, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WalletHandler) GetTransfer(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.GetTransfer(ctx, &walletv1.GetTransferRequest{TransferId: id})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListTransfers pages with before_id (the id of the last transfer on the previous page).
+func (h *WalletHandler) ListTransfers(c *gin.Context) {
+	req := &walletv1.ListTransfersRequest{Currency: c.Query("currency")}
+	if v := c.Query("before_id"); v != "" {
+		beforeID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeError(c, errno.ErrBind.WithMessage("before_id must be an integer"))
+			return
+		}
+		req.BeforeId = beforeID
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			writeError(c, errno.ErrBind.WithMessage("limit must be an integer"))
+			return
+		}
+		req.Limit = int32(limit)
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.ListTransfers(ctx, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WalletHandler) QuoteWithdrawal(c *gin.Context) {
 	currency := c.Query("currency")
+	if currency == "" {
+		writeError(c, errno.ErrBind.WithMessage("currency is required"))
+		return
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
 
-	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	resp, err := h.client.QuoteWithdrawal(ctx, &walletv1.QuoteWithdrawalRequest{
+		Currency:  currency,
+		ToAddress: c.Query("to_address"),
+		Amount:    c.Query("amount"),
+		Level:     c.Query("level"),
+	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id must be a valid number"})
+		writeError(c, err)
 		return
 	}
+	c.JSON(http.StatusOK, resp)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (h *WalletHandler) ListAddresses(c *gin.Context) {
+	ctx, cancel := rpcContext(c)
 	defer cancel()
 
-	resp, err := h.client.GetBalance(ctx, &walletv1.GetBalanceRequest{
-		UserId:   userID,
-		Currency: currency,
+	resp, err := h.client.ListAddresses(ctx, &walletv1.ListAddressesRequest{
+		Currency: c.Query("currency"),
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, resp)
 }
 
-func (h *WalletHandler) CreateWithdrawal(c *gin.Context) {
-	var req walletv1.CreateWithdrawalRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+func (h *WalletHandler) ValidateAddress(c *gin.Context) {
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.ValidateAddress(ctx, &walletv1.ValidateAddressRequest{
+		Currency: c.Query("currency"),
+		Address:  c.Query("address"),
+	})
+	if err != nil {
+		writeError(c, err)
 		return
 	}
+	c.JSON(http.StatusOK, resp)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (h *WalletHandler) GetSupportedAssets(c *gin.Context) {
+	ctx, cancel := rpcContext(c)
 	defer cancel()
 
-	resp, err := h.client.CreateWithdrawal(ctx, &req)
+	resp, err := h.client.GetSupportedAssets(ctx, &walletv1.GetSupportedAssetsRequest{})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WalletHandler) GetWithdrawal(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.GetWithdrawal(ctx, &walletv1.GetWithdrawalRequest{WithdrawalId: id})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WalletHandler) CancelWithdrawal(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.CancelWithdrawal(ctx, &walletv1.CancelWithdrawalRequest{WithdrawalId: id})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WalletHandler) GetDeposit(c *gin.Context) {
+	id, err := pathID(c)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := h.client.GetDeposit(ctx, &walletv1.GetDepositRequest{DepositId: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, resp)
 }
+
+// pathID parses the :id path parameter.
+func pathID(c *gin.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errno.ErrBind.WithMessage("id must be a positive integer")
+	}
+	return id, nil
+}
+
+func (h *WalletHandler) ListDeposits(c *gin.Context) {
+	h.listHistory(c, h.client.ListDeposits)
+}
+
+func (h *WalletHandler) ListWithdrawals(c *gin.Context) {
+	h.listHistory(c, h.client.ListWithdrawals)
+}
+
+func (h *WalletHandler) ListTransactions(c *gin.Context) {
+	h.listHistory(c, h.client.ListTransactions)
+}
+
+type historyCall func(ctx context.Context, in *walletv1.ListTransactionsRequest, opts ...grpc.CallOption) (*walletv1.ListTransactionsResponse, error)
+
+// listHistory maps the query string onto ListTransactionsRequest.
+// start_time / end_time accept unix seconds or RFC 3339.
+func (h *WalletHandler) listHistory(c *gin.Context, call historyCall) {
+	req := &walletv1.ListTransactionsRequest{
+		Currency: c.Query("currency"),
+		Status:   c.Query("status"),
+		TxHash:   c.Query("tx_hash"),
+		Cursor:   c.Query("cursor"),
+	}
+	var err error
+	if req.StartTime, err = queryUnix(c, "start_time"); err != nil {
+		writeError(c, err)
+		return
+	}
+	if req.EndTime, err = queryUnix(c, "end_time"); err != nil {
+		writeError(c, err)
+		return
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			writeError(c, errno.ErrBind.WithMessage("limit must be an integer"))
+			return
+		}
+		req.Limit = int32(limit)
+	}
+
+	ctx, cancel := rpcContext(c)
+	defer cancel()
+
+	resp, err := call(ctx, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func queryUnix(c *gin.Context, key string) (int64, error) {
+	v := c.Query(key)
+	if v == "" {
+		return 0, nil
+	}
+	if sec, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return sec, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return 0, errno.ErrBind.WithMessage(key + " must be unix seconds or RFC 3339")
+	}
+	return t.Unix(), nil
+}
@@ -1,24 +1,132 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
+	"strings"
 
 	"wallet-core/internal/handler/request"
 	"wallet-core/internal/handler/response"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/model"
 	"wallet-core/internal/service"
+	"wallet-core/internal/service/auth"
 	"wallet-core/pkg/errno"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type AdminHandler struct{}
 
 var Admin = &AdminHandler{}
 
+// Login 管理员登录
+// @Summary 管理员登录
+// @Description 用户名 + 密码 + TOTP 验证码登录后台，返回会话 token
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body request.AdminLoginRequest true "Login Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/auth/login [post]
+func (h *AdminHandler) Login(c *gin.Context) {
+	var req request.AdminLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	session, err := service.AdminAuth.Login(c.Request.Context(), req.Username, req.Password, req.TOTPCode, auditMeta(c))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, session)
+}
+
+// Logout 管理员退出登录
+// @Summary 管理员退出登录
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/auth/logout [post]
+func (h *AdminHandler) Logout(c *gin.Context) {
+	token := auth.BearerToken(c.GetHeader("Authorization"))
+	if err := service.AdminAuth.Logout(c.Request.Context(), middleware.CurrentAdmin(c), token, auditMeta(c)); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// CreateAdmin 创建管理员
+// @Summary 创建管理员
+// @Description 仅 superadmin。返回的 TOTP 密钥只出现这一次，需交给本人绑定验证器
+// @Tags Admin
+// @Security AdminAuth
+// @Accept json
+// @Produce json
+// @Param request body request.CreateAdminRequest true "Create Admin Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/admins [post]
+func (h *AdminHandler) CreateAdmin(c *gin.Context) {
+	var req request.CreateAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	creator := middleware.CurrentAdmin(c)
+	admin, secret, uri, err := service.AdminAuth.CreateAdmin(c.Request.Context(), creator.ID, req.Username, req.Email, req.Password, req.Role)
+	resourceID := ""
+	if admin != nil {
+		resourceID = strconv.FormatUint(admin.ID, 10)
+	}
+	h.audit(c, model.AuditActionAdminCreate, "admin", resourceID, "username="+req.Username+" role="+req.Role, err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"admin":            admin,
+		"totp_secret":      secret,
+		"provisioning_uri": uri,
+	})
+}
+
+// ListAuditLogs 审计日志
+// @Summary 审计日志
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param admin_id query int false "Admin ID"
+// @Param action query string false "Action, e.g. withdrawal.review"
+// @Param limit query int false "Limit (default 50, max 200)"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/audit-logs [get]
+func (h *AdminHandler) ListAuditLogs(c *gin.Context) {
+	adminID, _ := strconv.ParseUint(c.Query("admin_id"), 10, 64)
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	logs, err := service.AdminAuth.ListAuditLogs(c.Request.Context(), adminID, c.Query("action"), limit)
+	if err != nil {
+		response.Error(c, errno.ErrDatabase)
+		return
+	}
+
+	response.Success(c, logs)
+}
+
 // ReviewWithdrawal 审核提现
 // @Summary 审核提现
 // @Description 管理员对提现申请进行审批 (Approve/Reject)
 // @Tags Admin
+// @Security AdminAuth
 // @Accept json
 // @Produce json
 // @Param id path int true "Withdrawal ID"
@@ -41,21 +149,130 @@ func (h *AdminHandler) ReviewWithdrawal(c *gin.Context) {
 		return
 	}
 
-	// 3. 获取 Admin ID (Mock)
-	// 正常应该从 JWT 中获取 c.Get("uid")
-	// 为了演示，这里假设 header 中有 x-admin-id，或者先写死。
-	// 既然是教学，写个 Mock 获取逻辑。
-	adminIDStr := c.GetHeader("X-Admin-ID")
-	adminID, _ := strconv.ParseUint(adminIDStr, 10, 64)
-	if adminID == 0 {
-		adminID = 1 // 默认 Admin 1
-	}
+	// 3. 获取 Admin ID (AdminAuth 中间件校验过的会话)
+	adminID := c.GetUint64(middleware.ContextAdminID)
 
 	// 4. 调用 Service
-	if err := service.Admin.ReviewWithdrawal(c.Request.Context(), idStr, adminID, req.Action, req.Remark); err != nil {
+	err := service.Admin.ReviewWithdrawal(c.Request.Context(), idStr, adminID, req.Action, req.Remark)
+	h.audit(c, model.AuditActionWithdrawalReview, "withdrawal", idStr, "action="+req.Action, err)
+	if err != nil {
 		response.Error(c, err) // 这里应该区分 error 类型，简单处理
 		return
 	}
 
 	response.Success(c, nil)
 }
+
+// ListPendingWithdrawals 待审核提现列表
+// @Summary 待审核提现列表
+// @Description 列出待审核及被风控挂起的提现，按风控分数从高到低排列，附带命中的风控规则
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param limit query int false "Limit (default 50, max 100)"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/withdrawals/pending [get]
+func (h *AdminHandler) ListPendingWithdrawals(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	list, err := service.Withdraw.ListPendingWithdrawals(c.Request.Context(), limit)
+	if err != nil {
+		response.Error(c, errno.ErrDatabase)
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// SpeedUpWithdrawal 加速卡住的提现交易
+// @Summary 加速提现交易
+// @Description 同 nonce 提高手续费重新广播已广播但未打包的提现交易
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param id path int true "Withdrawal ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/withdrawals/{id}/speed-up [post]
+func (h *AdminHandler) SpeedUpWithdrawal(c *gin.Context) {
+	h.replaceWithdrawalTx(c, model.ReplaceActionSpeedUp)
+}
+
+// CancelWithdrawalTx 取消卡住的提现交易
+// @Summary 取消提现交易
+// @Description 同 nonce 广播一笔 0 值自转账，替换掉尚未打包的提现交易
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param id path int true "Withdrawal ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/withdrawals/{id}/cancel-tx [post]
+func (h *AdminHandler) CancelWithdrawalTx(c *gin.Context) {
+	h.replaceWithdrawalTx(c, model.ReplaceActionCancel)
+}
+
+func (h *AdminHandler) replaceWithdrawalTx(c *gin.Context, action string) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+	if service.Replacement == nil {
+		response.Error(c, errno.InternalServerError.WithMessage("Replacement service not initialized"))
+		return
+	}
+
+	adminID := c.GetUint64(middleware.ContextAdminID)
+
+	var r *model.TxReplacement
+	auditAction := model.AuditActionWithdrawalSpeed
+	if action == model.ReplaceActionCancel {
+		auditAction = model.AuditActionWithdrawalCancel
+		r, err = service.Replacement.Cancel(c.Request.Context(), id, model.ReplaceTriggerAdmin, adminID)
+	} else {
+		r, err = service.Replacement.SpeedUp(c.Request.Context(), id, model.ReplaceTriggerAdmin, adminID)
+	}
+	h.audit(c, auditAction, "withdrawal", c.Param("id"), "", err)
+	if err != nil {
+		response.Error(c, replaceError(err))
+		return
+	}
+
+	response.Success(c, r)
+}
+
+// audit 记录当前管理员的操作 (成功 / 失败都记录)
+func (h *AdminHandler) audit(c *gin.Context, action, resource, resourceID, detail string, err error) {
+	admin := middleware.CurrentAdmin(c)
+	entry := &model.AdminAuditLog{
+		AdminID:    admin.ID,
+		Username:   admin.Username,
+		Action:     action,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Success:    err == nil,
+		Detail:     detail,
+	}
+	if err != nil {
+		entry.Detail = strings.TrimSpace(detail + " error=" + err.Error())
+	}
+	service.AdminAuth.Audit(c.Request.Context(), entry, auditMeta(c))
+}
+
+func auditMeta(c *gin.Context) service.AuditMeta {
+	return service.AuditMeta{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+}
+
+// replaceError 把 Service 层错误转换成业务错误码
+func replaceError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return errno.ErrWithdrawalNotFound
+	case errors.Is(err, service.ErrWithdrawalNotReplaceable), errors.Is(err, service.ErrTxAlreadyMined),
+		errors.Is(err, service.ErrWithdrawalCancelling):
+		return errno.ErrWithdrawalStateInvalid.WithMessage(err.Error())
+	case errors.Is(err, service.ErrFeeCeilingReached):
+		return errno.ErrFeeCeilingReached
+	default:
+		return err
+	}
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+
+	"wallet-core/internal/handler/request"
+	"wallet-core/internal/handler/response"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/model"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/errno"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListKYCApplications 实名认证申请列表
+// @Summary 实名认证申请列表
+// @Description 待审核的按提交顺序排列，其余按时间倒序
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param status query string false "pending / approved / rejected，为空时返回全部"
+// @Param limit query int false "条数 (默认 50，最大 100)"
+// @Success 200 {object} response.Response{data=[]model.KYCApplication}
+// @Router /api/v1/admin/kyc/applications [get]
+func (h *AdminHandler) ListKYCApplications(c *gin.Context) {
+	var req request.KYCApplicationQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	list, err := service.KYC.ListApplications(c.Request.Context(), req.Status, req.Limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// GetKYCApplication 实名认证申请详情
+// @Summary 实名认证申请详情
+// @Description 申请资料及证件文件元数据，文件内容通过下载接口获取
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param id path int true "Application ID"
+// @Success 200 {object} response.Response{data=model.KYCApplication}
+// @Router /api/v1/admin/kyc/applications/{id} [get]
+func (h *AdminHandler) GetKYCApplication(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	app, err := service.KYC.GetApplication(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, app)
+}
+
+// DownloadKYCDocument 下载证件文件
+// @Summary 下载证件文件
+// @Description 解密后返回文件原文，每次下载都记录审计日志
+// @Tags Admin
+// @Security AdminAuth
+// @Produce octet-stream
+// @Param id path int true "Application ID"
+// @Param doc_id path int true "Document ID"
+// @Success 200 {file} file
+// @Router /api/v1/admin/kyc/applications/{id}/documents/{doc_id} [get]
+func (h *AdminHandler) DownloadKYCDocument(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+	docID, err := strconv.ParseUint(c.Param("doc_id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	doc, content, err := service.KYC.OpenDocument(c.Request.Context(), id, docID)
+	h.audit(c, model.AuditActionKYCDocumentView, "kyc_application", c.Param("id"), "document_id="+c.Param("doc_id"), err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": doc.FileName}))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, doc.ContentType, content)
+}
+
+// ReviewKYCApplication 审核实名认证申请
+// @Summary 审核实名认证申请
+// @Description 通过后用户升到申请的等级，并发送等级变更事件
+// @Tags Admin
+// @Security AdminAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Application ID"
+// @Param request body request.ReviewKYCRequest true "Review Request"
+// @Success 200 {object} response.Response{data=model.KYCApplication}
+// @Router /api/v1/admin/kyc/applications/{id}/review [post]
+func (h *AdminHandler) ReviewKYCApplication(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+	var req request.ReviewKYCRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	app, err := service.KYC.ReviewApplication(c.Request.Context(), id, c.GetUint64(middleware.ContextAdminID), req.Action, req.Remark)
+	detail := "action=" + req.Action
+	if app != nil {
+		detail += " user_id=" + strconv.FormatUint(app.UserID, 10) + " tier=" + app.Tier
+	}
+	h.audit(c, model.AuditActionKYCReview, "kyc_application", c.Param("id"), detail, err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, app)
+}
+
+// SetUserKYCTier 调整用户实名认证等级
+// @Summary 调整用户实名认证等级
+// @Description 直接设置用户等级 (可降级)，需要填写原因，并发送等级变更事件
+// @Tags Admin
+// @Security AdminAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body request.SetKYCTierRequest true "Set Tier Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/users/{id}/kyc-tier [post]
+func (h *AdminHandler) SetUserKYCTier(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+	var req request.SetKYCTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	err = service.KYC.SetTier(c.Request.Context(), userID, c.GetUint64(middleware.ContextAdminID), req.Tier, req.Reason)
+	h.audit(c, model.AuditActionKYCTierSet, "user", c.Param("id"), "tier="+req.Tier+" reason="+req.Reason, err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
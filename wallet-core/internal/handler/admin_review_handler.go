@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"strconv"
+
+	"wallet-core/internal/handler/request"
+	"wallet-core/internal/handler/response"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/model"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/errno"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+)
+
+// ListReviewQueue 提现审核队列
+// @Summary 提现审核队列
+// @Description 待审核及风控挂起的提现，按风控分数从高到低、同分按创建顺序排列，游标分页 (next_cursor 为空表示没有更多)。已过期的认领不返回 (assignee_id = 0)
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param status query string false "pending_review / risk_hold，为空时两者都查"
+// @Param chain query string false "链 (ETH / BTC)"
+// @Param min_amount query string false "最小金额 (包含)"
+// @Param max_amount query string false "最大金额 (包含)"
+// @Param min_risk_score query int false "最低风控分数 (包含)"
+// @Param max_risk_score query int false "最高风控分数 (包含)"
+// @Param assignee query string false "mine: 我认领的; unassigned: 未认领的"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页条数 (默认 50，最大 100)"
+// @Success 200 {object} response.Response{data=service.ReviewQueuePage}
+// @Router /api/v1/admin/withdrawals/queue [get]
+func (h *AdminHandler) ListReviewQueue(c *gin.Context) {
+	var req request.ReviewQueueQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+	f := service.ReviewQueueFilter{
+		Status:       req.Status,
+		Chain:        req.Chain,
+		MinRiskScore: req.MinRiskScore,
+		MaxRiskScore: req.MaxRiskScore,
+		Assignee:     req.Assignee,
+		Cursor:       req.Cursor,
+		Limit:        req.Limit,
+	}
+	var err error
+	if f.MinAmount, err = parseQueryDecimal(req.MinAmount, "min_amount"); err != nil {
+		response.Error(c, err)
+		return
+	}
+	if f.MaxAmount, err = parseQueryDecimal(req.MaxAmount, "max_amount"); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	page, err := service.Admin.ListReviewQueue(c.Request.Context(), c.GetUint64(middleware.ContextAdminID), f)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// GetWithdrawalDetail 提现审核详情
+// @Summary 提现审核详情
+// @Description 提现单、已有的审核记录，以及用户余额和近期充提记录
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param id path int true "Withdrawal ID"
+// @Success 200 {object} response.Response{data=service.WithdrawalDetail}
+// @Router /api/v1/admin/withdrawals/{id} [get]
+func (h *AdminHandler) GetWithdrawalDetail(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	detail, err := service.Admin.GetWithdrawalDetail(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, detail)
+}
+
+// BulkReviewWithdrawals 批量审核提现
+// @Summary 批量审核提现
+// @Description 批量通过 / 拒绝 (最多 100 笔)，每笔独立处理，返回每笔的结果
+// @Tags Admin
+// @Security AdminAuth
+// @Accept json
+// @Produce json
+// @Param request body request.BulkReviewRequest true "Bulk Review Request"
+// @Success 200 {object} response.Response{data=[]service.BulkReviewResult}
+// @Router /api/v1/admin/withdrawals/bulk-review [post]
+func (h *AdminHandler) BulkReviewWithdrawals(c *gin.Context) {
+	var req request.BulkReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	results, err := service.Admin.BulkReview(c.Request.Context(), req.IDs, c.GetUint64(middleware.ContextAdminID), req.Action, req.Remark)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	for _, r := range results {
+		h.audit(c, model.AuditActionWithdrawalReview, "withdrawal", strconv.FormatUint(r.ID, 10), "action="+req.Action+" bulk=true", r.Err)
+	}
+
+	response.Success(c, results)
+}
+
+// ClaimWithdrawal 认领审核任务
+// @Summary 认领审核任务
+// @Description 认领后其他管理员不能审核这笔提现，30 分钟未审核自动失效；重复认领会刷新有效期
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param id path int true "Withdrawal ID"
+// @Success 200 {object} response.Response{data=model.Withdrawal}
+// @Router /api/v1/admin/withdrawals/{id}/claim [post]
+func (h *AdminHandler) ClaimWithdrawal(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	w, err := service.Admin.ClaimWithdrawal(c.Request.Context(), id, c.GetUint64(middleware.ContextAdminID))
+	h.audit(c, model.AuditActionWithdrawalClaim, "withdrawal", c.Param("id"), "", err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, w)
+}
+
+// ReleaseWithdrawal 释放审核任务
+// @Summary 释放审核任务
+// @Description 放弃自己认领的审核任务，其他管理员可以重新认领
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param id path int true "Withdrawal ID"
+// @Success 200 {object} response.Response{data=model.Withdrawal}
+// @Router /api/v1/admin/withdrawals/{id}/release [post]
+func (h *AdminHandler) ReleaseWithdrawal(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	w, err := service.Admin.ReleaseWithdrawal(c.Request.Context(), id, c.GetUint64(middleware.ContextAdminID))
+	h.audit(c, model.AuditActionWithdrawalRelease, "withdrawal", c.Param("id"), "", err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, w)
+}
+
+// AssignWithdrawal 指派审核任务
+// @Summary 指派审核任务
+// @Description 把审核任务指派给有审核权限的管理员 (覆盖现有认领)，admin_id 为 0 表示取消指派
+// @Tags Admin
+// @Security AdminAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Withdrawal ID"
+// @Param request body request.AssignWithdrawalRequest true "Assign Request"
+// @Success 200 {object} response.Response{data=model.Withdrawal}
+// @Router /api/v1/admin/withdrawals/{id}/assign [post]
+func (h *AdminHandler) AssignWithdrawal(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+	var req request.AssignWithdrawalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	w, err := service.Admin.ReassignWithdrawal(c.Request.Context(), id, req.AdminID)
+	h.audit(c, model.AuditActionWithdrawalAssign, "withdrawal", c.Param("id"), "assignee_id="+strconv.FormatUint(req.AdminID, 10), err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, w)
+}
+
+// parseQueryDecimal 解析可选的金额参数，为空时返回 nil
+func parseQueryDecimal(s, field string) (*decimal.Decimal, error) {
+	if s == "" {
+		return nil, nil
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return nil, errno.ErrBind.WithMessage(field + " must be a decimal number")
+	}
+	return &d, nil
+}
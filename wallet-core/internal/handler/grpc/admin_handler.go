@@ -0,0 +1,270 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	adminv1 "wallet-core/api/gen/admin/v1"
+	"wallet-core/internal/interceptor"
+	"wallet-core/internal/model"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/errno"
+)
+
+// AdminMethodPermissions maps every admin.v1 method to the permission it requires.
+// interceptor.AdminAuth only guards the methods listed here.
+var AdminMethodPermissions = map[string]service.Permission{
+	adminv1.AdminService_ListReviewQueue_FullMethodName:       service.PermWithdrawalView,
+	adminv1.AdminService_GetWithdrawalDetail_FullMethodName:   service.PermWithdrawalView,
+	adminv1.AdminService_ReviewWithdrawal_FullMethodName:      service.PermWithdrawalReview,
+	adminv1.AdminService_BulkReviewWithdrawals_FullMethodName: service.PermWithdrawalReview,
+	adminv1.AdminService_ClaimWithdrawal_FullMethodName:       service.PermWithdrawalReview,
+	adminv1.AdminService_ReleaseWithdrawal_FullMethodName:     service.PermWithdrawalReview,
+	adminv1.AdminService_ReassignWithdrawal_FullMethodName:    service.PermWithdrawalAssign,
+}
+
+// AdminHandler implements adminv1.AdminServiceServer on top of service.Admin.
+// State-changing calls are written to the admin audit log, like the HTTP admin API.
+type AdminHandler struct {
+	adminv1.UnimplementedAdminServiceServer
+	auth *service.AdminAuthService
+}
+
+// NewAdminHandler creates the admin gRPC handler
+func NewAdminHandler(auth *service.AdminAuthService) *AdminHandler {
+	return &AdminHandler{auth: auth}
+}
+
+func (h *AdminHandler) ListReviewQueue(ctx context.Context, req *adminv1.ListReviewQueueRequest) (*adminv1.ListReviewQueueResponse, error) {
+	admin, err := interceptor.CurrentAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	f := service.ReviewQueueFilter{
+		Status:   req.GetStatus(),
+		Chain:    req.GetChain(),
+		Assignee: req.GetAssignee(),
+		Cursor:   req.GetCursor(),
+		Limit:    int(req.GetLimit()),
+	}
+	if f.MinAmount, err = optionalDecimal(req.GetMinAmount(), "min_amount"); err != nil {
+		return nil, err
+	}
+	if f.MaxAmount, err = optionalDecimal(req.GetMaxAmount(), "max_amount"); err != nil {
+		return nil, err
+	}
+	if score := int(req.GetMinRiskScore()); score > 0 {
+		f.MinRiskScore = &score
+	}
+	if score := int(req.GetMaxRiskScore()); score > 0 {
+		f.MaxRiskScore = &score
+	}
+
+	page, err := service.Admin.ListReviewQueue(ctx, admin.ID, f)
+	if err != nil {
+		return nil, err
+	}
+	resp := &adminv1.ListReviewQueueResponse{NextCursor: page.NextCursor}
+	for i := range page.Items {
+		resp.Items = append(resp.Items, toWithdrawal(&page.Items[i]))
+	}
+	return resp, nil
+}
+
+func (h *AdminHandler) GetWithdrawalDetail(ctx context.Context, req *adminv1.GetWithdrawalDetailRequest) (*adminv1.GetWithdrawalDetailResponse, error) {
+	d, err := service.Admin.GetWithdrawalDetail(ctx, uint64(req.GetWithdrawalId()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &adminv1.GetWithdrawalDetailResponse{
+		Withdrawal: toWithdrawal(&d.Withdrawal),
+		Activity: &adminv1.UserActivity{
+			UserId:   int64(d.Withdrawal.UserID),
+			Balances: make(map[string]*adminv1.Balance, len(d.Activity.Balances)),
+		},
+	}
+	for _, r := range d.Reviews {
+		resp.Reviews = append(resp.Reviews, &adminv1.Review{
+			AdminId:       int64(r.AdminID),
+			AdminUsername: r.AdminUsername,
+			Action:        r.Status,
+			Remark:        r.Remark,
+			CreatedAt:     r.CreatedAt.Unix(),
+		})
+	}
+
+	a := resp.Activity
+	if u := d.Activity.User; u != nil {
+		a.Username = u.Username
+		a.Email = u.Email
+		a.RegisteredAt = u.CreatedAt.Unix()
+		a.PasswordChangedAt = unix(u.PasswordChangedAt)
+	}
+	for _, b := range d.Activity.Balances {
+		a.Balances[b.Currency] = &adminv1.Balance{Total: b.Balance.String(), Locked: b.LockedBalance.String()}
+	}
+	for _, dep := range d.Activity.RecentDeposits {
+		a.RecentDeposits = append(a.RecentDeposits, &adminv1.Deposit{
+			Id:          int64(dep.ID),
+			Chain:       dep.Chain,
+			FromAddress: dep.FromAddress,
+			Amount:      dep.Amount.String(),
+			Status:      dep.Status,
+			TxHash:      dep.TxHash,
+			CreatedAt:   dep.CreatedAt.Unix(),
+		})
+	}
+	for i := range d.Activity.RecentWithdrawals {
+		a.RecentWithdrawals = append(a.RecentWithdrawals, toWithdrawal(&d.Activity.RecentWithdrawals[i]))
+	}
+	return resp, nil
+}
+
+func (h *AdminHandler) ReviewWithdrawal(ctx context.Context, req *adminv1.ReviewWithdrawalRequest) (*adminv1.ReviewWithdrawalResponse, error) {
+	admin, err := interceptor.CurrentAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id := strconv.FormatInt(req.GetWithdrawalId(), 10)
+
+	err = service.Admin.ReviewWithdrawal(ctx, id, admin.ID, req.GetAction(), req.GetRemark())
+	h.audit(ctx, admin, model.AuditActionWithdrawalReview, id, "action="+req.GetAction(), err)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := service.Admin.GetWithdrawal(ctx, uint64(req.GetWithdrawalId()))
+	if err != nil {
+		return nil, err
+	}
+	return &adminv1.ReviewWithdrawalResponse{Withdrawal: toWithdrawal(w)}, nil
+}
+
+func (h *AdminHandler) BulkReviewWithdrawals(ctx context.Context, req *adminv1.BulkReviewWithdrawalsRequest) (*adminv1.BulkReviewWithdrawalsResponse, error) {
+	admin, err := interceptor.CurrentAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint64, 0, len(req.GetWithdrawalIds()))
+	for _, id := range req.GetWithdrawalIds() {
+		ids = append(ids, uint64(id))
+	}
+
+	results, err := service.Admin.BulkReview(ctx, ids, admin.ID, req.GetAction(), req.GetRemark())
+	if err != nil {
+		return nil, err
+	}
+	resp := &adminv1.BulkReviewWithdrawalsResponse{}
+	for _, r := range results {
+		h.audit(ctx, admin, model.AuditActionWithdrawalReview, strconv.FormatUint(r.ID, 10), "action="+req.GetAction()+" bulk=true", r.Err)
+		resp.Results = append(resp.Results, &adminv1.BulkReviewResult{
+			WithdrawalId: int64(r.ID),
+			Success:      r.Success,
+			Code:         int32(r.Code),
+			Message:      r.Message,
+		})
+	}
+	return resp, nil
+}
+
+func (h *AdminHandler) ClaimWithdrawal(ctx context.Context, req *adminv1.ClaimWithdrawalRequest) (*adminv1.ClaimWithdrawalResponse, error) {
+	admin, err := interceptor.CurrentAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	w, err := service.Admin.ClaimWithdrawal(ctx, uint64(req.GetWithdrawalId()), admin.ID)
+	h.audit(ctx, admin, model.AuditActionWithdrawalClaim, strconv.FormatInt(req.GetWithdrawalId(), 10), "", err)
+	if err != nil {
+		return nil, err
+	}
+	return &adminv1.ClaimWithdrawalResponse{Withdrawal: toWithdrawal(w)}, nil
+}
+
+func (h *AdminHandler) ReleaseWithdrawal(ctx context.Context, req *adminv1.ReleaseWithdrawalRequest) (*adminv1.ReleaseWithdrawalResponse, error) {
+	admin, err := interceptor.CurrentAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	w, err := service.Admin.ReleaseWithdrawal(ctx, uint64(req.GetWithdrawalId()), admin.ID)
+	h.audit(ctx, admin, model.AuditActionWithdrawalRelease, strconv.FormatInt(req.GetWithdrawalId(), 10), "", err)
+	if err != nil {
+		return nil, err
+	}
+	return &adminv1.ReleaseWithdrawalResponse{Withdrawal: toWithdrawal(w)}, nil
+}
+
+func (h *AdminHandler) ReassignWithdrawal(ctx context.Context, req *adminv1.ReassignWithdrawalRequest) (*adminv1.ReassignWithdrawalResponse, error) {
+	admin, err := interceptor.CurrentAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	w, err := service.Admin.ReassignWithdrawal(ctx, uint64(req.GetWithdrawalId()), uint64(req.GetAssigneeId()))
+	h.audit(ctx, admin, model.AuditActionWithdrawalAssign, strconv.FormatInt(req.GetWithdrawalId(), 10),
+		"assignee_id="+strconv.FormatInt(req.GetAssigneeId(), 10), err)
+	if err != nil {
+		return nil, err
+	}
+	return &adminv1.ReassignWithdrawalResponse{Withdrawal: toWithdrawal(w)}, nil
+}
+
+// audit records the admin action, successful or not.
+func (h *AdminHandler) audit(ctx context.Context, admin *model.AdminUser, action, resourceID, detail string, err error) {
+	entry := &model.AdminAuditLog{
+		AdminID:    admin.ID,
+		Username:   admin.Username,
+		Action:     action,
+		Resource:   "withdrawal",
+		ResourceID: resourceID,
+		Success:    err == nil,
+		Detail:     detail,
+	}
+	if err != nil {
+		entry.Detail = strings.TrimSpace(detail + " error=" + err.Error())
+	}
+	h.auth.Audit(ctx, entry, interceptor.AuditMeta(ctx))
+}
+
+func toWithdrawal(w *model.Withdrawal) *adminv1.Withdrawal {
+	return &adminv1.Withdrawal{
+		Id:                int64(w.ID),
+		UserId:            int64(w.UserID),
+		Chain:             w.Chain,
+		ToAddress:         w.ToAddress,
+		Amount:            w.Amount.String(),
+		Status:            w.Status,
+		RiskScore:         int32(w.RiskScore),
+		RiskReasons:       w.RiskReasons,
+		RequiredApprovals: int32(w.RequiredApprovals),
+		CurrentApprovals:  int32(w.CurrentApprovals),
+		AssigneeId:        int64(w.AssigneeID),
+		ClaimExpiresAt:    unix(service.ClaimExpiresAt(w)),
+		TxHash:            w.TxHash,
+		ExecuteAfter:      unix(w.ExecuteAfter),
+		CreatedAt:         w.CreatedAt.Unix(),
+	}
+}
+
+// unix returns Unix seconds, or 0 for nil.
+func unix(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.Unix()
+}
+
+// optionalDecimal parses an optional decimal field; empty means unset.
+func optionalDecimal(s, field string) (*decimal.Decimal, error) {
+	if s == "" {
+		return nil, nil
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return nil, errno.ErrBind.WithMessage(field + " must be a decimal number")
+	}
+	return &d, nil
+}
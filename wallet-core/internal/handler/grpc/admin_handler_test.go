@@ -0,0 +1,18 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	adminv1 "wallet-core/api/gen/admin/v1"
+)
+
+// interceptor.AdminAuth lets unlisted methods through, so every admin method must have a permission.
+func TestAdminMethodPermissionsCoverService(t *testing.T) {
+	for _, m := range adminv1.AdminService_ServiceDesc.Methods {
+		method := "/" + adminv1.AdminService_ServiceDesc.ServiceName + "/" + m.MethodName
+		assert.Contains(t, AdminMethodPermissions, method)
+	}
+	assert.Len(t, AdminMethodPermissions, len(adminv1.AdminService_ServiceDesc.Methods))
+}
@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	merchantv1 "wallet-core/api/gen/merchant/v1"
+	"wallet-core/internal/interceptor"
+	"wallet-core/internal/model"
+	"wallet-core/internal/service"
+)
+
+// InvoiceMethodScopes maps every signed merchant.v1 method to the API key scope it requires.
+// interceptor.MerchantAuth only guards the methods listed here; GetCheckout is public on purpose.
+var InvoiceMethodScopes = map[string]string{
+	merchantv1.InvoiceService_CreateInvoice_FullMethodName: service.ScopeInvoiceWrite,
+	merchantv1.InvoiceService_GetInvoice_FullMethodName:    service.ScopeInvoiceRead,
+	merchantv1.InvoiceService_ListInvoices_FullMethodName:  service.ScopeInvoiceRead,
+}
+
+// InvoiceHandler implements merchantv1.InvoiceServiceServer on top of service.Invoice.
+type InvoiceHandler struct {
+	merchantv1.UnimplementedInvoiceServiceServer
+	invoices *service.InvoiceService
+}
+
+// NewInvoiceHandler creates the merchant invoice gRPC handler
+func NewInvoiceHandler(invoices *service.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{invoices: invoices}
+}
+
+func (h *InvoiceHandler) CreateInvoice(ctx context.Context, req *merchantv1.CreateInvoiceRequest) (*merchantv1.CreateInvoiceResponse, error) {
+	key, err := interceptor.CurrentMerchantKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	in := service.CreateInvoiceInput{
+		OrderID:       req.GetOrderId(),
+		Description:   req.GetDescription(),
+		Currency:      req.GetCurrency(),
+		PriceCurrency: req.GetPriceCurrency(),
+		AddressMode:   req.GetAddressMode(),
+		TTL:           time.Duration(req.GetTtlSeconds()) * time.Second,
+		RedirectURL:   req.GetRedirectUrl(),
+	}
+	if in.Amount, err = decimalOrZero(req.GetAmount(), "amount"); err != nil {
+		return nil, err
+	}
+	if in.PriceAmount, err = decimalOrZero(req.GetPriceAmount(), "price_amount"); err != nil {
+		return nil, err
+	}
+
+	inv, err := h.invoices.CreateInvoice(ctx, key.MerchantID, in)
+	if err != nil {
+		return nil, err
+	}
+	return &merchantv1.CreateInvoiceResponse{Invoice: h.toInvoice(inv)}, nil
+}
+
+func (h *InvoiceHandler) GetInvoice(ctx context.Context, req *merchantv1.GetInvoiceRequest) (*merchantv1.GetInvoiceResponse, error) {
+	key, err := interceptor.CurrentMerchantKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d, err := h.invoices.GetInvoice(ctx, key.MerchantID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	resp := &merchantv1.GetInvoiceResponse{Invoice: h.toInvoice(d.Invoice)}
+	for _, p := range d.Payments {
+		resp.Payments = append(resp.Payments, &merchantv1.Payment{
+			DepositId: int64(p.DepositID),
+			TxHash:    p.TxHash,
+			Amount:    p.Amount.String(),
+			Late:      p.Late,
+			CreatedAt: p.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+func (h *InvoiceHandler) ListInvoices(ctx context.Context, req *merchantv1.ListInvoicesRequest) (*merchantv1.ListInvoicesResponse, error) {
+	key, err := interceptor.CurrentMerchantKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := h.invoices.ListInvoices(ctx, key.MerchantID, req.GetStatus(), uint64(req.GetBeforeId()), int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	resp := &merchantv1.ListInvoicesResponse{}
+	for i := range list {
+		resp.Items = append(resp.Items, h.toInvoice(&list[i]))
+	}
+	return resp, nil
+}
+
+func (h *InvoiceHandler) GetCheckout(ctx context.Context, req *merchantv1.GetCheckoutRequest) (*merchantv1.GetCheckoutResponse, error) {
+	v, err := h.invoices.GetCheckout(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &merchantv1.GetCheckoutResponse{
+		Id:               v.ID,
+		Merchant:         v.Merchant,
+		Description:      v.Description,
+		Currency:         v.Currency,
+		Amount:           v.Amount,
+		PaidAmount:       v.PaidAmount,
+		RemainingAmount:  v.RemainingAmount,
+		PriceCurrency:    v.PriceCurrency,
+		PriceAmount:      v.PriceAmount,
+		ExchangeRate:     v.ExchangeRate,
+		Address:          v.Address,
+		ExactAmount:      v.ExactAmount,
+		PaymentUri:       v.PaymentURI,
+		Status:           v.Status,
+		ExpiresAt:        v.ExpiresAt.Unix(),
+		SecondsRemaining: v.SecondsRemaining,
+		PaidAt:           unix(v.PaidAt),
+		RedirectUrl:      v.RedirectURL,
+		CheckoutUrl:      v.CheckoutURL,
+	}, nil
+}
+
+func (h *InvoiceHandler) toInvoice(inv *model.Invoice) *merchantv1.Invoice {
+	out := &merchantv1.Invoice{
+		Id:            int64(inv.ID),
+		PublicId:      inv.PublicID,
+		OrderId:       inv.OrderID,
+		Description:   inv.Description,
+		Currency:      inv.Currency,
+		Amount:        inv.Amount.String(),
+		PaidAmount:    inv.PaidAmount.String(),
+		PriceCurrency: inv.PriceCurrency,
+		AddressMode:   inv.AddressMode,
+		Address:       inv.Address,
+		Status:        inv.Status,
+		RedirectUrl:   inv.RedirectURL,
+		CheckoutUrl:   h.invoices.CheckoutURL(inv.PublicID),
+		ExpiresAt:     inv.ExpiresAt.Unix(),
+		PaidAt:        unix(inv.PaidAt),
+		CreatedAt:     inv.CreatedAt.Unix(),
+	}
+	if inv.PriceCurrency != "" {
+		out.PriceAmount = inv.PriceAmount.String()
+		out.ExchangeRate = inv.ExchangeRate.String()
+	}
+	return out
+}
+
+// decimalOrZero parses an optional decimal field; empty means zero.
+func decimalOrZero(s, field string) (decimal.Decimal, error) {
+	d, err := optionalDecimal(s, field)
+	if err != nil || d == nil {
+		return decimal.Zero, err
+	}
+	return *d, nil
+}
@@ -0,0 +1,21 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	merchantv1 "wallet-core/api/gen/merchant/v1"
+)
+
+// interceptor.MerchantAuth lets unlisted methods through, so only GetCheckout may be missing a scope.
+func TestInvoiceMethodScopesCoverService(t *testing.T) {
+	for _, m := range merchantv1.InvoiceService_ServiceDesc.Methods {
+		method := "/" + merchantv1.InvoiceService_ServiceDesc.ServiceName + "/" + m.MethodName
+		if method == merchantv1.InvoiceService_GetCheckout_FullMethodName {
+			assert.NotContains(t, InvoiceMethodScopes, method)
+			continue
+		}
+		assert.Contains(t, InvoiceMethodScopes, method)
+	}
+}
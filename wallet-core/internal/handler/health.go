@@ -1,23 +1,43 @@
 package handler
 
 import (
-	"wallet-core/internal/handler/response"
+	"wallet-core/pkg/health"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Livez godoc
+// @Summary Liveness probe
+// @Description Runs the liveness checks (process-local: key loaded, observer progress). 200 when all pass, 503 otherwise
+// @Tags system
+// @Produce  json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /livez [get]
+func Livez(probes *health.Registry) gin.HandlerFunc {
+	return gin.WrapF(probes.LiveHandler())
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Description Runs every check (DB, Redis, MQ, RPC node sync plus the liveness checks). 200 when all pass, 503 otherwise
+// @Tags system
+// @Produce  json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /readyz [get]
+func Readyz(probes *health.Registry) gin.HandlerFunc {
+	return gin.WrapF(probes.ReadyHandler())
+}
+
 // HealthCheck godoc
 // @Summary Check system health
-// @Description Get the current health status of the server
+// @Description Kept for existing monitors; same as /readyz
 // @Tags system
-// @Accept  json
 // @Produce  json
-// @Success 200 {object} map[string]string
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
 // @Router /health [get]
-func HealthCheck(c *gin.Context) {
-	response.Success(c, gin.H{
-		"status":  "UP",
-		"version": "1.0.0",
-		"service": "wallet-server",
-	})
+func HealthCheck(probes *health.Registry) gin.HandlerFunc {
+	return Readyz(probes)
 }
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"wallet-core/internal/handler/request"
+	"wallet-core/internal/handler/response"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/errno"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HistoryHandler struct{}
+
+var History = &HistoryHandler{}
+
+// ListDeposits 充值记录
+// @Summary 充值记录
+// @Description 当前用户的充值记录，按时间倒序，游标分页 (next_cursor 为空表示没有更多)
+// @Tags Wallet
+// @Produce json
+// @Security BearerAuth
+// @Param currency query string false "币种 (ETH / BTC)"
+// @Param status query string false "状态"
+// @Param tx_hash query string false "交易哈希"
+// @Param start_time query string false "开始时间 (unix 秒或 RFC3339)"
+// @Param end_time query string false "结束时间 (unix 秒或 RFC3339)"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页条数 (默认 20，最大 100)"
+// @Success 200 {object} response.Response{data=service.HistoryPage}
+// @Router /api/v1/wallet/deposits [get]
+func (h *HistoryHandler) ListDeposits(c *gin.Context) {
+	h.list(c, service.History.ListDeposits)
+}
+
+// ListWithdrawals 提现记录
+// @Summary 提现记录
+// @Description 当前用户的提现记录，按时间倒序，游标分页 (next_cursor 为空表示没有更多)
+// @Tags Wallet
+// @Produce json
+// @Security BearerAuth
+// @Param currency query string false "币种 (ETH / BTC)"
+// @Param status query string false "状态"
+// @Param tx_hash query string false "交易哈希"
+// @Param start_time query string false "开始时间 (unix 秒或 RFC3339)"
+// @Param end_time query string false "结束时间 (unix 秒或 RFC3339)"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页条数 (默认 20，最大 100)"
+// @Success 200 {object} response.Response{data=service.HistoryPage}
+// @Router /api/v1/wallet/withdrawals [get]
+func (h *HistoryHandler) ListWithdrawals(c *gin.Context) {
+	h.list(c, service.History.ListWithdrawals)
+}
+
+// ListTransactions 交易记录 (充值和提现合并)
+// @Summary 交易记录
+// @Description 当前用户的充值和提现合并列表，按时间倒序，游标分页 (next_cursor 为空表示没有更多)
+// @Tags Wallet
+// @Produce json
+// @Security BearerAuth
+// @Param currency query string false "币种 (ETH / BTC)"
+// @Param status query string false "状态"
+// @Param tx_hash query string false "交易哈希"
+// @Param start_time query string false "开始时间 (unix 秒或 RFC3339)"
+// @Param end_time query string false "结束时间 (unix 秒或 RFC3339)"
+// @Param cursor query string false "上一页返回的 next_cursor"
+// @Param limit query int false "每页条数 (默认 20，最大 100)"
+// @Success 200 {object} response.Response{data=service.HistoryPage}
+// @Router /api/v1/wallet/transactions [get]
+func (h *HistoryHandler) ListTransactions(c *gin.Context) {
+	h.list(c, service.History.ListTransactions)
+}
+
+func (h *HistoryHandler) list(c *gin.Context, list func(context.Context, uint64, service.HistoryFilter) (*service.HistoryPage, error)) {
+	var req request.ListTransactionsQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	f := service.HistoryFilter{
+		Currency: req.Currency,
+		Status:   req.Status,
+		TxHash:   req.TxHash,
+		Cursor:   req.Cursor,
+		Limit:    req.Limit,
+	}
+	var err error
+	if f.Since, err = parseQueryTime("start_time", req.StartTime); err != nil {
+		response.Error(c, err)
+		return
+	}
+	if f.Until, err = parseQueryTime("end_time", req.EndTime); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	page, err := list(c.Request.Context(), c.GetUint64(middleware.ContextUserID), f)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// parseQueryTime 解析 unix 秒或 RFC3339 时间，为空返回 nil
+func parseQueryTime(name, v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	if sec, err := strconv.ParseInt(v, 10, 64); err == nil {
+		t := time.Unix(sec, 0)
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, errno.ErrBind.WithMessage(name + " must be unix seconds or RFC3339")
+	}
+	return &t, nil
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"time"
+
+	"wallet-core/internal/handler/request"
+	"wallet-core/internal/handler/response"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/errno"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InvoiceHandler struct{}
+
+var Invoice = &InvoiceHandler{}
+
+// CreateInvoice 创建收款账单
+// @Summary 创建收款账单
+// @Description 需要 invoice:write 权限。amount (币本位) 与 price_amount + price_currency (法币计价，按当前汇率换算并锁定) 二选一；
+// @Description address_mode=shared 时复用商户的共享地址，实际应付金额会带唯一尾数，以返回的 amount 为准
+// @Tags Merchant
+// @Accept json
+// @Produce json
+// @Param request body request.CreateInvoiceRequest true "Create Invoice Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/invoices [post]
+func (h *InvoiceHandler) CreateInvoice(c *gin.Context) {
+	var req request.CreateInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	inv, err := service.Invoice.CreateInvoice(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID), service.CreateInvoiceInput{
+		OrderID:       req.OrderID,
+		Description:   req.Description,
+		Currency:      req.Currency,
+		Amount:        req.Amount,
+		PriceCurrency: req.PriceCurrency,
+		PriceAmount:   req.PriceAmount,
+		AddressMode:   req.AddressMode,
+		TTL:           time.Duration(req.TTLSeconds) * time.Second,
+		RedirectURL:   req.RedirectURL,
+	})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	detail, err := service.Invoice.GetInvoice(c.Request.Context(), inv.MerchantID, inv.PublicID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, detail)
+}
+
+// ListInvoices 账单列表
+// @Summary 账单列表
+// @Description 需要 invoice:read 权限，按创建时间倒序，翻页时 before_id 传上一页最后一条的 id
+// @Tags Merchant
+// @Produce json
+// @Param status query string false "pending / underpaid / paid / overpaid / expired"
+// @Param before_id query int false "Cursor"
+// @Param limit query int false "Limit (default 20, max 100)"
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/invoices [get]
+func (h *InvoiceHandler) ListInvoices(c *gin.Context) {
+	var q request.InvoiceQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	list, err := service.Invoice.ListInvoices(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID), q.Status, q.BeforeID, q.Limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, list)
+}
+
+// GetInvoice 账单详情
+// @Summary 账单详情
+// @Description 需要 invoice:read 权限，含已匹配的付款记录
+// @Tags Merchant
+// @Produce json
+// @Param id path string true "Invoice public ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/invoices/{id} [get]
+func (h *InvoiceHandler) GetInvoice(c *gin.Context) {
+	detail, err := service.Invoice.GetInvoice(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, detail)
+}
+
+// ListDeliveries 账单的通知投递记录
+// @Summary 账单的通知投递记录
+// @Description 需要 invoice:read 权限
+// @Tags Merchant
+// @Produce json
+// @Param id path string true "Invoice public ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/invoices/{id}/deliveries [get]
+func (h *InvoiceHandler) ListDeliveries(c *gin.Context) {
+	list, err := service.Invoice.ListDeliveries(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, list)
+}
+
+// SetWebhook 设置账单通知地址
+// @Summary 设置账单通知地址
+// @Description 需要 invoice:write 权限。设置最早创建的通知地址 (没有时新建，订阅全部事件)，每次设置都会生成新的签名密钥 (只返回这一次)；
+// @Description 通知为 POST JSON，按 API 签名规则签名 (path 为通知地址的路径)，请求头 X-Webhook-Event / X-Webhook-Id / X-Webhook-Event-Id / X-Timestamp / X-Nonce / X-Signature；
+// @Description 多个通知地址见 /api/v1/merchant/webhooks
+// @Tags Merchant
+// @Accept json
+// @Produce json
+// @Param request body request.SetWebhookRequest true "Set Webhook Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/webhook [put]
+func (h *InvoiceHandler) SetWebhook(c *gin.Context) {
+	var req request.SetWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	hook, secret, err := service.Webhook.SetPrimary(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID), req.URL)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, gin.H{
+		"webhook": hook,
+		"secret":  secret,
+	})
+}
+
+// GetWebhook 当前的账单通知地址
+// @Summary 当前的账单通知地址
+// @Description 需要 invoice:read 权限，返回最早创建的通知地址
+// @Tags Merchant
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/webhook [get]
+func (h *InvoiceHandler) GetWebhook(c *gin.Context) {
+	hook, err := service.Webhook.GetPrimary(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, hook)
+}
+
+// GetCheckout 托管收银台数据
+// @Summary 托管收银台数据
+// @Description 公开接口，凭账单 public_id 访问；payment_uri 为 EIP-681 / BIP-21 付款链接 (按剩余应付金额)
+// @Tags Checkout
+// @Produce json
+// @Param id path string true "Invoice public ID"
+// @Success 200 {object} response.Response{data=service.CheckoutView}
+// @Router /api/v1/checkout/{id} [get]
+func (h *InvoiceHandler) GetCheckout(c *gin.Context) {
+	view, err := service.Invoice.GetCheckout(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, view)
+}
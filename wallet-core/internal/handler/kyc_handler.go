@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"io"
+	"mime/multipart"
+
+	"wallet-core/internal/handler/request"
+	"wallet-core/internal/handler/response"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/errno"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KYCHandler struct{}
+
+var KYC = &KYCHandler{}
+
+// GetStatus 实名认证状态
+// @Summary 实名认证状态
+// @Description 当前等级、能否提现、可用币种、24 小时提现额度及已用额度，以及最近一次申请
+// @Tags KYC
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.Response{data=service.KYCStatus}
+// @Router /api/v1/kyc [get]
+func (h *KYCHandler) GetStatus(c *gin.Context) {
+	status, err := service.KYC.Status(c.Request.Context(), c.GetUint64(middleware.ContextUserID))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, status)
+}
+
+// SubmitApplication 提交实名认证申请
+// @Summary 提交实名认证申请
+// @Description 申请升到更高等级，同时只能有一个待审核的申请。证件文件 (JPEG / PNG / PDF，最多 5 个) 加密存储
+// @Tags KYC
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param tier formData string true "申请的等级: basic / full"
+// @Param full_name formData string true "姓名"
+// @Param country formData string true "国家 (ISO 3166-1 alpha-2)"
+// @Param document_type formData string true "passport / id_card / driver_license / proof_of_address"
+// @Param document_number formData string true "证件号码 (只保存后 4 位)"
+// @Param document_expiry formData string false "证件有效期 (YYYY-MM-DD)"
+// @Param documents formData file true "证件文件，可重复"
+// @Success 200 {object} response.Response{data=model.KYCApplication}
+// @Router /api/v1/kyc/applications [post]
+func (h *KYCHandler) SubmitApplication(c *gin.Context) {
+	var req request.SubmitKYCRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, errno.ErrBind.WithMessage(err.Error()))
+		return
+	}
+	form, err := c.MultipartForm()
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	var files []service.KYCUpload
+	for _, fh := range form.File["documents"] {
+		f, err := readUpload(fh, service.KYC.MaxDocumentSize())
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+		files = append(files, f)
+	}
+
+	app, err := service.KYC.Submit(c.Request.Context(), c.GetUint64(middleware.ContextUserID), service.KYCSubmission{
+		Tier:           req.Tier,
+		FullName:       req.FullName,
+		Country:        req.Country,
+		DocumentType:   req.DocumentType,
+		DocumentNumber: req.DocumentNumber,
+		DocumentExpiry: req.DocumentExpiry,
+	}, files)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, app)
+}
+
+// readUpload 读取上传的文件，超过 maxSize 时不读入内存
+func readUpload(fh *multipart.FileHeader, maxSize int64) (service.KYCUpload, error) {
+	if fh.Size > maxSize {
+		return service.KYCUpload{}, errno.ErrKYCDocumentInvalid.WithMessage(fh.Filename + " is too large")
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return service.KYCUpload{}, err
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return service.KYCUpload{}, err
+	}
+	return service.KYCUpload{FileName: fh.Filename, Content: content}, nil
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"strconv"
+
+	"wallet-core/internal/handler/request"
+	"wallet-core/internal/handler/response"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/model"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/errno"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MerchantHandler struct{}
+
+var Merchant = &MerchantHandler{}
+
+// CreateMerchant 创建商户
+// @Summary 创建商户
+// @Tags Admin
+// @Security AdminAuth
+// @Accept json
+// @Produce json
+// @Param request body request.CreateMerchantRequest true "Create Merchant Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/merchants [post]
+func (h *MerchantHandler) CreateMerchant(c *gin.Context) {
+	var req request.CreateMerchantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	m, err := service.Merchant.CreateMerchant(c.Request.Context(), c.GetUint64(middleware.ContextAdminID), req.Name, req.Email)
+	resourceID := ""
+	if m != nil {
+		resourceID = strconv.FormatUint(m.ID, 10)
+	}
+	Admin.audit(c, model.AuditActionMerchantCreate, "merchant", resourceID, "name="+req.Name, err)
+	if err != nil {
+		response.Error(c, errno.ErrDatabase)
+		return
+	}
+
+	response.Success(c, m)
+}
+
+// ListMerchants 商户列表
+// @Summary 商户列表
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param limit query int false "Limit (default 50, max 200)"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/merchants [get]
+func (h *MerchantHandler) ListMerchants(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	list, err := service.Merchant.ListMerchants(c.Request.Context(), limit)
+	if err != nil {
+		response.Error(c, errno.ErrDatabase)
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// CreateAPIKey 创建商户 API Key
+// @Summary 创建商户 API Key
+// @Description 返回的 secret 只出现这一次，商户用它按 pkg/apisign 的规则对请求做 HMAC-SHA256 签名
+// @Tags Admin
+// @Security AdminAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Merchant ID"
+// @Param request body request.CreateAPIKeyRequest true "Create API Key Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/merchants/{id}/keys [post]
+func (h *MerchantHandler) CreateAPIKey(c *gin.Context) {
+	merchantID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+	var req request.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	key, secret, err := service.Merchant.CreateAPIKey(c.Request.Context(), c.GetUint64(middleware.ContextAdminID),
+		merchantID, req.Name, req.Scopes, req.IPAllowlist, req.ExpiresAt)
+	resourceID := ""
+	if key != nil {
+		resourceID = key.KeyID
+	}
+	Admin.audit(c, model.AuditActionAPIKeyCreate, "merchant_api_key", resourceID, "merchant_id="+c.Param("id"), err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"api_key": key,
+		"secret":  secret,
+	})
+}
+
+// ListAPIKeys 商户 API Key 列表
+// @Summary 商户 API Key 列表
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param id path int true "Merchant ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/merchants/{id}/keys [get]
+func (h *MerchantHandler) ListAPIKeys(c *gin.Context) {
+	merchantID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	list, err := service.Merchant.ListAPIKeys(c.Request.Context(), merchantID)
+	if err != nil {
+		response.Error(c, errno.ErrDatabase)
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// RevokeAPIKey 吊销商户 API Key
+// @Summary 吊销商户 API Key
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param id path int true "Merchant ID"
+// @Param key_id path string true "Key ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/merchants/{id}/keys/{key_id}/revoke [post]
+func (h *MerchantHandler) RevokeAPIKey(c *gin.Context) {
+	merchantID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	err = service.Merchant.RevokeAPIKey(c.Request.Context(), merchantID, c.Param("key_id"))
+	Admin.audit(c, model.AuditActionAPIKeyRevoke, "merchant_api_key", c.Param("key_id"), "merchant_id="+c.Param("id"), err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// SetSettlementAccount 绑定商户结算账户
+// @Summary 绑定商户结算账户
+// @Description 绑定后该钱包用户的充值 / 提现事件通过商户的通知地址推送，user_id 为 0 时解除绑定
+// @Tags Admin
+// @Security AdminAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Merchant ID"
+// @Param request body request.SetSettlementAccountRequest true "Set Settlement Account Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/merchants/{id}/account [put]
+func (h *MerchantHandler) SetSettlementAccount(c *gin.Context) {
+	merchantID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+	var req request.SetSettlementAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	m, err := service.Merchant.SetSettlementAccount(c.Request.Context(), merchantID, req.UserID)
+	Admin.audit(c, model.AuditActionMerchantAccount, "merchant", c.Param("id"), "user_id="+strconv.FormatUint(req.UserID, 10), err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, m)
+}
+
+// Me 当前商户信息 (API Key 签名认证)
+// @Summary 当前商户信息
+// @Description 需要 merchant:read 权限，请求头 X-Api-Key / X-Timestamp / X-Nonce / X-Signature
+// @Tags Merchant
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/me [get]
+func (h *MerchantHandler) Me(c *gin.Context) {
+	m, err := service.Merchant.GetMerchant(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	key := middleware.CurrentAPIKey(c)
+	response.Success(c, gin.H{
+		"merchant": m,
+		"key_id":   key.KeyID,
+		"scopes":   key.Scopes,
+	})
+}
@@ -1,6 +1,61 @@
 package request
 
+import "time"
+
 type ReviewWithdrawalRequest struct {
 	Action string `json:"action" binding:"required,oneof=approve reject"`
 	Remark string `json:"remark"`
 }
+
+type AdminLoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	TOTPCode string `json:"totp_code" binding:"required,len=6,numeric"`
+}
+
+type CreateAdminRequest struct {
+	Username string `json:"username" binding:"required,max=64"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+	Role     string `json:"role" binding:"required,oneof=reviewer finance ops compliance superadmin"`
+}
+
+type CreateMerchantRequest struct {
+	Name  string `json:"name" binding:"required,max=128"`
+	Email string `json:"email" binding:"required,email"`
+}
+
+// SetSettlementAccountRequest 绑定商户结算账户，user_id 为 0 时解除绑定
+type SetSettlementAccountRequest struct {
+	UserID uint64 `json:"user_id"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name        string     `json:"name" binding:"max=128"`
+	Scopes      []string   `json:"scopes" binding:"required,min=1"`
+	IPAllowlist []string   `json:"ip_allowlist"` // IP 或 CIDR，为空不限制
+	ExpiresAt   *time.Time `json:"expires_at"`   // 为空永不过期
+}
+
+// ReviewQueueQuery 审核队列查询参数
+type ReviewQueueQuery struct {
+	Status       string `form:"status" binding:"omitempty,oneof=pending_review risk_hold"`
+	Chain        string `form:"chain"`
+	MinAmount    string `form:"min_amount"`
+	MaxAmount    string `form:"max_amount"`
+	MinRiskScore *int   `form:"min_risk_score" binding:"omitempty,min=0"`
+	MaxRiskScore *int   `form:"max_risk_score" binding:"omitempty,min=0"`
+	Assignee     string `form:"assignee" binding:"omitempty,oneof=mine unassigned"`
+	Cursor       string `form:"cursor"`
+	Limit        int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type BulkReviewRequest struct {
+	IDs    []uint64 `json:"ids" binding:"required,min=1,max=100"`
+	Action string   `json:"action" binding:"required,oneof=approve reject"`
+	Remark string   `json:"remark"`
+}
+
+type AssignWithdrawalRequest struct {
+	AdminID uint64 `json:"admin_id"` // 0 表示取消指派
+}
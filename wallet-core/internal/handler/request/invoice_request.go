@@ -0,0 +1,28 @@
+package request
+
+import "github.com/shopspring/decimal"
+
+// CreateInvoiceRequest 创建收款账单，amount (币本位) 与 price_amount + price_currency (法币计价) 二选一
+type CreateInvoiceRequest struct {
+	OrderID       string          `json:"order_id" binding:"required,max=64"`
+	Description   string          `json:"description" binding:"max=255"`
+	Currency      string          `json:"currency" binding:"required"` // 收款币种 ETH / BTC
+	Amount        decimal.Decimal `json:"amount"`
+	PriceCurrency string          `json:"price_currency" binding:"omitempty,len=3,alpha"` // 法币 USD
+	PriceAmount   decimal.Decimal `json:"price_amount"`
+	AddressMode   string          `json:"address_mode" binding:"omitempty,oneof=dedicated shared"`
+	TTLSeconds    int64           `json:"ttl_seconds" binding:"omitempty,min=60"` // 为空时使用默认有效期
+	RedirectURL   string          `json:"redirect_url" binding:"omitempty,max=512"`
+}
+
+// InvoiceQuery 账单列表查询参数
+type InvoiceQuery struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending underpaid paid overpaid expired"`
+	BeforeID uint64 `form:"before_id"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// SetWebhookRequest 设置账单通知地址
+type SetWebhookRequest struct {
+	URL string `json:"url" binding:"required,max=512"`
+}
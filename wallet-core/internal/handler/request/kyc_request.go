@@ -0,0 +1,29 @@
+package request
+
+import "time"
+
+// SubmitKYCRequest 实名认证申请 (multipart/form-data，证件文件字段为 documents，可重复)
+type SubmitKYCRequest struct {
+	Tier           string     `form:"tier" binding:"required,oneof=basic full"`
+	FullName       string     `form:"full_name" binding:"required,max=128"`
+	Country        string     `form:"country" binding:"required,len=2,alpha"` // ISO 3166-1 alpha-2
+	DocumentType   string     `form:"document_type" binding:"required,oneof=passport id_card driver_license proof_of_address"`
+	DocumentNumber string     `form:"document_number" binding:"required,max=64"`
+	DocumentExpiry *time.Time `form:"document_expiry" time_format:"2006-01-02"`
+}
+
+// KYCApplicationQuery 实名认证申请列表查询参数
+type KYCApplicationQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type ReviewKYCRequest struct {
+	Action string `json:"action" binding:"required,oneof=approve reject"`
+	Remark string `json:"remark"`
+}
+
+type SetKYCTierRequest struct {
+	Tier   string `json:"tier" binding:"required,oneof=unverified basic full"`
+	Reason string `json:"reason" binding:"required,max=255"`
+}
@@ -0,0 +1,21 @@
+package request
+
+import "github.com/shopspring/decimal"
+
+type CreateTenantRequest struct {
+	Code string `json:"code" binding:"required,max=32"` // 客户端通过 X-Tenant 头指定
+	Name string `json:"name" binding:"required,max=128"`
+}
+
+type SetTenantStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active disabled"`
+}
+
+// SetTenantChainRequest 租户链配置，xpub 为空时由系统主密钥派生托管 xpub；限额为 0 表示不限制
+type SetTenantChainRequest struct {
+	XPub            string          `json:"xpub" binding:"max=128"`
+	HotWallet       string          `json:"hot_wallet" binding:"max=255"`
+	MinWithdrawal   decimal.Decimal `json:"min_withdrawal"`
+	MaxWithdrawal   decimal.Decimal `json:"max_withdrawal"`
+	DailyWithdrawal decimal.Decimal `json:"daily_withdrawal_limit"`
+}
@@ -0,0 +1,18 @@
+package request
+
+import "github.com/shopspring/decimal"
+
+type CreateTransferRequest struct {
+	To             string          `json:"to" binding:"required"` // 收款用户的用户名或邮箱 (同租户)
+	Amount         decimal.Decimal `json:"amount" binding:"required"`
+	Currency       string          `json:"currency" binding:"required"`
+	Memo           string          `json:"memo"`            // 可选: 备注，双方可见
+	IdempotencyKey string          `json:"idempotency_key"` // 可选: 幂等键，也可以通过 Idempotency-Key 请求头传递
+	TOTPCode       string          `json:"totp_code"`       // 开启两步验证后必填: 验证码或备用码
+}
+
+type TransferQuery struct {
+	Currency string `form:"currency"`
+	BeforeID uint64 `form:"before_id"`
+	Limit    int    `form:"limit"`
+}
@@ -5,3 +5,14 @@ type CreateDepositAddressRequest struct {
 	UserID   uint64 `json:"user_id" binding:"required"`
 	Currency string `json:"currency" binding:"required,oneof=BTC ETH"`
 }
+
+// ListTransactionsQuery 交易记录查询参数 (充值 / 提现 / 合并列表共用)
+type ListTransactionsQuery struct {
+	Currency  string `form:"currency"`
+	Status    string `form:"status"`
+	TxHash    string `form:"tx_hash"`
+	StartTime string `form:"start_time"` // unix 秒或 RFC3339，包含
+	EndTime   string `form:"end_time"`   // unix 秒或 RFC3339，不包含
+	Cursor    string `form:"cursor"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,18 @@
+package request
+
+// WebhookEndpointRequest 新增 / 修改通知地址
+type WebhookEndpointRequest struct {
+	URL         string   `json:"url" binding:"required,max=512"`
+	Description string   `json:"description" binding:"max=255"`
+	Events      []string `json:"events"`  // 订阅的事件，为空订阅全部
+	Enabled     *bool    `json:"enabled"` // 为空时新增为启用，修改时不变
+}
+
+// DeliveryQuery 投递记录查询参数
+type DeliveryQuery struct {
+	EndpointID uint64 `form:"endpoint_id"`
+	Event      string `form:"event" binding:"max=32"`
+	Status     string `form:"status" binding:"omitempty,oneof=pending delivered failed"`
+	BeforeID   uint64 `form:"before_id"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
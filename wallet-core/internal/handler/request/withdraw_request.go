@@ -1,9 +1,15 @@
 package request
 
-import "github.com/shopspring/decimal"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type CreateWithdrawalRequest struct {
-	ToAddress string          `json:"to_address" binding:"required"`
-	Amount    decimal.Decimal `json:"amount" binding:"required"`
-	Chain     string          `json:"chain" binding:"required"`
+	ToAddress    string          `json:"to_address" binding:"required"`
+	Amount       decimal.Decimal `json:"amount" binding:"required"`
+	Chain        string          `json:"chain" binding:"required"`
+	ExecuteAfter *time.Time      `json:"execute_after"` // 可选: 定时提现 (RFC3339)，审批通过后等到该时间才执行
+	TOTPCode     string          `json:"totp_code"`     // 开启两步验证后必填: 验证码或备用码
 }
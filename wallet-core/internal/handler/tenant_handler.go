@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"strconv"
+
+	"wallet-core/internal/handler/request"
+	"wallet-core/internal/handler/response"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/model"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/errno"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TenantHandler struct{}
+
+var Tenant = &TenantHandler{}
+
+// CreateTenant 创建租户
+// @Summary 创建租户
+// @Tags Admin
+// @Security AdminAuth
+// @Accept json
+// @Produce json
+// @Param request body request.CreateTenantRequest true "Create Tenant Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/tenants [post]
+func (h *TenantHandler) CreateTenant(c *gin.Context) {
+	var req request.CreateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	t, err := service.Tenant.CreateTenant(c.Request.Context(), c.GetUint64(middleware.ContextAdminID), req.Code, req.Name)
+	resourceID := ""
+	if t != nil {
+		resourceID = strconv.FormatUint(t.ID, 10)
+	}
+	Admin.audit(c, model.AuditActionTenantCreate, "tenant", resourceID, "code="+req.Code, err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, t)
+}
+
+// ListTenants 租户列表
+// @Summary 租户列表
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/tenants [get]
+func (h *TenantHandler) ListTenants(c *gin.Context) {
+	list, err := service.Tenant.ListTenants(c.Request.Context())
+	if err != nil {
+		response.Error(c, errno.ErrDatabase)
+		return
+	}
+
+	response.Success(c, list)
+}
+
+// SetTenantStatus 启用 / 停用租户
+// @Summary 启用 / 停用租户
+// @Tags Admin
+// @Security AdminAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Tenant ID"
+// @Param request body request.SetTenantStatusRequest true "Set Tenant Status Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/tenants/{id}/status [post]
+func (h *TenantHandler) SetTenantStatus(c *gin.Context) {
+	tenantID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+	var req request.SetTenantStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	err = service.Tenant.SetTenantStatus(c.Request.Context(), tenantID, req.Status)
+	Admin.audit(c, model.AuditActionTenantStatus, "tenant", c.Param("id"), "status="+req.Status, err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
+
+// SetTenantChain 设置租户的链配置 (xpub / 热钱包 / 提现限额)
+// @Summary 设置租户链配置
+// @Description xpub 为空时从系统主密钥派生 m/44'/coin'/tenant_id' 的托管 xpub；xpub 一经设置不能修改
+// @Tags Admin
+// @Security AdminAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Tenant ID"
+// @Param chain path string true "Chain (BTC / ETH)"
+// @Param request body request.SetTenantChainRequest true "Set Tenant Chain Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/tenants/{id}/chains/{chain} [put]
+func (h *TenantHandler) SetTenantChain(c *gin.Context) {
+	tenantID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+	var req request.SetTenantChainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	tc, err := service.Tenant.SetTenantChain(c.Request.Context(), c.GetUint64(middleware.ContextAdminID), tenantID, service.TenantChainInput{
+		Chain:           c.Param("chain"),
+		XPub:            req.XPub,
+		HotWallet:       req.HotWallet,
+		MinWithdrawal:   req.MinWithdrawal,
+		MaxWithdrawal:   req.MaxWithdrawal,
+		DailyWithdrawal: req.DailyWithdrawal,
+	})
+	Admin.audit(c, model.AuditActionTenantChainSet, "tenant", c.Param("id"), "chain="+c.Param("chain"), err)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, tc)
+}
+
+// ListTenantChains 租户的链配置
+// @Summary 租户链配置列表
+// @Tags Admin
+// @Security AdminAuth
+// @Produce json
+// @Param id path int true "Tenant ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/admin/tenants/{id}/chains [get]
+func (h *TenantHandler) ListTenantChains(c *gin.Context) {
+	tenantID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	list, err := service.Tenant.ListTenantChains(c.Request.Context(), tenantID)
+	if err != nil {
+		response.Error(c, errno.ErrDatabase)
+		return
+	}
+
+	response.Success(c, list)
+}
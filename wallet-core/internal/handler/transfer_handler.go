@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"strconv"
+
+	"wallet-core/internal/handler/request"
+	"wallet-core/internal/handler/response"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/errno"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferHandler struct{}
+
+var Transfer = &TransferHandler{}
+
+// CreateTransfer 站内转账
+// @Summary 站内转账
+// @Description 转账给同租户的另一个用户 (用户名或邮箱)，不上链，余额即时到账。
+// @Description 带幂等键 (idempotency_key 或 Idempotency-Key 请求头) 的重试返回首次创建的转账，同一个键用于不同参数时返回 20906
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.CreateTransferRequest true "Transfer Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/wallet/transfers [post]
+func (h *TransferHandler) CreateTransfer(c *gin.Context) {
+	var req request.CreateTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+	key := req.IdempotencyKey
+	if key == "" {
+		key = c.GetHeader("Idempotency-Key")
+	}
+
+	t, err := service.Transfer.Send(c.Request.Context(), service.TransferInput{
+		FromUserID:     c.GetUint64(middleware.ContextUserID),
+		Currency:       req.Currency,
+		Amount:         req.Amount,
+		Memo:           req.Memo,
+		IdempotencyKey: key,
+	}, req.To, req.TOTPCode)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, t)
+}
+
+// ListTransfers 站内转账记录
+// @Summary 站内转账记录
+// @Description 转出和转入的转账，按创建时间倒序，翻页时 before_id 传上一页最后一条的 id
+// @Tags Wallet
+// @Produce json
+// @Security BearerAuth
+// @Param currency query string false "Currency"
+// @Param before_id query int false "Cursor"
+// @Param limit query int false "Limit (default 20, max 100)"
+// @Success 200 {object} response.Response
+// @Router /api/v1/wallet/transfers [get]
+func (h *TransferHandler) ListTransfers(c *gin.Context) {
+	var q request.TransferQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	list, err := service.Transfer.List(c.Request.Context(), c.GetUint64(middleware.ContextUserID), q.Currency, q.BeforeID, q.Limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, list)
+}
+
+// GetTransfer 站内转账详情
+// @Summary 站内转账详情
+// @Description 只有转出方和转入方可以查看
+// @Tags Wallet
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/wallet/transfers/{id} [get]
+func (h *TransferHandler) GetTransfer(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	t, err := service.Transfer.Get(c.Request.Context(), c.GetUint64(middleware.ContextUserID), id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, t)
+}
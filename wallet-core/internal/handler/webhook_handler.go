@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"strconv"
+
+	"wallet-core/internal/handler/request"
+	"wallet-core/internal/handler/response"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/errno"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct{}
+
+var Webhook = &WebhookHandler{}
+
+// CreateEndpoint 新增通知地址
+// @Summary 新增通知地址
+// @Description 需要 webhook:write 权限。返回的 secret 只出现这一次；events 为空时订阅全部事件
+// @Description (deposit.detected / deposit.confirmed / withdrawal.created / withdrawal.completed / withdrawal.failed / invoice.*)
+// @Tags Merchant
+// @Accept json
+// @Produce json
+// @Param request body request.WebhookEndpointRequest true "Webhook Endpoint Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/webhooks [post]
+func (h *WebhookHandler) CreateEndpoint(c *gin.Context) {
+	var req request.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	hook, secret, err := service.Webhook.CreateEndpoint(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID), webhookInput(&req))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, gin.H{
+		"webhook": hook,
+		"secret":  secret,
+	})
+}
+
+// ListEndpoints 通知地址列表
+// @Summary 通知地址列表
+// @Description 需要 webhook:read 权限
+// @Tags Merchant
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/webhooks [get]
+func (h *WebhookHandler) ListEndpoints(c *gin.Context) {
+	list, err := service.Webhook.ListEndpoints(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, list)
+}
+
+// UpdateEndpoint 修改通知地址
+// @Summary 修改通知地址
+// @Description 需要 webhook:write 权限，签名密钥不变
+// @Tags Merchant
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param request body request.WebhookEndpointRequest true "Webhook Endpoint Request"
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateEndpoint(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+	var req request.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	hook, err := service.Webhook.UpdateEndpoint(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID), id, webhookInput(&req))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, hook)
+}
+
+// DeleteEndpoint 删除通知地址
+// @Summary 删除通知地址
+// @Description 需要 webhook:write 权限，尚未投递成功的通知不再重试
+// @Tags Merchant
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteEndpoint(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	if err := service.Webhook.DeleteEndpoint(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID), id); err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, nil)
+}
+
+// RotateSecret 轮换签名密钥
+// @Summary 轮换签名密钥
+// @Description 需要 webhook:write 权限。新密钥立即生效 (包括正在重试的通知)，只返回这一次
+// @Tags Merchant
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/webhooks/{id}/rotate-secret [post]
+func (h *WebhookHandler) RotateSecret(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	hook, secret, err := service.Webhook.RotateSecret(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID), id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, gin.H{
+		"webhook": hook,
+		"secret":  secret,
+	})
+}
+
+// ListDeliveries 通知投递记录
+// @Summary 通知投递记录
+// @Description 需要 webhook:read 权限，按创建时间倒序，翻页时 before_id 传上一页最后一条的 id
+// @Tags Merchant
+// @Produce json
+// @Param endpoint_id query int false "Webhook ID"
+// @Param event query string false "Event"
+// @Param status query string false "pending / delivered / failed"
+// @Param before_id query int false "Cursor"
+// @Param limit query int false "Limit (default 20, max 100)"
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	var q request.DeliveryQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	list, err := service.Webhook.ListDeliveries(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID), service.DeliveryQuery{
+		EndpointID: q.EndpointID,
+		Event:      q.Event,
+		Status:     q.Status,
+		BeforeID:   q.BeforeID,
+		Limit:      q.Limit,
+	})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, list)
+}
+
+// GetDelivery 投递详情
+// @Summary 投递详情
+// @Description 需要 webhook:read 权限，含请求体和每次尝试的响应码
+// @Tags Merchant
+// @Produce json
+// @Param id path int true "Delivery ID"
+// @Success 200 {object} response.Response{data=service.DeliveryDetail}
+// @Router /api/v1/merchant/deliveries/{id} [get]
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	detail, err := service.Webhook.GetDelivery(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID), id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, detail)
+}
+
+// ReplayDelivery 重放投递
+// @Summary 重放投递
+// @Description 需要 webhook:write 权限。按原请求体重新投递一次 (事件 ID 不变，使用当前密钥签名)，返回新的投递记录
+// @Tags Merchant
+// @Produce json
+// @Param id path int true "Delivery ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/merchant/deliveries/{id}/replay [post]
+func (h *WebhookHandler) ReplayDelivery(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	d, err := service.Webhook.Replay(c.Request.Context(), c.GetUint64(middleware.ContextMerchantID), id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, d)
+}
+
+func webhookInput(req *request.WebhookEndpointRequest) service.WebhookInput {
+	return service.WebhookInput{
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      req.Events,
+		Enabled:     req.Enabled,
+	}
+}
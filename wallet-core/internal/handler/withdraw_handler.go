@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"errors"
+	"strconv"
+
 	"wallet-core/internal/handler/request"
 	"wallet-core/internal/handler/response"
+	"wallet-core/internal/middleware"
 	"wallet-core/internal/model"
 	"wallet-core/internal/service"
 	"wallet-core/pkg/errno"
@@ -20,6 +24,7 @@ var Withdraw = &WithdrawHandler{}
 // @Tags Wallet
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param request body request.CreateWithdrawalRequest true "Withdraw Request"
 // @Success 200 {object} response.Response
 // @Router /api/v1/withdraw [post]
@@ -31,23 +36,54 @@ func (h *WithdrawHandler) CreateWithdrawal(c *gin.Context) {
 		return
 	}
 
-	// 2. 获取用户 ID (Mock)
-	// userID := c.GetUint64("uid")
-	userID := uint64(1) // Mock User 1
+	// 2. 获取用户 ID (JWTAuth 中间件写入)
+	userID := c.GetUint64(middleware.ContextUserID)
 
 	// 3. 构造 Model
 	w := &model.Withdrawal{
-		UserID:    userID,
-		ToAddress: req.ToAddress,
-		Amount:    req.Amount,
-		Chain:     req.Chain,
+		UserID:       userID,
+		ToAddress:    req.ToAddress,
+		Amount:       req.Amount,
+		Chain:        req.Chain,
+		ExecuteAfter: req.ExecuteAfter,
 	}
 
 	// 4. 调用 Service
-	if err := service.Withdraw.CreateWithdrawal(c.Request.Context(), userID, w); err != nil {
+	if err := service.Withdraw.CreateWithdrawal(c.Request.Context(), userID, w, req.TOTPCode); err != nil {
+		var e errno.Errno
+		if errors.As(err, &e) {
+			response.Error(c, err) // 业务错误 (制裁名单 / 预约时间非法 / 两步验证) 原样返回
+			return
+		}
 		response.Error(c, errno.ErrDatabase) // 简单处理
 		return
 	}
 
 	response.Success(c, w)
 }
+
+// CancelWithdrawal 取消提现
+// @Summary 取消提现
+// @Description 用户取消尚未执行的提现 (待审核 / 风控挂起 / 时间锁定中)
+// @Tags Wallet
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Withdrawal ID"
+// @Success 200 {object} response.Response
+// @Router /api/v1/wallet/withdraw/{id}/cancel [post]
+func (h *WithdrawHandler) CancelWithdrawal(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errno.ErrBind)
+		return
+	}
+
+	userID := c.GetUint64(middleware.ContextUserID)
+
+	if err := service.TimeLock.Cancel(c.Request.Context(), userID, id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, nil)
+}
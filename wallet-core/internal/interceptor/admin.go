@@ -0,0 +1,68 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"wallet-core/internal/model"
+	"wallet-core/internal/service"
+	"wallet-core/internal/service/auth"
+	"wallet-core/pkg/errno"
+)
+
+type adminKey struct{}
+
+// AdminAuth 校验后台管理员会话 (authorization: Bearer <admin token>)，并按角色权限矩阵鉴权
+// perms 为完整方法名 -> 所需权限，只拦截其中列出的方法，其余方法原样放行，
+// 所以管理后台服务可以和其他服务挂在同一个 Server 上；新增后台方法必须加入 perms
+func AdminAuth(svc *service.AdminAuthService, perms map[string]service.Permission) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		perm, ok := perms[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				token = auth.BearerToken(values[0])
+			}
+		}
+
+		admin, err := svc.Authenticate(ctx, token)
+		if err != nil {
+			var e errno.Errno
+			if !errors.As(err, &e) {
+				e = errno.ErrUnauthorized
+			}
+			return nil, e
+		}
+		if !service.HasPermission(admin.Role, perm) {
+			return nil, errno.ErrPermissionDenied
+		}
+		return handler(context.WithValue(ctx, adminKey{}, admin), req)
+	}
+}
+
+// CurrentAdmin 取出 AdminAuth 写入的管理员，缺失时返回 Unauthenticated (方法未加入 perms)
+func CurrentAdmin(ctx context.Context) (*model.AdminUser, error) {
+	admin, ok := ctx.Value(adminKey{}).(*model.AdminUser)
+	if !ok || admin == nil {
+		return nil, errno.ErrUnauthorized
+	}
+	return admin, nil
+}
+
+// AuditMeta 审计日志中记录的客户端 IP 和 User-Agent
+func AuditMeta(ctx context.Context) service.AuditMeta {
+	meta := service.AuditMeta{IP: clientIP(ctx)}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			meta.UserAgent = values[0]
+		}
+	}
+	return meta
+}
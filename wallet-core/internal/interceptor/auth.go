@@ -0,0 +1,55 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"wallet-core/internal/service/auth"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/tenant"
+)
+
+// Auth 校验 metadata 中的 authorization: Bearer <access token>，把用户 ID 和所属租户放入 context
+// token 中的租户优先于 Tenant 拦截器按 x-tenant 解析的租户
+// publicMethods 为无需登录的完整方法名，如 "/user.v1.UserService/Login"
+func Auth(a *auth.Service, publicMethods ...string) grpc.UnaryServerInterceptor {
+	public := make(map[string]bool, len(publicMethods))
+	for _, m := range publicMethods {
+		public[m] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if public[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				token = auth.BearerToken(values[0])
+			}
+		}
+
+		userID, tenantID, err := a.Authenticate(ctx, token)
+		if err != nil {
+			var e errno.Errno
+			if !errors.As(err, &e) {
+				e = errno.ErrUnauthorized
+			}
+			return nil, e
+		}
+		return handler(tenant.WithTenant(auth.WithUserID(ctx, userID), tenantID), req)
+	}
+}
+
+// UserID 取出拦截器写入的用户 ID，缺失时返回 Unauthenticated (拦截器未挂载或方法被误配为公开)
+func UserID(ctx context.Context) (uint64, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return 0, errno.ErrUnauthorized
+	}
+	return userID, nil
+}
@@ -0,0 +1,62 @@
+package interceptor
+
+import (
+	"google.golang.org/grpc"
+
+	"wallet-core/pkg/config"
+)
+
+// NewServer 创建带标准拦截器链的 gRPC Server，所有服务都应通过它创建
+// 顺序: ClientIP -> RequestID -> Logging -> Metrics -> Recovery -> Errors -> Deadline -> extra (Auth / RateLimit 等) -> Validate
+// Recovery / Errors 在 Logging / Metrics 之内，记录的是最终返回给调用方的状态码；Validate 最靠近 handler，只校验已通过认证的请求
+func NewServer(cfg config.GRPCConfig, extra ...grpc.UnaryServerInterceptor) *grpc.Server {
+	return NewServerWithStream(cfg, extra, nil)
+}
+
+// NewServerWithStream 同 NewServer，提供流式 RPC 的服务额外指定流式拦截器 (如 StreamAuth)
+// 流式顺序: ClientIP -> RequestID -> Logging -> Metrics -> Recovery -> Errors -> stream -> Validate
+func NewServerWithStream(cfg config.GRPCConfig, unary []grpc.UnaryServerInterceptor, stream []grpc.StreamServerInterceptor) *grpc.Server {
+	chain := []grpc.UnaryServerInterceptor{
+		ClientIP(cfg.TrustedForwarders),
+		RequestID(),
+		Logging(),
+		Metrics(),
+		Recovery(),
+		Errors(),
+		Deadline(cfg.DefaultTimeout, cfg.MaxTimeout),
+	}
+	chain = append(chain, unary...)
+	chain = append(chain, Validate())
+
+	streamChain := []grpc.StreamServerInterceptor{
+		StreamClientIP(cfg.TrustedForwarders),
+		StreamRequestID(),
+		StreamLogging(),
+		StreamMetrics(),
+		StreamRecovery(),
+		StreamErrors(),
+	}
+	streamChain = append(streamChain, stream...)
+	streamChain = append(streamChain, StreamValidate())
+
+	return grpc.NewServer(
+		grpc.ChainUnaryInterceptor(chain...),
+		grpc.ChainStreamInterceptor(streamChain...),
+	)
+}
+
+// ClientDialOptions 客户端标准拦截器链 (网关等调用方使用)
+// 顺序: Timeout -> Propagation -> Logging -> Metrics；流式调用是长连接，只传递请求 ID 和登录态
+func ClientDialOptions(cfg config.GRPCConfig) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(
+			ClientTimeout(cfg.ClientTimeout),
+			ClientPropagation(),
+			ClientLogging(),
+			ClientMetrics(),
+		),
+		grpc.WithChainStreamInterceptor(
+			ClientStreamPropagation(),
+		),
+	}
+}
@@ -0,0 +1,93 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"wallet-core/pkg/logger"
+	"wallet-core/pkg/monitor"
+	"wallet-core/pkg/requestid"
+)
+
+// ClientTimeout 调用方未设置 deadline 时使用默认超时 (0 表示不设置)
+func ClientTimeout(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// ClientPropagation 把请求 ID 和登录态传给下游服务
+// - x-request-id: 取自 context (网关的 RequestID 中间件或上游服务的 RequestID 拦截器写入)
+// - authorization: 调用方未显式设置时，沿用上游请求 metadata 中的 Access Token (服务间转调)
+func ClientPropagation() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(propagate(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// ClientStreamPropagation 见 ClientPropagation
+func ClientStreamPropagation() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(propagate(ctx), desc, cc, method, opts...)
+	}
+}
+
+func propagate(ctx context.Context) context.Context {
+	out, _ := metadata.FromOutgoingContext(ctx)
+
+	if id := requestid.FromContext(ctx); id != "" && len(out.Get(requestid.MetadataKey)) == 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, requestid.MetadataKey, id)
+	}
+	if len(out.Get("authorization")) == 0 {
+		if in, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := in.Get("authorization"); len(values) > 0 {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", values[0])
+			}
+		}
+	}
+	return ctx
+}
+
+// ClientLogging 记录失败的下游调用 (成功调用只在 Debug 级别记录)
+func ClientLogging() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		fields := []zap.Field{
+			zap.String("method", method),
+			zap.String("target", cc.Target()),
+			zap.String("code", status.Code(err).String()),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("request_id", requestid.FromContext(ctx)),
+		}
+		if err != nil {
+			logger.Warn("gRPC call failed", append(fields, zap.Error(err))...)
+		} else {
+			logger.Debug("gRPC call", fields...)
+		}
+		return err
+	}
+}
+
+// ClientMetrics 记录客户端 RPC 次数 (按状态码) 和耗时
+func ClientMetrics() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		monitor.GRPCClientHandledTotal.WithLabelValues(method, status.Code(err).String()).Inc()
+		monitor.GRPCClientHandlingSeconds.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
@@ -0,0 +1,105 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"wallet-core/pkg/logger"
+)
+
+type clientIPKey struct{}
+
+// ClientIP 解析客户端 IP 写入 context，供限流 / 商户 IP 白名单 / 审计 / 日志使用 (见 clientIP)
+// 只有来自受信任转发方 (grpc.trusted_forwarders，即网关) 的调用才采信 metadata 中的 x-forwarded-for，
+// 其他调用方带上该 metadata 也按连接对端地址计算，无法伪造 IP 绕过限流
+func ClientIP(trusted []string) grpc.UnaryServerInterceptor {
+	nets := parseForwarders(trusted)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(context.WithValue(ctx, clientIPKey{}, resolveClientIP(ctx, nets)), req)
+	}
+}
+
+// StreamClientIP 见 ClientIP
+func StreamClientIP(trusted []string) grpc.StreamServerInterceptor {
+	nets := parseForwarders(trusted)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		return handler(srv, withContext(ss, context.WithValue(ctx, clientIPKey{}, resolveClientIP(ctx, nets))))
+	}
+}
+
+// clientIP 取 ClientIP 解析的结果，没有经过 ClientIP 时取连接对端地址
+func clientIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return peerIP(ctx)
+}
+
+// resolveClientIP 对端属于受信任转发方时取 x-forwarded-for 的第一个地址，否则取对端地址
+func resolveClientIP(ctx context.Context, trusted []*net.IPNet) string {
+	addr := peerIP(ctx)
+	if !containsIP(trusted, addr) {
+		return addr
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			if ip := strings.TrimSpace(strings.Split(values[0], ",")[0]); net.ParseIP(ip) != nil {
+				return ip
+			}
+		}
+	}
+	return addr
+}
+
+func peerIP(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// parseForwarders 每一项为 IP 或 CIDR，无效项忽略 (不信任)
+func parseForwarders(list []string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, entry := range list {
+		entry = strings.TrimSpace(entry)
+		if ip := net.ParseIP(entry); ip != nil {
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(entry)
+		if err != nil {
+			logger.Warn("忽略无效的 grpc.trusted_forwarders 配置", zap.String("entry", entry))
+			continue
+		}
+		nets = append(nets, cidr)
+	}
+	return nets
+}
+
+func containsIP(nets []*net.IPNet, addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Deadline 保证每个请求都有截止时间
+// 调用方未设置 deadline 时使用 defaultTimeout，设置得比 maxTimeout 更长时截断为 maxTimeout (0 表示不限制)
+// 到达服务端时已经过期的请求直接返回 DeadlineExceeded，不再执行
+func Deadline(defaultTimeout, maxTimeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := withDeadline(ctx, defaultTimeout, maxTimeout)
+		defer cancel()
+
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		return handler(ctx, req)
+	}
+}
+
+func withDeadline(ctx context.Context, defaultTimeout, maxTimeout time.Duration) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	switch {
+	case !ok && defaultTimeout > 0:
+		return context.WithTimeout(ctx, defaultTimeout)
+	case ok && maxTimeout > 0 && time.Until(deadline) > maxTimeout:
+		return context.WithTimeout(ctx, maxTimeout)
+	}
+	return ctx, func() {}
+}
@@ -0,0 +1,54 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/logger"
+	"wallet-core/pkg/requestid"
+)
+
+// Errors 统一错误模型: handler 返回的 errno.Errno (包括被包装的) 和 gRPC status 原样返回 (Errno 自带状态码和业务码)；
+// context 取消 / 超时转为 Canceled / DeadlineExceeded；
+// 其余未归类的错误 (数据库、RPC 节点等) 记录原始信息后返回 errno.InternalServerError，避免内部细节泄露给调用方
+func Errors() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		return resp, normalizeError(ctx, info.FullMethod, err)
+	}
+}
+
+func normalizeError(ctx context.Context, method string, err error) error {
+	// 被 %w 包装的 Errno 只返回 Errno 本身，包装时附加的内部信息只记日志
+	var e errno.Errno
+	if errors.As(err, &e) {
+		if err.Error() != e.Message {
+			logger.Warn("gRPC handler error",
+				zap.String("method", method),
+				zap.String("request_id", requestid.FromContext(ctx)),
+				zap.Error(err),
+			)
+		}
+		return e
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	logger.Error("gRPC handler error",
+		zap.String("method", method),
+		zap.String("request_id", requestid.FromContext(ctx)),
+		zap.Error(err),
+	)
+	return errno.InternalServerError
+}
@@ -0,0 +1,175 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	walletv1 "wallet-core/api/gen/wallet/v1"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/requestid"
+)
+
+var testInfo = &grpc.UnaryServerInfo{FullMethod: "/test.v1.Test/Call"}
+
+func TestRecovery(t *testing.T) {
+	_, err := Recovery()(context.Background(), nil, testInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestDeadline(t *testing.T) {
+	var got time.Duration
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		got = time.Until(deadline)
+		return nil, nil
+	}
+	mw := Deadline(10*time.Second, 30*time.Second)
+
+	// 未设置 deadline: 使用默认超时
+	_, err := mw(context.Background(), nil, testInfo, handler)
+	require.NoError(t, err)
+	assert.InDelta(t, 10*time.Second, got, float64(time.Second))
+
+	// deadline 过长: 截断为最大超时
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	_, err = mw(ctx, nil, testInfo, handler)
+	require.NoError(t, err)
+	assert.InDelta(t, 30*time.Second, got, float64(time.Second))
+
+	// 已过期: 不执行 handler
+	expired, cancel2 := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel2()
+	_, err = mw(expired, nil, testInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		t.Fatal("handler must not run")
+		return nil, nil
+	})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestValidate(t *testing.T) {
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	_, err := Validate()(context.Background(), &walletv1.QuoteWithdrawalRequest{Currency: "ETH", Level: "turbo"}, testInfo, ok)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := Validate()(context.Background(), &walletv1.QuoteWithdrawalRequest{Currency: "ETH"}, testInfo, ok)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	// 未实现 Validate 的消息直接放行
+	_, err = Validate()(context.Background(), &walletv1.GetBalanceRequest{}, testInfo, ok)
+	assert.NoError(t, err)
+}
+
+func TestRequestID(t *testing.T) {
+	var got string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = requestid.FromContext(ctx)
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestid.MetadataKey, "upstream-id"))
+	_, _ = RequestID()(ctx, nil, testInfo, handler)
+	assert.Equal(t, "upstream-id", got)
+
+	_, _ = RequestID()(context.Background(), nil, testInfo, handler)
+	assert.Len(t, got, 32)
+}
+
+func TestClientPropagation(t *testing.T) {
+	ctx := requestid.WithContext(context.Background(), "rid")
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer upstream"))
+
+	var out metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		out, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	require.NoError(t, ClientPropagation()(ctx, "/m", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"rid"}, out.Get(requestid.MetadataKey))
+	assert.Equal(t, []string{"Bearer upstream"}, out.Get("authorization"))
+
+	// 显式设置的 authorization 不被覆盖
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer explicit")
+	require.NoError(t, ClientPropagation()(ctx, "/m", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"Bearer explicit"}, out.Get("authorization"))
+}
+
+func TestIsServerError(t *testing.T) {
+	assert.True(t, isServerError(codes.Internal))
+	assert.True(t, isServerError(codes.Unknown))
+	assert.False(t, isServerError(codes.InvalidArgument))
+	assert.False(t, isServerError(codes.ResourceExhausted))
+	assert.False(t, isServerError(codes.OK))
+}
+
+func TestErrors(t *testing.T) {
+	run := func(err error) error {
+		_, got := Errors()(context.Background(), nil, testInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, err
+		})
+		return got
+	}
+
+	assert.Equal(t, errno.ErrUserNotFound, run(errno.ErrUserNotFound))
+	// 包装时附加的信息不返回给调用方
+	assert.Equal(t, errno.ErrMFAInvalid, run(fmt.Errorf("verify totp for user 1: %w", errno.ErrMFAInvalid)))
+	assert.Equal(t, errno.InternalServerError, run(errors.New("pq: connection refused")))
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(run(context.DeadlineExceeded)))
+	assert.Equal(t, codes.NotFound, status.Code(run(status.Error(codes.NotFound, "x"))))
+	assert.NoError(t, run(nil))
+}
+
+func TestAdminAuthSkipsUnlistedMethods(t *testing.T) {
+	// 未列出的方法不经过管理员认证 (svc 为 nil 也不会被调用)
+	mw := AdminAuth(nil, nil)
+	called := false
+	_, err := mw(context.Background(), nil, testInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		_, err := CurrentAdmin(ctx)
+		assert.Equal(t, errno.ErrUnauthorized, err)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestClientIPTrustsOnlyForwarders(t *testing.T) {
+	var got string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = clientIP(ctx)
+		return nil, nil
+	}
+	mw := ClientIP([]string{"10.0.0.0/8", "127.0.0.1", "not-a-cidr"})
+	call := func(peerAddr, xff string) string {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(peerAddr), Port: 50051}})
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", xff))
+		_, err := mw(ctx, nil, testInfo, handler)
+		require.NoError(t, err)
+		return got
+	}
+
+	// 网关转发: 采信 x-forwarded-for
+	assert.Equal(t, "203.0.113.7", call("10.1.2.3", "203.0.113.7"))
+	assert.Equal(t, "203.0.113.7", call("127.0.0.1", "203.0.113.7, 10.0.0.1"))
+	// 直连调用方伪造 x-forwarded-for: 按连接对端地址
+	assert.Equal(t, "198.51.100.1", call("198.51.100.1", "203.0.113.7"))
+	// 转发的值不是 IP: 按连接对端地址
+	assert.Equal(t, "10.1.2.3", call("10.1.2.3", "garbage"))
+}
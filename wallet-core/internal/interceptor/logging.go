@@ -0,0 +1,67 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"wallet-core/pkg/logger"
+	"wallet-core/pkg/requestid"
+)
+
+// RequestID 沿用调用方 metadata 中的 x-request-id (通常由网关生成)，缺失时生成新的
+// 写入 context 供日志使用，并通过 response header 返回给调用方
+func RequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var id string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(requestid.MetadataKey); len(values) > 0 {
+				id = values[0]
+			}
+		}
+		id = requestid.Ensure(id)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+		return handler(requestid.WithContext(ctx, id), req)
+	}
+}
+
+// Logging 每个请求一条日志: 方法、状态码、耗时、请求 ID、客户端 IP
+// 服务端错误 (Internal / Unknown 等) 记为 Error，其余失败记为 Warn
+func Logging() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		fields := []zap.Field{
+			zap.String("method", info.FullMethod),
+			zap.String("code", code.String()),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("request_id", requestid.FromContext(ctx)),
+			zap.String("ip", clientIP(ctx)),
+		}
+		switch {
+		case err == nil:
+			logger.Info("gRPC request", fields...)
+		case isServerError(code):
+			logger.Error("gRPC request", append(fields, zap.Error(err))...)
+		default:
+			logger.Warn("gRPC request", append(fields, zap.Error(err))...)
+		}
+		return resp, err
+	}
+}
+
+// isServerError 是否为服务端自身的问题 (需要告警)，而不是调用方的错误
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unimplemented, codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
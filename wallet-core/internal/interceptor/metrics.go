@@ -0,0 +1,23 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"wallet-core/pkg/monitor"
+)
+
+// Metrics 记录服务端 RPC 次数 (按状态码) 和耗时
+func Metrics() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		monitor.GRPCServerHandledTotal.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		monitor.GRPCServerHandlingSeconds.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		return resp, err
+	}
+}
@@ -0,0 +1,52 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"wallet-core/internal/service/auth"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/logger"
+	"wallet-core/pkg/ratelimit"
+)
+
+// RateLimit 按方法限流，methodRules 为 完整方法名 -> 规则名，未列出的方法不限流
+// 按用户限流的规则依赖 Auth 写入的用户 ID，需要链在 Auth 之后
+// 命中时返回 errno.ErrTooManyRequests (ResourceExhausted)，并在 header metadata 中带上 ratelimit-* / retry-after
+func RateLimit(l *ratelimit.Limiter, methodRules map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		rule, ok := methodRules[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		id := ratelimit.Identity{IP: clientIP(ctx)}
+		id.UserID, _ = auth.UserIDFromContext(ctx)
+
+		res, err := l.Allow(ctx, rule, id)
+		if err != nil {
+			logger.Warn("限流器不可用", zap.String("rule", rule), zap.Bool("allowed", res.Allowed), zap.Error(err))
+			if !res.Allowed {
+				return nil, errno.ErrUnavailable
+			}
+			return handler(ctx, req)
+		}
+
+		h := res.Headers()
+		if len(h) > 0 {
+			md := metadata.MD{}
+			for k, v := range h {
+				md.Set(strings.ToLower(k), v)
+			}
+			_ = grpc.SetHeader(ctx, md)
+		}
+		if !res.Allowed {
+			return nil, errno.ErrTooManyRequests
+		}
+		return handler(ctx, req)
+	}
+}
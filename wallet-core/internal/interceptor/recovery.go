@@ -0,0 +1,31 @@
+package interceptor
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"wallet-core/pkg/logger"
+	"wallet-core/pkg/requestid"
+)
+
+// Recovery 捕获 handler 中的 panic，记录堆栈并返回 Internal，避免整个进程退出
+func Recovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("gRPC handler panic",
+					zap.String("method", info.FullMethod),
+					zap.String("request_id", requestid.FromContext(ctx)),
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+				resp, err = nil, status.Error(codes.Internal, "internal error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
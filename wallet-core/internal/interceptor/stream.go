@@ -0,0 +1,153 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"wallet-core/internal/service/auth"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/logger"
+	"wallet-core/pkg/monitor"
+	"wallet-core/pkg/requestid"
+	"wallet-core/pkg/tenant"
+)
+
+// 流式 RPC 的拦截器，与一元拦截器一一对应 (StreamValidate 见 validate.go)
+// 流式调用是长连接，没有对应 Deadline 的整体超时
+
+// wrappedStream 替换 ServerStream 的 context
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedStream) Context() context.Context {
+	return s.ctx
+}
+
+func withContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &wrappedStream{ServerStream: ss, ctx: ctx}
+}
+
+// StreamRequestID 见 RequestID
+func StreamRequestID() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		var id string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(requestid.MetadataKey); len(values) > 0 {
+				id = values[0]
+			}
+		}
+		id = requestid.Ensure(id)
+		_ = ss.SetHeader(metadata.Pairs(requestid.MetadataKey, id))
+		return handler(srv, withContext(ss, requestid.WithContext(ctx, id)))
+	}
+}
+
+// StreamLogging 每个流结束时一条日志，客户端断开 (Canceled) 视为正常结束
+func StreamLogging() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+
+		ctx := ss.Context()
+		code := status.Code(err)
+		fields := []zap.Field{
+			zap.String("method", info.FullMethod),
+			zap.String("code", code.String()),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("request_id", requestid.FromContext(ctx)),
+			zap.String("ip", clientIP(ctx)),
+		}
+		switch {
+		case err == nil || code == codes.Canceled:
+			logger.Info("gRPC stream", fields...)
+		case isServerError(code):
+			logger.Error("gRPC stream", append(fields, zap.Error(err))...)
+		default:
+			logger.Warn("gRPC stream", append(fields, zap.Error(err))...)
+		}
+		return err
+	}
+}
+
+// StreamMetrics 见 Metrics，耗时为整个流的持续时间
+func StreamMetrics() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+
+		monitor.GRPCServerHandledTotal.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		monitor.GRPCServerHandlingSeconds.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// StreamRecovery 见 Recovery
+func StreamRecovery() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("gRPC stream panic",
+					zap.String("method", info.FullMethod),
+					zap.String("request_id", requestid.FromContext(ss.Context())),
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// StreamErrors 见 Errors
+func StreamErrors() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err == nil {
+			return nil
+		}
+		return normalizeError(ss.Context(), info.FullMethod, err)
+	}
+}
+
+// StreamAuth 见 Auth
+func StreamAuth(a *auth.Service, publicMethods ...string) grpc.StreamServerInterceptor {
+	public := make(map[string]bool, len(publicMethods))
+	for _, m := range publicMethods {
+		public[m] = true
+	}
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if public[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		ctx := ss.Context()
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				token = auth.BearerToken(values[0])
+			}
+		}
+
+		userID, tenantID, err := a.Authenticate(ctx, token)
+		if err != nil {
+			var e errno.Errno
+			if !errors.As(err, &e) {
+				e = errno.ErrUnauthorized
+			}
+			return e
+		}
+		return handler(srv, withContext(ss, tenant.WithTenant(auth.WithUserID(ctx, userID), tenantID)))
+	}
+}
@@ -0,0 +1,30 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"wallet-core/internal/service"
+	"wallet-core/pkg/tenant"
+)
+
+// Tenant 按 metadata 中的 x-tenant (租户代码) 把租户放入 context，未携带时为默认租户
+// 供注册 / 登录 / 找回密码等未登录接口使用，需挂在 Auth 之前；已登录的请求由 Auth 改用 token 中的租户
+func Tenant(svc *service.TenantService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var code string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(tenant.MetadataKey); len(values) > 0 {
+				code = values[0]
+			}
+		}
+
+		tenantID, err := svc.ResolveTenant(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		return handler(tenant.WithTenant(ctx, tenantID), req)
+	}
+}
@@ -0,0 +1,49 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"wallet-core/pkg/errno"
+)
+
+// validator 请求消息可选实现的校验接口 (见 api/gen/*/v1/validate.go)
+type validator interface {
+	Validate() error
+}
+
+// Validate 请求消息实现了 Validate() 时先校验，失败返回 errno.ErrBind (InvalidArgument)
+func Validate() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if v, ok := req.(validator); ok {
+			if err := v.Validate(); err != nil {
+				return nil, errno.ErrBind.WithMessage(err.Error())
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamValidate 见 Validate，在 handler 读取每条请求消息时校验
+func StreamValidate() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if v, ok := m.(validator); ok {
+		if err := v.Validate(); err != nil {
+			return errno.ErrBind.WithMessage(err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"wallet-core/internal/handler/response"
+	"wallet-core/internal/service/auth"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/tenant"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextUserID / ContextTenantID gin.Context 中保存已认证用户 ID / 所属租户的 key
+const (
+	ContextUserID   = "uid"
+	ContextTenantID = "tid"
+)
+
+// JWTAuth 校验 Authorization: Bearer <access token>
+// 通过后把用户 ID 写入 gin.Context ("uid") 和 request context (auth.UserIDFromContext)，
+// 所属租户写入 gin.Context ("tid") 和 request context (tenant.FromContext)，之后的数据库查询按租户隔离
+func JWTAuth(a *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, tenantID, err := a.Authenticate(c.Request.Context(), auth.BearerToken(c.GetHeader("Authorization")))
+		if err != nil {
+			var e errno.Errno
+			if !errors.As(err, &e) {
+				e = errno.ErrUnauthorized
+			}
+			abort(c, http.StatusUnauthorized, e)
+			return
+		}
+
+		c.Set(ContextUserID, userID)
+		c.Set(ContextTenantID, tenantID)
+		ctx := tenant.WithTenant(auth.WithUserID(c.Request.Context(), userID), tenantID)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// abort 以统一响应格式终止请求
+func abort(c *gin.Context, status int, e errno.Errno) {
+	c.AbortWithStatusJSON(status, response.Response{
+		Code:    e.Code,
+		Message: e.Message,
+		Data:    gin.H{},
+	})
+}
+
+// TokenFromQuery 请求没有 Authorization 头时，取查询参数 param 中的 Access Token 补上
+// 仅用于浏览器无法设置请求头的长连接 (EventSource / WebSocket)，需挂在 JWTAuth 之前
+func TokenFromQuery(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query(param); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		c.Next()
+	}
+}
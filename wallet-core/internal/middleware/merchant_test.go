@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/apisign"
+	"wallet-core/pkg/config"
+)
+
+// merchantDB 只生成 SQL 的数据库，查询结果由 key 填充: API Key 只允许 203.0.113.7 访问
+func merchantDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:fill", func(tx *gorm.DB) {
+		switch dest := tx.Statement.Dest.(type) {
+		case *model.MerchantAPIKey:
+			*dest = model.MerchantAPIKey{ID: 1, MerchantID: 1, KeyID: "mk_test", SecretCiphertext: "!", IPAllowlist: model.StringList{"203.0.113.7"}}
+		case *model.Merchant:
+			dest.Status = model.MerchantStatusActive
+		}
+	}))
+	return db
+}
+
+func TestMerchantAuthIgnoresSpoofedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := service.NewMerchantService(merchantDB(t), nil, nil, config.MerchantConfig{})
+
+	r := gin.New()
+	require.NoError(t, SetTrustedProxies(r, []string{"10.0.0.0/8"}))
+	r.POST("/merchant", MerchantAuth(svc), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func(remoteAddr, xff string) int {
+		req := httptest.NewRequest(http.MethodPost, "/merchant", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", xff)
+		req.Header.Set(apisign.HeaderKeyID, "mk_test")
+		req.Header.Set(apisign.HeaderTimestamp, strconv.FormatInt(time.Now().Unix(), 10))
+		req.Header.Set(apisign.HeaderNonce, "n1")
+		req.Header.Set(apisign.HeaderSignature, "sig")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// 非受信任来源伪造白名单 IP: 按连接对端地址判断，拒绝
+	assert.Equal(t, http.StatusForbidden, send("198.51.100.1:4321", "203.0.113.7"))
+	// 受信任代理转发的白名单 IP: 通过 IP 白名单 (密钥无法解密，止于验签)
+	assert.Equal(t, http.StatusUnauthorized, send("10.1.2.3:4321", "203.0.113.7"))
+	// 受信任代理转发的非白名单 IP: 拒绝
+	assert.Equal(t, http.StatusForbidden, send("10.1.2.3:4321", "198.51.100.1"))
+}
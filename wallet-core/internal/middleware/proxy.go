@@ -0,0 +1,12 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// SetTrustedProxies 只采信受信任代理 (app.trusted_proxies) 写入的 X-Forwarded-For / X-Real-IP
+// 其他来源的请求 c.ClientIP() 取连接对端地址，伪造的转发头不会影响 IP 白名单、限流和审计日志
+// 所有 gin.Engine 创建后都必须调用 (gin 默认信任所有代理)
+func SetTrustedProxies(r *gin.Engine, proxies []string) error {
+	return r.SetTrustedProxies(proxies)
+}
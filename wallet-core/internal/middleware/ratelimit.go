@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/logger"
+	"wallet-core/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RateLimit 按规则 rule 限流 (规则见配置 ratelimit.rules)
+// 按用户 / API Key 限流的规则需要挂在 JWTAuth / MerchantAuth 之后，否则会退化为按 IP
+// 按 IP 限流取 c.ClientIP()，只采信受信任代理转发的地址 (见 SetTrustedProxies)
+// 命中时返回 429 并带上 RateLimit-* 和 Retry-After 头；Redis 不可用且 fail_open=false 时返回 503
+func RateLimit(l *ratelimit.Limiter, rule string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := ratelimit.Identity{
+			IP:     c.ClientIP(),
+			UserID: c.GetUint64(ContextUserID),
+		}
+		if key := CurrentAPIKey(c); key != nil {
+			id.APIKey = key.KeyID
+		}
+
+		res, err := l.Allow(c.Request.Context(), rule, id)
+		if err != nil {
+			logger.Warn("限流器不可用", zap.String("rule", rule), zap.Bool("allowed", res.Allowed), zap.Error(err))
+			if !res.Allowed {
+				abort(c, http.StatusServiceUnavailable, errno.ErrUnavailable)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		for k, v := range res.Headers() {
+			c.Header(k, v)
+		}
+		if !res.Allowed {
+			abort(c, http.StatusTooManyRequests, errno.ErrTooManyRequests)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"wallet-core/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextRequestID gin.Context 中保存请求 ID 的 key
+const ContextRequestID = "request_id"
+
+// RequestID 沿用请求头 X-Request-Id (不合法时重新生成)，写入响应头和 request context
+// 网关调用后端服务时由 interceptor.ClientPropagation 通过 x-request-id metadata 继续传递
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := requestid.Ensure(c.GetHeader(requestid.Header))
+		c.Set(ContextRequestID, id)
+		c.Header(requestid.Header, id)
+		c.Request = c.Request.WithContext(requestid.WithContext(c.Request.Context(), id))
+		c.Next()
+	}
+}
@@ -0,0 +1,76 @@
+package model
+
+import "time"
+
+// AdminUser 后台管理员，与终端用户 (users) 完全独立
+// 登录必须同时校验密码和 TOTP，TOTP 密钥经 KMS 加密后存储
+type AdminUser struct {
+	ID               uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Username         string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"username"`
+	Email            string     `gorm:"type:varchar(255);not null" json:"email"`
+	PasswordHash     string     `gorm:"type:varchar(255);not null" json:"-"`
+	Role             string     `gorm:"type:varchar(32);not null" json:"role"` // reviewer, finance, ops, compliance, superadmin
+	Disabled         bool       `gorm:"not null;default:false" json:"disabled"`
+	KeyID            string     `gorm:"type:varchar(64);not null" json:"-"` // 加密 TOTP 密钥的 KMS KeyID
+	SecretCiphertext string     `gorm:"type:text;not null" json:"-"`        // base64(KMS 加密后的 TOTP 密钥)
+	LastUsedStep     int64      `gorm:"not null;default:0" json:"-"`        // 最近一次通过校验的时间步，防止验证码重放
+	LastLoginAt      *time.Time `json:"last_login_at,omitempty"`
+	CreatedBy        uint64     `gorm:"not null;default:0" json:"created_by"` // 0 表示命令行初始化
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func (AdminUser) TableName() string {
+	return "admin_users"
+}
+
+// 管理员角色
+const (
+	AdminRoleReviewer   = "reviewer"   // 提现审核
+	AdminRoleFinance    = "finance"    // 财务: 提现审核 + 审计查询
+	AdminRoleOps        = "ops"        // 运维: 链上交易加速 / 取消
+	AdminRoleCompliance = "compliance" // 合规: 实名认证审核 + 审计查询
+	AdminRoleSuperAdmin = "superadmin" // 全部权限，包括管理员账号管理
+)
+
+// AdminAuditLog 管理员操作审计日志 (只追加，不修改)
+type AdminAuditLog struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	AdminID    uint64    `gorm:"not null;index" json:"admin_id"` // 登录失败时为 0
+	Username   string    `gorm:"type:varchar(64);not null" json:"username"`
+	Action     string    `gorm:"type:varchar(64);not null;index" json:"action"`
+	Resource   string    `gorm:"type:varchar(64)" json:"resource"`
+	ResourceID string    `gorm:"type:varchar(64)" json:"resource_id"`
+	Success    bool      `gorm:"not null" json:"success"`
+	Detail     string    `gorm:"type:text" json:"detail"`
+	IP         string    `gorm:"type:varchar(64)" json:"ip"`
+	UserAgent  string    `gorm:"type:varchar(255)" json:"user_agent"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
+
+func (AdminAuditLog) TableName() string {
+	return "admin_audit_logs"
+}
+
+// 审计动作
+const (
+	AuditActionLogin             = "admin.login"
+	AuditActionLogout            = "admin.logout"
+	AuditActionAdminCreate       = "admin.create"
+	AuditActionWithdrawalReview  = "withdrawal.review"
+	AuditActionWithdrawalSpeed   = "withdrawal.speed_up"
+	AuditActionWithdrawalCancel  = "withdrawal.cancel_tx"
+	AuditActionWithdrawalClaim   = "withdrawal.claim"
+	AuditActionWithdrawalRelease = "withdrawal.release"
+	AuditActionWithdrawalAssign  = "withdrawal.assign"
+	AuditActionMerchantCreate    = "merchant.create"
+	AuditActionAPIKeyCreate      = "merchant.api_key.create"
+	AuditActionAPIKeyRevoke      = "merchant.api_key.revoke"
+	AuditActionMerchantAccount   = "merchant.account_set"
+	AuditActionKYCReview         = "kyc.review"
+	AuditActionKYCTierSet        = "kyc.tier_set"
+	AuditActionKYCDocumentView   = "kyc.document_view"
+	AuditActionTenantCreate      = "tenant.create"
+	AuditActionTenantStatus      = "tenant.status"
+	AuditActionTenantChainSet    = "tenant.chain_set"
+)
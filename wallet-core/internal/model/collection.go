@@ -16,11 +16,30 @@ type Collection struct {
 	FromAddress string          `gorm:"type:varchar(42);not null"`
 	ToAddress   string          `gorm:"type:varchar(42);not null"`
 	Amount      decimal.Decimal `gorm:"type:decimal(30,0);not null"` // 实际归集金额 (余额 - Gas)
-	GasFee      decimal.Decimal `gorm:"type:decimal(30,0);not null"` // 消耗的 Gas 费
+	GasFee      decimal.Decimal `gorm:"type:decimal(30,0);not null"` // Gas 费: 广播时为预估值，确认后更新为实际值
+
+	// Gas 明细 (预估 vs 实际)
+	Nonce     uint64          `gorm:"not null;default:0"`
+	GasLimit  uint64          `gorm:"not null;default:0"`                    // 预估 Gas
+	TxType    uint8           `gorm:"not null;default:0"`                    // 0: Legacy, 2: EIP-1559
+	GasPrice  decimal.Decimal `gorm:"type:decimal(30,0);not null;default:0"` // Gas 单价 (Wei); EIP-1559 交易为 MaxFeePerGas
+	GasTipCap decimal.Decimal `gorm:"type:decimal(30,0);not null;default:0"` // EIP-1559: MaxPriorityFeePerGas (Wei)
+	GasUsed   uint64          `gorm:"not null;default:0"`                    // 实际消耗 Gas
 
 	// 状态
-	Status string `gorm:"type:varchar(20);not null;default:'pending'"` // pending, confirmed, failed
+	Status        string `gorm:"type:varchar(20);not null;default:'pending'"` // pending, confirmed, failed
+	BlockNumber   uint64 `gorm:"not null;default:0"`
+	Confirmations uint64 `gorm:"not null;default:0"`
+	FailReason    string `gorm:"type:text"`
+	ConfirmedAt   *time.Time
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// 归集状态
+const (
+	CollectionStatusPending   = "pending"
+	CollectionStatusConfirmed = "confirmed"
+	CollectionStatusFailed    = "failed"
+)
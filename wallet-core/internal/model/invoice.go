@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// 账单状态
+// pending -> underpaid (部分付款) -> paid / overpaid；pending / underpaid 到期未付清 -> expired
+// paid 之后再收到付款 -> overpaid；expired 之后的付款只记录，状态不变
+const (
+	InvoiceStatusPending   = "pending"
+	InvoiceStatusUnderpaid = "underpaid"
+	InvoiceStatusPaid      = "paid"
+	InvoiceStatusOverpaid  = "overpaid"
+	InvoiceStatusExpired   = "expired"
+)
+
+// 收款地址模式
+const (
+	InvoiceAddressDedicated = "dedicated" // 每张账单派生一个新地址，按地址匹配，可识别少付 / 多付
+	InvoiceAddressShared    = "shared"    // 商户复用同一地址，账单金额带唯一尾数，只有金额完全一致的付款才能匹配
+)
+
+// Invoice 商户收款账单
+// 金额以币本位记账 (Amount / PaidAmount)；法币计价时 PriceAmount / PriceCurrency 为原始报价，ExchangeRate 为创建时锁定的汇率
+type Invoice struct {
+	ID            uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	PublicID      string          `gorm:"type:varchar(40);not null;uniqueIndex" json:"public_id"` // 托管收银台使用的不可猜测 ID
+	MerchantID    uint64          `gorm:"not null;uniqueIndex:idx_invoices_merchant_order" json:"merchant_id"`
+	OrderID       string          `gorm:"type:varchar(64);not null;uniqueIndex:idx_invoices_merchant_order" json:"order_id"` // 商户订单号，同一商户内唯一
+	Description   string          `gorm:"type:varchar(255)" json:"description,omitempty"`
+	Currency      string          `gorm:"type:varchar(20);not null" json:"currency"` // 收款币种 (ETH, BTC)
+	Amount        decimal.Decimal `gorm:"type:decimal(32,18);not null" json:"amount"`
+	PaidAmount    decimal.Decimal `gorm:"type:decimal(32,18);not null;default:0" json:"paid_amount"`
+	PriceCurrency string          `gorm:"type:varchar(8)" json:"price_currency,omitempty"` // 法币 (USD)，币本位报价时为空
+	PriceAmount   decimal.Decimal `gorm:"type:decimal(32,8);not null;default:0" json:"price_amount"`
+	ExchangeRate  decimal.Decimal `gorm:"type:decimal(32,8);not null;default:0" json:"exchange_rate"` // 1 Currency = ExchangeRate PriceCurrency
+	AddressMode   string          `gorm:"type:varchar(16);not null" json:"address_mode"`
+	AddressID     uint64          `gorm:"not null;index" json:"-"` // 关联 Address.ID (Deposit.BlockAppID)
+	Address       string          `gorm:"type:varchar(255);not null" json:"address"`
+	Status        string          `gorm:"type:varchar(16);not null;index" json:"status"`
+	RedirectURL   string          `gorm:"type:varchar(512)" json:"redirect_url,omitempty"` // 付款完成后收银台跳转地址
+	ExpiresAt     time.Time       `gorm:"not null;index" json:"expires_at"`
+	PaidAt        *time.Time      `json:"paid_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+func (Invoice) TableName() string {
+	return "invoices"
+}
+
+// Open 账单是否还在等待付款
+func (i *Invoice) Open() bool {
+	return i.Status == InvoiceStatusPending || i.Status == InvoiceStatusUnderpaid
+}
+
+// InvoicePayment 匹配到账单的链上充值，一笔充值只能计入一张账单
+type InvoicePayment struct {
+	ID        uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	InvoiceID uint64          `gorm:"not null;index" json:"invoice_id"`
+	DepositID uint64          `gorm:"not null;uniqueIndex" json:"deposit_id"`
+	TxHash    string          `gorm:"type:varchar(255);not null" json:"tx_hash"`
+	Amount    decimal.Decimal `gorm:"type:decimal(32,18);not null" json:"amount"`
+	Late      bool            `gorm:"not null;default:false" json:"late"` // 账单过期后才到账
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func (InvoicePayment) TableName() string {
+	return "invoice_payments"
+}
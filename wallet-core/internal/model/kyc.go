@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+// 实名认证 (KYC) 等级，从低到高
+const (
+	KYCTierUnverified = "unverified" // 注册默认
+	KYCTierBasic      = "basic"      // 基础认证: 身份证件
+	KYCTierFull       = "full"       // 高级认证: 身份证件 + 地址证明等
+)
+
+// KYCTiers 全部等级，从低到高
+var KYCTiers = []string{KYCTierUnverified, KYCTierBasic, KYCTierFull}
+
+// KYC 申请状态
+const (
+	KYCStatusPending  = "pending"
+	KYCStatusApproved = "approved"
+	KYCStatusRejected = "rejected"
+)
+
+// KYCApplication 用户提交的等级申请，管理员审核证件后通过 / 拒绝
+// 证件号码只保存脱敏后的值，原件在 KYCDocument 中 (加密存储)
+type KYCApplication struct {
+	ID                   uint64        `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID               uint64        `gorm:"not null;index" json:"user_id"`
+	CurrentTier          string        `gorm:"type:varchar(16);not null" json:"current_tier"` // 提交时的等级
+	Tier                 string        `gorm:"type:varchar(16);not null" json:"tier"`         // 申请的等级
+	Status               string        `gorm:"type:varchar(16);not null;index" json:"status"`
+	FullName             string        `gorm:"type:varchar(128);not null" json:"full_name"`
+	Country              string        `gorm:"type:varchar(2);not null" json:"country"` // ISO 3166-1 alpha-2
+	DocumentType         string        `gorm:"type:varchar(32);not null" json:"document_type"`
+	DocumentNumberMasked string        `gorm:"type:varchar(64);not null" json:"document_number"`
+	DocumentExpiry       *time.Time    `json:"document_expiry,omitempty"`
+	ReviewerID           uint64        `gorm:"not null;default:0" json:"reviewer_id"`
+	ReviewRemark         string        `gorm:"type:text" json:"review_remark,omitempty"`
+	ReviewedAt           *time.Time    `json:"reviewed_at,omitempty"`
+	CreatedAt            time.Time     `json:"created_at"`
+	UpdatedAt            time.Time     `json:"updated_at"`
+	Documents            []KYCDocument `gorm:"foreignKey:ApplicationID" json:"documents,omitempty"`
+}
+
+func (KYCApplication) TableName() string {
+	return "kyc_applications"
+}
+
+// 证件类型
+const (
+	KYCDocumentPassport       = "passport"
+	KYCDocumentIDCard         = "id_card"
+	KYCDocumentDriverLicense  = "driver_license"
+	KYCDocumentProofOfAddress = "proof_of_address"
+)
+
+// KYCDocument 证件文件元数据
+// 文件内容经 KMS 加密后写入 kyc.document_dir，StoragePath 为相对该目录的路径
+type KYCDocument struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ApplicationID uint64    `gorm:"not null;index" json:"application_id"`
+	UserID        uint64    `gorm:"not null" json:"user_id"`
+	FileName      string    `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType   string    `gorm:"type:varchar(64);not null" json:"content_type"`
+	Size          int64     `gorm:"not null" json:"size"`
+	SHA256        string    `gorm:"column:sha256;type:char(64);not null" json:"sha256"` // 明文摘要，解密后校验
+	KMSKeyID      string    `gorm:"column:kms_key_id;type:varchar(64);not null" json:"-"`
+	StoragePath   string    `gorm:"type:varchar(255);not null" json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (KYCDocument) TableName() string {
+	return "kyc_documents"
+}
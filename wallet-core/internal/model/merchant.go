@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// Merchant B2B 商户账号 (通过 API Key 接入，不使用终端用户登录)
+type Merchant struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string    `gorm:"type:varchar(128);not null" json:"name"`
+	Email     string    `gorm:"type:varchar(255);not null" json:"email"`
+	Status    string    `gorm:"type:varchar(16);not null;default:'active'" json:"status"`                                                       // active, disabled
+	UserID    uint64    `gorm:"not null;default:0;index;uniqueIndex:idx_merchants_settlement_user,where:user_id <> 0" json:"user_id,omitempty"` // 结算账户 (钱包用户)，其充值 / 提现会通知商户
+	CreatedBy uint64    `gorm:"not null;default:0" json:"created_by"`                                                                           // 创建的管理员
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Merchant) TableName() string {
+	return "merchants"
+}
+
+// 商户状态
+const (
+	MerchantStatusActive   = "active"
+	MerchantStatusDisabled = "disabled"
+)
+
+// MerchantAPIKey 商户 API Key
+// HMAC 验签需要服务端持有密钥原文，因此密钥经 KMS 加密存储 (不可用单向哈希)
+// SecretHash 只用于展示 / 核对，明文仅在创建时返回一次
+type MerchantAPIKey struct {
+	ID               uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	MerchantID       uint64     `gorm:"not null;index" json:"merchant_id"`
+	KeyID            string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"key_id"` // 公开的 Key ID (X-Api-Key)
+	Name             string     `gorm:"type:varchar(128)" json:"name"`
+	KMSKeyID         string     `gorm:"column:kms_key_id;type:varchar(64);not null" json:"-"`
+	SecretCiphertext string     `gorm:"type:text;not null" json:"-"`                    // base64(KMS 加密后的密钥)
+	SecretHash       string     `gorm:"type:varchar(64);not null" json:"secret_sha256"` // 密钥 SHA-256 指纹
+	Scopes           StringList `gorm:"type:text" json:"scopes"`
+	IPAllowlist      StringList `gorm:"column:ip_allowlist;type:text" json:"ip_allowlist"` // IP 或 CIDR，为空不限制
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
+	CreatedBy        uint64     `gorm:"not null;default:0" json:"created_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func (MerchantAPIKey) TableName() string {
+	return "merchant_api_keys"
+}
+
+// Usable 未吊销且未过期
+func (k *MerchantAPIKey) Usable(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// UserMFA 用户两步验证 (TOTP)
+// 密钥经 KMS 加密后存储，数据库泄露也无法还原验证码
+type UserMFA struct {
+	ID               uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID           uint64     `gorm:"not null;uniqueIndex" json:"user_id"`
+	KeyID            string     `gorm:"type:varchar(64);not null" json:"-"` // 加密 TOTP 密钥的 KMS KeyID
+	SecretCiphertext string     `gorm:"type:text;not null" json:"-"`        // base64(KMS 加密后的 TOTP 密钥)
+	Enabled          bool       `gorm:"not null;default:false" json:"enabled"`
+	LastUsedStep     int64      `gorm:"not null;default:0" json:"-"` // 最近一次通过校验的时间步，防止验证码重放
+	BackupCodes      StringList `gorm:"type:text" json:"-"`          // 备用码 SHA-256，使用后移除
+	EnabledAt        *time.Time `json:"enabled_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func (UserMFA) TableName() string {
+	return "user_mfa"
+}
@@ -9,13 +9,17 @@ import (
 
 // User 用户表
 type User struct {
-	ID           uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
-	Username     string         `gorm:"type:varchar(255);not null;unique" json:"username"`
-	Email        string         `gorm:"type:varchar(255);not null;unique" json:"email"`
-	PasswordHash string         `gorm:"type:varchar(255);not null" json:"-"` // 不返回密码
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID          uint64         `gorm:"not null;default:1;uniqueIndex:idx_users_tenant_username,priority:1;uniqueIndex:idx_users_tenant_email,priority:1" json:"tenant_id"` // 用户名 / 邮箱在租户内唯一
+	Username          string         `gorm:"type:varchar(255);not null;uniqueIndex:idx_users_tenant_username,priority:2" json:"username"`
+	Email             string         `gorm:"type:varchar(255);not null;uniqueIndex:idx_users_tenant_email,priority:2" json:"email"`
+	PasswordHash      string         `gorm:"type:varchar(255);not null" json:"-"`                                            // 不返回密码
+	PasswordChangedAt *time.Time     `json:"password_changed_at,omitempty"`                                                  // 风控: 改密后短时间内提现
+	EmailVerifiedAt   *time.Time     `json:"email_verified_at,omitempty"`                                                    // 为空表示邮箱未验证
+	KYCTier           string         `gorm:"column:kyc_tier;type:varchar(16);not null;default:'unverified'" json:"kyc_tier"` // 实名认证等级，见 model.KYCTier*
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// 关联
 	Accounts []Account `gorm:"foreignKey:UserID" json:"accounts,omitempty"`
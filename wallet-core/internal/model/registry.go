@@ -4,11 +4,30 @@ package model
 // 新增表时，只需要在这里添加即可，不需要修改 main.go
 func AllModels() []interface{} {
 	return []interface{}{
+		&Tenant{},
+		&TenantChain{},
 		&User{},
+		&UserMFA{},
+		&UserToken{},
+		&KYCApplication{},
+		&KYCDocument{},
+		&AdminUser{},
+		&AdminAuditLog{},
+		&Merchant{},
+		&MerchantAPIKey{},
+		&MerchantWebhook{},
+		&Invoice{},
+		&InvoicePayment{},
+		&WebhookDelivery{},
+		&WebhookAttempt{},
 		&Account{},
 		&Address{},
 		&Deposit{},
 		&Withdrawal{},
+		&Transfer{},
+		&TxReplacement{},
+		&RiskRule{},
+		&ScreeningResult{},
 		&Collection{},
 		&OutboxMessage{},
 	}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RiskRule 风控规则 (risk.source=db 时使用)
+// 规则类型与参数含义见 internal/service/risk，修改后由风控引擎定时热加载
+type RiskRule struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string    `gorm:"type:varchar(64);not null;uniqueIndex" json:"name"`
+	Type      string    `gorm:"type:varchar(64);not null" json:"type"`
+	Score     int       `gorm:"not null" json:"score"`
+	Params    string    `gorm:"type:text" json:"params"` // JSON: {"multiple": 5, "min_history": 3}
+	Enabled   bool      `gorm:"not null;default:true" json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (RiskRule) TableName() string {
+	return "risk_rules"
+}
+
+// StringList 以 JSON 数组形式存储的字符串列表
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	return string(b), err
+}
+
+func (l *StringList) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	case []byte:
+		return json.Unmarshal(v, l)
+	default:
+		return fmt.Errorf("StringList: 不支持的类型 %T", src)
+	}
+}
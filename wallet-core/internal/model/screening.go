@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// ScreeningResult 制裁名单筛查命中记录 (合规审计用，只增不改)
+type ScreeningResult struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Direction   string    `gorm:"type:varchar(16);not null;index" json:"direction"` // deposit, withdrawal
+	Chain       string    `gorm:"type:varchar(20);not null" json:"chain"`
+	UserID      uint64    `gorm:"not null;index" json:"user_id"`
+	Address     string    `gorm:"type:varchar(255);not null;index" json:"address"` // 命中名单的地址 (充值付款方 / 提现收款方)
+	TxHash      string    `gorm:"type:varchar(255)" json:"tx_hash"`                // 充值交易 Hash (提现被拦截时为空)
+	Amount      string    `gorm:"type:varchar(64)" json:"amount"`
+	ListSource  string    `gorm:"type:varchar(255);not null" json:"list_source"` // 命中的名单文件
+	Action      string    `gorm:"type:varchar(16);not null" json:"action"`       // blocked, quarantined
+	ReferenceID uint64    `gorm:"not null;default:0" json:"reference_id"`        // 被隔离的 Deposit.ID
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (ScreeningResult) TableName() string {
+	return "screening_results"
+}
+
+// 筛查方向 / 处置动作
+const (
+	ScreeningDirectionDeposit    = "deposit"
+	ScreeningDirectionWithdrawal = "withdrawal"
+
+	ScreeningActionBlocked     = "blocked"     // 提现在创建时被拦截
+	ScreeningActionQuarantined = "quarantined" // 充值被隔离，不入账
+)
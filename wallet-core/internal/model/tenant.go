@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Tenant 租户 (品牌)
+// 用户 / 充值地址 / 充值 / 提现都带 tenant_id，按租户隔离 (见 pkg/tenant)
+// ID 为 1 的默认租户由迁移创建，多租户之前的数据都属于它
+type Tenant struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code      string    `gorm:"type:varchar(32);not null;uniqueIndex" json:"code"` // X-Tenant 头使用的代码
+	Name      string    `gorm:"type:varchar(128);not null" json:"name"`
+	Status    string    `gorm:"type:varchar(16);not null;default:'active'" json:"status"` // active, disabled
+	CreatedBy uint64    `gorm:"not null;default:0" json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Tenant) TableName() string {
+	return "tenants"
+}
+
+// 租户状态
+const (
+	TenantStatusActive   = "active"
+	TenantStatusDisabled = "disabled"
+)
+
+// TenantChain 租户在某条链上的配置
+// XPub 为账户级扩展公钥 (m/44'/coin'/account')，充值地址按 XPub/0/index 派生，index 按租户独立计数
+// Custody 为 internal 时 XPub 由本系统主密钥派生 (account = 租户 ID)，归集服务可以签名；
+// external 时私钥在租户自己手里，只派生地址和入账，不做归集
+// 默认租户不设置 XPub，沿用系统主密钥 (兼容多租户之前分配的地址)
+type TenantChain struct {
+	ID              uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID        uint64          `gorm:"not null;uniqueIndex:idx_tenant_chain" json:"tenant_id"`
+	Chain           string          `gorm:"type:varchar(20);not null;uniqueIndex:idx_tenant_chain" json:"chain"`
+	XPub            string          `gorm:"column:xpub;type:varchar(128);not null;default:''" json:"xpub"`
+	Custody         string          `gorm:"type:varchar(16);not null;default:'internal'" json:"custody"`
+	HotWallet       string          `gorm:"type:varchar(255);not null;default:''" json:"hot_wallet"`              // 归集目标兼出款地址，为空使用全局热钱包; 须为 m/44'/coin'/租户 ID'/0/0，否则该租户的提现不广播
+	MinWithdrawal   decimal.Decimal `gorm:"type:decimal(32,18);not null;default:0" json:"min_withdrawal"`         // 0 表示不限制
+	MaxWithdrawal   decimal.Decimal `gorm:"type:decimal(32,18);not null;default:0" json:"max_withdrawal"`         // 单笔上限，0 表示不限制
+	DailyWithdrawal decimal.Decimal `gorm:"type:decimal(32,18);not null;default:0" json:"daily_withdrawal_limit"` // 租户全部用户 24 小时合计，0 表示不限制
+	UpdatedBy       uint64          `gorm:"not null;default:0" json:"updated_by"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+func (TenantChain) TableName() string {
+	return "tenant_chains"
+}
+
+// 密钥托管方式
+const (
+	TenantCustodyInternal = "internal"
+	TenantCustodyExternal = "external"
+)
@@ -9,41 +9,131 @@ import (
 // Address 充值地址表
 type Address struct {
 	ID          uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantID    uint64    `gorm:"not null;default:1;uniqueIndex:idx_tenant_chain_path,priority:1" json:"tenant_id"`
 	UserID      uint64    `gorm:"not null;index" json:"user_id"`
-	Chain       string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_chain_address;uniqueIndex:idx_chain_path" json:"chain"` // bitcoin, ethereum
+	Chain       string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_chain_address;uniqueIndex:idx_tenant_chain_path,priority:2" json:"chain"` // bitcoin, ethereum
 	Address     string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_chain_address" json:"address"`
-	HDPathIndex int       `gorm:"not null;uniqueIndex:idx_chain_path" json:"hd_path_index"` // BIP-44 address_index
+	HDPathIndex int       `gorm:"not null;uniqueIndex:idx_tenant_chain_path,priority:3" json:"hd_path_index"` // BIP-44 address_index，每个租户独立计数
 	CreatedAt   time.Time `json:"created_at"`
 }
 
 // Deposit 充值记录表
+// (user_id, created_at, id) 复合索引用于交易记录游标分页
 type Deposit struct {
-	ID          uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
-	UserID      uint64          `gorm:"not null;index" json:"user_id"`
-	BlockAppID  uint64          `gorm:"not null;index" json:"block_app_id"` // 关联 Address.ID
-	TxHash      string          `gorm:"type:varchar(255);not null;uniqueIndex:idx_tx_app" json:"tx_hash"`
-	Amount      decimal.Decimal `gorm:"type:decimal(32,18);not null" json:"amount"`
-	BlockHeight uint64          `gorm:"not null" json:"block_height"`
-	Status      string          `gorm:"type:varchar(20);not null" json:"status"` // pending, confirmed
-	CreatedAt   time.Time       `json:"created_at"`
-	ConfirmedAt *time.Time      `json:"confirmed_at,omitempty"`
+	ID            uint64          `gorm:"primaryKey;autoIncrement;index:idx_deposits_user_created,priority:3,sort:desc" json:"id"`
+	TenantID      uint64          `gorm:"not null;default:1;index" json:"tenant_id"`
+	UserID        uint64          `gorm:"not null;index;index:idx_deposits_user_created,priority:1" json:"user_id"`
+	BlockAppID    uint64          `gorm:"not null;index" json:"block_app_id"`                // 关联 Address.ID
+	Chain         string          `gorm:"type:varchar(20);not null;default:''" json:"chain"` // ETH, BTC (与 Address.Chain 一致)
+	FromAddress   string          `gorm:"type:varchar(255)" json:"from_address"`             // 付款方地址 (制裁名单筛查)
+	TxHash        string          `gorm:"type:varchar(255);not null;uniqueIndex:idx_tx_app" json:"tx_hash"`
+	Amount        decimal.Decimal `gorm:"type:decimal(32,18);not null" json:"amount"`
+	BlockHeight   uint64          `gorm:"not null" json:"block_height"`
+	Confirmations uint64          `gorm:"not null;default:0" json:"confirmations"`
+	Status        string          `gorm:"type:varchar(20);not null" json:"status"` // pending, confirmed, quarantined
+	CreatedAt     time.Time       `gorm:"index:idx_deposits_user_created,priority:2,sort:desc" json:"created_at"`
+	ConfirmedAt   *time.Time      `json:"confirmed_at,omitempty"`
 }
 
+// 充值状态
+const (
+	DepositStatusPending     = "pending"
+	DepositStatusConfirmed   = "confirmed"
+	DepositStatusQuarantined = "quarantined" // 付款方命中制裁名单，冻结不入账，等待合规处理
+)
+
 // Withdrawal 提现记录表
+// (user_id, created_at, id) 复合索引用于交易记录游标分页
 type Withdrawal struct {
-	ID                uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
-	UserID            uint64          `gorm:"not null;index" json:"user_id"`
+	ID                uint64          `gorm:"primaryKey;autoIncrement;index:idx_withdrawals_user_created,priority:3,sort:desc" json:"id"`
+	TenantID          uint64          `gorm:"not null;default:1;index" json:"tenant_id"`
+	UserID            uint64          `gorm:"not null;index;index:idx_withdrawals_user_created,priority:1" json:"user_id"`
 	ToAddress         string          `gorm:"type:varchar(255);not null" json:"to_address"`
 	Amount            decimal.Decimal `gorm:"type:decimal(32,18);not null" json:"amount"`
 	Chain             string          `gorm:"type:varchar(20);not null" json:"chain"`
-	TxHash            string          `gorm:"type:varchar(255)" json:"tx_hash"`                                 // 提现发出后的 Hash
-	Status            string          `gorm:"type:varchar(32);not null;default:'pending_review'" json:"status"` // pending_review, pending_broadcast, completed, failed
+	TxHash            string          `gorm:"type:varchar(255);index:idx_withdrawals_tx_hash" json:"tx_hash"`   // 提现发出后的 Hash
+	Status            string          `gorm:"type:varchar(32);not null;default:'pending_review'" json:"status"` // pending_review, risk_hold, time_locked, pending_broadcast, broadcasting, broadcasted, completed, failed
 	RequiredApprovals int             `gorm:"not null;default:2" json:"required_approvals"`
 	CurrentApprovals  int             `gorm:"not null;default:0" json:"current_approvals"`
-	CreatedAt         time.Time       `json:"created_at"`
-	UpdatedAt         time.Time       `json:"updated_at"`
+
+	// 定时 / 时间锁: 审批通过后需等到该时间才能广播 (nil 表示审批后立即执行)
+	ExecuteAfter *time.Time `gorm:"index" json:"execute_after,omitempty"`
+
+	// 风控评分 (创建时由风控引擎写入，审核时展示给管理员)
+	RiskScore   int        `gorm:"not null;default:0;index" json:"risk_score"`
+	RiskReasons StringList `gorm:"type:text" json:"risk_reasons"`
+
+	// 审核认领: 认领后其他管理员不能审核，超过 ClaimTTL 未处理自动失效 (见 service.ReviewClaimTTL)
+	AssigneeID uint64     `gorm:"not null;default:0;index" json:"assignee_id"`
+	ClaimedAt  *time.Time `json:"claimed_at,omitempty"`
+
+	// 链上确认跟踪 (TxTracker 维护)
+	FromAddress   string          `gorm:"type:varchar(255)" json:"from_address"`                    // 出款地址 (热钱包)
+	RawTx         string          `gorm:"type:text;not null;default:''" json:"-"`                   // 已签名交易 (hex)，广播前落库，广播失败时原样重发
+	Nonce         uint64          `gorm:"not null;default:0" json:"nonce"`                          // 出款交易 nonce，用于识别被替换/丢弃的交易
+	GasLimit      uint64          `gorm:"not null;default:0" json:"gas_limit"`                      // 广播时预估的 Gas
+	TxType        uint8           `gorm:"not null;default:0" json:"tx_type"`                        // 0: Legacy, 2: EIP-1559
+	GasPrice      decimal.Decimal `gorm:"type:decimal(30,0);not null;default:0" json:"gas_price"`   // 广播时的 Gas 单价 (Wei); EIP-1559 交易为 MaxFeePerGas
+	GasTipCap     decimal.Decimal `gorm:"type:decimal(30,0);not null;default:0" json:"gas_tip_cap"` // EIP-1559: MaxPriorityFeePerGas (Wei)
+	GasUsed       uint64          `gorm:"not null;default:0" json:"gas_used"`                       // 上链后实际消耗的 Gas
+	GasFee        decimal.Decimal `gorm:"type:decimal(30,0);not null;default:0" json:"gas_fee"`     // 广播时为预估值，确认后更新为实际值 (Wei)
+	BlockNumber   uint64          `gorm:"not null;default:0" json:"block_number"`
+	Confirmations uint64          `gorm:"not null;default:0" json:"confirmations"`
+	FailReason    string          `gorm:"type:text" json:"fail_reason,omitempty"`
+	ReplaceCount  int             `gorm:"not null;default:0" json:"replace_count"`                              // 加速 / 取消次数
+	ReplaceAction string          `gorm:"type:varchar(16);not null;default:''" json:"replace_action,omitempty"` // 最近一次替换动作; cancel 之后只允许继续加速取消交易
+	BroadcastAt   *time.Time      `json:"broadcast_at,omitempty"`
+	ConfirmedAt   *time.Time      `json:"confirmed_at,omitempty"`
+
+	// 收款地址属于本平台用户时不上链，由站内转账直接结算 (创建即 completed)
+	TransferID uint64 `gorm:"not null;default:0" json:"transfer_id,omitempty"`
+
+	CreatedAt time.Time `gorm:"index:idx_withdrawals_user_created,priority:2,sort:desc" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// 提现状态
+const (
+	WithdrawalStatusPendingReview    = "pending_review"
+	WithdrawalStatusRiskHold         = "risk_hold"   // 风控分数过高，自动挂起等待人工审核
+	WithdrawalStatusTimeLocked       = "time_locked" // 已审批通过，等待 ExecuteAfter 到期
+	WithdrawalStatusPendingBroadcast = "pending_broadcast"
+	WithdrawalStatusBroadcasting     = "broadcasting" // 已签名落库，等待广播 (失败时重发同一笔交易)
+	WithdrawalStatusBroadcasted      = "broadcasted"  // 已广播，等待链上确认
+	WithdrawalStatusCompleted        = "completed"    // 已达到确认数
+	WithdrawalStatusFailed           = "failed"       // 链上执行失败 / 被替换 / 被丢弃
+	WithdrawalStatusCancelled        = "cancelled"    // 取消交易 (同 nonce 自转账) 已上链
+	WithdrawalStatusRejected         = "rejected"
+	WithdrawalStatusUserCancelled    = "user_cancelled" // 用户在执行前主动取消
+)
+
+// TxReplacement 交易替换记录 (Fee Replacement)
+// ETH: 同 nonce 提高 Gas 重发 (BTC 出款尚未上线，暂不支持 RBF)
+// 每次替换都留一条记录，TxTracker 会同时跟踪原交易和所有替换交易，谁先上链以谁为准
+type TxReplacement struct {
+	ID           uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	WithdrawalID uint64          `gorm:"not null;index" json:"withdrawal_id"`
+	Chain        string          `gorm:"type:varchar(20);not null" json:"chain"`
+	Action       string          `gorm:"type:varchar(16);not null" json:"action"`  // speed_up, cancel
+	Trigger      string          `gorm:"type:varchar(16);not null" json:"trigger"` // auto, admin
+	AdminID      uint64          `gorm:"not null;default:0" json:"admin_id"`       // Trigger=admin 时记录操作人
+	Nonce        uint64          `gorm:"not null" json:"nonce"`
+	OldTxHash    string          `gorm:"type:varchar(255);not null" json:"old_tx_hash"`
+	NewTxHash    string          `gorm:"type:varchar(255);not null;index" json:"new_tx_hash"`
+	OldFee       decimal.Decimal `gorm:"type:decimal(30,0);not null" json:"old_fee"` // Gas 单价 / MaxFeePerGas (Wei)
+	NewFee       decimal.Decimal `gorm:"type:decimal(30,0);not null" json:"new_fee"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// 交易替换动作 / 触发方式
+const (
+	ReplaceActionSpeedUp = "speed_up"
+	ReplaceActionCancel  = "cancel"
+
+	ReplaceTriggerAuto  = "auto"
+	ReplaceTriggerAdmin = "admin"
+)
+
 // WithdrawalReview 提现审核记录表
 type WithdrawalReview struct {
 	ID           uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
@@ -58,6 +148,10 @@ func (WithdrawalReview) TableName() string {
 	return "withdrawal_reviews"
 }
 
+func (TxReplacement) TableName() string {
+	return "tx_replacements"
+}
+
 func (Address) TableName() string {
 	return "addresses"
 }
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// 站内转账来源
+const (
+	TransferSourceTransfer   = "transfer"   // 用户发起的站内转账
+	TransferSourceWithdrawal = "withdrawal" // 提现的收款地址属于本平台用户，改为站内结算
+)
+
+// Transfer 站内转账: 两个账户之间直接划转余额，不上链
+// 同一转出用户的幂等键唯一，重复提交返回首次创建的记录；RequestHash 用于识别同一个键被用于不同参数
+type Transfer struct {
+	ID             uint64          `gorm:"primaryKey;autoIncrement;index:idx_transfers_from_created,priority:3,sort:desc" json:"id"`
+	TenantID       uint64          `gorm:"not null;default:1;index" json:"tenant_id"`
+	FromUserID     uint64          `gorm:"not null;index:idx_transfers_from_created,priority:1;uniqueIndex:idx_transfers_idempotency,priority:1,where:idempotency_key <> ''" json:"from_user_id"`
+	ToUserID       uint64          `gorm:"not null;index" json:"to_user_id"`
+	Currency       string          `gorm:"type:varchar(10);not null" json:"currency"`
+	Amount         decimal.Decimal `gorm:"type:decimal(32,18);not null" json:"amount"`
+	Memo           string          `gorm:"type:varchar(255)" json:"memo,omitempty"`
+	Source         string          `gorm:"type:varchar(16);not null;default:'transfer'" json:"source"`
+	WithdrawalID   uint64          `gorm:"not null;default:0;index" json:"withdrawal_id,omitempty"` // Source 为 withdrawal 时关联的提现记录
+	ToAddress      string          `gorm:"type:varchar(255)" json:"to_address,omitempty"`           // Source 为 withdrawal 时的收款地址
+	IdempotencyKey string          `gorm:"type:varchar(64);not null;default:'';uniqueIndex:idx_transfers_idempotency,priority:2" json:"idempotency_key,omitempty"`
+	RequestHash    string          `gorm:"type:varchar(64);not null;default:''" json:"-"`
+	CreatedAt      time.Time       `gorm:"index:idx_transfers_from_created,priority:2,sort:desc" json:"created_at"`
+}
+
+func (Transfer) TableName() string {
+	return "transfers"
+}
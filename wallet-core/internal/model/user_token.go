@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// UserToken 邮件中发出的一次性令牌 (邮箱验证 / 重置密码)
+// 只保存令牌的 SHA-256，数据库泄露也无法还原出可用的链接
+type UserToken struct {
+	ID        uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uint64     `gorm:"not null;index" json:"user_id"`
+	Purpose   string     `gorm:"type:varchar(32);not null" json:"purpose"` // email_verify, password_reset
+	TokenHash string     `gorm:"type:char(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"` // 已使用或被新令牌作废
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
+
+// 令牌用途
+const (
+	TokenPurposeEmailVerify   = "email_verify"
+	TokenPurposePasswordReset = "password_reset"
+)
@@ -0,0 +1,83 @@
+package model
+
+import "time"
+
+// MerchantWebhook 商户的通知地址 (endpoint)，每个商户可以配置多个，按事件类型订阅
+// 签名密钥与 API Key 一样经 KMS 加密存储，明文只在创建 / 轮换时返回一次
+type MerchantWebhook struct {
+	ID               uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	MerchantID       uint64     `gorm:"not null;index" json:"merchant_id"`
+	URL              string     `gorm:"type:varchar(512);not null" json:"url"`
+	Description      string     `gorm:"type:varchar(255)" json:"description,omitempty"`
+	Events           StringList `gorm:"type:text" json:"events"` // 订阅的事件，为空订阅全部
+	Enabled          bool       `gorm:"not null;default:true" json:"enabled"`
+	KMSKeyID         string     `gorm:"column:kms_key_id;type:varchar(64);not null" json:"-"`
+	SecretCiphertext string     `gorm:"type:text;not null" json:"-"`
+	SecretHash       string     `gorm:"type:varchar(64);not null" json:"secret_sha256"`
+	SecretRotatedAt  *time.Time `json:"secret_rotated_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func (MerchantWebhook) TableName() string {
+	return "merchant_webhooks"
+}
+
+// Subscribed 是否订阅了该事件
+func (w *MerchantWebhook) Subscribed(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// 通知投递状态
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed" // 重试次数用尽
+)
+
+// WebhookDelivery 一个事件发往一个通知地址的投递记录，由 asynq 任务投递并重试
+// 同一事件在同一地址上只有一条原始投递 (部分唯一索引 idx_webhook_deliveries_event)；
+// 重放时新建一条记录，EventID 与 Payload 不变，ReplayOf 指向原始投递
+type WebhookDelivery struct {
+	ID           uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	MerchantID   uint64     `gorm:"not null;index" json:"merchant_id"`
+	EndpointID   uint64     `gorm:"not null;default:0;uniqueIndex:idx_webhook_deliveries_event,where:replay_of = 0" json:"endpoint_id"`
+	EventID      string     `gorm:"type:varchar(40);not null;default:'';uniqueIndex:idx_webhook_deliveries_event" json:"event_id"`
+	InvoiceID    uint64     `gorm:"not null;default:0;index" json:"invoice_id,omitempty"` // 账单事件关联的账单
+	Event        string     `gorm:"type:varchar(32);not null" json:"event"`
+	Payload      string     `gorm:"type:text;not null" json:"-"`
+	Status       string     `gorm:"type:varchar(16);not null;index" json:"status"`
+	Attempts     int        `gorm:"not null;default:0" json:"attempts"`
+	ResponseCode int        `gorm:"not null;default:0" json:"response_code"` // 最后一次尝试的响应码，0 表示未收到响应
+	LastError    string     `gorm:"type:text" json:"last_error,omitempty"`
+	ReplayOf     uint64     `gorm:"not null;default:0" json:"replay_of,omitempty"`
+	DeliveredAt  *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookAttempt 一次投递尝试 (投递日志，只追加)
+type WebhookAttempt struct {
+	ID           uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	DeliveryID   uint64    `gorm:"not null;index" json:"delivery_id"`
+	ResponseCode int       `gorm:"not null;default:0" json:"response_code"`
+	Error        string    `gorm:"type:text" json:"error,omitempty"`
+	DurationMs   int64     `gorm:"not null;default:0" json:"duration_ms"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (WebhookAttempt) TableName() string {
+	return "webhook_attempts"
+}
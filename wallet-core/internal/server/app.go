@@ -26,6 +26,7 @@ type App struct {
 	httpServer   *http.Server
 	grpcServer   *grpc.Server
 	grpcListener net.Listener
+	beforeStop   []func()
 }
 
 func New(cfg Config, httpHandler *gin.Engine, grpcServer *grpc.Server) (*App, error) {
@@ -48,6 +49,11 @@ func New(cfg Config, httpHandler *gin.Engine, grpcServer *grpc.Server) (*App, er
 	}, nil
 }
 
+// BeforeShutdown 收到关闭信号后、停止 HTTP / gRPC 之前执行 (如把健康状态置为 NOT_SERVING)
+func (a *App) BeforeShutdown(fn func()) {
+	a.beforeStop = append(a.beforeStop, fn)
+}
+
 // Run 启动服务并阻塞，直到收到关闭信号
 func (a *App) Run() {
 	// 1. Start HTTP
@@ -71,6 +77,9 @@ func (a *App) Run() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logger.Info("⚠️  Shutting down server...")
+	for _, fn := range a.beforeStop {
+		fn()
+	}
 
 	// 4. Graceful Shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
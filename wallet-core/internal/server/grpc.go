@@ -3,17 +3,26 @@ package server
 import (
 	"google.golang.org/grpc"
 
+	handler_grpc "wallet-core/internal/handler/grpc"
+	"wallet-core/internal/interceptor"
 	"wallet-core/internal/server/routes"
 	"wallet-core/internal/service"
+	"wallet-core/pkg/config"
 )
 
-// NewGRPCServer 初始化并注册 gRPC 服务
-func NewGRPCServer(addressService service.AddressService) *grpc.Server {
-	s := grpc.NewServer()
+// NewGRPCServer 初始化并注册 gRPC 服务 (标准拦截器链，见 interceptor.NewServer)
+// AdminService 的方法需要管理员会话，InvoiceService 的方法 (收银台除外) 需要商户 API Key 签名，AddressService 不受影响
+func NewGRPCServer(addressService service.AddressService, adminAuth *service.AdminAuthService, merchants *service.MerchantService, invoices *service.InvoiceService) *grpc.Server {
+	s := interceptor.NewServer(config.Global.GRPC,
+		interceptor.AdminAuth(adminAuth, handler_grpc.AdminMethodPermissions),
+		interceptor.MerchantAuth(merchants, handler_grpc.InvoiceMethodScopes),
+	)
 
 	// 注册 AddressService
 	// 注册 gRPC 服务
 	routes.RegisterAddressGRPC(s, addressService)
+	routes.RegisterAdminGRPC(s, adminAuth)
+	routes.RegisterMerchantGRPC(s, invoices)
 
 	return s
 }
@@ -3,9 +3,14 @@ package server
 import (
 	"wallet-core/internal/handler"
 	"wallet-core/internal/handler/response"
+	"wallet-core/internal/middleware"
 	"wallet-core/internal/server/routes"
+	"wallet-core/internal/service"
+	"wallet-core/internal/service/auth"
 
+	"wallet-core/pkg/health"
 	"wallet-core/pkg/monitor"
+	"wallet-core/pkg/ratelimit"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -14,7 +19,9 @@ import (
 )
 
 // NewHTTPRouter 初始化并返回一个 Gin Engine
-func NewHTTPRouter() *gin.Engine {
+// authSvc 用于校验用户的 Access Token (JWT)，adminAuth 用于校验管理员会话，merchants 用于校验商户 API 签名
+// limiter 为注册 / 登录 / 提现 / 商户 API 等敏感路由限流，probes 提供 /livez /readyz 的检查项
+func NewHTTPRouter(authSvc *auth.Service, adminAuth *service.AdminAuthService, merchants *service.MerchantService, limiter *ratelimit.Limiter, probes *health.Registry) *gin.Engine {
 	// 0. 初始化监控指标
 	monitor.Init()
 
@@ -22,10 +29,13 @@ func NewHTTPRouter() *gin.Engine {
 	r := gin.Default()
 
 	// 2. 注册通用中间件
+	r.Use(middleware.RequestID())
 	r.Use(monitor.PrometheusMiddleware()) // [NEW] 监控埋点
 
 	// 3. 注册基础路由
-	r.GET("/health", handler.HealthCheck)
+	r.GET("/livez", handler.Livez(probes))
+	r.GET("/readyz", handler.Readyz(probes))
+	r.GET("/health", handler.HealthCheck(probes))    // 兼容旧的监控配置，等同 /readyz
 	r.GET("/metrics", gin.WrapH(promhttp.Handler())) // [NEW] 暴露给 Prometheus
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -37,13 +47,19 @@ func NewHTTPRouter() *gin.Engine {
 		})
 
 		// 注册用户模块路由
-		routes.RegisterUserRoutes(api)
+		routes.RegisterUserRoutes(api, limiter)
 
 		// 注册管理后台路由 [NEW]
-		routes.RegisterAdminRoutes(api)
+		routes.RegisterAdminRoutes(api, adminAuth, limiter)
 
 		// 注册钱包业务路由 [NEW]
-		routes.RegisterWalletRoutes(api)
+		routes.RegisterWalletRoutes(api, middleware.JWTAuth(authSvc), limiter)
+
+		// 注册实名认证路由
+		routes.RegisterKYCRoutes(api, middleware.JWTAuth(authSvc))
+
+		// 注册商户 API 路由 (API Key + HMAC 签名)
+		routes.RegisterMerchantRoutes(api, merchants, limiter)
 	}
 
 	return r
@@ -2,14 +2,56 @@ package routes
 
 import (
 	"wallet-core/internal/handler"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/ratelimit"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterAdminRoutes(rg *gin.RouterGroup) {
+// RegisterAdminRoutes 注册管理后台路由
+// 除登录外所有路由都需要管理员会话，并按角色权限矩阵 (service.HasPermission) 校验
+func RegisterAdminRoutes(rg *gin.RouterGroup, adminAuth *service.AdminAuthService, limiter *ratelimit.Limiter) {
 	adminGroup := rg.Group("/admin")
-	// 可以在这里添加 AdminAuth 中间件
+	adminGroup.POST("/auth/login", middleware.RateLimit(limiter, "admin_login"), handler.Admin.Login)
+
+	authed := adminGroup.Group("", middleware.AdminAuth(adminAuth))
 	{
-		adminGroup.POST("/withdrawals/:id/review", handler.Admin.ReviewWithdrawal)
+		authed.POST("/auth/logout", handler.Admin.Logout)
+
+		authed.GET("/withdrawals/pending", middleware.RequirePermission(service.PermWithdrawalView), handler.Admin.ListPendingWithdrawals)
+		authed.GET("/withdrawals/queue", middleware.RequirePermission(service.PermWithdrawalView), handler.Admin.ListReviewQueue)
+		authed.GET("/withdrawals/:id", middleware.RequirePermission(service.PermWithdrawalView), handler.Admin.GetWithdrawalDetail)
+		authed.POST("/withdrawals/:id/review", middleware.RequirePermission(service.PermWithdrawalReview), handler.Admin.ReviewWithdrawal)
+		authed.POST("/withdrawals/bulk-review", middleware.RequirePermission(service.PermWithdrawalReview), handler.Admin.BulkReviewWithdrawals)
+		authed.POST("/withdrawals/:id/claim", middleware.RequirePermission(service.PermWithdrawalReview), handler.Admin.ClaimWithdrawal)
+		authed.POST("/withdrawals/:id/release", middleware.RequirePermission(service.PermWithdrawalReview), handler.Admin.ReleaseWithdrawal)
+		authed.POST("/withdrawals/:id/assign", middleware.RequirePermission(service.PermWithdrawalAssign), handler.Admin.AssignWithdrawal)
+		authed.POST("/withdrawals/:id/speed-up", middleware.RequirePermission(service.PermWithdrawalReplace), handler.Admin.SpeedUpWithdrawal)
+		authed.POST("/withdrawals/:id/cancel-tx", middleware.RequirePermission(service.PermWithdrawalReplace), handler.Admin.CancelWithdrawalTx)
+
+		authed.POST("/admins", middleware.RequirePermission(service.PermAdminManage), handler.Admin.CreateAdmin)
+		authed.GET("/audit-logs", middleware.RequirePermission(service.PermAuditView), handler.Admin.ListAuditLogs)
+
+		authed.GET("/kyc/applications", middleware.RequirePermission(service.PermKYCView), handler.Admin.ListKYCApplications)
+		authed.GET("/kyc/applications/:id", middleware.RequirePermission(service.PermKYCView), handler.Admin.GetKYCApplication)
+		authed.GET("/kyc/applications/:id/documents/:doc_id", middleware.RequirePermission(service.PermKYCView), handler.Admin.DownloadKYCDocument)
+		authed.POST("/kyc/applications/:id/review", middleware.RequirePermission(service.PermKYCReview), handler.Admin.ReviewKYCApplication)
+		authed.POST("/users/:id/kyc-tier", middleware.RequirePermission(service.PermKYCReview), handler.Admin.SetUserKYCTier)
+
+		merchants := authed.Group("/merchants", middleware.RequirePermission(service.PermMerchantManage))
+		merchants.POST("", handler.Merchant.CreateMerchant)
+		merchants.GET("", handler.Merchant.ListMerchants)
+		merchants.PUT("/:id/account", handler.Merchant.SetSettlementAccount)
+		merchants.POST("/:id/keys", handler.Merchant.CreateAPIKey)
+		merchants.GET("/:id/keys", handler.Merchant.ListAPIKeys)
+		merchants.POST("/:id/keys/:key_id/revoke", handler.Merchant.RevokeAPIKey)
+
+		tenants := authed.Group("/tenants", middleware.RequirePermission(service.PermTenantManage))
+		tenants.POST("", handler.Tenant.CreateTenant)
+		tenants.GET("", handler.Tenant.ListTenants)
+		tenants.POST("/:id/status", handler.Tenant.SetTenantStatus)
+		tenants.GET("/:id/chains", handler.Tenant.ListTenantChains)
+		tenants.PUT("/:id/chains/:chain", handler.Tenant.SetTenantChain)
 	}
 }
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"google.golang.org/grpc"
+
+	adminv1 "wallet-core/api/gen/admin/v1"
+	handler_grpc "wallet-core/internal/handler/grpc"
+	"wallet-core/internal/service"
+)
+
+// RegisterAdminGRPC 注册 admin.v1 AdminService (管理员会话认证见 interceptor.AdminAuth)
+func RegisterAdminGRPC(s *grpc.Server, adminAuth *service.AdminAuthService) {
+	adminv1.RegisterAdminServiceServer(s, handler_grpc.NewAdminHandler(adminAuth))
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"google.golang.org/grpc"
+
+	merchantv1 "wallet-core/api/gen/merchant/v1"
+	handler_grpc "wallet-core/internal/handler/grpc"
+	"wallet-core/internal/service"
+)
+
+// RegisterMerchantGRPC 注册 merchant.v1 InvoiceService (API Key 签名认证见 interceptor.MerchantAuth)
+func RegisterMerchantGRPC(s *grpc.Server, invoices *service.InvoiceService) {
+	merchantv1.RegisterInvoiceServiceServer(s, handler_grpc.NewInvoiceHandler(invoices))
+}
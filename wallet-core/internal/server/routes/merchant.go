@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"wallet-core/internal/handler"
+	"wallet-core/internal/middleware"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterMerchantRoutes 注册商户 (B2B) 路由
+// 全部使用 API Key + HMAC 请求签名认证，并按 API Key 的 scopes 授权和限流
+func RegisterMerchantRoutes(rg *gin.RouterGroup, merchants *service.MerchantService, limiter *ratelimit.Limiter) {
+	merchantGroup := rg.Group("/merchant", middleware.MerchantAuth(merchants), middleware.RateLimit(limiter, "merchant"))
+	{
+		merchantGroup.GET("/me", middleware.RequireScope(service.ScopeMerchantRead), handler.Merchant.Me)
+
+		// 收款账单
+		merchantGroup.POST("/invoices", middleware.RequireScope(service.ScopeInvoiceWrite), handler.Invoice.CreateInvoice)
+		merchantGroup.GET("/invoices", middleware.RequireScope(service.ScopeInvoiceRead), handler.Invoice.ListInvoices)
+		merchantGroup.GET("/invoices/:id", middleware.RequireScope(service.ScopeInvoiceRead), handler.Invoice.GetInvoice)
+		merchantGroup.GET("/invoices/:id/deliveries", middleware.RequireScope(service.ScopeInvoiceRead), handler.Invoice.ListDeliveries)
+		merchantGroup.PUT("/webhook", middleware.RequireScope(service.ScopeInvoiceWrite), handler.Invoice.SetWebhook)
+		merchantGroup.GET("/webhook", middleware.RequireScope(service.ScopeInvoiceRead), handler.Invoice.GetWebhook)
+
+		// 通知地址与投递记录
+		merchantGroup.POST("/webhooks", middleware.RequireScope(service.ScopeWebhookWrite), handler.Webhook.CreateEndpoint)
+		merchantGroup.GET("/webhooks", middleware.RequireScope(service.ScopeWebhookRead), handler.Webhook.ListEndpoints)
+		merchantGroup.PUT("/webhooks/:id", middleware.RequireScope(service.ScopeWebhookWrite), handler.Webhook.UpdateEndpoint)
+		merchantGroup.DELETE("/webhooks/:id", middleware.RequireScope(service.ScopeWebhookWrite), handler.Webhook.DeleteEndpoint)
+		merchantGroup.POST("/webhooks/:id/rotate-secret", middleware.RequireScope(service.ScopeWebhookWrite), handler.Webhook.RotateSecret)
+		merchantGroup.GET("/deliveries", middleware.RequireScope(service.ScopeWebhookRead), handler.Webhook.ListDeliveries)
+		merchantGroup.GET("/deliveries/:id", middleware.RequireScope(service.ScopeWebhookRead), handler.Webhook.GetDelivery)
+		merchantGroup.POST("/deliveries/:id/replay", middleware.RequireScope(service.ScopeWebhookWrite), handler.Webhook.ReplayDelivery)
+	}
+
+	// 托管收银台 (公开，凭不可猜测的账单 public_id 访问)
+	rg.GET("/checkout/:id", middleware.RateLimit(limiter, "checkout"), handler.Invoice.GetCheckout)
+}
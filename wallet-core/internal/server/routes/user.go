@@ -4,11 +4,13 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"wallet-core/internal/handler"
+	"wallet-core/internal/middleware"
+	"wallet-core/pkg/ratelimit"
 )
 
 // RegisterUserRoutes 注册用户模块路由
-func RegisterUserRoutes(rg *gin.RouterGroup) {
+func RegisterUserRoutes(rg *gin.RouterGroup, limiter *ratelimit.Limiter) {
 	// 用户相关路由
 	// POST /api/v1/register
-	rg.POST("/register", handler.Register)
+	rg.POST("/register", middleware.RateLimit(limiter, "register"), handler.Register)
 }
@@ -2,14 +2,35 @@ package routes
 
 import (
 	"wallet-core/internal/handler"
+	"wallet-core/internal/middleware"
+	"wallet-core/pkg/ratelimit"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterWalletRoutes(rg *gin.RouterGroup) {
-	walletGroup := rg.Group("/wallet")
-	// Auth middleware here
+// RegisterWalletRoutes 注册钱包业务路由，authMW 为登录校验中间件 (middleware.JWTAuth)
+// 提现 / 站内转账按用户限流，需要在 authMW 之后
+func RegisterWalletRoutes(rg *gin.RouterGroup, authMW gin.HandlerFunc, limiter *ratelimit.Limiter) {
+	walletGroup := rg.Group("/wallet", authMW)
 	{
-		walletGroup.POST("/withdraw", handler.Withdraw.CreateWithdrawal)
+		walletGroup.POST("/withdraw", middleware.RateLimit(limiter, "withdraw"), handler.Withdraw.CreateWithdrawal)
+		walletGroup.POST("/withdraw/:id/cancel", handler.Withdraw.CancelWithdrawal)
+
+		walletGroup.GET("/deposits", handler.History.ListDeposits)
+		walletGroup.GET("/withdrawals", handler.History.ListWithdrawals)
+		walletGroup.GET("/transactions", handler.History.ListTransactions)
+
+		walletGroup.POST("/transfers", middleware.RateLimit(limiter, "transfer"), handler.Transfer.CreateTransfer)
+		walletGroup.GET("/transfers", handler.Transfer.ListTransfers)
+		walletGroup.GET("/transfers/:id", handler.Transfer.GetTransfer)
+	}
+}
+
+// RegisterKYCRoutes 注册实名认证路由 (用户查询等级 / 提交申请)
+func RegisterKYCRoutes(rg *gin.RouterGroup, authMW gin.HandlerFunc) {
+	kycGroup := rg.Group("/kyc", authMW)
+	{
+		kycGroup.GET("", handler.KYC.GetStatus)
+		kycGroup.POST("/applications", handler.KYC.SubmitApplication)
 	}
 }
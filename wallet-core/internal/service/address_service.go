@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
@@ -13,17 +15,24 @@ import (
 	"wallet-core/pkg/address"
 	"wallet-core/pkg/bip32"
 	"wallet-core/pkg/cache"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/tenant"
 )
 
 // SQLAddressService 是 AddressService 的实现
 type SQLAddressService struct {
 	db          *gorm.DB
 	redis       *redis.Client
-	masterKey   bip32.ExtendedKey // 系统级的主公钥 (xpub)，用于派生子地址
+	masterKey   bip32.ExtendedKey // 系统级的主公钥 (xpub)，默认租户用它派生子地址
 	btcGen      *address.BTCGenerator
 	ethGen      *address.ETHGenerator
+	network     *chaincfg.Params
 	networkType string // "mainnet" or "testnet"
 	cache       cache.Cache
+
+	// 其他租户的账户级 xpub (见 model.TenantChain)，按 xpub 字符串缓存解析结果
+	mu    sync.Mutex
+	xpubs map[string]bip32.ExtendedKey
 }
 
 // NewSQLAddressService 构造函数
@@ -39,19 +48,23 @@ func NewSQLAddressService(db *gorm.DB, rdb *redis.Client, masterKey bip32.Extend
 		masterKey:   masterKey,
 		btcGen:      address.NewBTCGenerator(network),
 		ethGen:      address.NewETHGenerator(),
+		network:     network,
 		networkType: network.Name,
 		cache:       c,
+		xpubs:       make(map[string]bip32.ExtendedKey),
 	}, nil
 }
 
 // GetDepositAddress 获取充值地址
 // 逻辑:
 // 1. 查询 DB 是否已存在该链的地址 (同一个用户每条链目前只分配一个充值地址)
-// 2. 如果不存在，从 Redis 获取下一个 path_index
-// 3. 派生子公钥 -> 生成地址
+// 2. 如果不存在，从 Redis 获取用户所属租户的下一个 path_index
+// 3. 用租户的 xpub 派生子公钥 -> 生成地址
 // 4. 保存到 DB
 // 5. 返回地址
 func (s *SQLAddressService) GetDepositAddress(uid uint64, chain string) (string, int, error) {
+	ctx := context.Background()
+
 	// 1. 查库
 	var existingAddr model.Address
 	err := s.db.Where("user_id = ? AND chain = ?", uid, chain).First(&existingAddr).Error
@@ -64,15 +77,49 @@ func (s *SQLAddressService) GetDepositAddress(uid uint64, chain string) (string,
 		return "", 0, fmt.Errorf("数据库查询错误: %w", err)
 	}
 
-	// 2. 生成新地址
-	// 使用 Redis INCR 原子递增作为 HD Path Index
-	// Key: wallet:hd_index:{chain}
-	redisKey := fmt.Sprintf("wallet:hd_index:%s", chain)
-	index, err := s.redis.Incr(context.Background(), redisKey).Result()
+	// 2. 生成新地址 (按用户所属租户派生)
+	tenantID, err := UserTenantID(ctx, s.db, uid)
+	if err != nil {
+		return "", 0, err
+	}
+	hdPathIndex, addressStr, err := s.deriveNext(ctx, tenantID, chain)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// 4. 保存到 DB
+	newAddr := model.Address{
+		TenantID:    tenantID,
+		UserID:      uid,
+		Chain:       chain,
+		Address:     addressStr,
+		HDPathIndex: hdPathIndex,
+		CreatedAt:   time.Now(),
+	}
+
+	// 处理并发: 唯一索引冲突 (有可能两个请求同时拿到同一个 Redis index? 不可能，Redis 是原子的)
+	// 但有可能同一个用户并发请求，导致这里重复插入
+	// 使用 FirstOrCreate 或者 事务
+	err = s.db.Create(&newAddr).Error
+	if err != nil {
+		// 如果是唯一索引冲突，说明已经有一条了，再查一次返回即可
+		// 这里简化处理
+		return "", 0, fmt.Errorf("保存地址到数据库失败: %w", err)
+	}
+
+	return addressStr, hdPathIndex, nil
+}
+
+// deriveNext 从 Redis 取租户的下一个 path_index 并派生该链的地址
+func (s *SQLAddressService) deriveNext(ctx context.Context, tenantID uint64, chain string) (int, string, error) {
+	accountKey, err := s.accountKey(ctx, tenantID, chain)
+	if err != nil {
+		return 0, "", err
+	}
+	hdPathIndex, err := s.nextIndex(ctx, tenantID, chain)
 	if err != nil {
-		return "", 0, fmt.Errorf("Redis INCR 失败: %w", err)
+		return 0, "", err
 	}
-	hdPathIndex := int(index)
 
 	// 3. 派生密钥
 	// 假设我们使用 BIP-44 路径的简化版: m/purpose'/coin_type'/0'/0/index
@@ -87,22 +134,23 @@ func (s *SQLAddressService) GetDepositAddress(uid uint64, chain string) (string,
 	// 这里为了简化演示，我们假设 masterKey 是 Root Xpub，我们只做非硬化派生
 	// 派生路径: m/0/index (0=External Chain)
 	// 注意: 实际生产中应严格管理 xpub 的层级
+	// 其他租户的 accountKey 就是标准的 account key (m/44'/coin'/tenant')，同样派生 /0/index
 
 	// 派生路径: 0 (external chain)
-	chainKey, err := s.masterKey.Derive(0)
+	chainKey, err := accountKey.Derive(0)
 	if err != nil {
-		return "", 0, fmt.Errorf("密钥派生失败 (chain): %w", err)
+		return 0, "", fmt.Errorf("密钥派生失败 (chain): %w", err)
 	}
 	// 派生路径: index
 	childKey, err := chainKey.Derive(uint32(hdPathIndex))
 	if err != nil {
-		return "", 0, fmt.Errorf("密钥派生失败 (index): %w", err)
+		return 0, "", fmt.Errorf("密钥派生失败 (index): %w", err)
 	}
 
 	// 转换公钥
 	ecPubKey, err := childKey.ECPubKey()
 	if err != nil {
-		return "", 0, fmt.Errorf("获取 EC 公钥失败: %w", err)
+		return 0, "", fmt.Errorf("获取 EC 公钥失败: %w", err)
 	}
 
 	var addressStr string
@@ -111,33 +159,102 @@ func (s *SQLAddressService) GetDepositAddress(uid uint64, chain string) (string,
 	} else if chain == "ETH" {
 		addressStr, err = s.ethGen.PubKeyToAddress(ecPubKey.SerializeUncompressed())
 	} else {
-		return "", 0, fmt.Errorf("不支持的链: %s", chain)
+		return 0, "", fmt.Errorf("不支持的链: %s", chain)
 	}
 
 	if err != nil {
-		return "", 0, fmt.Errorf("地址生成失败: %w", err)
+		return 0, "", fmt.Errorf("地址生成失败: %w", err)
 	}
+	return hdPathIndex, addressStr, nil
+}
 
-	// 4. 保存到 DB
-	newAddr := model.Address{
-		UserID:      uid,
+// AllocateAddress 派生一个不属于任何用户的新地址 (UserID 为 0)，供商户收款账单使用
+// 地址写入 addresses 表 (属于默认租户)，扫块器会像用户地址一样识别发往它的充值
+func (s *SQLAddressService) AllocateAddress(chain string) (*model.Address, error) {
+	hdPathIndex, addressStr, err := s.deriveNext(context.Background(), tenant.DefaultID, chain)
+	if err != nil {
+		return nil, err
+	}
+	addr := &model.Address{
+		TenantID:    tenant.DefaultID,
 		Chain:       chain,
 		Address:     addressStr,
 		HDPathIndex: hdPathIndex,
 		CreatedAt:   time.Now(),
 	}
+	if err := s.db.Create(addr).Error; err != nil {
+		return nil, fmt.Errorf("保存地址到数据库失败: %w", err)
+	}
+	return addr, nil
+}
 
-	// 处理并发: 唯一索引冲突 (有可能两个请求同时拿到同一个 Redis index? 不可能，Redis 是原子的)
-	// 但有可能同一个用户并发请求，导致这里重复插入
-	// 使用 FirstOrCreate 或者 事务
-	err = s.db.Create(&newAddr).Error
+// accountKey 租户派生地址用的扩展公钥
+// 默认租户沿用系统主公钥 (兼容多租户之前分配的地址)，其他租户使用 TenantChain.XPub，未配置时不能分配地址
+func (s *SQLAddressService) accountKey(ctx context.Context, tenantID uint64, chain string) (bip32.ExtendedKey, error) {
+	if tenantID == tenant.DefaultID {
+		return s.masterKey, nil
+	}
+	tc, err := LoadTenantChain(ctx, s.db, tenantID, chain)
 	if err != nil {
-		// 如果是唯一索引冲突，说明已经有一条了，再查一次返回即可
-		// 这里简化处理
-		return "", 0, fmt.Errorf("保存地址到数据库失败: %w", err)
+		return nil, err
+	}
+	if tc == nil || tc.XPub == "" {
+		return nil, errno.ErrTenantChainNotReady
 	}
 
-	return addressStr, hdPathIndex, nil
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key, ok := s.xpubs[tc.XPub]; ok {
+		return key, nil
+	}
+	key, err := bip32.ParseExtendedKey(tc.XPub, s.network)
+	if err != nil {
+		return nil, fmt.Errorf("租户 %d 的 %s xpub 无效: %w", tenantID, chain, err)
+	}
+	if key.IsPrivate() {
+		return nil, fmt.Errorf("租户 %d 的 %s 配置了私钥，应该只配置 xpub", tenantID, chain)
+	}
+	s.xpubs[tc.XPub] = key
+	return key, nil
+}
+
+// nextIndex 使用 Redis INCR 原子递增作为 HD Path Index
+// Key: wallet:hd_index:{tenant}:{chain}，每个租户独立计数
+// 计数器不存在时 (新租户 / Redis 数据丢失) 先用库里该租户已分配的最大 index 初始化，避免派生出重复地址；
+// 默认租户还要参考多租户之前的全局计数器 wallet:hd_index:{chain}
+func (s *SQLAddressService) nextIndex(ctx context.Context, tenantID uint64, chain string) (int, error) {
+	redisKey := fmt.Sprintf("wallet:hd_index:%d:%s", tenantID, chain)
+
+	exists, err := s.redis.Exists(ctx, redisKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("Redis EXISTS 失败: %w", err)
+	}
+	if exists == 0 {
+		var maxIndex int64
+		if err := s.db.WithContext(ctx).Model(&model.Address{}).Scopes(tenant.Scope(tenantID)).
+			Where("chain = ?", chain).Select("COALESCE(MAX(hd_path_index), 0)").Scan(&maxIndex).Error; err != nil {
+			return 0, fmt.Errorf("查询已分配的 HD index 失败: %w", err)
+		}
+		if tenantID == tenant.DefaultID {
+			legacy, err := s.redis.Get(ctx, fmt.Sprintf("wallet:hd_index:%s", chain)).Int64()
+			if err != nil && !errors.Is(err, redis.Nil) {
+				return 0, fmt.Errorf("读取旧 HD index 计数器失败: %w", err)
+			}
+			if legacy > maxIndex {
+				maxIndex = legacy
+			}
+		}
+		// SETNX: 并发初始化时只有一个生效
+		if err := s.redis.SetNX(ctx, redisKey, maxIndex, 0).Err(); err != nil {
+			return 0, fmt.Errorf("初始化 HD index 计数器失败: %w", err)
+		}
+	}
+
+	index, err := s.redis.Incr(ctx, redisKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("Redis INCR 失败: %w", err)
+	}
+	return int(index), nil
 }
 
 // GetSupportedCurrencies 获取支持的币种列表 (演示缓存)
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+	"wallet-core/internal/service/auth"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/kms"
+	"wallet-core/pkg/totp"
+)
+
+// AdminAuthService 后台管理员身份
+// 1. 管理员账号与终端用户独立，只能由 superadmin (或命令行) 创建，创建时即生成 TOTP 密钥
+// 2. 登录必须同时提供密码和 TOTP 验证码，失败次数超限后锁定
+// 3. 会话为 JWT (独立签名密钥)，服务端在 Redis 登记，退出登录即失效
+// 4. 每次请求都重新加载管理员 (禁用 / 改角色立即生效)
+type AdminAuthService struct {
+	db          *gorm.DB
+	rdb         *redis.Client
+	keys        kms.KeyManager
+	tokens      *auth.TokenManager
+	issuer      string // 验证器 App 中显示的发行方
+	sessionTTL  time.Duration
+	maxAttempts int
+	window      time.Duration
+}
+
+var AdminAuth *AdminAuthService
+
+// adminTokenIssuer 管理员会话的 JWT iss，与终端用户 token 区分
+const adminTokenIssuer = "wallet-core-admin"
+
+// AdminSession 登录成功后返回的会话
+type AdminSession struct {
+	Token     string           `json:"token"`
+	ExpiresAt time.Time        `json:"expires_at"`
+	Admin     *model.AdminUser `json:"admin"`
+}
+
+// AuditMeta 审计日志的请求上下文
+type AuditMeta struct {
+	IP        string
+	UserAgent string
+}
+
+func NewAdminAuthService(db *gorm.DB, rdb *redis.Client, keys kms.KeyManager, cfg config.AdminConfig, mfaCfg config.MFAConfig) (*AdminAuthService, error) {
+	if cfg.JWTSecret == "" {
+		return nil, fmt.Errorf("admin.jwt_secret 未配置")
+	}
+	sessionTTL := cfg.SessionTTL
+	if sessionTTL <= 0 {
+		sessionTTL = 8 * time.Hour
+	}
+	s := &AdminAuthService{
+		db:          db,
+		rdb:         rdb,
+		keys:        keys,
+		tokens:      auth.NewTokenManager(cfg.JWTSecret, adminTokenIssuer, sessionTTL),
+		issuer:      mfaCfg.Issuer + " Admin",
+		sessionTTL:  sessionTTL,
+		maxAttempts: cfg.MaxAttempts,
+		window:      cfg.AttemptWindow,
+	}
+	if s.maxAttempts <= 0 {
+		s.maxAttempts = 5
+	}
+	if s.window <= 0 {
+		s.window = 15 * time.Minute
+	}
+	return s, nil
+}
+
+// CreateAdmin 创建管理员，返回 TOTP 密钥和 otpauth URI (只返回这一次，需交给本人绑定验证器)
+// creatorID 为 0 表示命令行初始化
+func (s *AdminAuthService) CreateAdmin(ctx context.Context, creatorID uint64, username, email, password, role string) (*model.AdminUser, string, string, error) {
+	if !ValidAdminRole(role) {
+		return nil, "", "", errno.ErrAdminRoleInvalid
+	}
+	username = strings.TrimSpace(username)
+	if username == "" || len(password) < 8 {
+		return nil, "", "", errno.ErrBind.WithMessage("username is required and password must be at least 8 characters")
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&model.AdminUser{}).Where("username = ?", username).Count(&count).Error; err != nil {
+		return nil, "", "", err
+	}
+	if count > 0 {
+		return nil, "", "", errno.ErrAdminAlreadyExists
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, "", "", err
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, "", "", err
+	}
+	ciphertext, err := s.keys.Encrypt(AdminTOTPKeyID, []byte(secret))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("加密 TOTP 密钥失败: %w", err)
+	}
+
+	admin := &model.AdminUser{
+		Username:         username,
+		Email:            email,
+		PasswordHash:     string(hash),
+		Role:             role,
+		KeyID:            AdminTOTPKeyID,
+		SecretCiphertext: base64.StdEncoding.EncodeToString(ciphertext),
+		CreatedBy:        creatorID,
+	}
+	if err := s.db.WithContext(ctx).Create(admin).Error; err != nil {
+		return nil, "", "", err
+	}
+
+	return admin, secret, totp.ProvisioningURI(s.issuer, username, secret), nil
+}
+
+// Login 密码 + TOTP 登录
+// 用户不存在 / 密码错误 / 验证码错误统一返回 ErrAdminLoginFailed，不泄露具体原因
+func (s *AdminAuthService) Login(ctx context.Context, username, password, code string, meta AuditMeta) (*AdminSession, error) {
+	// 失败次数按 用户名 + 客户端 IP 计数: 他人在别处猜密码不会把管理员锁在门外
+	attemptsKey := attemptsKeyAdmin(username, meta.IP)
+	if err := s.checkAttempts(ctx, attemptsKey); err != nil {
+		s.Audit(ctx, &model.AdminAuditLog{Username: username, Action: model.AuditActionLogin, Detail: "locked"}, meta)
+		return nil, err
+	}
+
+	admin, err := s.verifyCredentials(ctx, username, password, code)
+	if err != nil {
+		entry := &model.AdminAuditLog{Username: username, Action: model.AuditActionLogin, Detail: err.Error()}
+		if admin != nil {
+			entry.AdminID = admin.ID
+		}
+		s.Audit(ctx, entry, meta)
+
+		// 具体原因只写审计日志，对外统一返回 ErrAdminLoginFailed
+		if errors.Is(err, errno.ErrAdminLoginFailed) {
+			s.recordFailure(ctx, attemptsKey)
+			return nil, errno.ErrAdminLoginFailed
+		}
+		return nil, err
+	}
+	s.rdb.Del(ctx, attemptsKey)
+
+	token, expiresAt, err := s.tokens.Issue(admin.ID, 0, 0, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rdb.Set(ctx, adminSessionKey(token), admin.ID, s.sessionTTL).Err(); err != nil {
+		return nil, err
+	}
+
+	s.Audit(ctx, &model.AdminAuditLog{AdminID: admin.ID, Username: admin.Username, Action: model.AuditActionLogin, Success: true}, meta)
+	return &AdminSession{Token: token, ExpiresAt: expiresAt, Admin: admin}, nil
+}
+
+// verifyCredentials 校验密码和 TOTP (含防重放)，admin 非 nil 时表示账号存在
+// 校验失败返回包装了 ErrAdminLoginFailed 的错误，附带具体原因
+func (s *AdminAuthService) verifyCredentials(ctx context.Context, username, password, code string) (*model.AdminUser, error) {
+	var admin model.AdminUser
+	if err := s.db.WithContext(ctx).Where("username = ?", username).First(&admin).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: unknown username", errno.ErrAdminLoginFailed)
+		}
+		return nil, err
+	}
+	if admin.Disabled {
+		return &admin, fmt.Errorf("%w: account disabled", errno.ErrAdminLoginFailed)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(admin.PasswordHash), []byte(password)) != nil {
+		return &admin, fmt.Errorf("%w: wrong password", errno.ErrAdminLoginFailed)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(admin.SecretCiphertext)
+	if err != nil {
+		return &admin, err
+	}
+	secret, err := s.keys.Decrypt(admin.KeyID, ciphertext)
+	if err != nil {
+		return &admin, fmt.Errorf("解密 TOTP 密钥失败: %w", err)
+	}
+	step, ok := totp.Validate(string(secret), code, time.Now(), 1)
+	if !ok || step <= admin.LastUsedStep {
+		return &admin, fmt.Errorf("%w: wrong or reused verification code", errno.ErrAdminLoginFailed)
+	}
+
+	// 条件更新: 并发登录时同一个验证码只有一个能成功
+	now := time.Now()
+	res := s.db.WithContext(ctx).Model(&model.AdminUser{}).
+		Where("id = ? AND last_used_step < ?", admin.ID, step).
+		Updates(map[string]interface{}{"last_used_step": step, "last_login_at": now})
+	if res.Error != nil {
+		return &admin, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return &admin, fmt.Errorf("%w: reused verification code", errno.ErrAdminLoginFailed)
+	}
+	admin.LastUsedStep = step
+	admin.LastLoginAt = &now
+	return &admin, nil
+}
+
+// Authenticate 校验会话 token，返回当前管理员
+func (s *AdminAuthService) Authenticate(ctx context.Context, token string) (*model.AdminUser, error) {
+	if token == "" {
+		return nil, errno.ErrUnauthorized
+	}
+	claims, err := s.tokens.Parse(token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	adminID, _ := claims.UserID()
+
+	// 会话必须仍在 Redis 中登记 (退出登录后立即失效)
+	sessionAdmin, err := s.rdb.Get(ctx, adminSessionKey(token)).Uint64()
+	if errors.Is(err, redis.Nil) || (err == nil && sessionAdmin != adminID) {
+		return nil, errno.ErrTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var admin model.AdminUser
+	if err := s.db.WithContext(ctx).First(&admin, adminID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrTokenInvalid
+		}
+		return nil, err
+	}
+	if admin.Disabled {
+		return nil, errno.ErrTokenInvalid
+	}
+	return &admin, nil
+}
+
+// Logout 注销会话
+func (s *AdminAuthService) Logout(ctx context.Context, admin *model.AdminUser, token string, meta AuditMeta) error {
+	if err := s.rdb.Del(ctx, adminSessionKey(token)).Err(); err != nil {
+		return err
+	}
+	s.Audit(ctx, &model.AdminAuditLog{AdminID: admin.ID, Username: admin.Username, Action: model.AuditActionLogout, Success: true}, meta)
+	return nil
+}
+
+// Audit 写入审计日志，失败只记录日志不影响业务
+func (s *AdminAuthService) Audit(ctx context.Context, entry *model.AdminAuditLog, meta AuditMeta) {
+	entry.IP = meta.IP
+	entry.UserAgent = truncate(meta.UserAgent, 255)
+	if err := s.db.WithContext(ctx).Create(entry).Error; err != nil {
+		log.Printf("[AdminAudit] 写入审计日志失败: action=%s admin=%d err=%v", entry.Action, entry.AdminID, err)
+	}
+}
+
+// ListAuditLogs 审计日志查询 (按时间倒序)，adminID / action 为空时不过滤
+func (s *AdminAuthService) ListAuditLogs(ctx context.Context, adminID uint64, action string, limit int) ([]model.AdminAuditLog, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	q := s.db.WithContext(ctx).Model(&model.AdminAuditLog{})
+	if adminID > 0 {
+		q = q.Where("admin_id = ?", adminID)
+	}
+	if action != "" {
+		q = q.Where("action = ?", action)
+	}
+	var logs []model.AdminAuditLog
+	err := q.Order("id DESC").Limit(limit).Find(&logs).Error
+	return logs, err
+}
+
+// adminSessionKey Redis 中只保存 token 的哈希
+func adminSessionKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "admin:session:" + hex.EncodeToString(sum[:])
+}
+
+func attemptsKeyAdmin(username, ip string) string {
+	return "admin:login:attempts:" + strings.ToLower(username) + ":" + ip
+}
+
+// checkAttempts 登录失败次数达到上限后拒绝登录，直到窗口过期
+func (s *AdminAuthService) checkAttempts(ctx context.Context, key string) error {
+	n, err := s.rdb.Get(ctx, key).Int()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+	if n >= s.maxAttempts {
+		return errno.ErrMFALocked
+	}
+	return nil
+}
+
+func (s *AdminAuthService) recordFailure(ctx context.Context, key string) {
+	pipe := s.rdb.TxPipeline()
+	pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, s.window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("[AdminAuth] 记录登录失败次数失败: %v", err)
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
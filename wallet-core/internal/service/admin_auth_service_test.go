@@ -0,0 +1,15 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminLoginAttemptsKeyedByUsernameAndIP(t *testing.T) {
+	// 用户名不区分大小写
+	assert.Equal(t, attemptsKeyAdmin("Root", "203.0.113.7"), attemptsKeyAdmin("root", "203.0.113.7"))
+	// 其他 IP 上的失败不会锁住同一个用户名
+	assert.NotEqual(t, attemptsKeyAdmin("root", "203.0.113.7"), attemptsKeyAdmin("root", "198.51.100.1"))
+	assert.NotEqual(t, attemptsKeyAdmin("root", "203.0.113.7"), attemptsKeyAdmin("alice", "203.0.113.7"))
+}
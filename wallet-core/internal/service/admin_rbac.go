@@ -0,0 +1,50 @@
+package service
+
+import "wallet-core/internal/model"
+
+// Permission 后台操作权限
+type Permission string
+
+const (
+	PermWithdrawalView    Permission = "withdrawal:view"    // 查看待审核提现
+	PermWithdrawalReview  Permission = "withdrawal:review"  // 审核 (通过 / 拒绝) 提现，认领 / 释放审核任务
+	PermWithdrawalAssign  Permission = "withdrawal:assign"  // 把审核任务指派给其他管理员
+	PermWithdrawalReplace Permission = "withdrawal:replace" // 加速 / 取消链上交易
+	PermAuditView         Permission = "audit:view"         // 查看审计日志
+	PermAdminManage       Permission = "admin:manage"       // 管理员账号管理
+	PermMerchantManage    Permission = "merchant:manage"    // 商户及 API Key 管理
+	PermKYCView           Permission = "kyc:view"           // 查看实名认证申请及证件
+	PermKYCReview         Permission = "kyc:review"         // 审核实名认证申请，调整用户等级
+	PermTenantManage      Permission = "tenant:manage"      // 租户及其 xpub / 热钱包 / 限额配置 (仅 superadmin)
+)
+
+// rolePermissions 角色 -> 权限矩阵
+// superadmin 拥有全部权限，不在这里列出
+var rolePermissions = map[string][]Permission{
+	model.AdminRoleReviewer:   {PermWithdrawalView, PermWithdrawalReview},
+	model.AdminRoleFinance:    {PermWithdrawalView, PermWithdrawalReview, PermWithdrawalAssign, PermAuditView},
+	model.AdminRoleOps:        {PermWithdrawalView, PermWithdrawalReplace, PermMerchantManage},
+	model.AdminRoleCompliance: {PermKYCView, PermKYCReview, PermAuditView},
+}
+
+// ValidAdminRole 是否为已定义的角色
+func ValidAdminRole(role string) bool {
+	if role == model.AdminRoleSuperAdmin {
+		return true
+	}
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// HasPermission 角色是否拥有某权限
+func HasPermission(role string, perm Permission) bool {
+	if role == model.AdminRoleSuperAdmin {
+		return true
+	}
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"wallet-core/internal/model"
+)
+
+func TestHasPermission(t *testing.T) {
+	cases := []struct {
+		role string
+		perm Permission
+		want bool
+	}{
+		{model.AdminRoleReviewer, PermWithdrawalView, true},
+		{model.AdminRoleReviewer, PermWithdrawalReview, true},
+		{model.AdminRoleReviewer, PermWithdrawalReplace, false},
+		{model.AdminRoleReviewer, PermAuditView, false},
+		{model.AdminRoleFinance, PermWithdrawalReview, true},
+		{model.AdminRoleFinance, PermAuditView, true},
+		{model.AdminRoleFinance, PermWithdrawalAssign, true},
+		{model.AdminRoleReviewer, PermWithdrawalAssign, false},
+		{model.AdminRoleFinance, PermAdminManage, false},
+		{model.AdminRoleOps, PermWithdrawalReplace, true},
+		{model.AdminRoleOps, PermWithdrawalReview, false},
+		{model.AdminRoleOps, PermMerchantManage, true},
+		{model.AdminRoleFinance, PermMerchantManage, false},
+		{model.AdminRoleCompliance, PermKYCReview, true},
+		{model.AdminRoleCompliance, PermKYCView, true},
+		{model.AdminRoleCompliance, PermAuditView, true},
+		{model.AdminRoleCompliance, PermWithdrawalReview, false},
+		{model.AdminRoleReviewer, PermKYCReview, false},
+		{model.AdminRoleSuperAdmin, PermAdminManage, true},
+		{model.AdminRoleSuperAdmin, PermWithdrawalReplace, true},
+		{model.AdminRoleSuperAdmin, PermTenantManage, true},
+		{model.AdminRoleOps, PermTenantManage, false},
+		{"", PermWithdrawalView, false},
+		{"root", PermWithdrawalView, false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, HasPermission(c.role, c.perm), "%s %s", c.role, c.perm)
+	}
+}
+
+func TestValidAdminRole(t *testing.T) {
+	for _, role := range []string{model.AdminRoleReviewer, model.AdminRoleFinance, model.AdminRoleOps, model.AdminRoleCompliance, model.AdminRoleSuperAdmin} {
+		assert.True(t, ValidAdminRole(role), role)
+	}
+	assert.False(t, ValidAdminRole("admin"))
+	assert.False(t, ValidAdminRole(""))
+}
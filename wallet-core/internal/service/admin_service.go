@@ -3,9 +3,11 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"wallet-core/internal/model"
 	"wallet-core/pkg/database"
+	"wallet-core/pkg/errno"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -15,6 +17,12 @@ type AdminService struct{}
 
 var Admin = &AdminService{}
 
+// 审核动作
+const (
+	ReviewActionApprove = "approve"
+	ReviewActionReject  = "reject"
+)
+
 // ReviewWithdrawal 审核提现
 func (s *AdminService) ReviewWithdrawal(ctx context.Context, txID string, adminID uint64, action string, remark string) error {
 	return database.DB.Transaction(func(tx *gorm.DB) error {
@@ -31,37 +39,53 @@ func (s *AdminService) ReviewWithdrawal(ctx context.Context, txID string, adminI
 		// 看着 model 定义，ID 是 uint64，TxHash 是提现后的 hash。
 		// 所以 API 应该传 ID。
 
+		if action != ReviewActionApprove && action != ReviewActionReject {
+			return errno.ErrBind.WithMessage(`action must be "approve" or "reject"`)
+		}
 		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 			First(&w, "id = ?", txID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.ErrWithdrawalNotFound
+			}
 			return err
 		}
 
-		// 2. 状态检查
-		if w.Status != "pending_review" {
-			return errors.New("withdrawal is not in pending_review state")
+		// 2. 状态检查 (风控挂起的提现同样走人工审核，只是需要更多审批人)
+		if !Reviewable(w.Status) {
+			return errNotAwaitingReview
 		}
 
-		// 3. 检查是否已审批
-		var count int64
-		tx.Model(&model.WithdrawalReview{}).
-			Where("withdrawal_id = ? AND admin_id = ?", w.ID, adminID).
-			Count(&count)
-		if count > 0 {
-			return errors.New("admin has already reviewed this withdrawal")
+		// 3. 被其他管理员认领 (且未过期) 时不能审核
+		now := time.Now()
+		if claimActive(&w, now) && w.AssigneeID != adminID {
+			return errno.ErrWithdrawalClaimed
+		}
+
+		// 4. 检查是否已审批
+		reviewed, err := hasReviewed(tx, w.ID, adminID)
+		if err != nil {
+			return err
+		}
+		if reviewed {
+			return errno.ErrAlreadyReviewed
 		}
 
-		// 4. 执行审批逻辑
-		if action == "approve" {
+		// 5. 执行审批逻辑
+		if action == ReviewActionApprove {
 			w.CurrentApprovals++
 			// 阈值判断
 			if w.CurrentApprovals >= w.RequiredApprovals {
-				w.Status = "pending_broadcast"
+				// 时间锁未到期则进入 time_locked，由 TimeLockService 到期放行
+				w.Status = ApprovedStatus(&w, now)
 			}
-		} else if action == "reject" {
-			w.Status = "rejected"
+		} else {
+			w.Status = model.WithdrawalStatusRejected
 		}
+		// 本人的审核已完成，释放认领，多人审批时由下一位审核人认领
+		w.AssigneeID = 0
+		w.ClaimedAt = nil
 
-		// 5. 插入审核记录
+		// 6. 插入审核记录
 		review := model.WithdrawalReview{
 			WithdrawalID: w.ID,
 			AdminID:      adminID,
@@ -72,7 +96,21 @@ func (s *AdminService) ReviewWithdrawal(ctx context.Context, txID string, adminI
 			return err
 		}
 
-		// 6. 保存提现单状态
-		return tx.Save(&w).Error
+		// 7. 保存提现单状态
+		if err := tx.Save(&w).Error; err != nil {
+			return err
+		}
+
+		// 8. 拒绝: 冻结资金退回可用余额
+		if w.Status == model.WithdrawalStatusRejected {
+			if err := ReleaseWithdrawalFunds(tx, &w); err != nil {
+				return err
+			}
+		}
+
+		if w.Status == model.WithdrawalStatusTimeLocked {
+			TimeLock.ScheduleRelease(&w)
+		}
+		return nil
 	})
 }
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+)
+
+// Asset 支持的币种及其参数 (来自配置 chains.<name>)
+type Asset struct {
+	Currency      string          `json:"currency"` // ETH, BTC
+	Name          string          `json:"name"`
+	Decimals      int32           `json:"decimals"`
+	MinDeposit    decimal.Decimal `json:"min_deposit"`
+	MinWithdrawal decimal.Decimal `json:"min_withdrawal"`
+	Confirmations uint64          `json:"confirmations"`
+}
+
+// SupportedAssets 所有已配置的币种，按币种名排序
+func SupportedAssets() []Asset {
+	assets := make([]Asset, 0, len(config.Global.Chains))
+	for name := range config.Global.Chains {
+		if a, ok := LookupAsset(name); ok {
+			assets = append(assets, a)
+		}
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Currency < assets[j].Currency })
+	return assets
+}
+
+// LookupAsset 查询币种参数 (大小写不敏感)，未配置时 ok 为 false
+func LookupAsset(currency string) (Asset, bool) {
+	c, ok := config.Global.Chains[strings.ToLower(currency)]
+	if !ok {
+		return Asset{}, false
+	}
+	return Asset{
+		Currency:      strings.ToUpper(currency),
+		Name:          c.Name,
+		Decimals:      c.Decimals,
+		MinDeposit:    decimal.NewFromFloat(c.MinDeposit),
+		MinWithdrawal: decimal.NewFromFloat(c.MinWithdrawal),
+		Confirmations: c.Confirmations,
+	}, true
+}
+
+// CheckWithdrawalAmount 校验提现金额: 大于 0、不低于最小提现金额、小数位不超过币种精度
+func CheckWithdrawalAmount(currency string, amount decimal.Decimal) error {
+	if !amount.IsPositive() {
+		return errno.ErrAmountInvalid
+	}
+	a, ok := LookupAsset(currency)
+	if !ok {
+		return errno.ErrBind.WithMessage("unsupported currency " + currency)
+	}
+	if a.MinWithdrawal.IsPositive() && amount.LessThan(a.MinWithdrawal) {
+		return errno.ErrAmountInvalid.WithMessage("amount is below the minimum withdrawal of " + a.MinWithdrawal.String() + " " + a.Currency)
+	}
+	if a.Decimals > 0 && !amount.Equal(amount.Truncate(a.Decimals)) {
+		return errno.ErrAmountInvalid.WithMessage("amount has more than " + decimal.NewFromInt32(a.Decimals).String() + " decimal places")
+	}
+	return nil
+}
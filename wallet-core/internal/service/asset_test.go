@@ -0,0 +1,59 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+)
+
+func TestSupportedAssets(t *testing.T) {
+	old := config.Global.Chains
+	defer func() { config.Global.Chains = old }()
+	config.Global.Chains = map[string]config.ChainConfig{
+		"eth": {Name: "Ethereum", Decimals: 18, MinWithdrawal: 0.01, Confirmations: 12},
+		"btc": {Name: "Bitcoin", Decimals: 8, MinDeposit: 0.0001, MinWithdrawal: 0.001, Confirmations: 6},
+	}
+
+	assets := SupportedAssets()
+	require.Len(t, assets, 2)
+	assert.Equal(t, "BTC", assets[0].Currency)
+	assert.Equal(t, "0.0001", assets[0].MinDeposit.String())
+	assert.Equal(t, "ETH", assets[1].Currency)
+
+	_, ok := LookupAsset("doge")
+	assert.False(t, ok)
+}
+
+func TestCheckWithdrawalAmount(t *testing.T) {
+	old := config.Global.Chains
+	defer func() { config.Global.Chains = old }()
+	config.Global.Chains = map[string]config.ChainConfig{
+		"btc": {Decimals: 8, MinWithdrawal: 0.001},
+	}
+
+	assert.NoError(t, CheckWithdrawalAmount("BTC", decimal.RequireFromString("0.001")))
+	assert.NoError(t, CheckWithdrawalAmount("btc", decimal.RequireFromString("1.12345678")))
+	assert.NoError(t, CheckWithdrawalAmount("BTC", decimal.RequireFromString("1.100000000"))) // 末尾的 0 不算精度
+
+	for _, tc := range []struct {
+		currency, amount string
+		code             int
+	}{
+		{"BTC", "0", errno.ErrAmountInvalid.Code},
+		{"BTC", "-1", errno.ErrAmountInvalid.Code},
+		{"BTC", "0.0009", errno.ErrAmountInvalid.Code},
+		{"BTC", "1.123456789", errno.ErrAmountInvalid.Code},
+		{"DOGE", "1", errno.ErrBind.Code},
+	} {
+		err := CheckWithdrawalAmount(tc.currency, decimal.RequireFromString(tc.amount))
+		var e errno.Errno
+		require.True(t, errors.As(err, &e), tc.amount)
+		assert.Equal(t, tc.code, e.Code, tc.amount)
+	}
+}
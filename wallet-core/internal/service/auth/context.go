@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"context"
+	"strings"
+)
+
+type ctxKey struct{}
+
+// WithUserID 把已认证的用户 ID 放入 context (由 HTTP 中间件 / gRPC 拦截器调用)
+func WithUserID(ctx context.Context, userID uint64) context.Context {
+	return context.WithValue(ctx, ctxKey{}, userID)
+}
+
+// UserIDFromContext 取出已认证的用户 ID，未认证时 ok 为 false
+// 业务代码只能通过这里拿用户 ID，不要信任请求参数中的 user_id
+func UserIDFromContext(ctx context.Context) (uint64, bool) {
+	userID, ok := ctx.Value(ctxKey{}).(uint64)
+	return userID, ok && userID != 0
+}
+
+// BearerToken 从 "Bearer <token>" 中提取 token
+func BearerToken(header string) string {
+	const prefix = "bearer "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(header[len(prefix):])
+}
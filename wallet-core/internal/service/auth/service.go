@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/safe_random"
+	"wallet-core/pkg/tenant"
+)
+
+// TokenPair 登录 / 刷新返回的凭证
+type TokenPair struct {
+	UserID       uint64
+	TenantID     uint64
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time // Access Token 过期时间
+}
+
+// Service 登录态管理
+// - Access Token: JWT，有效期短，各服务本地验签，只额外查一次 Redis 中的 token 版本号
+// - Refresh Token: 随机串，Redis 中只保存其 SHA-256，每次刷新都轮换 (旧的立即作废)
+// - 已轮换的 Refresh Token 再次出现视为泄露，吊销该用户全部登录态
+// - 退出所有设备: 删除全部 Refresh Token 并递增 token 版本号，已签发的 Access Token 随之失效
+type Service struct {
+	rdb        *redis.Client
+	tokens     *TokenManager
+	refreshTTL time.Duration
+}
+
+func NewService(rdb *redis.Client, cfg config.AuthConfig) (*Service, error) {
+	if cfg.JWTSecret == "" {
+		return nil, fmt.Errorf("auth.jwt_secret 未配置")
+	}
+	accessTTL := cfg.AccessTTL
+	if accessTTL <= 0 {
+		accessTTL = 15 * time.Minute
+	}
+	refreshTTL := cfg.RefreshTTL
+	if refreshTTL <= 0 {
+		refreshTTL = 30 * 24 * time.Hour
+	}
+	return &Service{
+		rdb:        rdb,
+		tokens:     NewTokenManager(cfg.JWTSecret, cfg.Issuer, accessTTL),
+		refreshTTL: refreshTTL,
+	}, nil
+}
+
+func refreshKey(hash string) string    { return "auth:refresh:" + hash }
+func rotatedKey(hash string) string    { return "auth:rotated:" + hash }
+func sessionsKey(userID uint64) string { return fmt.Sprintf("auth:sessions:%d", userID) }
+func versionKey(userID uint64) string  { return fmt.Sprintf("auth:ver:%d", userID) }
+
+// formatSession Refresh Token 在 Redis 中的值: "<userID>:<tenantID>"，刷新时据此重新签发
+func formatSession(userID, tenantID uint64) string {
+	return strconv.FormatUint(userID, 10) + ":" + strconv.FormatUint(tenantID, 10)
+}
+
+// parseSession 解析 formatSession 的值，多租户之前写入的值只有用户 ID，视为默认租户
+func parseSession(v string) (uint64, uint64, error) {
+	userPart, tenantPart, hasTenant := strings.Cut(v, ":")
+	userID, err := strconv.ParseUint(userPart, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !hasTenant {
+		return userID, tenant.DefaultID, nil
+	}
+	tenantID, err := strconv.ParseUint(tenantPart, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return userID, tenantID, nil
+}
+
+// hashToken Redis 中只保存 Refresh Token 的哈希，Redis 泄露也无法直接冒用
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// version 当前 token 版本号 (未设置时为 0)
+func (s *Service) version(ctx context.Context, userID uint64) (int64, error) {
+	v, err := s.rdb.Get(ctx, versionKey(userID)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return v, err
+}
+
+// Issue 登录成功后签发一组新凭证，tenantID 为用户所属租户
+func (s *Service) Issue(ctx context.Context, userID, tenantID uint64) (*TokenPair, error) {
+	ver, err := s.version(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	access, expiresAt, err := s.tokens.Issue(userID, tenantID, ver, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, err := safe_random.GenerateRandomHexString(32)
+	if err != nil {
+		return nil, err
+	}
+	hash := hashToken(refresh)
+
+	pipe := s.rdb.TxPipeline()
+	pipe.Set(ctx, refreshKey(hash), formatSession(userID, tenantID), s.refreshTTL)
+	pipe.SAdd(ctx, sessionsKey(userID), hash)
+	pipe.Expire(ctx, sessionsKey(userID), s.refreshTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		UserID:       userID,
+		TenantID:     tenantID,
+		AccessToken:  access,
+		RefreshToken: refresh,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// Refresh 用 Refresh Token 换一组新凭证 (轮换)
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	if refreshToken == "" {
+		return nil, errno.ErrTokenInvalid
+	}
+	hash := hashToken(refreshToken)
+
+	// GETDEL 保证同一个 Refresh Token 只能成功使用一次
+	session, err := s.rdb.GetDel(ctx, refreshKey(hash)).Result()
+	if errors.Is(err, redis.Nil) {
+		// 已轮换过的 token 被再次使用: 可能已泄露，吊销该用户全部登录态
+		if reusedBy, rerr := s.rdb.Get(ctx, rotatedKey(hash)).Uint64(); rerr == nil {
+			log.Printf("[Auth] 用户 %d 的 Refresh Token 被重复使用，吊销全部登录态", reusedBy)
+			if err := s.LogoutAll(ctx, reusedBy); err != nil {
+				log.Printf("[Auth] 吊销用户 %d 登录态失败: %v", reusedBy, err)
+			}
+		}
+		return nil, errno.ErrTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	userID, tenantID, err := parseSession(session)
+	if err != nil {
+		return nil, errno.ErrTokenInvalid
+	}
+
+	pipe := s.rdb.TxPipeline()
+	pipe.SRem(ctx, sessionsKey(userID), hash)
+	pipe.Set(ctx, rotatedKey(hash), userID, s.refreshTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.Issue(ctx, userID, tenantID)
+}
+
+// Logout 吊销单个 Refresh Token (当前设备退出)
+// 对应的 Access Token 在过期前仍然有效，所以 access_ttl 要保持较短
+func (s *Service) Logout(ctx context.Context, refreshToken string) error {
+	hash := hashToken(refreshToken)
+	session, err := s.rdb.GetDel(ctx, refreshKey(hash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	userID, _, err := parseSession(session)
+	if err != nil {
+		return nil
+	}
+	return s.rdb.SRem(ctx, sessionsKey(userID), hash).Err()
+}
+
+// LogoutAll 退出所有设备: 吊销全部 Refresh Token，并使已签发的 Access Token 立即失效
+func (s *Service) LogoutAll(ctx context.Context, userID uint64) error {
+	hashes, err := s.rdb.SMembers(ctx, sessionsKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+
+	pipe := s.rdb.TxPipeline()
+	for _, h := range hashes {
+		pipe.Del(ctx, refreshKey(h))
+	}
+	pipe.Del(ctx, sessionsKey(userID))
+	pipe.Incr(ctx, versionKey(userID))
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Authenticate 校验 Access Token，返回用户 ID 和所属租户
+func (s *Service) Authenticate(ctx context.Context, accessToken string) (uint64, uint64, error) {
+	if accessToken == "" {
+		return 0, 0, errno.ErrUnauthorized
+	}
+	claims, err := s.tokens.Parse(accessToken, time.Now())
+	if err != nil {
+		return 0, 0, err
+	}
+	userID, _ := claims.UserID()
+
+	ver, err := s.version(ctx, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if claims.Version != ver {
+		return 0, 0, errno.ErrTokenInvalid
+	}
+	return userID, claims.TenantID(), nil
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/tenant"
+)
+
+// Claims Access Token 载荷
+// Version 为签发时用户的 token 版本号，"退出所有设备" 会递增版本号，使已签发的 Access Token 全部失效
+// Tenant 为用户所属租户 (tid)，多租户之前签发的 token 没有该字段，视为默认租户
+type Claims struct {
+	Version int64  `json:"ver"`
+	Tenant  uint64 `json:"tid,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// UserID 从 sub 解析用户 ID
+func (c *Claims) UserID() (uint64, error) {
+	return strconv.ParseUint(c.Subject, 10, 64)
+}
+
+// TenantID 用户所属租户
+func (c *Claims) TenantID() uint64 {
+	if c.Tenant == 0 {
+		return tenant.DefaultID
+	}
+	return c.Tenant
+}
+
+// TokenManager 签发 / 校验 JWT Access Token (HS256)
+type TokenManager struct {
+	secret []byte
+	issuer string
+	ttl    time.Duration
+}
+
+func NewTokenManager(secret, issuer string, ttl time.Duration) *TokenManager {
+	return &TokenManager{secret: []byte(secret), issuer: issuer, ttl: ttl}
+}
+
+// Issue 签发 Access Token，返回 token 和过期时间
+// tenantID 为 0 时不写 tid (管理员会话不属于任何租户)
+func (m *TokenManager) Issue(userID, tenantID uint64, version int64, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(m.ttl)
+	claims := Claims{
+		Version: version,
+		Tenant:  tenantID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    m.issuer,
+			Subject:   strconv.FormatUint(userID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// Parse 校验签名 / 签发方 / 有效期，只接受 HS256 (拒绝 alg=none 等降级攻击)
+func (m *TokenManager) Parse(token string, now time.Time) (*Claims, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, errno.ErrTokenExpired
+		}
+		return nil, errno.ErrTokenInvalid
+	}
+	if _, err := claims.UserID(); err != nil {
+		return nil, errno.ErrTokenInvalid
+	}
+	return &claims, nil
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/tenant"
+)
+
+func TestTokenIssueAndParse(t *testing.T) {
+	m := NewTokenManager("secret", "wallet-core", 15*time.Minute)
+	now := time.Now()
+
+	token, expiresAt, err := m.Issue(42, 7, 3, now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(15*time.Minute), expiresAt)
+
+	claims, err := m.Parse(token, now.Add(time.Minute))
+	require.NoError(t, err)
+	userID, err := claims.UserID()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), userID)
+	assert.Equal(t, int64(3), claims.Version)
+	assert.Equal(t, uint64(7), claims.TenantID())
+
+	// 过期
+	_, err = m.Parse(token, now.Add(16*time.Minute))
+	assert.ErrorIs(t, err, errno.ErrTokenExpired)
+
+	// 密钥不同 / 签发方不同
+	_, err = NewTokenManager("other", "wallet-core", time.Minute).Parse(token, now)
+	assert.ErrorIs(t, err, errno.ErrTokenInvalid)
+	_, err = NewTokenManager("secret", "other", time.Minute).Parse(token, now)
+	assert.ErrorIs(t, err, errno.ErrTokenInvalid)
+}
+
+func TestTokenRejectsAlgNone(t *testing.T) {
+	m := NewTokenManager("secret", "wallet-core", time.Minute)
+	now := time.Now()
+
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "wallet-core",
+			Subject:   "1",
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	_, err = m.Parse(unsigned, now)
+	assert.ErrorIs(t, err, errno.ErrTokenInvalid)
+}
+
+func TestTokenWithoutTenantIsDefault(t *testing.T) {
+	m := NewTokenManager("secret", "wallet-core", time.Minute)
+	now := time.Now()
+
+	// 多租户之前签发的 token 没有 tid
+	token, _, err := m.Issue(42, 0, 0, now)
+	require.NoError(t, err)
+	claims, err := m.Parse(token, now)
+	require.NoError(t, err)
+	assert.Equal(t, tenant.DefaultID, claims.TenantID())
+}
+
+func TestParseSession(t *testing.T) {
+	userID, tenantID, err := parseSession(formatSession(42, 7))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), userID)
+	assert.Equal(t, uint64(7), tenantID)
+
+	// 多租户之前写入的 Refresh Token 只有用户 ID
+	userID, tenantID, err = parseSession("42")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), userID)
+	assert.Equal(t, tenant.DefaultID, tenantID)
+
+	_, _, err = parseSession("42:x")
+	assert.Error(t, err)
+}
+
+func TestBearerToken(t *testing.T) {
+	assert.Equal(t, "abc", BearerToken("Bearer abc"))
+	assert.Equal(t, "abc", BearerToken("bearer  abc "))
+	assert.Equal(t, "", BearerToken("Basic abc"))
+	assert.Equal(t, "", BearerToken(""))
+}
@@ -2,17 +2,27 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
+	"strings"
 	"time"
 
 	"wallet-core/internal/model"
+	"wallet-core/internal/service/fee"
 	"wallet-core/pkg/bip32"
 	"wallet-core/pkg/monitor"
+	"wallet-core/pkg/wallet/ethtx"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // BroadcasterService 负责将审批通过的提现单广播上链
@@ -20,12 +30,25 @@ type BroadcasterService struct {
 	db        *gorm.DB
 	ethClient *ethclient.Client
 	masterKey bip32.ExtendedKey
+	hotKey    bip32.ExtendedKey // 热钱包私钥 (m/0/0)，地址与 wallet.hot_wallet 一致
+	hotAddr   common.Address
 	chainID   *big.Int
+	fees      *fee.Service // 手续费估算
 }
 
 var Broadcaster *BroadcasterService
 
-func NewBroadcasterService(db *gorm.DB, rpcURL string, masterKey bip32.ExtendedKey) (*BroadcasterService, error) {
+// ErrPayoutAccountUnavailable 租户配置的热钱包不是本系统可以签名的地址，该租户的提现不广播
+var ErrPayoutAccountUnavailable = errors.New("租户热钱包无法由本系统签名")
+
+// NewBroadcasterService hotWallet 为配置的热钱包地址 (归集目标)，必须与主密钥派生的出款地址一致，
+// 否则出款地址永远收不到归集的资金
+func NewBroadcasterService(db *gorm.DB, rpcURL string, masterKey bip32.ExtendedKey, hotWallet string, fees *fee.Service) (*BroadcasterService, error) {
+	hotKey, hotAddr, err := hotWalletKey(masterKey, hotWallet)
+	if err != nil {
+		return nil, err
+	}
+
 	client, err := ethclient.Dial(rpcURL)
 	chainID := big.NewInt(1)
 	if err == nil {
@@ -35,13 +58,17 @@ func NewBroadcasterService(db *gorm.DB, rpcURL string, masterKey bip32.ExtendedK
 		}
 	} else {
 		log.Printf("[Broadcaster] Warning: RPC 无法连接，将运行在模拟模式")
+		client = nil
 	}
 
 	return &BroadcasterService{
 		db:        db,
 		ethClient: client,
 		masterKey: masterKey,
+		hotKey:    hotKey,
+		hotAddr:   hotAddr,
 		chainID:   chainID,
+		fees:      fees,
 	}, nil
 }
 
@@ -62,81 +89,390 @@ func (s *BroadcasterService) Start(ctx context.Context) {
 	}()
 }
 
+// broadcastBatchSize 每轮最多认领的提现单数量
+const broadcastBatchSize = 10
+
+// processPendingWithdrawals 先重发已签名但未确认广播成功的交易，再逐笔认领新的提现单
+// 认领 (SKIP LOCKED)、签名、落库 (raw tx / nonce / hash) 在同一事务内完成，提交后才广播:
+// 多个实例不会拿到同一笔提现; 广播失败或进程退出时提现单停在 broadcasting，下一轮重发同一笔交易，不会换 nonce 重复出款
 func (s *BroadcasterService) processPendingWithdrawals(ctx context.Context) {
-	var withdrawals []model.Withdrawal
-	// 查询 batches 避免内存溢出
-	if err := s.db.Where("status = ?", "pending_broadcast").Limit(10).Find(&withdrawals).Error; err != nil {
-		log.Printf("[Broadcaster] 查询失败: %v", err)
-		return
+	s.resendSigned(ctx)
+
+	var skipped []uint64 // 本轮无法签名的提现单，不再重复认领
+	for claimed := 0; claimed < broadcastBatchSize; {
+		w, signedTx, err := s.claimNext(ctx, skipped)
+		if errors.Is(err, ErrPayoutAccountUnavailable) {
+			log.Printf("[Broadcaster] ⚠️ 提现单 %d 暂不广播，需要人工处理: %v", w.ID, err)
+			skipped = append(skipped, w.ID)
+			continue
+		}
+		if err != nil {
+			log.Printf("[Broadcaster] 认领提现单失败: %v", err)
+			return
+		}
+		if w == nil {
+			return // 没有待广播的提现
+		}
+		claimed++
+		if signedTx != nil {
+			s.send(ctx, w, signedTx)
+		}
 	}
+}
+
+// claimNext 认领一笔到期的 pending_broadcast 提现单 (跳过 skip 中的)，签名后置为 broadcasting (模拟模式直接置为 broadcasted)
+// 没有可认领的提现单时返回 nil; 租户热钱包无法签名时返回该提现单和 ErrPayoutAccountUnavailable，提现单保持不变
+func (s *BroadcasterService) claimNext(ctx context.Context, skip []uint64) (*model.Withdrawal, *types.Transaction, error) {
+	var (
+		w        model.Withdrawal
+		signedTx *types.Transaction
+		found    bool
+	)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// execute_after 再校验一次，防止时间锁未到期的提现被误放行
+		// 只处理 ETH: BTC 出款尚未接入签名器，TxTracker 也只跟踪 ETH，其他链的提现留在 pending_broadcast 等待人工处理
+		// (模拟模式同样不能伪造 Hash 置为 broadcasted，否则永远不会被确认，冻结的资金也不会释放)
+		q := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND UPPER(chain) = ? AND (execute_after IS NULL OR execute_after <= ?)",
+				model.WithdrawalStatusPendingBroadcast, "ETH", time.Now())
+		if len(skip) > 0 {
+			q = q.Where("id NOT IN ?", skip)
+		}
+		err := q.Order("id").First(&w).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		found = true
+		log.Printf("[Broadcaster] 开始处理提现单 ID: %d, To: %s, Amount: %s", w.ID, w.ToAddress, w.Amount)
 
-	for _, w := range withdrawals {
-		s.broadcastOne(ctx, &w)
+		if s.ethClient == nil {
+			// 模拟模式: 不签名，只生成假 Hash，手续费按兜底值记录
+			return s.mockBroadcast(ctx, tx, &w)
+		}
+		signedTx, err = s.signWithdrawal(ctx, tx, &w)
+		return err
+	})
+	if !found {
+		return nil, nil, err
 	}
+	if err != nil {
+		return &w, nil, err
+	}
+	return &w, signedTx, nil
 }
 
-func (s *BroadcasterService) broadcastOne(ctx context.Context, w *model.Withdrawal) {
-	log.Printf("[Broadcaster] 开始处理提现单 ID: %d, To: %s, Amount: %s", w.ID, w.ToAddress, w.Amount)
+// signWithdrawal 签名提现交易，并在广播前把 raw tx / nonce / hash / 手续费写回提现单 (status: broadcasting)
+func (s *BroadcasterService) signWithdrawal(ctx context.Context, tx *gorm.DB, w *model.Withdrawal) (*types.Transaction, error) {
+	key, fromAddr, err := s.payoutAccount(ctx, tx, w.TenantID, w.Chain)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := s.nextNonce(ctx, tx, fromAddr)
+	if err != nil {
+		return nil, err
+	}
+	estimate, err := s.fees.Estimate(ctx, w.Chain)
+	if err != nil {
+		return nil, err
+	}
+	fees := estimate.Quote(fee.LevelStandard).EthFees()
+	gasLimit := fee.GasLimitTransfer // 标准转账
 
-	// 1. 派生热钱包私钥 (假设热钱包使用 Path m/44'/60'/0'/0/0)
-	// 这里简化：假设 SweeperService 里的 Hot Wallet 就是 Master Key 派生出来的第一个地址
-	// 或者为了演示，直接使用 MasterPrivate Key 签发 (不推荐生产)
-	// 更好的做法是 Config 里配置 HotWalletPrivateKey，或者像 SweeperService 一样管理 Keys.
-	// 假设我们沿用 SweeperService 的逻辑：热钱包是 m/44'/60'/0'/0/0 (Account 0)
+	signedTx, err := s.signEth(key, nonce, common.HexToAddress(w.ToAddress), ethToWei(w.Amount), gasLimit, fees)
+	if err != nil {
+		return nil, err
+	}
+	rawTx, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	w.Status = model.WithdrawalStatusBroadcasting
+	w.TxHash = signedTx.Hash().Hex()
+	w.RawTx = hexutil.Encode(rawTx)
+	w.FromAddress = fromAddr.Hex()
+	w.Nonce = nonce
+	setWithdrawalFees(w, signedTx.Type(), gasLimit, fees)
+	err = s.claimUpdate(tx, w, map[string]interface{}{
+		"status":       w.Status,
+		"tx_hash":      w.TxHash,
+		"raw_tx":       w.RawTx,
+		"from_address": w.FromAddress,
+		"nonce":        w.Nonce,
+		"gas_limit":    w.GasLimit,
+		"tx_type":      w.TxType,
+		"gas_price":    w.GasPrice,
+		"gas_tip_cap":  w.GasTipCap,
+		"gas_fee":      w.GasFee,
+		"updated_at":   time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return signedTx, nil
+}
+
+// mockBroadcast 模拟模式: 记录假 Hash 和估算的手续费，直接置为 broadcasted
+func (s *BroadcasterService) mockBroadcast(ctx context.Context, tx *gorm.DB, w *model.Withdrawal) error {
+	if err := s.applyFees(ctx, w, fee.GasLimitTransfer); err != nil {
+		return err
+	}
+	now := time.Now()
+	w.TxHash = fmt.Sprintf("0xmocked_tx_hash_%d_%d", w.ID, now.Unix())
+	w.Status = model.WithdrawalStatusBroadcasted
+	w.BroadcastAt = &now
+	return s.claimUpdate(tx, w, map[string]interface{}{
+		"status":       w.Status,
+		"tx_hash":      w.TxHash,
+		"gas_limit":    w.GasLimit,
+		"tx_type":      w.TxType,
+		"gas_price":    w.GasPrice,
+		"gas_tip_cap":  w.GasTipCap,
+		"gas_fee":      w.GasFee,
+		"broadcast_at": now,
+		"updated_at":   now,
+	})
+}
 
-	// ...
-	hotWalletKey, err := s.deriveHotWalletKey()
+// claimUpdate 只更新仍处于 pending_broadcast 的提现单，避免覆盖并发的取消 / 驳回
+func (s *BroadcasterService) claimUpdate(tx *gorm.DB, w *model.Withdrawal, updates map[string]interface{}) error {
+	res := tx.Model(&model.Withdrawal{}).
+		Where("id = ? AND status = ?", w.ID, model.WithdrawalStatusPendingBroadcast).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("提现单 %d 状态已变化，放弃广播", w.ID)
+	}
+	return nil
+}
+
+// nextNonce 出款地址的下一个 nonce
+// 取链上 pending nonce 与 broadcasting 交易最大 nonce + 1 中的较大者: 已签名但还没广播成功的交易不在节点交易池里，
+// 不能把它的 nonce 分配给下一笔提现; advisory lock 保证多个实例串行分配
+func (s *BroadcasterService) nextNonce(ctx context.Context, tx *gorm.DB, from common.Address) (uint64, error) {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", from.Hex()).Error; err != nil {
+		return 0, err
+	}
+	nonce, err := s.ethClient.PendingNonceAt(ctx, from)
 	if err != nil {
-		log.Printf("[Broadcaster] 派生私钥失败: %v", err)
+		return 0, err
+	}
+	var signed struct {
+		Count    int64
+		MaxNonce uint64
+	}
+	if err := tx.Model(&model.Withdrawal{}).
+		Select("COUNT(*) AS count, COALESCE(MAX(nonce), 0) AS max_nonce").
+		Where("from_address = ? AND status = ?", from.Hex(), model.WithdrawalStatusBroadcasting).
+		Scan(&signed).Error; err != nil {
+		return 0, err
+	}
+	if signed.Count > 0 && signed.MaxNonce+1 > nonce {
+		nonce = signed.MaxNonce + 1
+	}
+	return nonce, nil
+}
+
+// send 广播已落库的交易，成功后置为 broadcasted
+// 广播成功 != 提现完成，最终状态由 TxTracker 根据确认数决定
+func (s *BroadcasterService) send(ctx context.Context, w *model.Withdrawal, signedTx *types.Transaction) {
+	if err := s.ethClient.SendTransaction(ctx, signedTx); err != nil && !alreadySent(err) {
+		log.Printf("[Broadcaster] 广播失败 ID: %d, TxHash: %s (下一轮重发): %v", w.ID, w.TxHash, err)
+		return
+	}
+
+	now := time.Now()
+	res := s.db.WithContext(ctx).Model(&model.Withdrawal{}).
+		Where("id = ? AND status = ? AND tx_hash = ?", w.ID, model.WithdrawalStatusBroadcasting, w.TxHash).
+		Updates(map[string]interface{}{
+			"status":       model.WithdrawalStatusBroadcasted,
+			"broadcast_at": now,
+			"updated_at":   now,
+		})
+	if res.Error != nil {
+		// 交易已经发出，下一轮重发同一笔交易后会再次尝试更新
+		log.Printf("[Broadcaster] 保存状态失败 ID: %d: %v", w.ID, res.Error)
+		return
+	}
+	if res.RowsAffected == 0 {
+		return // 其他实例已经更新过
+	}
+
+	// Metric
+	if monitor.Business != nil && monitor.Business.WithdrawalSuccessTotal != nil {
+		monitor.Business.WithdrawalSuccessTotal.WithLabelValues(w.Chain).Inc()
+	}
+
+	log.Printf("[Broadcaster] ✅ 提现广播成功! TxHash: %s", w.TxHash)
+}
+
+// resendSigned 重发已签名落库、但还没确认广播成功的交易 (同一笔 raw tx，同 hash，重复广播不会重复出款)
+func (s *BroadcasterService) resendSigned(ctx context.Context) {
+	if s.ethClient == nil {
+		return
+	}
+	var withdrawals []model.Withdrawal
+	if err := s.db.WithContext(ctx).
+		Where("status = ?", model.WithdrawalStatusBroadcasting).
+		Order("id").Limit(broadcastBatchSize).Find(&withdrawals).Error; err != nil {
+		log.Printf("[Broadcaster] 查询待重发交易失败: %v", err)
 		return
 	}
-	// hotWalletKey 是 *bip32.ExtendedKey interface?? No, it's struct in my pkg
-	// Check pkg/bip32 definition. If it's *ExtendedKey, call method directly.
-	// If the error says "type *bip32.ExtendedKey is pointer to interface", then ExtendedKey is likely an interface.
 
-	// Assuming pkg/bip32.ExtendedKey is an interface, we shouldn't use *
-	// based on error: "type *bip32.ExtendedKey is pointer to interface"
-	// So deriveHotWalletKey returns *Interface, which is wrong. It should return Interface.
+	for i := range withdrawals {
+		w := &withdrawals[i]
+		signedTx, err := decodeRawTx(w.RawTx)
+		if err != nil {
+			log.Printf("[Broadcaster] ⚠️ 提现单 %d 的已签名交易无法解析，需要人工处理: %v", w.ID, err)
+			continue
+		}
+		s.send(ctx, w, signedTx)
+	}
+}
 
-	// Let's look at the error again: "hotWalletKey.ToECDSA undefined (type *bip32.ExtendedKey is pointer to interface, not interface)"
-	// This implies `hotWalletKey` is of type `*bip32.ExtendedKey`, and `bip32.ExtendedKey` IS an interface.
-	// So I should dereference it or change return type.
+// decodeRawTx 解析落库的已签名交易 (0x 开头的 hex)
+func decodeRawTx(raw string) (*types.Transaction, error) {
+	b, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return signedTx, nil
+}
 
-	// However, to be safe and quick, I will just use the value if I can, or fix the return type of deriveHotWalletKey.
+// alreadySent 节点已经收到过这笔交易 (重发)，或 nonce 已被打包 (上次广播成功后没来得及更新状态)
+// 都按广播成功处理，交易是否真的上链由 TxTracker 按 nonce 判断
+func alreadySent(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "nonce too low")
+}
 
-	// For now, let me comment out the unused vars to make it compile, as this is legacy code we are replacing anyway with Broadcaster Worker.
-	// But wait, Broadcaster Worker uses MAIN.GO, this file is the OLD service.
-	// I should probably just fix it to compile.
+// applyFees 模拟模式下按估算结果记录手续费 (不签名)
+func (s *BroadcasterService) applyFees(ctx context.Context, w *model.Withdrawal, gasLimit uint64) error {
+	estimate, err := s.fees.Estimate(ctx, w.Chain)
+	if err != nil {
+		return err
+	}
+	fees := estimate.Quote(fee.LevelStandard).EthFees()
+	txType := uint8(types.LegacyTxType)
+	if fees.Dynamic {
+		txType = types.DynamicFeeTxType
+	}
+	setWithdrawalFees(w, txType, gasLimit, fees)
+	return nil
+}
 
-	_ = hotWalletKey // suppress unused (if I comment out below)
-	// ecdsaKey := (*hotWalletKey).ToECDSA() // Try dereference if it's pointer to interface
+// setWithdrawalFees 把广播时的手续费参数记录到提现单上 (GasFee 为按 MaxPrice 预估的上限)
+func setWithdrawalFees(w *model.Withdrawal, txType uint8, gasLimit uint64, fees ethtx.Fees) {
+	w.GasLimit = gasLimit
+	w.TxType = txType
+	w.GasPrice = decimalOrZero(fees.MaxPrice())
+	w.GasTipCap = decimalOrZero(fees.GasTipCap)
+	w.GasFee = w.GasPrice.Mul(decimal.NewFromInt(int64(gasLimit)))
+}
 
-	// 暂时注释掉未使用的变量，为了通过编译
-	// nonce := uint64(0)
+// signEth 用出款账户私钥签名一笔交易 (Legacy / EIP-1559)，不广播
+// 提现 / 加速 / 取消 都先签名并落库，提交后再广播，私钥只在 Broadcaster 内部使用
+func (s *BroadcasterService) signEth(key bip32.ExtendedKey, nonce uint64, to common.Address, value *big.Int, gasLimit uint64, fees ethtx.Fees) (*types.Transaction, error) {
+	privKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
 
-	// 模拟广播成功
-	txHash := fmt.Sprintf("0xmocked_tx_hash_%d_%d", w.ID, time.Now().Unix())
+	tx := ethtx.NewTx(s.chainID, nonce, to, value, gasLimit, fees, nil)
+	signedTx, err := types.SignTx(tx, ethtx.Signer(s.chainID), privKey.ToECDSA())
+	if err != nil {
+		return nil, fmt.Errorf("签名失败: %w", err)
+	}
+	return signedTx, nil
+}
 
-	// 3. 更新数据库
-	w.Status = "completed" // 或者 success
-	w.TxHash = txHash
-	w.UpdatedAt = time.Now()
+// payoutAccount 租户的出款账户，与归集目标一致 (见 SweeperService.tenantAccount)
+// - 租户未单独配置热钱包: 全局热钱包 (m/0/0)
+// - internal 托管的租户配置了热钱包: 租户账户密钥下的 account/0/0 (m/44'/coin'/租户 ID'/0/0)，须与配置的地址一致
+// - 其他情况 (external 托管 / 配置的热钱包不是本系统派生的) 返回 ErrPayoutAccountUnavailable
+func (s *BroadcasterService) payoutAccount(ctx context.Context, db *gorm.DB, tenantID uint64, chain string) (bip32.ExtendedKey, common.Address, error) {
+	tc, err := LoadTenantChain(ctx, db, tenantID, chain)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	if tc == nil || tc.HotWallet == "" || common.HexToAddress(tc.HotWallet) == s.hotAddr {
+		return s.hotKey, s.hotAddr, nil
+	}
+	if tc.Custody != model.TenantCustodyInternal {
+		return nil, common.Address{}, fmt.Errorf("%w: 租户 %d 自行保管私钥", ErrPayoutAccountUnavailable, tenantID)
+	}
 
-	if err := s.db.Save(w).Error; err != nil {
-		log.Printf("[Broadcaster] 保存状态失败: %v", err)
-		return
+	account, err := TenantAccountKey(s.masterKey, tenantID, chain)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	key, err := deriveHotWalletKey(account)
+	if err != nil {
+		return nil, common.Address{}, err
 	}
+	addr, err := keyAddress(key)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	if common.HexToAddress(tc.HotWallet) != addr {
+		return nil, common.Address{}, fmt.Errorf("%w: 租户 %d 的热钱包 %s 不是 %s", ErrPayoutAccountUnavailable, tenantID, tc.HotWallet, addr.Hex())
+	}
+	return key, addr, nil
+}
 
-	// Metric
-	// 使用刚添加的字段
-	if monitor.Business.WithdrawalSuccessTotal != nil {
-		monitor.Business.WithdrawalSuccessTotal.WithLabelValues(w.Chain).Inc()
+// hotWalletKey 派生热钱包私钥，并校验派生地址与配置的热钱包 (归集目标) 一致
+// 热钱包使用 m/0/0: 用户充值地址的 index 由 Redis INCR 分配，从 1 开始，不会与之冲突
+func hotWalletKey(masterKey bip32.ExtendedKey, hotWallet string) (bip32.ExtendedKey, common.Address, error) {
+	key, err := deriveHotWalletKey(masterKey)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("派生热钱包私钥失败: %w", err)
 	}
+	addr, err := keyAddress(key)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	if !common.IsHexAddress(hotWallet) || common.HexToAddress(hotWallet) != addr {
+		return nil, common.Address{}, fmt.Errorf("热钱包地址 %q 与主密钥派生的出款地址 %s 不一致", hotWallet, addr.Hex())
+	}
+	return key, addr, nil
+}
 
-	log.Printf("[Broadcaster] ✅ 提现广播成功! TxHash: %s", txHash)
+// deriveHotWalletKey 账户密钥下的热钱包私钥 (account/0/0)
+func deriveHotWalletKey(account bip32.ExtendedKey) (bip32.ExtendedKey, error) {
+	chainKey, err := account.Derive(0)
+	if err != nil {
+		return nil, err
+	}
+	return chainKey.Derive(0)
+}
+
+// keyAddress 私钥对应的 ETH 地址
+func keyAddress(key bip32.ExtendedKey) (common.Address, error) {
+	pubKey, err := key.ECPubKey()
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey.ToECDSA()), nil
+}
+
+// decimalOrZero Legacy 交易没有小费，记 0
+func decimalOrZero(v *big.Int) decimal.Decimal {
+	if v == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromBigInt(v, 0)
 }
 
-func (s *BroadcasterService) deriveHotWalletKey() (bip32.ExtendedKey, error) {
-	// 简化：直接用 Master Key (仅作演示)
-	return s.masterKey, nil
+// ethToWei 提现金额单位是 ETH，链上需要 Wei
+func ethToWei(amount decimal.Decimal) *big.Int {
+	return amount.Mul(decimal.New(1, 18)).BigInt()
 }
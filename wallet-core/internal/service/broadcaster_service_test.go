@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/bip32"
+	"wallet-core/pkg/bip39"
+)
+
+func TestClaimUpdateOnlyTouchesPendingBroadcast(t *testing.T) {
+	db := dryRunDB(t)
+	var sql string
+	var vars []interface{}
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("capture", func(d *gorm.DB) {
+		sql, vars = d.Statement.SQL.String(), d.Statement.Vars
+	}))
+
+	s := &BroadcasterService{db: db}
+	err := s.claimUpdate(db, &model.Withdrawal{ID: 5}, map[string]interface{}{"status": model.WithdrawalStatusBroadcasting})
+	// DryRun 不影响任何行，等同于提现单已被取消 / 驳回: 放弃广播
+	assert.Error(t, err)
+	assert.Contains(t, sql, `WHERE id = $3 AND status = $4`)
+	assert.Equal(t, []interface{}{uint64(5), model.WithdrawalStatusPendingBroadcast}, vars[len(vars)-2:])
+}
+
+func TestDecodeRawTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(1)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		To:        &common.Address{1},
+		Value:     big.NewInt(1e18),
+		Gas:       21000,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(30e9),
+	})
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+	require.NoError(t, err)
+	raw, err := signed.MarshalBinary()
+	require.NoError(t, err)
+
+	// 重发的是同一笔交易 (同 hash / 同 nonce)
+	decoded, err := decodeRawTx(hexutil.Encode(raw))
+	require.NoError(t, err)
+	assert.Equal(t, signed.Hash(), decoded.Hash())
+	assert.Equal(t, uint64(7), decoded.Nonce())
+
+	_, err = decodeRawTx("")
+	assert.Error(t, err)
+}
+
+func TestAlreadySent(t *testing.T) {
+	assert.True(t, alreadySent(errors.New("already known")))
+	assert.True(t, alreadySent(errors.New("nonce too low: next nonce 8, tx nonce 7")))
+	assert.False(t, alreadySent(errors.New("insufficient funds for gas * price + value")))
+}
+
+func TestHotWalletKeyMatchesConfiguredHotWallet(t *testing.T) {
+	seed := bip39.NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	w, err := bip32.NewMasterKeyFromSeed(seed, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	// config.yaml 中的热钱包即 m/0/0
+	key, addr, err := hotWalletKey(w.MasterKey(), "0x2d46f53e3e0fb19d37c7cb8df3bec7c93e052482")
+	require.NoError(t, err)
+	assert.NotNil(t, key)
+	assert.Equal(t, common.HexToAddress("0x2D46F53e3e0fB19d37C7CB8df3beC7c93e052482"), addr)
+
+	// 归集目标不是出款地址: 拒绝启动
+	_, _, err = hotWalletKey(w.MasterKey(), "0xBeE4e510825B3F4588E9152C9F8E45402F000000")
+	assert.Error(t, err)
+	_, _, err = hotWalletKey(w.MasterKey(), "")
+	assert.Error(t, err)
+}
+
+func TestPayoutAccountFollowsTenantHotWallet(t *testing.T) {
+	seed := bip39.NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	w, err := bip32.NewMasterKeyFromSeed(seed, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	master := w.MasterKey()
+	hotKey, hotAddr, err := hotWalletKey(master, "0x2D46F53e3e0fB19d37C7CB8df3beC7c93e052482")
+	require.NoError(t, err)
+
+	// 租户 2 的出款地址: m/44'/60'/2'/0/0
+	account, err := TenantAccountKey(master, 2, "ETH")
+	require.NoError(t, err)
+	tenantKey, err := deriveHotWalletKey(account)
+	require.NoError(t, err)
+	tenantAddr, err := keyAddress(tenantKey)
+	require.NoError(t, err)
+
+	var tc *model.TenantChain // 查询 tenant_chains 的结果，nil 表示未配置
+	db := dryRunDB(t)
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:fill", func(tx *gorm.DB) {
+		if dest, ok := tx.Statement.Dest.(*model.TenantChain); ok && tc != nil {
+			*dest = *tc
+			tx.RowsAffected = 1
+		}
+	}))
+	s := &BroadcasterService{db: db, masterKey: master, hotKey: hotKey, hotAddr: hotAddr}
+
+	// 未配置租户热钱包: 全局热钱包
+	_, addr, err := s.payoutAccount(context.Background(), db, 2, "ETH")
+	require.NoError(t, err)
+	assert.Equal(t, hotAddr, addr)
+
+	// internal 托管，热钱包为租户账户派生的地址
+	tc = &model.TenantChain{TenantID: 2, Chain: "ETH", Custody: model.TenantCustodyInternal, HotWallet: tenantAddr.Hex()}
+	_, addr, err = s.payoutAccount(context.Background(), db, 2, "ETH")
+	require.NoError(t, err)
+	assert.Equal(t, tenantAddr, addr)
+
+	// 热钱包不是本系统派生的 / external 托管: 不广播
+	tc = &model.TenantChain{TenantID: 2, Chain: "ETH", Custody: model.TenantCustodyInternal, HotWallet: "0xBeE4e510825B3F4588E9152C9F8E45402F000000"}
+	_, _, err = s.payoutAccount(context.Background(), db, 2, "ETH")
+	assert.ErrorIs(t, err, ErrPayoutAccountUnavailable)
+	tc = &model.TenantChain{TenantID: 2, Chain: "ETH", Custody: model.TenantCustodyExternal, HotWallet: tenantAddr.Hex()}
+	_, _, err = s.payoutAccount(context.Background(), db, 2, "ETH")
+	assert.ErrorIs(t, err, ErrPayoutAccountUnavailable)
+}
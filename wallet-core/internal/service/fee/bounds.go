@@ -0,0 +1,93 @@
+package fee
+
+import (
+	"math/big"
+
+	"wallet-core/pkg/config"
+)
+
+const (
+	defaultFallbackGasPriceGwei = 20 // 未配置 fallback_gas_price_gwei 时的兜底值
+	defaultFallbackFeeRate      = 10 // 未配置 fallback_fee_rate 时的兜底值 (sat/vB)
+)
+
+var (
+	levels = []Level{LevelSlow, LevelStandard, LevelFast}
+
+	// levelPercent 没有分档数据源时 (eth_gasPrice / 兜底值)，各档位相对标准价的比例
+	levelPercent = map[Level]int64{
+		LevelSlow:     90,
+		LevelStandard: 100,
+		LevelFast:     125,
+	}
+
+	defaultFallbackTipCap = big.NewInt(1_000_000_000) // 1 Gwei
+	gwei                  = big.NewInt(1_000_000_000)
+)
+
+// applyBounds 把估算结果限制在配置的 [min, max] 之间 (0 表示不限制)
+// EIP-1559 交易只约束 MaxFeePerGas，同时保证小费不超过 MaxFeePerGas
+func applyBounds(est *Estimate, cfg config.ChainConfig) {
+	if est.TxType == TxTypeUTXO {
+		for _, level := range levels {
+			q := est.Quote(level)
+			q.FeeRate = clampInt(q.FeeRate, cfg.MinFeeRate, cfg.MaxFeeRate)
+			est.set(level, q)
+		}
+		return
+	}
+
+	var lo, hi *big.Int
+	if cfg.MinGasPriceGwei > 0 {
+		lo = new(big.Int).Mul(big.NewInt(cfg.MinGasPriceGwei), gwei)
+	}
+	if cfg.MaxGasPriceGwei > 0 {
+		hi = new(big.Int).Mul(big.NewInt(cfg.MaxGasPriceGwei), gwei)
+	}
+
+	for _, level := range levels {
+		q := est.Quote(level)
+		if q.GasFeeCap != nil {
+			q.GasFeeCap = clampBig(q.GasFeeCap, lo, hi)
+			if q.GasTipCap != nil {
+				q.GasTipCap = minBig(q.GasTipCap, q.GasFeeCap)
+			}
+		} else if q.GasPrice != nil {
+			q.GasPrice = clampBig(q.GasPrice, lo, hi)
+		}
+		est.set(level, q)
+	}
+}
+
+// scale 返回 v * percent / 100
+func scale(v *big.Int, percent int64) *big.Int {
+	r := new(big.Int).Mul(v, big.NewInt(percent))
+	return r.Div(r, big.NewInt(100))
+}
+
+func clampBig(v, lo, hi *big.Int) *big.Int {
+	if lo != nil && v.Cmp(lo) < 0 {
+		return new(big.Int).Set(lo)
+	}
+	if hi != nil && v.Cmp(hi) > 0 {
+		return new(big.Int).Set(hi)
+	}
+	return v
+}
+
+func minBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) > 0 {
+		return new(big.Int).Set(b)
+	}
+	return new(big.Int).Set(a)
+}
+
+func clampInt(v, lo, hi int64) int64 {
+	if lo > 0 && v < lo {
+		return lo
+	}
+	if hi > 0 && v > hi {
+		return hi
+	}
+	return v
+}
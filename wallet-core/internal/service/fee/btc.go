@@ -0,0 +1,56 @@
+package fee
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"wallet-core/pkg/config"
+)
+
+// recommendedFees mempool.space /api/v1/fees/recommended 的返回结构 (sat/vB)
+type recommendedFees struct {
+	FastestFee  int64 `json:"fastestFee"`  // 下一个区块
+	HalfHourFee int64 `json:"halfHourFee"` // 约 3 个区块
+	HourFee     int64 `json:"hourFee"`     // 约 6 个区块
+	EconomyFee  int64 `json:"economyFee"`
+	MinimumFee  int64 `json:"minimumFee"`
+}
+
+// fetchBtc 从费率接口获取 BTC 三档费率
+func (s *Service) fetchBtc(ctx context.Context, cfg config.ChainConfig) (*Estimate, error) {
+	if cfg.FeeAPI == "" {
+		return nil, errors.New("未配置 BTC 费率接口 (chains.btc.fee_api)")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.FeeAPI, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("费率接口返回 %d", resp.StatusCode)
+	}
+
+	var fees recommendedFees
+	if err := json.NewDecoder(resp.Body).Decode(&fees); err != nil {
+		return nil, err
+	}
+	if fees.FastestFee <= 0 {
+		return nil, errors.New("费率接口返回无效数据")
+	}
+
+	est := &Estimate{}
+	est.Slow, est.Standard, est.Fast = btcLevels(fees.HourFee, fees.HalfHourFee, fees.FastestFee)
+	return est, nil
+}
+
+func btcLevels(slow, standard, fast int64) (Quote, Quote, Quote) {
+	return Quote{FeeRate: slow}, Quote{FeeRate: standard}, Quote{FeeRate: fast}
+}
@@ -0,0 +1,102 @@
+package fee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+
+	"wallet-core/pkg/wallet/ethtx"
+)
+
+const (
+	// GasLimitTransfer 原生币转账固定消耗 21000 Gas
+	GasLimitTransfer = uint64(21000)
+	// gasLimitContractFallback RPC 不可用时合约调用 (如 ERC20 transfer) 的兜底 Gas
+	gasLimitContractFallback = uint64(100000)
+	// gasLimitBufferPercent eth_estimateGas 结果上浮 20%，防止状态变化导致 out of gas
+	gasLimitBufferPercent = 120
+
+	feeHistoryBlocks = 20
+)
+
+// feeHistoryPercentiles 慢 / 标准 / 快 分别取区块内小费的 10% / 50% / 90% 分位
+var feeHistoryPercentiles = []float64{10, 50, 90}
+
+// fetchEth 估算 EVM 链三档手续费
+// - Legacy: eth_gasPrice 按档位缩放
+// - Dynamic: 一次 eth_feeHistory 取三个分位的小费
+func (s *Service) fetchEth(ctx context.Context, txType string) (*Estimate, error) {
+	if s.ethClient == nil {
+		return nil, errors.New("RPC 未连接 (模拟模式)")
+	}
+
+	est := &Estimate{}
+	if txType != ethtx.TxTypeDynamic {
+		gasPrice, err := s.ethClient.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, level := range levels {
+			est.set(level, Quote{GasPrice: scale(gasPrice, levelPercent[level])})
+		}
+		return est, nil
+	}
+
+	history, err := s.ethClient.FeeHistory(ctx, feeHistoryBlocks, nil, feeHistoryPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	fallbackTip, err := s.ethClient.SuggestGasTipCap(ctx)
+	if err != nil {
+		fallbackTip = nil
+	}
+	for i, level := range levels {
+		fees, err := ethtx.DynamicFeesAt(history, i, fallbackTip)
+		if err != nil {
+			return nil, err
+		}
+		est.set(level, Quote{GasTipCap: fees.GasTipCap, GasFeeCap: fees.GasFeeCap})
+	}
+	return est, nil
+}
+
+// EstimateGas 估算 EVM 交易的 Gas Limit
+// - 原生币转账 (无 Data): 固定 21000
+// - 合约调用 (如 ERC20 transfer): eth_estimateGas 并上浮 20%，结果按 (to, 方法签名) 缓存
+// 模拟模式下合约调用使用兜底值; 真实节点返回错误 (通常是 revert) 时直接返回错误，不能盲目兜底
+func (s *Service) EstimateGas(ctx context.Context, chain string, msg ethereum.CallMsg) (uint64, error) {
+	if !strings.EqualFold(chain, "ETH") {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedChain, chain)
+	}
+	if len(msg.Data) == 0 {
+		return GasLimitTransfer, nil
+	}
+	if s.ethClient == nil {
+		return gasLimitContractFallback, nil
+	}
+
+	key := ""
+	if msg.To != nil && len(msg.Data) >= 4 {
+		key = fmt.Sprintf("fee:gas:%s:%s:%x", strings.ToUpper(chain), msg.To.Hex(), msg.Data[:4])
+		var cached uint64
+		if err := s.cache.Get(ctx, key, &cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	gas, err := s.ethClient.EstimateGas(ctx, msg)
+	if err != nil {
+		log.Printf("[Fee] eth_estimateGas 失败: %v", err)
+		return 0, err
+	}
+	gas = gas * gasLimitBufferPercent / 100
+
+	if key != "" {
+		_ = s.cache.Set(ctx, key, gas, s.lastGoodTTL)
+	}
+	return gas, nil
+}
@@ -0,0 +1,222 @@
+package fee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"wallet-core/pkg/cache"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/wallet/ethtx"
+)
+
+var (
+	ErrUnsupportedChain = errors.New("不支持该链的手续费估算")
+	ErrInvalidLevel     = errors.New("无效的手续费档位 (slow / standard / fast)")
+)
+
+// Level 手续费档位
+type Level string
+
+const (
+	LevelSlow     Level = "slow"     // 不急: 归集等内部操作
+	LevelStandard Level = "standard" // 默认: 用户提现
+	LevelFast     Level = "fast"     // 加速: 卡单替换
+)
+
+// ParseLevel 解析档位，空字符串视为 standard
+func ParseLevel(s string) (Level, error) {
+	switch Level(strings.ToLower(s)) {
+	case "", LevelStandard:
+		return LevelStandard, nil
+	case LevelSlow:
+		return LevelSlow, nil
+	case LevelFast:
+		return LevelFast, nil
+	default:
+		return "", ErrInvalidLevel
+	}
+}
+
+// 交易类型: EVM 为 ethtx.TxTypeLegacy / ethtx.TxTypeDynamic，BTC 为 utxo
+const TxTypeUTXO = "utxo"
+
+// Quote 某一档位的手续费
+type Quote struct {
+	GasPrice  *big.Int `json:"gas_price,omitempty"`   // EVM Legacy: Gas 单价 (Wei)
+	GasTipCap *big.Int `json:"gas_tip_cap,omitempty"` // EVM EIP-1559: MaxPriorityFeePerGas (Wei)
+	GasFeeCap *big.Int `json:"gas_fee_cap,omitempty"` // EVM EIP-1559: MaxFeePerGas (Wei)
+	FeeRate   int64    `json:"fee_rate,omitempty"`    // BTC: sat/vB
+}
+
+// EthFees 转换成 EVM 交易的手续费参数
+func (q Quote) EthFees() ethtx.Fees {
+	if q.GasFeeCap != nil {
+		return ethtx.Fees{Dynamic: true, GasTipCap: q.GasTipCap, GasFeeCap: q.GasFeeCap}
+	}
+	return ethtx.Fees{GasPrice: q.GasPrice}
+}
+
+// Estimate 一条链的 慢 / 标准 / 快 三档手续费
+type Estimate struct {
+	Chain     string    `json:"chain"`
+	TxType    string    `json:"tx_type"` // legacy, dynamic, utxo
+	Slow      Quote     `json:"slow"`
+	Standard  Quote     `json:"standard"`
+	Fast      Quote     `json:"fast"`
+	Fallback  bool      `json:"fallback"` // true: RPC 不可用，使用的是上次成功的结果或配置兜底值
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Quote 返回指定档位的手续费
+func (e *Estimate) Quote(level Level) Quote {
+	switch level {
+	case LevelSlow:
+		return e.Slow
+	case LevelFast:
+		return e.Fast
+	default:
+		return e.Standard
+	}
+}
+
+// Service 手续费估算服务
+// 1. 每条链一次 RPC 请求算出三档手续费，结果缓存 ttl (默认 15s，约一个区块)
+// 2. 估算结果限制在配置的 [min, max] 之间，防止节点返回异常值
+// 3. RPC 不可用时，依次退回到上次成功的结果 (lastGoodTTL 内) 和配置的兜底值
+type Service struct {
+	cache       cache.Cache
+	ethClient   *ethclient.Client // nil: 模拟模式，直接使用兜底值
+	httpClient  *http.Client      // BTC 费率接口
+	ttl         time.Duration
+	lastGoodTTL time.Duration
+}
+
+func NewService(c cache.Cache, rpcURL string) (*Service, error) {
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		log.Printf("[Fee] Warning: RPC 无法连接，将使用兜底手续费")
+		client = nil
+	}
+
+	return &Service{
+		cache:       c,
+		ethClient:   client,
+		httpClient:  &http.Client{Timeout: 5 * time.Second},
+		ttl:         15 * time.Second,
+		lastGoodTTL: time.Hour,
+	}, nil
+}
+
+// Estimate 按链配置的交易类型估算手续费
+func (s *Service) Estimate(ctx context.Context, chain string) (*Estimate, error) {
+	txType := config.Chain(chain).TxType
+	if strings.EqualFold(chain, "BTC") {
+		txType = TxTypeUTXO
+	}
+	return s.EstimateTxType(ctx, chain, txType)
+}
+
+// EstimateTxType 按指定交易类型估算手续费 (wallet-cli 等不读取服务端配置的场景)
+func (s *Service) EstimateTxType(ctx context.Context, chain, txType string) (*Estimate, error) {
+	chain = strings.ToUpper(chain)
+	if chain != "ETH" && chain != "BTC" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedChain, chain)
+	}
+	if txType == "" {
+		txType = ethtx.TxTypeLegacy
+	}
+
+	key := fmt.Sprintf("fee:estimate:%s:%s", chain, txType)
+	var cached Estimate
+	if err := s.cache.Get(ctx, key, &cached); err == nil {
+		return &cached, nil
+	}
+
+	cfg := config.Chain(chain)
+	lastGoodKey := fmt.Sprintf("fee:last_good:%s:%s", chain, txType)
+
+	est, err := s.fetch(ctx, chain, txType, cfg)
+	if err != nil {
+		log.Printf("[Fee] %s 手续费估算失败，使用兜底值: %v", chain, err)
+		est = s.fallback(ctx, lastGoodKey, chain, txType, cfg)
+	} else {
+		_ = s.cache.Set(ctx, lastGoodKey, est, s.lastGoodTTL)
+	}
+
+	_ = s.cache.Set(ctx, key, est, s.ttl)
+	return est, nil
+}
+
+func (s *Service) fetch(ctx context.Context, chain, txType string, cfg config.ChainConfig) (*Estimate, error) {
+	var (
+		est *Estimate
+		err error
+	)
+	if chain == "BTC" {
+		est, err = s.fetchBtc(ctx, cfg)
+	} else {
+		est, err = s.fetchEth(ctx, txType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	est.Chain = chain
+	est.TxType = txType
+	est.UpdatedAt = time.Now()
+	applyBounds(est, cfg)
+	return est, nil
+}
+
+// fallback RPC 不可用: 优先使用上次成功的估算，其次使用配置的兜底值
+func (s *Service) fallback(ctx context.Context, lastGoodKey, chain, txType string, cfg config.ChainConfig) *Estimate {
+	var last Estimate
+	if err := s.cache.Get(ctx, lastGoodKey, &last); err == nil {
+		last.Fallback = true
+		return &last
+	}
+
+	est := &Estimate{Chain: chain, TxType: txType, Fallback: true, UpdatedAt: time.Now()}
+	if chain == "BTC" {
+		rate := cfg.FallbackFeeRate
+		if rate <= 0 {
+			rate = defaultFallbackFeeRate
+		}
+		est.Slow, est.Standard, est.Fast = btcLevels(rate*levelPercent[LevelSlow]/100, rate, rate*levelPercent[LevelFast]/100)
+	} else {
+		gwei := cfg.FallbackGasPriceGwei
+		if gwei <= 0 {
+			gwei = defaultFallbackGasPriceGwei
+		}
+		base := new(big.Int).Mul(big.NewInt(gwei), big.NewInt(1_000_000_000))
+		for _, level := range levels {
+			price := scale(base, levelPercent[level])
+			q := Quote{GasPrice: price}
+			if txType == ethtx.TxTypeDynamic {
+				q = Quote{GasTipCap: minBig(defaultFallbackTipCap, price), GasFeeCap: price}
+			}
+			est.set(level, q)
+		}
+	}
+	applyBounds(est, cfg)
+	return est
+}
+
+func (e *Estimate) set(level Level, q Quote) {
+	switch level {
+	case LevelSlow:
+		e.Slow = q
+	case LevelFast:
+		e.Fast = q
+	default:
+		e.Standard = q
+	}
+}
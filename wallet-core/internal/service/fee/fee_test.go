@@ -0,0 +1,124 @@
+package fee
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/pkg/cache"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/wallet/ethtx"
+)
+
+func toGwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), gwei)
+}
+
+// newTestService 模拟模式 (无 RPC) 的估算服务
+func newTestService() *Service {
+	return &Service{
+		cache:       cache.NewMemoryCache(time.Minute, time.Minute),
+		ttl:         time.Minute,
+		lastGoodTTL: time.Hour,
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for in, want := range map[string]Level{"": LevelStandard, "slow": LevelSlow, "FAST": LevelFast} {
+		got, err := ParseLevel(in)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseLevel("turbo")
+	assert.ErrorIs(t, err, ErrInvalidLevel)
+}
+
+func TestApplyBounds(t *testing.T) {
+	cfg := config.ChainConfig{MinGasPriceGwei: 5, MaxGasPriceGwei: 100}
+
+	est := &Estimate{
+		TxType:   ethtx.TxTypeLegacy,
+		Slow:     Quote{GasPrice: toGwei(1)},
+		Standard: Quote{GasPrice: toGwei(50)},
+		Fast:     Quote{GasPrice: toGwei(300)},
+	}
+	applyBounds(est, cfg)
+	assert.Equal(t, 0, toGwei(5).Cmp(est.Slow.GasPrice))
+	assert.Equal(t, 0, toGwei(50).Cmp(est.Standard.GasPrice))
+	assert.Equal(t, 0, toGwei(100).Cmp(est.Fast.GasPrice))
+
+	// EIP-1559: 约束 MaxFee，小费不能超过 MaxFee
+	est = &Estimate{
+		TxType: ethtx.TxTypeDynamic,
+		Fast:   Quote{GasTipCap: toGwei(150), GasFeeCap: toGwei(400)},
+	}
+	applyBounds(est, cfg)
+	assert.Equal(t, 0, toGwei(100).Cmp(est.Fast.GasFeeCap))
+	assert.Equal(t, 0, toGwei(100).Cmp(est.Fast.GasTipCap))
+
+	est = &Estimate{TxType: TxTypeUTXO, Slow: Quote{FeeRate: 0}, Fast: Quote{FeeRate: 900}}
+	applyBounds(est, config.ChainConfig{MinFeeRate: 1, MaxFeeRate: 300})
+	assert.Equal(t, int64(1), est.Slow.FeeRate)
+	assert.Equal(t, int64(300), est.Fast.FeeRate)
+}
+
+func TestEstimateFallback(t *testing.T) {
+	config.Global.Chains = map[string]config.ChainConfig{
+		"eth": {TxType: ethtx.TxTypeDynamic, FallbackGasPriceGwei: 20, MaxGasPriceGwei: 500},
+		"btc": {FallbackFeeRate: 10, MaxFeeRate: 300},
+	}
+	defer func() { config.Global.Chains = nil }()
+
+	s := newTestService()
+	ctx := context.Background()
+
+	est, err := s.Estimate(ctx, "eth")
+	require.NoError(t, err)
+	assert.True(t, est.Fallback)
+	assert.Equal(t, ethtx.TxTypeDynamic, est.TxType)
+	assert.Equal(t, 0, toGwei(20).Cmp(est.Standard.GasFeeCap))
+	assert.Equal(t, 0, toGwei(25).Cmp(est.Fast.GasFeeCap))
+	assert.True(t, est.Standard.EthFees().Dynamic)
+
+	est, err = s.Estimate(ctx, "BTC")
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), est.Slow.FeeRate)
+	assert.Equal(t, int64(10), est.Standard.FeeRate)
+	assert.Equal(t, int64(12), est.Fast.FeeRate)
+
+	_, err = s.Estimate(ctx, "TRON")
+	assert.ErrorIs(t, err, ErrUnsupportedChain)
+}
+
+func TestEstimateUsesLastGood(t *testing.T) {
+	s := newTestService()
+	ctx := context.Background()
+
+	last := &Estimate{Chain: "ETH", TxType: ethtx.TxTypeLegacy, Standard: Quote{GasPrice: toGwei(33)}}
+	require.NoError(t, s.cache.Set(ctx, "fee:last_good:ETH:legacy", last, time.Hour))
+
+	est, err := s.EstimateTxType(ctx, "ETH", ethtx.TxTypeLegacy)
+	require.NoError(t, err)
+	assert.True(t, est.Fallback)
+	assert.Equal(t, 0, toGwei(33).Cmp(est.Standard.GasPrice))
+}
+
+func TestEstimateGas(t *testing.T) {
+	s := newTestService()
+	ctx := context.Background()
+	to := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+
+	gas, err := s.EstimateGas(ctx, "ETH", ethereum.CallMsg{To: &to})
+	require.NoError(t, err)
+	assert.Equal(t, GasLimitTransfer, gas)
+
+	gas, err = s.EstimateGas(ctx, "ETH", ethereum.CallMsg{To: &to, Data: []byte{0xa9, 0x05, 0x9c, 0xbb}})
+	require.NoError(t, err)
+	assert.Equal(t, gasLimitContractFallback, gas)
+}
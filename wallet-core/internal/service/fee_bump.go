@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"math/big"
+
+	"wallet-core/pkg/wallet/ethtx"
+)
+
+// ErrFeeCeilingReached 替换交易所需手续费超过配置上限
+var ErrFeeCeilingReached = errors.New("手续费已达到上限，无法继续加速")
+
+// minGasPriceBumpPercent geth 交易池要求同 nonce 替换交易至少提价 10%
+// 否则返回 "replacement transaction underpriced"
+const minGasPriceBumpPercent = 10
+
+// bumpGasPrice 计算 ETH 替换交易的 Gas 单价
+// 1. 在旧价格基础上提高 bumpPercent (至少 10%)
+// 2. 如果当前网络建议价更高，直接使用建议价
+// 3. 不超过 ceiling (nil 表示不封顶); 如果连最低提价都超过上限，返回 ErrFeeCeilingReached
+func bumpGasPrice(old, suggested *big.Int, bumpPercent int64, ceiling *big.Int) (*big.Int, error) {
+	if bumpPercent < minGasPriceBumpPercent {
+		bumpPercent = minGasPriceBumpPercent
+	}
+
+	minRequired := percentOf(old, 100+minGasPriceBumpPercent)
+	newPrice := percentOf(old, 100+bumpPercent)
+	if suggested != nil && suggested.Cmp(newPrice) > 0 {
+		newPrice = new(big.Int).Set(suggested)
+	}
+
+	if ceiling != nil && newPrice.Cmp(ceiling) > 0 {
+		if minRequired.Cmp(ceiling) > 0 {
+			return nil, ErrFeeCeilingReached
+		}
+		newPrice = new(big.Int).Set(ceiling)
+	}
+	return newPrice, nil
+}
+
+// bumpEthFees 计算 ETH 替换交易的手续费，交易类型保持不变
+// EIP-1559 交易的 MaxPriorityFeePerGas 和 MaxFeePerGas 都必须至少提高 10%，上限只约束 MaxFeePerGas
+func bumpEthFees(old, suggested ethtx.Fees, bumpPercent int64, ceiling *big.Int) (ethtx.Fees, error) {
+	if !old.Dynamic {
+		gasPrice, err := bumpGasPrice(old.GasPrice, suggested.MaxPrice(), bumpPercent, ceiling)
+		if err != nil {
+			return ethtx.Fees{}, err
+		}
+		return ethtx.Fees{GasPrice: gasPrice}, nil
+	}
+
+	feeCap, err := bumpGasPrice(old.GasFeeCap, suggested.GasFeeCap, bumpPercent, ceiling)
+	if err != nil {
+		return ethtx.Fees{}, err
+	}
+	tip, err := bumpGasPrice(old.GasTipCap, suggested.GasTipCap, bumpPercent, feeCap)
+	if err != nil {
+		return ethtx.Fees{}, err
+	}
+	return ethtx.Fees{Dynamic: true, GasTipCap: tip, GasFeeCap: feeCap}, nil
+}
+
+// percentOf 返回 v * percent / 100，向上取整，保证提价幅度不会因整除被吃掉
+func percentOf(v *big.Int, percent int64) *big.Int {
+	r := new(big.Int).Mul(v, big.NewInt(percent))
+	r.Add(r, big.NewInt(99))
+	return r.Div(r, big.NewInt(100))
+}
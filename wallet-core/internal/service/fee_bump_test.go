@@ -0,0 +1,65 @@
+package service
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"wallet-core/pkg/wallet/ethtx"
+)
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1_000_000_000))
+}
+
+func TestBumpGasPrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		old       *big.Int
+		suggested *big.Int
+		bump      int64
+		ceiling   *big.Int
+		want      *big.Int
+		wantErr   error
+	}{
+		{"按比例提价", gwei(20), gwei(10), 20, nil, gwei(24), nil},
+		{"提价不足 10% 时按 10% 计算", gwei(20), nil, 5, nil, gwei(22), nil},
+		{"网络建议价更高时使用建议价", gwei(20), gwei(50), 20, nil, gwei(50), nil},
+		{"超过上限时封顶", gwei(20), gwei(50), 20, gwei(30), gwei(30), nil},
+		{"最低提价都超过上限", gwei(100), nil, 20, gwei(105), nil, ErrFeeCeilingReached},
+		{"整除不吃掉提价", big.NewInt(15), nil, 10, nil, big.NewInt(17), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bumpGasPrice(tt.old, tt.suggested, tt.bump, tt.ceiling)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 0, tt.want.Cmp(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestBumpEthFees(t *testing.T) {
+	// Legacy: 与 bumpGasPrice 一致
+	fees, err := bumpEthFees(ethtx.Fees{GasPrice: gwei(20)}, ethtx.Fees{}, 20, nil)
+	assert.NoError(t, err)
+	assert.False(t, fees.Dynamic)
+	assert.Equal(t, 0, gwei(24).Cmp(fees.GasPrice))
+
+	// EIP-1559: 小费和 MaxFee 同时提价
+	old := ethtx.Fees{Dynamic: true, GasTipCap: gwei(2), GasFeeCap: gwei(40)}
+	fees, err = bumpEthFees(old, ethtx.Fees{Dynamic: true, GasTipCap: gwei(3), GasFeeCap: gwei(30)}, 20, nil)
+	assert.NoError(t, err)
+	assert.True(t, fees.Dynamic)
+	assert.Equal(t, 0, gwei(3).Cmp(fees.GasTipCap), "tip: %s", fees.GasTipCap)
+	assert.Equal(t, 0, gwei(48).Cmp(fees.GasFeeCap), "feeCap: %s", fees.GasFeeCap)
+
+	// MaxFee 已到上限
+	_, err = bumpEthFees(old, ethtx.Fees{}, 20, gwei(41))
+	assert.ErrorIs(t, err, ErrFeeCeilingReached)
+}
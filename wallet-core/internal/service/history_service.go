@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+)
+
+// 交易记录类型
+const (
+	TxKindDeposit    = "deposit"
+	TxKindWithdrawal = "withdrawal"
+)
+
+// 每页条数
+const (
+	HistoryDefaultLimit = 20
+	HistoryMaxLimit     = 100
+)
+
+// HistoryFilter 交易记录查询条件，均为可选
+type HistoryFilter struct {
+	Currency string     // ETH, BTC
+	Status   string     // 充值 / 提现状态 (model.DepositStatus* / model.WithdrawalStatus*)
+	TxHash   string     // 精确匹配
+	Since    *time.Time // created_at >= Since
+	Until    *time.Time // created_at < Until
+	Cursor   string     // 上一页返回的 NextCursor，为空表示第一页
+	Limit    int
+}
+
+// HistoryItem 一条交易记录 (充值或提现)
+type HistoryItem struct {
+	Kind                  string          `json:"kind"` // deposit, withdrawal
+	ID                    uint64          `json:"id"`
+	Currency              string          `json:"currency"`
+	TxHash                string          `json:"tx_hash"`
+	Amount                decimal.Decimal `json:"amount"`
+	Status                string          `json:"status"`
+	Address               string          `json:"address"` // 对手方地址: 充值为付款方，提现为收款方
+	Confirmations         uint64          `json:"confirmations"`
+	RequiredConfirmations uint64          `json:"required_confirmations"`
+	ExplorerURL           string          `json:"explorer_url,omitempty"`
+	CreatedAt             time.Time       `json:"created_at"`
+	ConfirmedAt           *time.Time      `json:"confirmed_at,omitempty"`
+}
+
+// HistoryPage 一页交易记录，NextCursor 为空表示没有更多
+type HistoryPage struct {
+	Items      []HistoryItem `json:"items"`
+	NextCursor string        `json:"next_cursor"`
+}
+
+// HistoryService 用户交易记录 (充值 / 提现 / 合并列表)
+// 按 (created_at, kind, id) 倒序做游标分页: 游标记录上一页最后一条的位置，
+// 新数据插入不会导致翻页时重复或遗漏 (offset 分页会)
+// 合并列表分别从两张表各取 limit+1 条 (都走 (user_id, created_at, id) 索引)，在内存中归并
+type HistoryService struct {
+	db *gorm.DB
+}
+
+var History *HistoryService
+
+func NewHistoryService(db *gorm.DB) *HistoryService {
+	return &HistoryService{db: db}
+}
+
+// ListDeposits 充值记录
+func (s *HistoryService) ListDeposits(ctx context.Context, userID uint64, f HistoryFilter) (*HistoryPage, error) {
+	return s.list(ctx, userID, f, TxKindDeposit)
+}
+
+// ListWithdrawals 提现记录
+func (s *HistoryService) ListWithdrawals(ctx context.Context, userID uint64, f HistoryFilter) (*HistoryPage, error) {
+	return s.list(ctx, userID, f, TxKindWithdrawal)
+}
+
+// ListTransactions 充值和提现合并后的交易记录
+func (s *HistoryService) ListTransactions(ctx context.Context, userID uint64, f HistoryFilter) (*HistoryPage, error) {
+	return s.list(ctx, userID, f, TxKindDeposit, TxKindWithdrawal)
+}
+
+func (s *HistoryService) list(ctx context.Context, userID uint64, f HistoryFilter, kinds ...string) (*HistoryPage, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = HistoryDefaultLimit
+	}
+	if limit > HistoryMaxLimit {
+		limit = HistoryMaxLimit
+	}
+	cur, err := decodeHistoryCursor(f.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if f.Since != nil && f.Until != nil && !f.Since.Before(*f.Until) {
+		return nil, errno.ErrBind.WithMessage("start_time must be before end_time")
+	}
+
+	var items []HistoryItem
+	for _, kind := range kinds {
+		var got []HistoryItem
+		if kind == TxKindDeposit {
+			got, err = s.queryDeposits(ctx, userID, f, cur, limit+1)
+		} else {
+			got, err = s.queryWithdrawals(ctx, userID, f, cur, limit+1)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, got...)
+	}
+	return paginateHistory(items, limit), nil
+}
+
+func (s *HistoryService) queryDeposits(ctx context.Context, userID uint64, f HistoryFilter, cur *historyCursor, n int) ([]HistoryItem, error) {
+	q := applyHistoryFilter(s.db.WithContext(ctx).Model(&model.Deposit{}).Where("user_id = ?", userID), f, TxKindDeposit, cur)
+	var rows []model.Deposit
+	if err := q.Order("created_at DESC, id DESC").Limit(n).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]HistoryItem, 0, len(rows))
+	for _, d := range rows {
+		items = append(items, HistoryItem{
+			Kind:                  TxKindDeposit,
+			ID:                    d.ID,
+			Currency:              d.Chain,
+			TxHash:                d.TxHash,
+			Amount:                d.Amount,
+			Status:                d.Status,
+			Address:               d.FromAddress,
+			Confirmations:         d.Confirmations,
+			RequiredConfirmations: config.Chain(d.Chain).Confirmations,
+			ExplorerURL:           ExplorerTxURL(d.Chain, d.TxHash),
+			CreatedAt:             d.CreatedAt,
+			ConfirmedAt:           d.ConfirmedAt,
+		})
+	}
+	return items, nil
+}
+
+func (s *HistoryService) queryWithdrawals(ctx context.Context, userID uint64, f HistoryFilter, cur *historyCursor, n int) ([]HistoryItem, error) {
+	q := applyHistoryFilter(s.db.WithContext(ctx).Model(&model.Withdrawal{}).Where("user_id = ?", userID), f, TxKindWithdrawal, cur)
+	var rows []model.Withdrawal
+	if err := q.Order("created_at DESC, id DESC").Limit(n).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]HistoryItem, 0, len(rows))
+	for _, w := range rows {
+		items = append(items, HistoryItem{
+			Kind:                  TxKindWithdrawal,
+			ID:                    w.ID,
+			Currency:              w.Chain,
+			TxHash:                w.TxHash,
+			Amount:                w.Amount,
+			Status:                w.Status,
+			Address:               w.ToAddress,
+			Confirmations:         w.Confirmations,
+			RequiredConfirmations: config.Chain(w.Chain).Confirmations,
+			ExplorerURL:           ExplorerTxURL(w.Chain, w.TxHash),
+			CreatedAt:             w.CreatedAt,
+			ConfirmedAt:           w.ConfirmedAt,
+		})
+	}
+	return items, nil
+}
+
+// applyHistoryFilter 两张表共用的过滤条件 (列名一致: chain, status, tx_hash, created_at, id)
+func applyHistoryFilter(q *gorm.DB, f HistoryFilter, kind string, cur *historyCursor) *gorm.DB {
+	if f.Currency != "" {
+		q = q.Where("chain = ?", strings.ToUpper(f.Currency))
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if f.TxHash != "" {
+		q = q.Where("tx_hash = ?", f.TxHash)
+	}
+	if f.Since != nil {
+		q = q.Where("created_at >= ?", *f.Since)
+	}
+	if f.Until != nil {
+		q = q.Where("created_at < ?", *f.Until)
+	}
+	if cond, args := cur.condition(kind); cond != "" {
+		q = q.Where(cond, args...)
+	}
+	return q
+}
+
+// historyCursor 上一页最后一条记录的位置
+type historyCursor struct {
+	CreatedAt time.Time
+	Kind      string
+	ID        uint64
+}
+
+// condition 某张表中排在游标之后的记录
+// 整体顺序为 (created_at, kind, id) 倒序，而单张表内 kind 固定，所以:
+// - kind 与游标相同: (created_at, id) < 游标
+// - kind 排在游标之前 (更大): 只能取更早的时间
+// - kind 排在游标之后 (更小): 同一时间的记录也在游标之后
+func (c *historyCursor) condition(kind string) (string, []interface{}) {
+	if c == nil {
+		return "", nil
+	}
+	switch {
+	case kind == c.Kind:
+		return "(created_at, id) < (?, ?)", []interface{}{c.CreatedAt, c.ID}
+	case kind > c.Kind:
+		return "created_at < ?", []interface{}{c.CreatedAt}
+	default:
+		return "created_at <= ?", []interface{}{c.CreatedAt}
+	}
+}
+
+func (c historyCursor) encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + c.Kind + ":" + strconv.FormatUint(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeHistoryCursor(s string) (*historyCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	invalid := errno.ErrBind.WithMessage("invalid cursor")
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, invalid
+	}
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 || (parts[1] != TxKindDeposit && parts[1] != TxKindWithdrawal) {
+		return nil, invalid
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+	id, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+	return &historyCursor{CreatedAt: time.Unix(0, nanos).UTC(), Kind: parts[1], ID: id}, nil
+}
+
+// paginateHistory 按 (created_at, kind, id) 倒序归并，截取一页并生成下一页游标
+func paginateHistory(items []HistoryItem, limit int) *HistoryPage {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		if a.Kind != b.Kind {
+			return a.Kind > b.Kind
+		}
+		return a.ID > b.ID
+	})
+
+	page := &HistoryPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		last := page.Items[limit-1]
+		page.NextCursor = historyCursor{CreatedAt: last.CreatedAt, Kind: last.Kind, ID: last.ID}.encode()
+	}
+	if page.Items == nil {
+		page.Items = []HistoryItem{}
+	}
+	return page
+}
+
+// ExplorerTxURL 区块浏览器链接，未配置模板或尚未上链时为空
+func ExplorerTxURL(chain, txHash string) string {
+	tmpl := config.Chain(chain).ExplorerTxURL
+	if tmpl == "" || txHash == "" {
+		return ""
+	}
+	return strings.ReplaceAll(tmpl, "{tx_hash}", txHash)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+)
+
+func TestHistoryCursorRoundTrip(t *testing.T) {
+	c := historyCursor{CreatedAt: time.Date(2026, 3, 1, 12, 0, 0, 123456000, time.UTC), Kind: TxKindWithdrawal, ID: 42}
+	got, err := decodeHistoryCursor(c.encode())
+	require.NoError(t, err)
+	assert.True(t, c.CreatedAt.Equal(got.CreatedAt))
+	assert.Equal(t, c.Kind, got.Kind)
+	assert.Equal(t, c.ID, got.ID)
+
+	got, err = decodeHistoryCursor("")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	for _, bad := range []string{"%%%", "MTIz", historyCursor{Kind: "swap", ID: 1}.encode()} {
+		_, err := decodeHistoryCursor(bad)
+		var e errno.Errno
+		require.True(t, errors.As(err, &e), bad)
+		assert.Equal(t, errno.ErrBind.Code, e.Code)
+	}
+}
+
+func TestHistoryCursorCondition(t *testing.T) {
+	var none *historyCursor
+	cond, _ := none.condition(TxKindDeposit)
+	assert.Empty(t, cond)
+
+	c := &historyCursor{CreatedAt: time.Now(), Kind: TxKindDeposit, ID: 7}
+	cond, args := c.condition(TxKindDeposit)
+	assert.Equal(t, "(created_at, id) < (?, ?)", cond)
+	assert.Len(t, args, 2)
+
+	// withdrawal > deposit: 同一时间的提现排在游标 (充值) 之前，已经返回过
+	cond, _ = c.condition(TxKindWithdrawal)
+	assert.Equal(t, "created_at < ?", cond)
+
+	c.Kind = TxKindWithdrawal
+	cond, _ = c.condition(TxKindDeposit)
+	assert.Equal(t, "created_at <= ?", cond)
+}
+
+func TestPaginateHistory(t *testing.T) {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	items := []HistoryItem{
+		{Kind: TxKindDeposit, ID: 1, CreatedAt: base},
+		{Kind: TxKindDeposit, ID: 2, CreatedAt: base.Add(time.Minute)},
+		{Kind: TxKindWithdrawal, ID: 1, CreatedAt: base.Add(time.Minute)},
+		{Kind: TxKindWithdrawal, ID: 2, CreatedAt: base.Add(2 * time.Minute)},
+	}
+
+	page := paginateHistory(items, 3)
+	require.Len(t, page.Items, 3)
+	assert.Equal(t, TxKindWithdrawal, page.Items[0].Kind)
+	assert.Equal(t, uint64(2), page.Items[0].ID)
+	// 同一时间: withdrawal 排在 deposit 之前
+	assert.Equal(t, TxKindWithdrawal, page.Items[1].Kind)
+	assert.Equal(t, TxKindDeposit, page.Items[2].Kind)
+
+	cur, err := decodeHistoryCursor(page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, TxKindDeposit, cur.Kind)
+	assert.Equal(t, uint64(2), cur.ID)
+
+	// 最后一页没有游标，空列表返回 [] 而不是 null
+	assert.Empty(t, paginateHistory(items[:1], 3).NextCursor)
+	assert.NotNil(t, paginateHistory(nil, 3).Items)
+}
+
+func TestExplorerTxURL(t *testing.T) {
+	old := config.Global.Chains
+	defer func() { config.Global.Chains = old }()
+	config.Global.Chains = map[string]config.ChainConfig{
+		"eth": {ExplorerTxURL: "https://etherscan.io/tx/{tx_hash}"},
+	}
+
+	assert.Equal(t, "https://etherscan.io/tx/0xabc", ExplorerTxURL("ETH", "0xabc"))
+	assert.Empty(t, ExplorerTxURL("ETH", ""))
+	assert.Empty(t, ExplorerTxURL("BTC", "abc"))
+}
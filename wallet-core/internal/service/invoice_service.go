@@ -0,0 +1,658 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"wallet-core/internal/event"
+	"wallet-core/internal/model"
+	"wallet-core/internal/service/mq"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/safe_random"
+)
+
+// 商户 API Key 权限范围: 账单
+const (
+	ScopeInvoiceRead  = "invoice:read"  // 查询账单 / 通知配置
+	ScopeInvoiceWrite = "invoice:write" // 创建账单 / 设置通知地址
+)
+
+// 账单通知事件
+const (
+	InvoiceEventUnderpaid   = "invoice.underpaid"
+	InvoiceEventPaid        = "invoice.paid"
+	InvoiceEventOverpaid    = "invoice.overpaid"
+	InvoiceEventExpired     = "invoice.expired"
+	InvoiceEventLatePayment = "invoice.late_payment" // 账单过期后收到付款
+)
+
+const (
+	maxInvoicePrecision = 8    // 账单金额最多保留的小数位 (ETH 按 18 位记账，付款金额不需要那么细)
+	maxSharedOffsets    = 1000 // 共享地址上同一基础金额最多同时存在的待付款账单数
+	minInvoiceTTL       = time.Minute
+)
+
+// RateSource 法币汇率: 1 个 asset 值多少 fiat
+type RateSource interface {
+	Rate(ctx context.Context, fiat, asset string) (decimal.Decimal, error)
+}
+
+// ConfigRates 从配置 invoice.rates 读取的静态汇率 (key 为小写)
+type ConfigRates map[string]map[string]float64
+
+func (r ConfigRates) Rate(ctx context.Context, fiat, asset string) (decimal.Decimal, error) {
+	rate := r[strings.ToLower(fiat)][strings.ToLower(asset)]
+	if rate <= 0 {
+		return decimal.Zero, errno.ErrRateUnavailable.WithMessage(fmt.Sprintf("no %s rate for %s", strings.ToUpper(fiat), strings.ToUpper(asset)))
+	}
+	return decimal.NewFromFloat(rate), nil
+}
+
+// AddressAllocator 为账单派生不属于任何用户的收款地址 (见 SQLAddressService.AllocateAddress)
+type AddressAllocator interface {
+	AllocateAddress(chain string) (*model.Address, error)
+}
+
+// InvoiceService 商户收款账单
+// 1. 账单金额以币本位记账；法币计价时按创建时的汇率换算并锁定，有效期内不变
+// 2. dedicated 模式每张账单一个新地址，发往该地址的所有付款都计入账单 (可识别少付 / 多付)
+// 3. shared 模式同一商户同一币种复用一个地址，待付款账单的金额加唯一尾数，只有金额完全一致的付款才能匹配
+// 4. 消费充值消息 (只处理已确认、不属于用户的充值) 更新账单状态，到期未付清的账单由定时扫描置为 expired
+// 5. 每次状态变化在同一事务中写入通知记录，由 WebhookService 投递
+type InvoiceService struct {
+	db        *gorm.DB
+	addresses AddressAllocator
+	webhooks  *WebhookService
+	rates     RateSource
+	cfg       config.InvoiceConfig
+	interval  time.Duration
+}
+
+var Invoice *InvoiceService
+
+func NewInvoiceService(db *gorm.DB, addresses AddressAllocator, webhooks *WebhookService, rates RateSource, cfg config.InvoiceConfig) *InvoiceService {
+	if cfg.DefaultTTL <= 0 {
+		cfg.DefaultTTL = 15 * time.Minute
+	}
+	if cfg.MaxTTL < cfg.DefaultTTL {
+		cfg.MaxTTL = cfg.DefaultTTL
+	}
+	return &InvoiceService{
+		db:        db,
+		addresses: addresses,
+		webhooks:  webhooks,
+		rates:     rates,
+		cfg:       cfg,
+		interval:  30 * time.Second,
+	}
+}
+
+// CreateInvoiceInput 创建账单参数，Amount (币本位) 与 PriceAmount + PriceCurrency (法币计价) 二选一
+type CreateInvoiceInput struct {
+	OrderID       string
+	Description   string
+	Currency      string
+	Amount        decimal.Decimal
+	PriceCurrency string
+	PriceAmount   decimal.Decimal
+	AddressMode   string        // 为空时使用 dedicated
+	TTL           time.Duration // 为空时使用 invoice.default_ttl
+	RedirectURL   string
+}
+
+// CheckoutView 托管收银台展示的账单信息 (公开接口，不含商户内部字段)
+type CheckoutView struct {
+	ID               string     `json:"id"`
+	Merchant         string     `json:"merchant"`
+	Description      string     `json:"description,omitempty"`
+	Currency         string     `json:"currency"`
+	Amount           string     `json:"amount"`
+	PaidAmount       string     `json:"paid_amount"`
+	RemainingAmount  string     `json:"remaining_amount"`
+	PriceCurrency    string     `json:"price_currency,omitempty"`
+	PriceAmount      string     `json:"price_amount,omitempty"`
+	ExchangeRate     string     `json:"exchange_rate,omitempty"`
+	Address          string     `json:"address"`
+	ExactAmount      bool       `json:"exact_amount"` // 共享地址: 必须一次付清且金额完全一致
+	PaymentURI       string     `json:"payment_uri,omitempty"`
+	Status           string     `json:"status"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	SecondsRemaining int64      `json:"seconds_remaining"`
+	PaidAt           *time.Time `json:"paid_at,omitempty"`
+	RedirectURL      string     `json:"redirect_url,omitempty"`
+	CheckoutURL      string     `json:"checkout_url"`
+}
+
+// InvoiceDetail 账单及其付款记录
+type InvoiceDetail struct {
+	Invoice     *model.Invoice         `json:"invoice"`
+	Payments    []model.InvoicePayment `json:"payments"`
+	CheckoutURL string                 `json:"checkout_url"`
+}
+
+// CreateInvoice 创建账单并分配收款地址
+func (s *InvoiceService) CreateInvoice(ctx context.Context, merchantID uint64, in CreateInvoiceInput) (*model.Invoice, error) {
+	asset, ok := LookupAsset(in.Currency)
+	if !ok {
+		return nil, errno.ErrInvoiceInvalid.WithMessage("unsupported currency " + in.Currency)
+	}
+	precision := invoicePrecision(asset.Decimals)
+
+	inv := &model.Invoice{
+		MerchantID:  merchantID,
+		OrderID:     in.OrderID,
+		Description: in.Description,
+		Currency:    asset.Currency,
+		AddressMode: in.AddressMode,
+		Status:      model.InvoiceStatusPending,
+		RedirectURL: in.RedirectURL,
+	}
+	if inv.AddressMode == "" {
+		inv.AddressMode = model.InvoiceAddressDedicated
+	}
+	if inv.AddressMode != model.InvoiceAddressDedicated && inv.AddressMode != model.InvoiceAddressShared {
+		return nil, errno.ErrInvoiceInvalid.WithMessage("address_mode must be dedicated or shared")
+	}
+	if err := validateRedirectURL(in.RedirectURL); err != nil {
+		return nil, err
+	}
+	ttl, err := invoiceTTL(in.TTL, s.cfg.DefaultTTL, s.cfg.MaxTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case in.PriceCurrency != "" && in.Amount.IsZero():
+		if !in.PriceAmount.IsPositive() {
+			return nil, errno.ErrInvoiceInvalid.WithMessage("price_amount must be positive")
+		}
+		rate, err := s.rates.Rate(ctx, in.PriceCurrency, asset.Currency)
+		if err != nil {
+			return nil, err
+		}
+		inv.PriceCurrency = strings.ToUpper(in.PriceCurrency)
+		inv.PriceAmount = in.PriceAmount
+		inv.ExchangeRate = rate
+		inv.Amount = convertPrice(in.PriceAmount, rate, precision)
+	case in.PriceCurrency == "" && in.PriceAmount.IsZero():
+		if !in.Amount.IsPositive() {
+			return nil, errno.ErrInvoiceInvalid.WithMessage("amount must be positive")
+		}
+		if !in.Amount.Equal(in.Amount.Truncate(precision)) {
+			return nil, errno.ErrInvoiceInvalid.WithMessage(fmt.Sprintf("amount has more than %d decimal places", precision))
+		}
+		inv.Amount = in.Amount
+	default:
+		return nil, errno.ErrInvoiceInvalid.WithMessage("set either amount or price_amount with price_currency")
+	}
+	if asset.MinDeposit.IsPositive() && inv.Amount.LessThan(asset.MinDeposit) {
+		// 低于最小充值金额的付款不会被扫块器入账
+		return nil, errno.ErrInvoiceInvalid.WithMessage("amount is below the minimum deposit of " + asset.MinDeposit.String() + " " + asset.Currency)
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&model.Invoice{}).
+		Where("merchant_id = ? AND order_id = ?", merchantID, in.OrderID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errno.ErrInvoiceOrderExists
+	}
+
+	publicID, err := safe_random.GenerateRandomHexString(16)
+	if err != nil {
+		return nil, err
+	}
+	inv.PublicID = "inv_" + publicID
+
+	if inv.AddressMode == model.InvoiceAddressShared {
+		inv.AddressID, inv.Address, err = s.sharedAddress(ctx, merchantID, asset.Currency)
+	} else {
+		var addr *model.Address
+		if addr, err = s.addresses.AllocateAddress(asset.Currency); err == nil {
+			inv.AddressID, inv.Address = addr.ID, addr.Address
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	inv.ExpiresAt = now.Add(ttl)
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if inv.AddressMode == model.InvoiceAddressShared {
+			// 同一共享地址上的账单串行分配尾数，部分唯一索引 idx_invoices_shared_amount 兜底
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", inv.AddressID).Error; err != nil {
+				return err
+			}
+			var taken []decimal.Decimal
+			if err := tx.Model(&model.Invoice{}).
+				Where("address_id = ? AND address_mode = ? AND status = ?", inv.AddressID, model.InvoiceAddressShared, model.InvoiceStatusPending).
+				Pluck("amount", &taken).Error; err != nil {
+				return err
+			}
+			amount, err := uniqueSharedAmount(inv.Amount, taken, precision)
+			if err != nil {
+				return err
+			}
+			inv.Amount = amount
+		}
+		if err := tx.Create(inv).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errno.ErrInvoiceOrderExists
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// sharedAddress 商户在该币种上的共享收款地址，第一次使用时分配
+func (s *InvoiceService) sharedAddress(ctx context.Context, merchantID uint64, currency string) (uint64, string, error) {
+	var first model.Invoice
+	err := s.db.WithContext(ctx).Select("address_id", "address").
+		Where("merchant_id = ? AND currency = ? AND address_mode = ?", merchantID, currency, model.InvoiceAddressShared).
+		Order("id").First(&first).Error
+	if err == nil {
+		return first.AddressID, first.Address, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, "", err
+	}
+	addr, err := s.addresses.AllocateAddress(currency)
+	if err != nil {
+		return 0, "", err
+	}
+	return addr.ID, addr.Address, nil
+}
+
+// GetInvoice 商户查询自己的账单 (按 public_id)
+func (s *InvoiceService) GetInvoice(ctx context.Context, merchantID uint64, publicID string) (*InvoiceDetail, error) {
+	var inv model.Invoice
+	if err := s.db.WithContext(ctx).Where("public_id = ? AND merchant_id = ?", publicID, merchantID).First(&inv).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+	payments := []model.InvoicePayment{}
+	if err := s.db.WithContext(ctx).Where("invoice_id = ?", inv.ID).Order("id").Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return &InvoiceDetail{Invoice: &inv, Payments: payments, CheckoutURL: checkoutURL(s.cfg.CheckoutURL, inv.PublicID)}, nil
+}
+
+// ListDeliveries 账单的通知投递记录
+func (s *InvoiceService) ListDeliveries(ctx context.Context, merchantID uint64, publicID string) ([]model.WebhookDelivery, error) {
+	var inv model.Invoice
+	if err := s.db.WithContext(ctx).Select("id").Where("public_id = ? AND merchant_id = ?", publicID, merchantID).First(&inv).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+	return s.webhooks.ListDeliveries(ctx, merchantID, DeliveryQuery{InvoiceID: inv.ID, Limit: 100})
+}
+
+// ListInvoices 商户账单列表，按 ID 倒序，beforeID 为上一页最后一条的 ID
+func (s *InvoiceService) ListInvoices(ctx context.Context, merchantID uint64, status string, beforeID uint64, limit int) ([]model.Invoice, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	q := s.db.WithContext(ctx).Where("merchant_id = ?", merchantID)
+	if status != "" {
+		if !validInvoiceStatus(status) {
+			return nil, errno.ErrInvoiceInvalid.WithMessage("unknown status " + status)
+		}
+		q = q.Where("status = ?", status)
+	}
+	if beforeID > 0 {
+		q = q.Where("id < ?", beforeID)
+	}
+	list := []model.Invoice{}
+	err := q.Order("id DESC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+// GetCheckout 托管收银台数据 (公开，凭不可猜测的 public_id 访问)
+func (s *InvoiceService) GetCheckout(ctx context.Context, publicID string) (*CheckoutView, error) {
+	var inv model.Invoice
+	if err := s.db.WithContext(ctx).Where("public_id = ?", publicID).First(&inv).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+	var merchant model.Merchant
+	if err := s.db.WithContext(ctx).Select("name").First(&merchant, inv.MerchantID).Error; err != nil {
+		return nil, err
+	}
+	return buildCheckout(&inv, merchant.Name, checkoutURL(s.cfg.CheckoutURL, inv.PublicID), time.Now()), nil
+}
+
+// ConsumeDeposits 订阅充值消息并匹配账单 (阻塞，直到 ctx 取消)
+// 使用独立的消费者组，与归集服务互不影响
+func (s *InvoiceService) ConsumeDeposits(ctx context.Context, consumer mq.Consumer) error {
+	log.Println("[Invoice] 开始消费充值消息...")
+	return consumer.Subscribe(ctx, event.TopicDeposit, func(msg *mq.Message) error {
+		var e event.DepositEvent
+		if err := json.Unmarshal(msg.Payload, &e); err != nil {
+			log.Printf("[Invoice] 解析充值消息失败: %v", err)
+			return nil // 格式错误，不再重试
+		}
+		return s.ApplyDeposit(ctx, e)
+	})
+}
+
+// ApplyDeposit 已确认的充值计入对应账单，同一笔充值重复处理无副作用 (invoice_payments.deposit_id 唯一)
+// 用户地址的充值 (UserID 不为 0) 直接忽略
+func (s *InvoiceService) ApplyDeposit(ctx context.Context, e event.DepositEvent) error {
+	if e.UserID != 0 || e.Status != model.DepositStatusConfirmed {
+		return nil
+	}
+	var dep model.Deposit
+	if err := s.db.WithContext(ctx).First(&dep, e.DepositID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if dep.UserID != 0 || dep.BlockAppID == 0 {
+		return nil
+	}
+
+	var deliveries []model.WebhookDelivery
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		inv, err := matchInvoice(tx, dep.BlockAppID, dep.Amount)
+		if err != nil || inv == nil {
+			return err
+		}
+
+		status, late := nextInvoiceStatus(inv.Status, inv.Amount, inv.PaidAmount.Add(dep.Amount))
+		payment := &model.InvoicePayment{
+			InvoiceID: inv.ID,
+			DepositID: dep.ID,
+			TxHash:    dep.TxHash,
+			Amount:    dep.Amount,
+			Late:      late,
+		}
+		inserted, err := recordPayment(tx, payment)
+		if err != nil || !inserted {
+			return err // 未插入: 已处理过
+		}
+
+		updates := map[string]interface{}{
+			"paid_amount": gorm.Expr("paid_amount + ?", dep.Amount),
+			"status":      status,
+		}
+		if status == model.InvoiceStatusPaid && inv.PaidAt == nil {
+			updates["paid_at"] = time.Now()
+		}
+		if err := tx.Model(inv).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.First(inv, inv.ID).Error; err != nil {
+			return err
+		}
+
+		ev := "invoice." + status
+		if late {
+			ev = InvoiceEventLatePayment
+		}
+		log.Printf("[Invoice] 账单 %s 收到付款 %s %s (Tx=%s)，状态 %s", inv.PublicID, dep.Amount, inv.Currency, dep.TxHash, status)
+		deliveries, err = s.webhooks.Record(tx, inv.MerchantID, ev, "", inv.ID, inv)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.webhooks.Enqueue(deliveries)
+	return nil
+}
+
+// recordPayment 写入付款记录，同一笔充值已经记过时 inserted 为 false
+// 用 ON CONFLICT DO NOTHING 而不是捕获唯一约束错误: Postgres 在约束冲突后会中止整个事务，提交也会失败
+func recordPayment(tx *gorm.DB, payment *model.InvoicePayment) (inserted bool, err error) {
+	res := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "deposit_id"}}, DoNothing: true}).Create(payment)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// matchInvoice 找到充值对应的账单并加行锁，没有匹配时返回 nil
+// dedicated 地址只属于一张账单；shared 地址先按金额匹配待付款账单，再匹配同金额最近过期的账单 (记为逾期付款)
+func matchInvoice(tx *gorm.DB, addressID uint64, amount decimal.Decimal) (*model.Invoice, error) {
+	var inv model.Invoice
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("address_id = ? AND (address_mode = ? OR (status = ? AND amount = ?))",
+			addressID, model.InvoiceAddressDedicated, model.InvoiceStatusPending, amount).
+		Order("id").First(&inv).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("address_id = ? AND address_mode = ? AND status = ? AND amount = ?",
+				addressID, model.InvoiceAddressShared, model.InvoiceStatusExpired, amount).
+			Order("id DESC").First(&inv).Error
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("[Invoice] 地址 #%d 收到 %s 但没有匹配的账单，需要人工对账", addressID, amount)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// Start 定时将到期未付清的账单置为 expired (阻塞，直到 ctx 取消)
+func (s *InvoiceService) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.expireDue(ctx)
+		}
+	}
+}
+
+func (s *InvoiceService) expireDue(ctx context.Context) {
+	var ids []uint64
+	if err := s.db.WithContext(ctx).Model(&model.Invoice{}).
+		Where("status IN ? AND expires_at <= ?", openInvoiceStatuses, time.Now()).
+		Limit(100).Pluck("id", &ids).Error; err != nil {
+		log.Printf("[Invoice] 扫描到期账单失败: %v", err)
+		return
+	}
+	for _, id := range ids {
+		if err := s.expire(ctx, id); err != nil {
+			log.Printf("[Invoice] 账单 #%d 过期处理失败: %v", id, err)
+		}
+	}
+}
+
+var openInvoiceStatuses = []string{model.InvoiceStatusPending, model.InvoiceStatusUnderpaid}
+
+// expire 条件更新为 expired (与付款并发时以先提交者为准)，成功时记录 invoice.expired 通知
+func (s *InvoiceService) expire(ctx context.Context, id uint64) error {
+	var deliveries []model.WebhookDelivery
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Invoice{}).
+			Where("id = ? AND status IN ? AND expires_at <= ?", id, openInvoiceStatuses, time.Now()).
+			Update("status", model.InvoiceStatusExpired)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		var inv model.Invoice
+		if err := tx.First(&inv, id).Error; err != nil {
+			return err
+		}
+		var err error
+		deliveries, err = s.webhooks.Record(tx, inv.MerchantID, InvoiceEventExpired, "", inv.ID, &inv)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.webhooks.Enqueue(deliveries)
+	return nil
+}
+
+// invoicePrecision 账单金额的小数位
+func invoicePrecision(decimals int32) int32 {
+	if decimals > maxInvoicePrecision {
+		return maxInvoicePrecision
+	}
+	return decimals
+}
+
+// convertPrice 法币价格按汇率换算为币本位金额，向上取整到 precision 位 (商户不少收)
+func convertPrice(price, rate decimal.Decimal, precision int32) decimal.Decimal {
+	return price.DivRound(rate, precision+8).RoundUp(precision)
+}
+
+// invoiceTTL 账单有效期: 为空时取默认值，必须在 [1m, max] 之间
+func invoiceTTL(requested, def, max time.Duration) (time.Duration, error) {
+	if requested == 0 {
+		return def, nil
+	}
+	if requested < minInvoiceTTL || requested > max {
+		return 0, errno.ErrInvoiceInvalid.WithMessage(fmt.Sprintf("ttl must be between %s and %s", minInvoiceTTL, max))
+	}
+	return requested, nil
+}
+
+// uniqueSharedAmount 在 base 上加最小单位的整数倍，直到与 taken (同地址其他待付款账单的金额) 都不相同
+func uniqueSharedAmount(base decimal.Decimal, taken []decimal.Decimal, precision int32) (decimal.Decimal, error) {
+	used := make(map[string]bool, len(taken))
+	for _, t := range taken {
+		used[t.StringFixed(precision)] = true
+	}
+	unit := decimal.New(1, -precision)
+	for k := int64(0); k < maxSharedOffsets; k++ {
+		candidate := base.Add(unit.Mul(decimal.NewFromInt(k)))
+		if !used[candidate.StringFixed(precision)] {
+			return candidate, nil
+		}
+	}
+	return decimal.Zero, errno.ErrInvoiceAmountBusy
+}
+
+// nextInvoiceStatus 收到付款后的账单状态，paid 为含本次付款的累计金额
+// 已过期的账单状态不变，付款记为逾期 (late)
+func nextInvoiceStatus(current string, amount, paid decimal.Decimal) (string, bool) {
+	if current == model.InvoiceStatusExpired {
+		return model.InvoiceStatusExpired, true
+	}
+	switch paid.Cmp(amount) {
+	case -1:
+		return model.InvoiceStatusUnderpaid, false
+	case 0:
+		return model.InvoiceStatusPaid, false
+	default:
+		return model.InvoiceStatusOverpaid, false
+	}
+}
+
+func validInvoiceStatus(status string) bool {
+	switch status {
+	case model.InvoiceStatusPending, model.InvoiceStatusUnderpaid, model.InvoiceStatusPaid,
+		model.InvoiceStatusOverpaid, model.InvoiceStatusExpired:
+		return true
+	}
+	return false
+}
+
+// validateRedirectURL 付款完成后的跳转地址，可为空
+func validateRedirectURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errno.ErrInvoiceInvalid.WithMessage("redirect_url must be an absolute http(s) URL")
+	}
+	return nil
+}
+
+// CheckoutURL 账单的托管收银台页面地址
+func (s *InvoiceService) CheckoutURL(publicID string) string {
+	return checkoutURL(s.cfg.CheckoutURL, publicID)
+}
+
+// checkoutURL 托管收银台页面地址
+func checkoutURL(pattern, publicID string) string {
+	return strings.ReplaceAll(pattern, "{id}", url.PathEscape(publicID))
+}
+
+// buildCheckout 收银台数据，付款链接按剩余应付金额生成
+func buildCheckout(inv *model.Invoice, merchant, checkout string, now time.Time) *CheckoutView {
+	asset, _ := LookupAsset(inv.Currency)
+	remaining := inv.Amount.Sub(inv.PaidAmount)
+	if remaining.IsNegative() {
+		remaining = decimal.Zero
+	}
+
+	v := &CheckoutView{
+		ID:              inv.PublicID,
+		Merchant:        merchant,
+		Description:     inv.Description,
+		Currency:        inv.Currency,
+		Amount:          inv.Amount.String(),
+		PaidAmount:      inv.PaidAmount.String(),
+		RemainingAmount: remaining.String(),
+		Address:         inv.Address,
+		ExactAmount:     inv.AddressMode == model.InvoiceAddressShared,
+		Status:          inv.Status,
+		ExpiresAt:       inv.ExpiresAt,
+		PaidAt:          inv.PaidAt,
+		RedirectURL:     inv.RedirectURL,
+		CheckoutURL:     checkout,
+	}
+	if inv.PriceCurrency != "" {
+		v.PriceCurrency = inv.PriceCurrency
+		v.PriceAmount = inv.PriceAmount.String()
+		v.ExchangeRate = inv.ExchangeRate.String()
+	}
+	if inv.Open() {
+		if left := inv.ExpiresAt.Sub(now); left > 0 {
+			v.SecondsRemaining = int64(left / time.Second)
+		}
+		if remaining.IsPositive() {
+			v.PaymentURI = paymentURI(inv.Currency, inv.Address, remaining, asset.Decimals)
+		}
+	}
+	return v
+}
+
+// paymentURI 钱包可识别的付款链接: ETH 为 EIP-681 (value 单位 wei)，BTC 为 BIP-21，其他币种不生成
+func paymentURI(currency, address string, amount decimal.Decimal, decimals int32) string {
+	switch strings.ToUpper(currency) {
+	case "ETH":
+		if decimals <= 0 {
+			decimals = 18
+		}
+		return fmt.Sprintf("ethereum:%s?value=%s", address, amount.Shift(decimals).Truncate(0).String())
+	case "BTC":
+		return fmt.Sprintf("bitcoin:%s?amount=%s", address, amount.String())
+	}
+	return ""
+}
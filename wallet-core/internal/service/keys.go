@@ -0,0 +1,38 @@
+package service
+
+import (
+	"wallet-core/internal/service/mfa"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/kms"
+)
+
+// 各子系统加密敏感数据使用的 KMS 密钥，互不共用 (用户 TOTP 见 mfa.KeyID)
+// 记录中保存了加密时的 KeyID，解密按记录中的 KeyID 进行
+const (
+	AdminTOTPKeyID = "admin-totp"      // 管理员 TOTP 密钥
+	MerchantKeyID  = "merchant-secret" // 商户 API 密钥
+	KYCKeyID       = "kyc-document"    // KYC 证件文件
+	WebhookKeyID   = "webhook-secret"  // Webhook 签名密钥
+)
+
+// KeyIDs 本服务导入的全部 KMS 密钥 (健康检查逐一探测)
+var KeyIDs = []string{mfa.KeyID, AdminTOTPKeyID, MerchantKeyID, KYCKeyID, WebhookKeyID}
+
+// NewKeyManager 创建导入了用户 TOTP 和各子系统密钥的 KMS
+func NewKeyManager(cfg *config.Config) (kms.KeyManager, error) {
+	km, err := mfa.NewKeyManager(cfg.MFA, cfg.App.Env)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range []struct{ id, setting, hexKey string }{
+		{AdminTOTPKeyID, "admin.encryption_key", cfg.Admin.EncryptionKey},
+		{MerchantKeyID, "merchant.encryption_key", cfg.Merchant.EncryptionKey},
+		{KYCKeyID, "kyc.encryption_key", cfg.KYC.EncryptionKey},
+		{WebhookKeyID, "invoice.webhook_encryption_key", cfg.Invoice.WebhookEncryptionKey},
+	} {
+		if err := mfa.ImportKey(km, k.id, k.setting, k.hexKey, cfg.App.Env); err != nil {
+			return nil, err
+		}
+	}
+	return km, nil
+}
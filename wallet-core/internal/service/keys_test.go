@@ -0,0 +1,46 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/internal/service/mfa"
+	"wallet-core/pkg/config"
+)
+
+func TestNewKeyManagerUsesSeparateSubsystemKeys(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.Env = "production"
+	cfg.MFA.EncryptionKey = strings.Repeat("01", 32)
+	cfg.Admin.EncryptionKey = strings.Repeat("02", 32)
+	cfg.Merchant.EncryptionKey = strings.Repeat("03", 32)
+	cfg.KYC.EncryptionKey = strings.Repeat("04", 32)
+
+	// 生产环境缺少任一子系统的密钥: 拒绝启动
+	_, err := NewKeyManager(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invoice.webhook_encryption_key")
+
+	cfg.Invoice.WebhookEncryptionKey = strings.Repeat("05", 32)
+	km, err := NewKeyManager(cfg)
+	require.NoError(t, err)
+
+	// 每个子系统的密文只能用自己的密钥解开
+	for _, id := range KeyIDs {
+		ciphertext, err := km.Encrypt(id, []byte("secret"))
+		require.NoError(t, err)
+		for _, other := range KeyIDs {
+			plaintext, err := km.Decrypt(other, ciphertext)
+			if other == id {
+				require.NoError(t, err)
+				assert.Equal(t, "secret", string(plaintext))
+			} else {
+				assert.Error(t, err, "%s 的密文不应能用 %s 解密", id, other)
+			}
+		}
+	}
+	assert.Contains(t, KeyIDs, mfa.KeyID)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+)
+
+// kycLimitWindow 提现额度的统计窗口 (滚动 24 小时)
+const kycLimitWindow = 24 * time.Hour
+
+// TierPolicy 等级对应的权限 (配置 kyc.tiers.<tier>)
+// 未配置的等级按 unverified 处理，unverified 也未配置时不允许提现
+func TierPolicy(tier string) config.KYCTierConfig {
+	if p, ok := config.Global.KYC.Tiers[tier]; ok {
+		return p
+	}
+	return config.Global.KYC.Tiers[model.KYCTierUnverified]
+}
+
+// ValidKYCTier 是否为已定义的等级
+func ValidKYCTier(tier string) bool {
+	return kycTierRank(tier) >= 0
+}
+
+// kycTierRank 等级高低，未定义的等级返回 -1
+func kycTierRank(tier string) int {
+	for i, t := range model.KYCTiers {
+		if t == tier {
+			return i
+		}
+	}
+	return -1
+}
+
+// AssetsForTier 等级可用的币种
+func AssetsForTier(tier string) []Asset {
+	p := TierPolicy(tier)
+	var assets []Asset
+	for _, a := range SupportedAssets() {
+		if tierAllowsAsset(p, a.Currency) {
+			assets = append(assets, a)
+		}
+	}
+	return assets
+}
+
+// tierAllowsAsset 等级是否可以使用该币种，未限制币种时全部可用
+func tierAllowsAsset(p config.KYCTierConfig, currency string) bool {
+	if len(p.Assets) == 0 {
+		return true
+	}
+	for _, a := range p.Assets {
+		if strings.EqualFold(a, currency) {
+			return true
+		}
+	}
+	return false
+}
+
+// dailyWithdrawalLimit 该币种 24 小时提现额度，ok 为 false 表示不限额
+// viper 会把 map key 转成小写，所以按小写币种查找
+func dailyWithdrawalLimit(p config.KYCTierConfig, currency string) (decimal.Decimal, bool) {
+	v, ok := p.DailyWithdrawal[strings.ToLower(currency)]
+	if !ok {
+		return decimal.Zero, false
+	}
+	return decimal.NewFromFloat(v), true
+}
+
+// checkDailyLimit 已用额度加上本次金额不能超过额度
+func checkDailyLimit(currency string, limit, used, amount decimal.Decimal) error {
+	if used.Add(amount).LessThanOrEqual(limit) {
+		return nil
+	}
+	remaining := decimal.Max(limit.Sub(used), decimal.Zero)
+	return errno.ErrKYCLimitExceeded.WithMessage("daily withdrawal limit is " + limit.String() + " " + strings.ToUpper(currency) +
+		", remaining " + remaining.String())
+}
+
+// UserKYCTier 查询用户当前等级
+func UserKYCTier(ctx context.Context, db *gorm.DB, userID uint64) (string, error) {
+	var u model.User
+	if err := db.WithContext(ctx).Select("id", "kyc_tier").First(&u, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errno.ErrUserNotFound
+		}
+		return "", err
+	}
+	return u.KYCTier, nil
+}
+
+// CheckKYCAsset 校验用户等级能否使用该币种 (生成充值地址)
+func CheckKYCAsset(ctx context.Context, db *gorm.DB, userID uint64, currency string) error {
+	tier, err := UserKYCTier(ctx, db, userID)
+	if err != nil {
+		return err
+	}
+	if !tierAllowsAsset(TierPolicy(tier), currency) {
+		return errno.ErrKYCAssetNotAllowed
+	}
+	return nil
+}
+
+// CheckKYCWithdrawal 校验用户等级是否允许这笔提现: 提现开关、可用币种、24 小时累计额度
+// 在持有账户行锁的事务中调用时，同一用户同币种的并发提现不会突破额度
+func CheckKYCWithdrawal(ctx context.Context, db *gorm.DB, userID uint64, currency string, amount decimal.Decimal) error {
+	tier, err := UserKYCTier(ctx, db, userID)
+	if err != nil {
+		return err
+	}
+	p := TierPolicy(tier)
+	if !p.WithdrawalEnabled {
+		return errno.ErrKYCWithdrawalDisabled
+	}
+	if !tierAllowsAsset(p, currency) {
+		return errno.ErrKYCAssetNotAllowed
+	}
+	limit, ok := dailyWithdrawalLimit(p, currency)
+	if !ok {
+		return nil
+	}
+	used, err := WithdrawnSince(ctx, db, userID, currency, time.Now().Add(-kycLimitWindow))
+	if err != nil {
+		return err
+	}
+	return checkDailyLimit(currency, limit, used, amount)
+}
+
+// CheckKYCTransfer 校验站内转账 (含提现转站内结算) 双方的等级
+// 资金离开转出方账户，与提现一样要求提现开关和可用币种，否则低等级用户可以转给他人代为提现;
+// 转入方的等级必须可用该币种。转账按 transfer 配置单独限额，不占用提现额度
+func CheckKYCTransfer(ctx context.Context, db *gorm.DB, fromUserID, toUserID uint64, currency string) error {
+	fromTier, err := UserKYCTier(ctx, db, fromUserID)
+	if err != nil {
+		return err
+	}
+	toTier, err := UserKYCTier(ctx, db, toUserID)
+	if err != nil {
+		return err
+	}
+	return checkTransferTiers(TierPolicy(fromTier), TierPolicy(toTier), currency)
+}
+
+func checkTransferTiers(from, to config.KYCTierConfig, currency string) error {
+	if !from.WithdrawalEnabled {
+		return errno.ErrKYCWithdrawalDisabled
+	}
+	if !tierAllowsAsset(from, currency) {
+		return errno.ErrKYCAssetNotAllowed
+	}
+	if !tierAllowsAsset(to, currency) {
+		return errno.ErrKYCAssetNotAllowed.WithMessage("Currency is not available for the recipient's KYC tier")
+	}
+	return nil
+}
+
+// WithdrawnSince 用户某币种自 since 起的提现总额 (不含已拒绝 / 失败 / 取消的提现，不含站内结算的提现)
+func WithdrawnSince(ctx context.Context, db *gorm.DB, userID uint64, currency string, since time.Time) (decimal.Decimal, error) {
+	var sum decimal.NullDecimal
+	err := db.WithContext(ctx).Model(&model.Withdrawal{}).
+		Select("SUM(amount)").
+		Where("user_id = ? AND UPPER(chain) = ? AND created_at >= ? AND transfer_id = 0", userID, strings.ToUpper(currency), since).
+		Where("status NOT IN ?", []string{
+			model.WithdrawalStatusRejected,
+			model.WithdrawalStatusFailed,
+			model.WithdrawalStatusCancelled,
+			model.WithdrawalStatusUserCancelled,
+		}).
+		Scan(&sum).Error
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if !sum.Valid {
+		return decimal.Zero, nil
+	}
+	return sum.Decimal, nil
+}
@@ -0,0 +1,439 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"wallet-core/internal/event"
+	"wallet-core/internal/model"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/kms"
+	"wallet-core/pkg/safe_random"
+)
+
+// maxKYCDocuments 一次申请最多上传的证件文件数
+const maxKYCDocuments = 5
+
+// kycContentTypes 允许上传的文件类型 (按内容识别，不信任客户端声明的类型)
+var kycContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+var kycDocumentTypes = map[string]bool{
+	model.KYCDocumentPassport:       true,
+	model.KYCDocumentIDCard:         true,
+	model.KYCDocumentDriverLicense:  true,
+	model.KYCDocumentProofOfAddress: true,
+}
+
+// KYCService 实名认证等级
+// 1. 用户提交申请 (证件元数据 + 文件)，文件经 KMS 加密后写入 kyc.document_dir，库中只保存元数据
+// 2. 管理员审核通过后用户升到申请的等级，管理员也可以直接调整等级 (含降级)
+// 3. 等级变更写入 Outbox (wallet_events_kyc_tier_changed)，与变更在同一事务中
+// 等级对提现和币种的限制见 kyc_policy.go
+type KYCService struct {
+	db      *gorm.DB
+	keys    kms.KeyManager
+	dir     string
+	maxSize int64
+}
+
+var KYC *KYCService
+
+func NewKYCService(db *gorm.DB, keys kms.KeyManager, cfg config.KYCConfig) *KYCService {
+	maxSize := cfg.MaxDocumentSize
+	if maxSize <= 0 {
+		maxSize = 5 << 20
+	}
+	return &KYCService{db: db, keys: keys, dir: cfg.DocumentDir, maxSize: maxSize}
+}
+
+// MaxDocumentSize 单个证件文件上限 (字节)
+func (s *KYCService) MaxDocumentSize() int64 {
+	return s.maxSize
+}
+
+// KYCSubmission 用户提交的申请资料
+type KYCSubmission struct {
+	Tier           string
+	FullName       string
+	Country        string
+	DocumentType   string
+	DocumentNumber string
+	DocumentExpiry *time.Time
+}
+
+// KYCUpload 上传的证件文件
+type KYCUpload struct {
+	FileName string
+	Content  []byte
+}
+
+// KYCLimit 一个币种的 24 小时提现额度
+type KYCLimit struct {
+	Currency  string          `json:"currency"`
+	Limit     decimal.Decimal `json:"limit"`
+	Used      decimal.Decimal `json:"used"`
+	Remaining decimal.Decimal `json:"remaining"`
+}
+
+// KYCStatus 用户当前等级及其权限
+type KYCStatus struct {
+	Tier              string                `json:"tier"`
+	WithdrawalEnabled bool                  `json:"withdrawal_enabled"`
+	Assets            []Asset               `json:"assets"`
+	DailyWithdrawal   []KYCLimit            `json:"daily_withdrawal"`      // 未列出的币种不限额
+	Application       *model.KYCApplication `json:"application,omitempty"` // 最近一次申请
+}
+
+// Status 查询用户的等级、权限、额度使用情况和最近一次申请
+func (s *KYCService) Status(ctx context.Context, userID uint64) (*KYCStatus, error) {
+	tier, err := UserKYCTier(ctx, s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	p := TierPolicy(tier)
+	st := &KYCStatus{
+		Tier:              tier,
+		WithdrawalEnabled: p.WithdrawalEnabled,
+		Assets:            AssetsForTier(tier),
+		DailyWithdrawal:   []KYCLimit{},
+	}
+
+	since := time.Now().Add(-kycLimitWindow)
+	for _, a := range st.Assets {
+		limit, ok := dailyWithdrawalLimit(p, a.Currency)
+		if !ok {
+			continue
+		}
+		used, err := WithdrawnSince(ctx, s.db, userID, a.Currency, since)
+		if err != nil {
+			return nil, err
+		}
+		st.DailyWithdrawal = append(st.DailyWithdrawal, KYCLimit{
+			Currency:  a.Currency,
+			Limit:     limit,
+			Used:      used,
+			Remaining: decimal.Max(limit.Sub(used), decimal.Zero),
+		})
+	}
+
+	var app model.KYCApplication
+	err = s.db.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").First(&app).Error
+	switch {
+	case err == nil:
+		st.Application = &app
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+	return st, nil
+}
+
+// Submit 提交等级申请，同一时间只能有一个待审核的申请
+func (s *KYCService) Submit(ctx context.Context, userID uint64, sub KYCSubmission, files []KYCUpload) (*model.KYCApplication, error) {
+	current, err := UserKYCTier(ctx, s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !ValidKYCTier(sub.Tier) || kycTierRank(sub.Tier) <= kycTierRank(current) {
+		return nil, errno.ErrKYCTierInvalid.WithMessage("tier must be higher than the current tier " + current)
+	}
+	if !kycDocumentTypes[sub.DocumentType] {
+		return nil, errno.ErrKYCDocumentInvalid.WithMessage("unsupported document_type " + sub.DocumentType)
+	}
+	if len(files) == 0 || len(files) > maxKYCDocuments {
+		return nil, errno.ErrKYCDocumentInvalid.WithMessage("upload 1 to " + strconv.Itoa(maxKYCDocuments) + " documents")
+	}
+	for _, f := range files {
+		if err := s.checkUpload(f); err != nil {
+			return nil, err
+		}
+	}
+
+	// 先加密落盘，事务失败时删除已写入的文件
+	docs := make([]model.KYCDocument, 0, len(files))
+	for _, f := range files {
+		doc, err := s.storeDocument(userID, f)
+		if err != nil {
+			s.removeDocuments(docs)
+			return nil, err
+		}
+		docs = append(docs, *doc)
+	}
+
+	app := &model.KYCApplication{
+		UserID:               userID,
+		CurrentTier:          current,
+		Tier:                 sub.Tier,
+		Status:               model.KYCStatusPending,
+		FullName:             strings.TrimSpace(sub.FullName),
+		Country:              strings.ToUpper(sub.Country),
+		DocumentType:         sub.DocumentType,
+		DocumentNumberMasked: maskDocumentNumber(sub.DocumentNumber),
+		DocumentExpiry:       sub.DocumentExpiry,
+		Documents:            docs,
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pending int64
+		if err := tx.Model(&model.KYCApplication{}).
+			Where("user_id = ? AND status = ?", userID, model.KYCStatusPending).
+			Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending > 0 {
+			return errno.ErrKYCApplicationPending
+		}
+		// 并发提交由部分唯一索引 idx_kyc_applications_pending 兜底
+		if err := tx.Create(app).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errno.ErrKYCApplicationPending
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		s.removeDocuments(docs)
+		return nil, err
+	}
+	return app, nil
+}
+
+// checkUpload 校验文件大小和类型
+func (s *KYCService) checkUpload(f KYCUpload) error {
+	if len(f.Content) == 0 {
+		return errno.ErrKYCDocumentInvalid.WithMessage(f.FileName + " is empty")
+	}
+	if int64(len(f.Content)) > s.maxSize {
+		return errno.ErrKYCDocumentInvalid.WithMessage(f.FileName + " exceeds " + strconv.FormatInt(s.maxSize, 10) + " bytes")
+	}
+	if !kycContentTypes[detectContentType(f.Content)] {
+		return errno.ErrKYCDocumentInvalid.WithMessage(f.FileName + " must be a JPEG, PNG or PDF file")
+	}
+	return nil
+}
+
+// storeDocument 加密并写入文件，返回待保存的元数据
+func (s *KYCService) storeDocument(userID uint64, f KYCUpload) (*model.KYCDocument, error) {
+	ciphertext, err := s.keys.Encrypt(KYCKeyID, f.Content)
+	if err != nil {
+		return nil, fmt.Errorf("加密证件文件失败: %w", err)
+	}
+	name, err := safe_random.GenerateRandomHexString(16)
+	if err != nil {
+		return nil, err
+	}
+	rel := filepath.Join(strconv.FormatUint(userID, 10), name+".enc")
+	path := filepath.Join(s.dir, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, ciphertext, 0o600); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(f.Content)
+	return &model.KYCDocument{
+		UserID:      userID,
+		FileName:    cleanFileName(f.FileName),
+		ContentType: detectContentType(f.Content),
+		Size:        int64(len(f.Content)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		KMSKeyID:    KYCKeyID,
+		StoragePath: rel,
+	}, nil
+}
+
+func (s *KYCService) removeDocuments(docs []model.KYCDocument) {
+	for _, d := range docs {
+		_ = os.Remove(filepath.Join(s.dir, d.StoragePath))
+	}
+}
+
+// ListApplications 申请列表，status 为空时返回全部，待审核的按提交顺序排列
+func (s *KYCService) ListApplications(ctx context.Context, status string, limit int) ([]model.KYCApplication, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	q := s.db.WithContext(ctx)
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	if status == model.KYCStatusPending {
+		q = q.Order("id ASC")
+	} else {
+		q = q.Order("id DESC")
+	}
+	var list []model.KYCApplication
+	err := q.Limit(limit).Find(&list).Error
+	return list, err
+}
+
+// GetApplication 申请详情 (含证件文件元数据)
+func (s *KYCService) GetApplication(ctx context.Context, id uint64) (*model.KYCApplication, error) {
+	var app model.KYCApplication
+	if err := s.db.WithContext(ctx).Preload("Documents").First(&app, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrKYCApplicationNotFound
+		}
+		return nil, err
+	}
+	return &app, nil
+}
+
+// OpenDocument 解密证件文件，并校验内容摘要
+func (s *KYCService) OpenDocument(ctx context.Context, applicationID, documentID uint64) (*model.KYCDocument, []byte, error) {
+	var doc model.KYCDocument
+	err := s.db.WithContext(ctx).Where("id = ? AND application_id = ?", documentID, applicationID).First(&doc).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errno.ErrKYCDocumentNotFound
+		}
+		return nil, nil, err
+	}
+
+	ciphertext, err := os.ReadFile(filepath.Join(s.dir, doc.StoragePath))
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取证件文件失败: %w", err)
+	}
+	content, err := s.keys.Decrypt(doc.KMSKeyID, ciphertext)
+	if err != nil {
+		return nil, nil, fmt.Errorf("解密证件文件失败: %w", err)
+	}
+	if sum := sha256.Sum256(content); hex.EncodeToString(sum[:]) != doc.SHA256 {
+		return nil, nil, fmt.Errorf("证件文件 %d 摘要不一致", doc.ID)
+	}
+	return &doc, content, nil
+}
+
+// ReviewApplication 审核申请: 通过后用户升到申请的等级 (已不低于该等级时不变)
+func (s *KYCService) ReviewApplication(ctx context.Context, id, adminID uint64, action, remark string) (*model.KYCApplication, error) {
+	if action != ReviewActionApprove && action != ReviewActionReject {
+		return nil, errno.ErrBind.WithMessage(`action must be "approve" or "reject"`)
+	}
+
+	var app model.KYCApplication
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&app, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.ErrKYCApplicationNotFound
+			}
+			return err
+		}
+		if app.Status != model.KYCStatusPending {
+			return errno.ErrKYCApplicationReviewed
+		}
+
+		now := time.Now()
+		app.Status = model.KYCStatusRejected
+		if action == ReviewActionApprove {
+			app.Status = model.KYCStatusApproved
+		}
+		app.ReviewerID = adminID
+		app.ReviewRemark = remark
+		app.ReviewedAt = &now
+		if err := tx.Model(&app).Updates(map[string]interface{}{
+			"status":        app.Status,
+			"reviewer_id":   adminID,
+			"review_remark": remark,
+			"reviewed_at":   now,
+		}).Error; err != nil {
+			return err
+		}
+
+		if action == ReviewActionApprove {
+			return changeKYCTier(tx, app.UserID, app.Tier, true, event.KYCTierChangedEvent{
+				ApplicationID: app.ID,
+				AdminID:       adminID,
+				Reason:        remark,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// SetTier 管理员直接调整用户等级 (含降级)，reason 写入事件
+func (s *KYCService) SetTier(ctx context.Context, userID, adminID uint64, tier, reason string) error {
+	if !ValidKYCTier(tier) {
+		return errno.ErrKYCTierInvalid
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return changeKYCTier(tx, userID, tier, false, event.KYCTierChangedEvent{AdminID: adminID, Reason: reason})
+	})
+}
+
+// changeKYCTier 修改用户等级并写入 Outbox 事件，等级不变时什么都不做
+// upgradeOnly 为 true 时只升不降 (审核通过的申请不会覆盖管理员期间调高的等级)
+func changeKYCTier(tx *gorm.DB, userID uint64, tier string, upgradeOnly bool, e event.KYCTierChangedEvent) error {
+	var u model.User
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "kyc_tier").First(&u, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrUserNotFound
+		}
+		return err
+	}
+	if u.KYCTier == tier || (upgradeOnly && kycTierRank(u.KYCTier) > kycTierRank(tier)) {
+		return nil
+	}
+
+	if err := tx.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"kyc_tier":   tier,
+		"updated_at": time.Now(),
+	}).Error; err != nil {
+		return err
+	}
+
+	e.UserID = userID
+	e.OldTier = u.KYCTier
+	e.NewTier = tier
+	return model.CreateOutboxMessage(tx, event.TopicKYCTierChanged, e)
+}
+
+// maskDocumentNumber 证件号码只保留后 4 位
+func maskDocumentNumber(number string) string {
+	number = strings.TrimSpace(number)
+	r := []rune(number)
+	keep := 4
+	if len(r) <= keep {
+		keep = 0
+	}
+	return strings.Repeat("*", len(r)-keep) + string(r[len(r)-keep:])
+}
+
+// cleanFileName 去掉客户端文件名中的路径，只用于展示
+func cleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" {
+		name = "document"
+	}
+	if r := []rune(name); len(r) > 255 {
+		name = string(r[:255])
+	}
+	return name
+}
+
+// detectContentType 按文件内容识别类型，去掉 charset 等参数
+func detectContentType(content []byte) string {
+	ct, _, _ := strings.Cut(http.DetectContentType(content), ";")
+	return ct
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+)
+
+func TestTierPolicy(t *testing.T) {
+	old := config.Global.KYC
+	defer func() { config.Global.KYC = old }()
+	config.Global.KYC.Tiers = map[string]config.KYCTierConfig{
+		model.KYCTierUnverified: {WithdrawalEnabled: false},
+		model.KYCTierBasic:      {WithdrawalEnabled: true, DailyWithdrawal: map[string]float64{"eth": 5}},
+	}
+
+	assert.True(t, TierPolicy(model.KYCTierBasic).WithdrawalEnabled)
+	// 未配置的等级按 unverified 处理
+	assert.False(t, TierPolicy(model.KYCTierFull).WithdrawalEnabled)
+	assert.False(t, TierPolicy("").WithdrawalEnabled)
+
+	limit, ok := dailyWithdrawalLimit(TierPolicy(model.KYCTierBasic), "ETH")
+	require.True(t, ok)
+	assert.Equal(t, "5", limit.String())
+	_, ok = dailyWithdrawalLimit(TierPolicy(model.KYCTierBasic), "BTC")
+	assert.False(t, ok)
+}
+
+func TestKYCTierRank(t *testing.T) {
+	assert.Less(t, kycTierRank(model.KYCTierUnverified), kycTierRank(model.KYCTierBasic))
+	assert.Less(t, kycTierRank(model.KYCTierBasic), kycTierRank(model.KYCTierFull))
+	assert.True(t, ValidKYCTier(model.KYCTierFull))
+	assert.False(t, ValidKYCTier("gold"))
+	assert.False(t, ValidKYCTier(""))
+}
+
+func TestAssetsForTier(t *testing.T) {
+	oldChains, oldKYC := config.Global.Chains, config.Global.KYC
+	defer func() { config.Global.Chains, config.Global.KYC = oldChains, oldKYC }()
+	config.Global.Chains = map[string]config.ChainConfig{"eth": {}, "btc": {}}
+	config.Global.KYC.Tiers = map[string]config.KYCTierConfig{
+		model.KYCTierUnverified: {Assets: []string{"eth"}},
+		model.KYCTierBasic:      {},
+	}
+
+	unverified := AssetsForTier(model.KYCTierUnverified)
+	require.Len(t, unverified, 1)
+	assert.Equal(t, "ETH", unverified[0].Currency)
+	assert.Len(t, AssetsForTier(model.KYCTierBasic), 2)
+}
+
+func TestCheckDailyLimit(t *testing.T) {
+	d := decimal.RequireFromString
+	assert.NoError(t, checkDailyLimit("eth", d("5"), d("3"), d("2")))
+
+	err := checkDailyLimit("eth", d("5"), d("3"), d("2.5"))
+	var e errno.Errno
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, errno.ErrKYCLimitExceeded.Code, e.Code)
+	assert.Contains(t, e.Message, "remaining 2")
+
+	// 已超额时剩余额度为 0
+	err = checkDailyLimit("eth", d("5"), d("6"), d("1"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "remaining 0")
+}
+
+func TestMaskDocumentNumber(t *testing.T) {
+	assert.Equal(t, "*****6789", maskDocumentNumber("E12346789"))
+	assert.Equal(t, "****", maskDocumentNumber("1234"))
+	assert.Equal(t, "", maskDocumentNumber(" "))
+}
+
+func TestCheckUpload(t *testing.T) {
+	s := &KYCService{maxSize: 16}
+	assert.NoError(t, s.checkUpload(KYCUpload{FileName: "id.pdf", Content: []byte("%PDF-1.7\n")}))
+	assert.Error(t, s.checkUpload(KYCUpload{FileName: "empty.pdf"}))
+	assert.Error(t, s.checkUpload(KYCUpload{FileName: "big.pdf", Content: []byte("%PDF-1.7\n0123456789")}))
+	assert.Error(t, s.checkUpload(KYCUpload{FileName: "id.html", Content: []byte("<html></html>")}))
+}
+
+func TestCleanFileName(t *testing.T) {
+	assert.Equal(t, "passport.png", cleanFileName("../../etc/passport.png"))
+	assert.Equal(t, "scan.pdf", cleanFileName(`C:\Users\me\scan.pdf`))
+	assert.Equal(t, "document", cleanFileName(""))
+}
+
+func TestCheckTransferTiers(t *testing.T) {
+	enabled := config.KYCTierConfig{WithdrawalEnabled: true}
+	ethOnly := config.KYCTierConfig{WithdrawalEnabled: true, Assets: []string{"eth"}}
+
+	assert.NoError(t, checkTransferTiers(enabled, enabled, "ETH"))
+	assert.NoError(t, checkTransferTiers(ethOnly, ethOnly, "ETH"))
+	// 转出方不允许提现: 不能转给他人代为提现
+	assert.ErrorIs(t, checkTransferTiers(config.KYCTierConfig{}, enabled, "ETH"), errno.ErrKYCWithdrawalDisabled)
+	// 转出方 / 转入方不可用该币种
+	assert.ErrorIs(t, checkTransferTiers(ethOnly, enabled, "BTC"), errno.ErrKYCAssetNotAllowed)
+	var e errno.Errno
+	require.True(t, errors.As(checkTransferTiers(enabled, ethOnly, "BTC"), &e))
+	assert.Equal(t, errno.ErrKYCAssetNotAllowed.Code, e.Code)
+	// 转入方不需要提现权限
+	assert.NoError(t, checkTransferTiers(enabled, config.KYCTierConfig{}, "ETH"))
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/apisign"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/kms"
+	"wallet-core/pkg/safe_random"
+)
+
+// 商户 API Key 权限范围
+const (
+	ScopeMerchantRead = "merchant:read" // 查询商户自身信息
+)
+
+var merchantScopes = map[string]bool{
+	ScopeMerchantRead: true,
+	ScopeInvoiceRead:  true,
+	ScopeInvoiceWrite: true,
+	ScopeWebhookRead:  true,
+	ScopeWebhookWrite: true,
+}
+
+// 验签结果 (Prometheus label)
+const (
+	MerchantAuthOK               = "ok"
+	MerchantAuthInvalidKey       = "invalid_key"
+	MerchantAuthInvalidSignature = "invalid_signature"
+	MerchantAuthReplayed         = "replayed"
+	MerchantAuthIPDenied         = "ip_denied"
+	MerchantAuthScopeDenied      = "scope_denied"
+)
+
+// MerchantService 商户与 API Key 管理，以及请求验签
+// 1. API Key 由管理员创建，密钥明文只在创建时返回一次，库中保存 KMS 密文 (HMAC 验签需要原文)
+// 2. 请求签名见 pkg/apisign; 时间戳偏差超过 clock_skew 或 nonce 重复均视为重放
+// 3. 每个 Key 可限制 scopes、IP 白名单和过期时间，吊销后立即失效
+type MerchantService struct {
+	db        *gorm.DB
+	rdb       *redis.Client
+	keys      kms.KeyManager
+	clockSkew time.Duration
+}
+
+var Merchant *MerchantService
+
+func NewMerchantService(db *gorm.DB, rdb *redis.Client, keys kms.KeyManager, cfg config.MerchantConfig) *MerchantService {
+	skew := cfg.ClockSkew
+	if skew <= 0 {
+		skew = 5 * time.Minute
+	}
+	return &MerchantService{db: db, rdb: rdb, keys: keys, clockSkew: skew}
+}
+
+// SignedRequest 待验签的请求
+type SignedRequest struct {
+	KeyID     string
+	Timestamp string
+	Nonce     string
+	Signature string
+	Method    string
+	Path      string // URL.RequestURI()
+	Body      []byte
+	ClientIP  string
+}
+
+// CreateMerchant 创建商户
+func (s *MerchantService) CreateMerchant(ctx context.Context, adminID uint64, name, email string) (*model.Merchant, error) {
+	m := &model.Merchant{
+		Name:      name,
+		Email:     email,
+		Status:    model.MerchantStatusActive,
+		CreatedBy: adminID,
+	}
+	if err := s.db.WithContext(ctx).Create(m).Error; err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ListMerchants 商户列表
+func (s *MerchantService) ListMerchants(ctx context.Context, limit int) ([]model.Merchant, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	var list []model.Merchant
+	err := s.db.WithContext(ctx).Order("id DESC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+// CreateAPIKey 为商户创建 API Key，返回 Key 和密钥明文 (只返回这一次)
+func (s *MerchantService) CreateAPIKey(ctx context.Context, adminID, merchantID uint64, name string, scopes, ipAllowlist []string, expiresAt *time.Time) (*model.MerchantAPIKey, string, error) {
+	if err := s.db.WithContext(ctx).First(&model.Merchant{}, merchantID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", errno.ErrMerchantNotFound
+		}
+		return nil, "", err
+	}
+	if err := ValidateScopes(scopes); err != nil {
+		return nil, "", err
+	}
+	if err := validateIPAllowlist(ipAllowlist); err != nil {
+		return nil, "", err
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", errno.ErrBind.WithMessage("expires_at must be in the future")
+	}
+
+	keyID, err := safe_random.GenerateRandomHexString(12)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := safe_random.GenerateRandomHexString(32)
+	if err != nil {
+		return nil, "", err
+	}
+	ciphertext, err := s.keys.Encrypt(MerchantKeyID, []byte(secret))
+	if err != nil {
+		return nil, "", fmt.Errorf("加密 API 密钥失败: %w", err)
+	}
+	sum := sha256.Sum256([]byte(secret))
+
+	key := &model.MerchantAPIKey{
+		MerchantID:       merchantID,
+		KeyID:            "mk_" + keyID,
+		Name:             name,
+		KMSKeyID:         MerchantKeyID,
+		SecretCiphertext: base64.StdEncoding.EncodeToString(ciphertext),
+		SecretHash:       hex.EncodeToString(sum[:]),
+		Scopes:           model.StringList(scopes),
+		IPAllowlist:      model.StringList(ipAllowlist),
+		ExpiresAt:        expiresAt,
+		CreatedBy:        adminID,
+	}
+	if err := s.db.WithContext(ctx).Create(key).Error; err != nil {
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+// ListAPIKeys 商户的全部 API Key (含已吊销)
+func (s *MerchantService) ListAPIKeys(ctx context.Context, merchantID uint64) ([]model.MerchantAPIKey, error) {
+	var list []model.MerchantAPIKey
+	err := s.db.WithContext(ctx).Where("merchant_id = ?", merchantID).Order("id DESC").Find(&list).Error
+	return list, err
+}
+
+// RevokeAPIKey 吊销 API Key，立即生效
+func (s *MerchantService) RevokeAPIKey(ctx context.Context, merchantID uint64, keyID string) error {
+	res := s.db.WithContext(ctx).Model(&model.MerchantAPIKey{}).
+		Where("merchant_id = ? AND key_id = ? AND revoked_at IS NULL", merchantID, keyID).
+		Update("revoked_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errno.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// SetSettlementAccount 绑定商户的结算账户 (钱包用户)，该用户的充值 / 提现会通知商户；userID 为 0 时解除绑定
+// 一个用户只能绑定一个商户
+func (s *MerchantService) SetSettlementAccount(ctx context.Context, merchantID, userID uint64) (*model.Merchant, error) {
+	m, err := s.GetMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != 0 {
+		if err := s.db.WithContext(ctx).Select("id").First(&model.User{}, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errno.ErrMerchantAccount.WithMessage("user not found")
+			}
+			return nil, err
+		}
+		var count int64
+		if err := s.db.WithContext(ctx).Model(&model.Merchant{}).
+			Where("user_id = ? AND id <> ?", userID, merchantID).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, errno.ErrMerchantAccount.WithMessage("user is already bound to another merchant")
+		}
+	}
+	if err := s.db.WithContext(ctx).Model(&model.Merchant{}).Where("id = ?", merchantID).Update("user_id", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errno.ErrMerchantAccount.WithMessage("user is already bound to another merchant")
+		}
+		return nil, err
+	}
+	m.UserID = userID
+	return m, nil
+}
+
+// GetMerchant 查询商户
+func (s *MerchantService) GetMerchant(ctx context.Context, merchantID uint64) (*model.Merchant, error) {
+	var m model.Merchant
+	if err := s.db.WithContext(ctx).First(&m, merchantID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrMerchantNotFound
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Authenticate 校验签名请求，返回 API Key 和验签结果 (用于监控)
+// 顺序: 时间戳 -> Key 状态 -> IP 白名单 -> 签名 -> nonce，签名通过后才占用 nonce，避免伪造请求消耗 nonce
+func (s *MerchantService) Authenticate(ctx context.Context, req SignedRequest, now time.Time) (*model.MerchantAPIKey, string, error) {
+	if req.KeyID == "" || req.Signature == "" || req.Nonce == "" {
+		return nil, MerchantAuthInvalidSignature, errno.ErrSignatureInvalid
+	}
+	if err := checkTimestamp(req.Timestamp, now, s.clockSkew); err != nil {
+		return nil, MerchantAuthReplayed, err
+	}
+
+	var key model.MerchantAPIKey
+	if err := s.db.WithContext(ctx).Where("key_id = ?", req.KeyID).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, MerchantAuthInvalidKey, errno.ErrAPIKeyInvalid
+		}
+		return nil, MerchantAuthInvalidKey, err
+	}
+	if !key.Usable(now) {
+		return &key, MerchantAuthInvalidKey, errno.ErrAPIKeyInvalid
+	}
+	var merchant model.Merchant
+	if err := s.db.WithContext(ctx).Select("status").First(&merchant, key.MerchantID).Error; err != nil {
+		return &key, MerchantAuthInvalidKey, err
+	}
+	if merchant.Status != model.MerchantStatusActive {
+		return &key, MerchantAuthInvalidKey, errno.ErrAPIKeyInvalid
+	}
+	if !ipAllowed(key.IPAllowlist, req.ClientIP) {
+		return &key, MerchantAuthIPDenied, errno.ErrIPNotAllowed
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(key.SecretCiphertext)
+	if err != nil {
+		return &key, MerchantAuthInvalidKey, err
+	}
+	secret, err := s.keys.Decrypt(key.KMSKeyID, ciphertext)
+	if err != nil {
+		return &key, MerchantAuthInvalidKey, fmt.Errorf("解密 API 密钥失败: %w", err)
+	}
+	if !apisign.Verify(string(secret), req.Signature, req.Method, req.Path, req.Timestamp, req.Nonce, req.Body) {
+		return &key, MerchantAuthInvalidSignature, errno.ErrSignatureInvalid
+	}
+
+	// nonce 在时间窗口内只能使用一次 (窗口两侧各 clock_skew)
+	ok, err := s.rdb.SetNX(ctx, "merchant:nonce:"+key.KeyID+":"+req.Nonce, 1, 2*s.clockSkew).Result()
+	if err != nil {
+		return &key, MerchantAuthReplayed, err
+	}
+	if !ok {
+		return &key, MerchantAuthReplayed, errno.ErrRequestReplayed
+	}
+
+	// 最近使用时间，每分钟最多更新一次
+	s.db.WithContext(ctx).Model(&model.MerchantAPIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", key.ID, now.Add(-time.Minute)).
+		Update("last_used_at", now)
+
+	return &key, MerchantAuthOK, nil
+}
+
+// HasScope API Key 是否拥有某权限
+func HasScope(key *model.MerchantAPIKey, scope string) bool {
+	for _, s := range key.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateScopes 校验 scopes 均为已定义的权限
+func ValidateScopes(scopes []string) error {
+	for _, s := range scopes {
+		if !merchantScopes[s] {
+			return errno.ErrScopeInvalid.WithMessage("unknown scope: " + s)
+		}
+	}
+	return nil
+}
+
+// checkTimestamp X-Timestamp (unix 秒) 必须在 now ± skew 之内
+func checkTimestamp(ts string, now time.Time, skew time.Duration) error {
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return errno.ErrRequestReplayed
+	}
+	diff := now.Sub(time.Unix(sec, 0))
+	if diff > skew || diff < -skew {
+		return errno.ErrRequestReplayed
+	}
+	return nil
+}
+
+// validateIPAllowlist 每一项必须是 IP 或 CIDR
+func validateIPAllowlist(list []string) error {
+	for _, entry := range list {
+		if net.ParseIP(entry) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(entry); err != nil {
+			return errno.ErrBind.WithMessage("invalid ip_allowlist entry: " + entry)
+		}
+	}
+	return nil
+}
+
+// ipAllowed 白名单为空时不限制
+func ipAllowed(list []string, clientIP string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	ip := net.ParseIP(strings.TrimSpace(clientIP))
+	if ip == nil {
+		return false
+	}
+	for _, entry := range list {
+		if allowed := net.ParseIP(entry); allowed != nil {
+			if allowed.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil && cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/errno"
+)
+
+func TestCheckTimestamp(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	skew := 5 * time.Minute
+
+	assert.NoError(t, checkTimestamp(strconv.FormatInt(now.Unix(), 10), now, skew))
+	assert.NoError(t, checkTimestamp(strconv.FormatInt(now.Add(-4*time.Minute).Unix(), 10), now, skew))
+	assert.NoError(t, checkTimestamp(strconv.FormatInt(now.Add(4*time.Minute).Unix(), 10), now, skew))
+
+	assert.ErrorIs(t, checkTimestamp(strconv.FormatInt(now.Add(-6*time.Minute).Unix(), 10), now, skew), errno.ErrRequestReplayed)
+	assert.ErrorIs(t, checkTimestamp(strconv.FormatInt(now.Add(6*time.Minute).Unix(), 10), now, skew), errno.ErrRequestReplayed)
+	assert.ErrorIs(t, checkTimestamp("not-a-number", now, skew), errno.ErrRequestReplayed)
+}
+
+func TestIPAllowlist(t *testing.T) {
+	assert.True(t, ipAllowed(nil, "203.0.113.7"), "空白名单不限制")
+
+	list := []string{"203.0.113.7", "10.0.0.0/8", "2001:db8::/32"}
+	assert.NoError(t, validateIPAllowlist(list))
+	assert.True(t, ipAllowed(list, "203.0.113.7"))
+	assert.True(t, ipAllowed(list, "10.20.30.40"))
+	assert.True(t, ipAllowed(list, "2001:db8::1"))
+	assert.False(t, ipAllowed(list, "203.0.113.8"))
+	assert.False(t, ipAllowed(list, "not-an-ip"))
+
+	assert.Error(t, validateIPAllowlist([]string{"10.0.0.0/33"}))
+	assert.Error(t, validateIPAllowlist([]string{"example.com"}))
+}
+
+func TestScopes(t *testing.T) {
+	assert.NoError(t, ValidateScopes([]string{ScopeMerchantRead}))
+	var e errno.Errno
+	assert.ErrorAs(t, ValidateScopes([]string{"withdrawal:everything"}), &e)
+	assert.Equal(t, errno.ErrScopeInvalid.Code, e.Code)
+
+	key := &model.MerchantAPIKey{Scopes: model.StringList{ScopeMerchantRead}}
+	assert.True(t, HasScope(key, ScopeMerchantRead))
+	assert.False(t, HasScope(key, "other"))
+}
+
+func TestMerchantAPIKeyUsable(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	assert.True(t, (&model.MerchantAPIKey{}).Usable(now))
+	assert.True(t, (&model.MerchantAPIKey{ExpiresAt: &future}).Usable(now))
+	assert.False(t, (&model.MerchantAPIKey{ExpiresAt: &past}).Usable(now))
+	assert.False(t, (&model.MerchantAPIKey{RevokedAt: &past}).Usable(now))
+}
@@ -0,0 +1,332 @@
+package mfa
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/kms"
+	"wallet-core/pkg/safe_random"
+	"wallet-core/pkg/totp"
+)
+
+const (
+	// KeyID 加密 TOTP 密钥使用的 KMS 密钥
+	KeyID = "mfa-totp"
+
+	backupCodeCount = 10
+	clockSkew       = 1 // 允许前后各 1 个时间步 (±30s) 的时钟偏差
+)
+
+// NewKeyManager 创建用于加密 TOTP 密钥的 KMS
+// 其他子系统 (商户 API 密钥、KYC 证件、Webhook 签名密钥、管理员 TOTP) 用 ImportKey 导入各自的密钥，不与 TOTP 共用
+func NewKeyManager(cfg config.MFAConfig, env string) (*kms.LocalKMS, error) {
+	km := kms.NewLocalKMS()
+	if err := ImportKey(km, KeyID, "mfa.encryption_key", cfg.EncryptionKey, env); err != nil {
+		return nil, err
+	}
+	return km, nil
+}
+
+// ImportKey 把配置项 setting 中的 AES-256 密钥 (hex) 以 keyID 导入 km
+// 未配置时仅开发环境 (app.env=development / dev) 生成临时密钥，其他环境拒绝启动; 临时密钥重启后已加密的数据都无法解密
+func ImportKey(km *kms.LocalKMS, keyID, setting, hexKey, env string) error {
+	material, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return fmt.Errorf("%s 不是合法的 hex: %w", setting, err)
+	}
+	if len(material) == 0 {
+		envName := strings.ToUpper(strings.ReplaceAll(setting, ".", "_"))
+		if env != "development" && env != "dev" {
+			return fmt.Errorf("%s 未配置 (环境变量 %s)，%s 环境不能使用临时密钥", setting, envName, env)
+		}
+		log.Printf("[MFA] Warning: 未配置 %s，使用临时密钥，重启后用它加密的数据将无法解密", setting)
+		if material, err = safe_random.GenerateRandomBytes(32); err != nil {
+			return err
+		}
+	}
+	return km.ImportKey(keyID, kms.KeyTypeAES, material)
+}
+
+// Service 两步验证 (TOTP, RFC 6238)
+// 1. Enroll: 生成密钥 + otpauth URI (前端渲染二维码)，此时尚未生效
+// 2. Activate: 校验第一个验证码后生效，并返回一次性备用码
+// 3. Require: 登录 / 提现等敏感操作调用，已开启的用户必须提供验证码或备用码
+// 同一时间步的验证码只能使用一次 (防重放)，校验失败次数超限后锁定一段时间
+// 目前接入 Require 的有登录、提现和站内转账; 本系统还没有用户提现地址白名单，白名单上线时其增删接口也必须调用 Require
+type Service struct {
+	db          *gorm.DB
+	rdb         *redis.Client // 失败次数计数 (nil 时不限流)
+	keys        kms.KeyManager
+	issuer      string
+	maxAttempts int
+	window      time.Duration
+}
+
+func NewService(db *gorm.DB, rdb *redis.Client, keys kms.KeyManager, cfg config.MFAConfig) *Service {
+	s := &Service{
+		db:          db,
+		rdb:         rdb,
+		keys:        keys,
+		issuer:      cfg.Issuer,
+		maxAttempts: cfg.MaxAttempts,
+		window:      cfg.AttemptWindow,
+	}
+	if s.maxAttempts <= 0 {
+		s.maxAttempts = 5
+	}
+	if s.window <= 0 {
+		s.window = 15 * time.Minute
+	}
+	return s
+}
+
+// Enroll 生成新的 TOTP 密钥，返回密钥和 provisioning URI
+// 已开启的用户不能重复绑定; 未激活的绑定会被覆盖
+func (s *Service) Enroll(ctx context.Context, userID uint64, account string) (secret, uri string, err error) {
+	var existing model.UserMFA
+	err = s.db.WithContext(ctx).Where("user_id = ?", userID).First(&existing).Error
+	if err == nil && existing.Enabled {
+		return "", "", errno.ErrMFAAlreadyEnabled
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", "", err
+	}
+
+	secret, err = totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	ciphertext, err := s.keys.Encrypt(KeyID, []byte(secret))
+	if err != nil {
+		return "", "", fmt.Errorf("加密 TOTP 密钥失败: %w", err)
+	}
+
+	record := model.UserMFA{
+		UserID:           userID,
+		KeyID:            KeyID,
+		SecretCiphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}
+	err = s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"key_id", "secret_ciphertext", "last_used_step", "updated_at"}),
+	}).Create(&record).Error
+	if err != nil {
+		return "", "", err
+	}
+
+	return secret, totp.ProvisioningURI(s.issuer, account, secret), nil
+}
+
+// Activate 校验绑定后的第一个验证码，开启两步验证并生成备用码 (明文只返回这一次)
+func (s *Service) Activate(ctx context.Context, userID uint64, code string) ([]string, error) {
+	if err := s.checkAttempts(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var backupCodes []string
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var record model.UserMFA
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", userID).First(&record).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.ErrMFANotEnrolled
+			}
+			return err
+		}
+		if record.Enabled {
+			return errno.ErrMFAAlreadyEnabled
+		}
+
+		secret, err := s.decryptSecret(&record)
+		if err != nil {
+			return err
+		}
+		step, ok := totp.Validate(secret, code, time.Now(), clockSkew)
+		if !ok {
+			return errno.ErrMFAInvalid
+		}
+
+		plain, hashes, err := generateBackupCodes()
+		if err != nil {
+			return err
+		}
+		backupCodes = plain
+
+		now := time.Now()
+		return tx.Model(&record).Updates(map[string]interface{}{
+			"enabled":        true,
+			"enabled_at":     now,
+			"last_used_step": step,
+			"backup_codes":   model.StringList(hashes),
+		}).Error
+	})
+
+	if err := s.recordResult(ctx, userID, err); err != nil {
+		return nil, err
+	}
+	return backupCodes, nil
+}
+
+// Enabled 用户是否已开启两步验证
+func (s *Service) Enabled(ctx context.Context, userID uint64) (bool, error) {
+	var count int64
+	err := s.db.WithContext(ctx).Model(&model.UserMFA{}).
+		Where("user_id = ? AND enabled = ?", userID, true).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Require 敏感操作前调用: 未开启两步验证的用户直接放行，已开启的必须提供有效验证码
+// nil *Service 视为未启用两步验证
+func (s *Service) Require(ctx context.Context, userID uint64, code string) error {
+	if s == nil {
+		return nil
+	}
+	enabled, err := s.Enabled(ctx, userID)
+	if err != nil || !enabled {
+		return err
+	}
+	if strings.TrimSpace(code) == "" {
+		return errno.ErrMFARequired
+	}
+	return s.Verify(ctx, userID, code)
+}
+
+// Verify 校验 TOTP 验证码或备用码
+func (s *Service) Verify(ctx context.Context, userID uint64, code string) error {
+	if err := s.checkAttempts(ctx, userID); err != nil {
+		return err
+	}
+
+	code = strings.TrimSpace(code)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var record model.UserMFA
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND enabled = ?", userID, true).First(&record).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.ErrMFANotEnrolled
+			}
+			return err
+		}
+
+		if len(code) == totp.Digits {
+			secret, err := s.decryptSecret(&record)
+			if err != nil {
+				return err
+			}
+			step, ok := totp.Validate(secret, code, time.Now(), clockSkew)
+			// 防重放: 时间步必须大于上次使用的时间步
+			if !ok || step <= record.LastUsedStep {
+				return errno.ErrMFAInvalid
+			}
+			return tx.Model(&record).Update("last_used_step", step).Error
+		}
+
+		// 备用码: 用过即作废
+		remaining, ok := consumeBackupCode(record.BackupCodes, code)
+		if !ok {
+			return errno.ErrMFAInvalid
+		}
+		log.Printf("[MFA] 用户 %d 使用了备用码，剩余 %d 个", userID, len(remaining))
+		return tx.Model(&record).Update("backup_codes", remaining).Error
+	})
+
+	return s.recordResult(ctx, userID, err)
+}
+
+func (s *Service) decryptSecret(record *model.UserMFA) (string, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(record.SecretCiphertext)
+	if err != nil {
+		return "", err
+	}
+	secret, err := s.keys.Decrypt(record.KeyID, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("解密 TOTP 密钥失败: %w", err)
+	}
+	return string(secret), nil
+}
+
+func attemptsKey(userID uint64) string {
+	return fmt.Sprintf("mfa:attempts:%d", userID)
+}
+
+// checkAttempts 失败次数达到上限后拒绝校验，直到窗口过期
+func (s *Service) checkAttempts(ctx context.Context, userID uint64) error {
+	if s.rdb == nil {
+		return nil
+	}
+	n, err := s.rdb.Get(ctx, attemptsKey(userID)).Int()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+	if n >= s.maxAttempts {
+		return errno.ErrMFALocked
+	}
+	return nil
+}
+
+// recordResult 校验失败时累加失败次数，成功时清零
+func (s *Service) recordResult(ctx context.Context, userID uint64, err error) error {
+	if s.rdb == nil {
+		return err
+	}
+	key := attemptsKey(userID)
+	if err == nil {
+		s.rdb.Del(ctx, key)
+		return nil
+	}
+	if errors.Is(err, errno.ErrMFAInvalid) {
+		pipe := s.rdb.TxPipeline()
+		pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, s.window)
+		if _, perr := pipe.Exec(ctx); perr != nil {
+			log.Printf("[MFA] 记录失败次数失败: %v", perr)
+		}
+	}
+	return err
+}
+
+// generateBackupCodes 生成备用码，返回明文 (展示给用户) 和哈希 (入库)
+func generateBackupCodes() (plain, hashes []string, err error) {
+	for i := 0; i < backupCodeCount; i++ {
+		raw, err := safe_random.GenerateRandomHexString(5)
+		if err != nil {
+			return nil, nil, err
+		}
+		plain = append(plain, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hashBackupCode(raw))
+	}
+	return plain, hashes, nil
+}
+
+func hashBackupCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// consumeBackupCode 匹配备用码，返回移除该码后的列表
+func consumeBackupCode(hashes model.StringList, code string) (model.StringList, bool) {
+	h := hashBackupCode(code)
+	for i, stored := range hashes {
+		if stored == h {
+			remaining := append(model.StringList{}, hashes[:i]...)
+			return append(remaining, hashes[i+1:]...), true
+		}
+	}
+	return hashes, false
+}
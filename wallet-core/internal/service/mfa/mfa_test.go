@@ -0,0 +1,56 @@
+package mfa
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/pkg/config"
+)
+
+func TestBackupCodes(t *testing.T) {
+	plain, hashes, err := generateBackupCodes()
+	require.NoError(t, err)
+	require.Len(t, plain, backupCodeCount)
+	require.Len(t, hashes, backupCodeCount)
+	assert.Len(t, plain[0], 11) // xxxxx-xxxxx
+
+	// 大小写 / 分隔符不敏感
+	remaining, ok := consumeBackupCode(hashes, strings.ToUpper(strings.ReplaceAll(plain[3], "-", "")))
+	assert.True(t, ok)
+	assert.Len(t, remaining, backupCodeCount-1)
+	assert.Len(t, hashes, backupCodeCount, "原列表不应被修改")
+
+	// 用过的备用码不能再用
+	_, ok = consumeBackupCode(remaining, plain[3])
+	assert.False(t, ok)
+}
+
+func TestNewKeyManager(t *testing.T) {
+	_, err := NewKeyManager(config.MFAConfig{EncryptionKey: "not-hex"}, "development")
+	assert.Error(t, err)
+
+	key := strings.Repeat("ab", 32)
+	km1, err := NewKeyManager(config.MFAConfig{EncryptionKey: key}, "production")
+	require.NoError(t, err)
+	km2, err := NewKeyManager(config.MFAConfig{EncryptionKey: key}, "production")
+	require.NoError(t, err)
+
+	ct, err := km1.Encrypt(KeyID, []byte("JBSWY3DPEHPK3PXP"))
+	require.NoError(t, err)
+	pt, err := km2.Decrypt(KeyID, ct)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", string(pt))
+
+	// 未配置密钥: 只有开发环境可以使用临时密钥
+	_, err = NewKeyManager(config.MFAConfig{}, "development")
+	assert.NoError(t, err)
+	_, err = NewKeyManager(config.MFAConfig{}, "dev")
+	assert.NoError(t, err)
+	_, err = NewKeyManager(config.MFAConfig{}, "production")
+	assert.Error(t, err)
+	_, err = NewKeyManager(config.MFAConfig{}, "")
+	assert.Error(t, err)
+}
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"wallet-core/internal/event"
 	"wallet-core/internal/model"
 	"wallet-core/internal/service/mq"
+	"wallet-core/internal/service/screening"
+	"wallet-core/pkg/config"
 
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
@@ -33,10 +37,16 @@ type EthObserver struct {
 
 	// 消息队列生产者
 	producer mq.Producer
+
+	// 制裁名单筛查 (付款方命中则隔离充值)
+	screener *screening.Screener
+
+	// 最近一次处理完区块的时间 (UnixNano)，健康检查据此判断扫块是否卡死
+	lastProcessed atomic.Int64
 }
 
 // NewEthObserver 创建一个新的 ETH 扫描器
-func NewEthObserver(db *gorm.DB, producer mq.Producer, startHeight uint64, workerCount int) *EthObserver {
+func NewEthObserver(db *gorm.DB, producer mq.Producer, screener *screening.Screener, startHeight uint64, workerCount int) *EthObserver {
 	return &EthObserver{
 		db:          db,
 		startHeight: startHeight,
@@ -45,6 +55,7 @@ func NewEthObserver(db *gorm.DB, producer mq.Producer, startHeight uint64, worke
 		blocksChan: make(chan *Block, workerCount*2),
 		// 初始化 MQ Producer
 		producer: producer,
+		screener: screener,
 	}
 }
 
@@ -75,6 +86,15 @@ func (o *EthObserver) GetCurrentHeight() uint64 {
 	return o.currentHeight
 }
 
+// LastProcessedAt 最近一次处理完区块的时间，尚未处理过时为零值
+func (o *EthObserver) LastProcessedAt() time.Time {
+	ns := o.lastProcessed.Load()
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
 // fetcher (生产者): 模拟从节点获取区块
 func (o *EthObserver) fetcher(ctx context.Context) {
 	defer o.wg.Done()
@@ -124,6 +144,7 @@ func (o *EthObserver) worker(ctx context.Context, id int) {
 			o.processTransaction(tx)
 		}
 
+		o.lastProcessed.Store(time.Now().UnixNano())
 		log.Printf("Worker-%d: 完成区块 #%d 的处理", id, block.Height)
 	}
 
@@ -149,22 +170,40 @@ func (o *EthObserver) processTransaction(tx Transaction) {
 		// 2. 命中！这是充值交易
 		log.Printf("  [$$$] 发现充值交易! Tx: %s, To: %s, Amount: %s", tx.Hash, tx.To, tx.Value)
 
+		// 低于最小充值金额的不入账 (粉尘攻击 / 归集成本高于金额)
+		if min := config.Chain("ETH").MinDeposit; min > 0 {
+			if v, err := decimal.NewFromString(tx.Value); err == nil && v.LessThan(decimal.NewFromFloat(min)) {
+				log.Printf("  [Skip] 充值金额低于最小充值金额 %v，忽略: Tx=%s", min, tx.Hash)
+				return
+			}
+		}
+
+		// 制裁名单筛查: 付款方命中时只记录充值 (quarantined)，不发入账消息
+		listSource, sanctioned := o.screener.Check(tx.From)
+
 		// 3. 开启事务 (Transactional Outbox 核心)
 		err = o.db.Transaction(func(dbTx *gorm.DB) error {
 			// A. 写入 Deposit 表
 			deposit := model.Deposit{
-				UserID:      1, // Hack
-				BlockAppID:  0, // Hack
-				TxHash:      tx.Hash,
-				Amount:      decimal.RequireFromString(tx.Value),
-				BlockHeight: o.currentHeight,
-				Status:      "confirmed",
-				CreatedAt:   time.Now(),
+				UserID:        1, // Hack
+				BlockAppID:    0, // Hack
+				Chain:         "ETH",
+				FromAddress:   tx.From,
+				TxHash:        tx.Hash,
+				Amount:        decimal.RequireFromString(tx.Value),
+				BlockHeight:   o.currentHeight,
+				Confirmations: config.Chain("ETH").Confirmations, // 模拟扫描器直接按已确认入账
+				Status:        model.DepositStatusConfirmed,
+				CreatedAt:     time.Now(),
+			}
+			if sanctioned {
+				deposit.Status = model.DepositStatusQuarantined
 			}
 
 			// 完善逻辑
 			var addr model.Address
 			dbTx.Where("address = ?", tx.To).First(&addr)
+			deposit.TenantID = addr.TenantID
 			deposit.UserID = addr.UserID
 			deposit.BlockAppID = addr.ID
 
@@ -172,16 +211,25 @@ func (o *EthObserver) processTransaction(tx Transaction) {
 				return err // 回滚
 			}
 
+			if sanctioned {
+				log.Printf("  [Screening] ⛔ 充值付款方命中制裁名单，已隔离: Tx=%s, From=%s, List=%s", tx.Hash, tx.From, listSource)
+				return screening.RecordDepositHit(dbTx, &deposit, "ETH", listSource)
+			}
+
 			// B. 写入 Outbox 消息表 (在同一个事务中!)
-			payloadMap := map[string]interface{}{
-				"user_id": deposit.UserID,
-				"amount":  deposit.Amount.String(),
-				"tx_hash": deposit.TxHash,
-				"chain":   "ETH",
+			payload := event.DepositEvent{
+				DepositID:     deposit.ID,
+				TenantID:      deposit.TenantID,
+				UserID:        deposit.UserID,
+				Chain:         "ETH",
+				TxHash:        deposit.TxHash,
+				Amount:        deposit.Amount.String(),
+				Status:        deposit.Status,
+				Confirmations: deposit.Confirmations,
 			}
 
 			// Topic: wallet_events_deposit
-			if err := model.CreateOutboxMessage(dbTx, "wallet_events_deposit", payloadMap); err != nil {
+			if err := model.CreateOutboxMessage(dbTx, event.TopicDeposit, payload); err != nil {
 				return err // 回滚
 			}
 
@@ -206,8 +254,8 @@ func (o *EthObserver) mockFetchBlock(height uint64) *Block {
 	}
 
 	txs := []Transaction{
-		{Hash: fmt.Sprintf("0xhash_%d_1", height), To: targetAddr, Value: "0.5"},
-		{Hash: fmt.Sprintf("0xhash_%d_2", height), To: "0xSomeoneElse", Value: "100"},
+		{Hash: fmt.Sprintf("0xhash_%d_1", height), From: "0xSender", To: targetAddr, Value: "0.5"},
+		{Hash: fmt.Sprintf("0xhash_%d_2", height), From: "0xSender", To: "0xSomeoneElse", Value: "100"},
 	}
 	return &Block{
 		Height:       height,
@@ -0,0 +1,77 @@
+package push
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+	"wallet-core/internal/service/mq"
+	"wallet-core/pkg/logger"
+)
+
+// Bridge 消费 MQ 钱包事件，转换后写入用户事件流
+type Bridge struct {
+	hub *Hub
+	db  *gorm.DB
+}
+
+func NewBridge(hub *Hub, db *gorm.DB) *Bridge {
+	return &Bridge{hub: hub, db: db}
+}
+
+// Start 订阅 Topics 中的所有主题，newConsumer 为每个主题创建独立的消费者 (同一消费组)
+// Redis Stream 消费者的 Subscribe 会阻塞，因此每个主题一个 goroutine
+func (b *Bridge) Start(ctx context.Context, newConsumer func(topic string) mq.Consumer) {
+	for _, topic := range Topics {
+		topic := topic
+		consumer := newConsumer(topic)
+		go func() {
+			if err := consumer.Subscribe(ctx, topic, b.handle); err != nil {
+				logger.Error("推送事件订阅失败", zap.String("topic", topic), zap.Error(err))
+			}
+		}()
+	}
+}
+
+func (b *Bridge) handle(msg *mq.Message) error {
+	events, err := translate(msg.Topic, msg.Payload)
+	if err != nil {
+		logger.Warn("推送事件解析失败", zap.String("topic", msg.Topic), zap.Error(err))
+		return nil // 格式错误，不再重试
+	}
+
+	ctx := context.Background()
+	for _, e := range events {
+		if e.UserID == 0 {
+			continue
+		}
+		if data, ok := e.Data.(BalanceData); ok {
+			if e.Data, err = b.balance(ctx, e.UserID, data.Currency); err != nil {
+				return err
+			}
+		}
+		if _, err := b.hub.Publish(ctx, e.UserID, e.Type, e.Data); err != nil {
+			return err // 重试，订阅端可能收到重复事件
+		}
+	}
+	return nil
+}
+
+// balance 当前余额 (与 GetBalance 一致: 总额 = 可用 + 冻结)
+func (b *Bridge) balance(ctx context.Context, userID uint64, currency string) (BalanceData, error) {
+	data := BalanceData{Currency: currency, Balance: "0", LockedBalance: "0"}
+	var acc model.Account
+	err := b.db.WithContext(ctx).Where("user_id = ? AND currency = ?", userID, currency).First(&acc).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return data, nil
+	}
+	if err != nil {
+		return data, err
+	}
+	data.Balance = acc.Balance.Add(acc.LockedBalance).String()
+	data.LockedBalance = acc.LockedBalance.String()
+	return data, nil
+}
@@ -0,0 +1,228 @@
+// Package push 账户事件实时推送
+//
+// MQ 中的钱包事件 (wallet_events_*) 由 Bridge 转换为按用户划分的账户事件，
+// 写入 Redis Stream push:events:<user_id>，流中的消息 ID 即事件 ID，客户端断线重连时
+// 带上最后收到的事件 ID 即可从断点续传。流按长度裁剪，只保留最近的事件，
+// 断点已被裁剪时先推送一条 resync 事件，提示客户端重新拉取余额和交易记录。
+//
+// 新事件写入后通过 Pub/Sub 频道 push:notify 通知各进程的 Hub，
+// 每个进程只占用一个订阅连接，订阅者收到通知后用 XRANGE 读取增量。
+package push
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"wallet-core/internal/event"
+	"wallet-core/internal/model"
+)
+
+// 事件类型
+const (
+	TypeDepositDetected  = "deposit.detected"
+	TypeDepositConfirmed = "deposit.confirmed"
+	TypeWithdrawalStatus = "withdrawal.status"
+	TypeBalanceChanged   = "balance.changed"
+	TypeKYCTierChanged   = "kyc.tier_changed"
+	TypeTransferSent     = "transfer.sent"
+	TypeTransferReceived = "transfer.received"
+	TypeResync           = "resync" // 断点已被裁剪，客户端需要全量刷新
+)
+
+// Event 一条推送给用户的账户事件
+type Event struct {
+	ID        string          `json:"id"` // Redis Stream ID，用于断点续传
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// DepositData deposit.detected / deposit.confirmed
+type DepositData struct {
+	DepositID     uint64 `json:"deposit_id"`
+	Currency      string `json:"currency"`
+	TxHash        string `json:"tx_hash"`
+	Amount        string `json:"amount"`
+	Status        string `json:"status"`
+	Confirmations uint64 `json:"confirmations"`
+}
+
+// WithdrawalData withdrawal.status
+type WithdrawalData struct {
+	WithdrawalID uint64 `json:"withdrawal_id"`
+	Currency     string `json:"currency"`
+	TxHash       string `json:"tx_hash,omitempty"`
+	Amount       string `json:"amount"`
+	Status       string `json:"status"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// BalanceData balance.changed，余额由 Bridge 查询账户表填充
+type BalanceData struct {
+	Currency      string `json:"currency"`
+	Balance       string `json:"balance"`
+	LockedBalance string `json:"locked_balance"`
+}
+
+// TransferData transfer.sent / transfer.received
+type TransferData struct {
+	TransferID   uint64 `json:"transfer_id"`
+	Currency     string `json:"currency"`
+	Amount       string `json:"amount"`
+	WithdrawalID uint64 `json:"withdrawal_id,omitempty"`
+}
+
+// KYCTierData kyc.tier_changed
+type KYCTierData struct {
+	OldTier string `json:"old_tier"`
+	NewTier string `json:"new_tier"`
+}
+
+// userEvent 待写入用户事件流的事件
+type userEvent struct {
+	UserID uint64
+	Type   string
+	Data   interface{}
+}
+
+// translate 把一条 MQ 消息转换为用户事件，不关心的 topic 返回空
+// 余额变化只带币种，余额在发布前查询 (见 Bridge.handle)
+func translate(topic string, payload []byte) ([]userEvent, error) {
+	switch topic {
+	case event.TopicDeposit:
+		var e event.DepositEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		if e.UserID == 0 {
+			return nil, nil // 商户账单地址收到的付款，不属于任何用户
+		}
+		data := DepositData{
+			DepositID:     e.DepositID,
+			Currency:      e.Chain,
+			TxHash:        e.TxHash,
+			Amount:        e.Amount,
+			Status:        e.Status,
+			Confirmations: e.Confirmations,
+		}
+		if e.Status != model.DepositStatusConfirmed {
+			return []userEvent{{UserID: e.UserID, Type: TypeDepositDetected, Data: data}}, nil
+		}
+		return []userEvent{
+			{UserID: e.UserID, Type: TypeDepositConfirmed, Data: data},
+			{UserID: e.UserID, Type: TypeBalanceChanged, Data: BalanceData{Currency: e.Chain}},
+		}, nil
+
+	case event.TopicWithdrawal:
+		var e event.WithdrawalCreatedEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		status := e.Status
+		if status == "" {
+			status = model.WithdrawalStatusPendingReview
+		}
+		return []userEvent{{UserID: e.UserID, Type: TypeWithdrawalStatus, Data: WithdrawalData{
+			WithdrawalID: e.WithdrawalID,
+			Currency:     e.Chain,
+			Amount:       e.Amount,
+			Status:       status,
+		}}}, nil
+
+	case event.TopicWithdrawalConfirmed:
+		var e event.WithdrawalConfirmedEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		return []userEvent{
+			{UserID: e.UserID, Type: TypeWithdrawalStatus, Data: WithdrawalData{
+				WithdrawalID: e.WithdrawalID,
+				Currency:     e.Chain,
+				TxHash:       e.TxHash,
+				Amount:       e.Amount,
+				Status:       model.WithdrawalStatusCompleted,
+			}},
+			{UserID: e.UserID, Type: TypeBalanceChanged, Data: BalanceData{Currency: e.Chain}},
+		}, nil
+
+	case event.TopicWithdrawalFailed:
+		var e event.WithdrawalFailedEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		return []userEvent{{UserID: e.UserID, Type: TypeWithdrawalStatus, Data: WithdrawalData{
+			WithdrawalID: e.WithdrawalID,
+			Currency:     e.Chain,
+			TxHash:       e.TxHash,
+			Amount:       e.Amount,
+			Status:       model.WithdrawalStatusFailed,
+			Reason:       e.Reason,
+		}}}, nil
+
+	case event.TopicKYCTierChanged:
+		var e event.KYCTierChangedEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		return []userEvent{{UserID: e.UserID, Type: TypeKYCTierChanged, Data: KYCTierData{
+			OldTier: e.OldTier,
+			NewTier: e.NewTier,
+		}}}, nil
+
+	case event.TopicTransfer:
+		var e event.TransferEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		data := TransferData{TransferID: e.TransferID, Currency: e.Currency, Amount: e.Amount, WithdrawalID: e.WithdrawalID}
+		events := []userEvent{
+			{UserID: e.ToUserID, Type: TypeTransferReceived, Data: data},
+			{UserID: e.ToUserID, Type: TypeBalanceChanged, Data: BalanceData{Currency: e.Currency}},
+		}
+		if e.WithdrawalID != 0 {
+			return events, nil // 提现转站内结算，转出方已经收到 withdrawal.status / balance.changed
+		}
+		return append(events,
+			userEvent{UserID: e.FromUserID, Type: TypeTransferSent, Data: data},
+			userEvent{UserID: e.FromUserID, Type: TypeBalanceChanged, Data: BalanceData{Currency: e.Currency}},
+		), nil
+	}
+	return nil, nil
+}
+
+// Topics Bridge 需要订阅的 MQ 主题
+var Topics = []string{
+	event.TopicDeposit,
+	event.TopicWithdrawal,
+	event.TopicWithdrawalConfirmed,
+	event.TopicWithdrawalFailed,
+	event.TopicKYCTierChanged,
+	event.TopicTransfer,
+}
+
+// parseStreamID 解析 Redis Stream ID (<ms>-<seq>)，只有毫秒部分时 seq 为 0
+func parseStreamID(id string) (ms, seq uint64, err error) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if ms, err = strconv.ParseUint(msPart, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid event id %q", id)
+	}
+	if found {
+		if seq, err = strconv.ParseUint(seqPart, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid event id %q", id)
+		}
+	}
+	return ms, seq, nil
+}
+
+// streamIDLess a 是否排在 b 之前 (两者都必须是合法 ID)
+func streamIDLess(a, b string) bool {
+	ams, aseq, _ := parseStreamID(a)
+	bms, bseq, _ := parseStreamID(b)
+	if ams != bms {
+		return ams < bms
+	}
+	return aseq < bseq
+}
@@ -0,0 +1,120 @@
+package push
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/internal/event"
+	"wallet-core/internal/model"
+)
+
+func mustJSON(t *testing.T, v interface{}) []byte {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
+func TestTranslateDeposit(t *testing.T) {
+	e := event.DepositEvent{DepositID: 9, UserID: 42, Chain: "ETH", TxHash: "0xabc", Amount: "0.5", Status: model.DepositStatusPending, Confirmations: 1}
+	got, err := translate(event.TopicDeposit, mustJSON(t, e))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, TypeDepositDetected, got[0].Type)
+	assert.Equal(t, uint64(42), got[0].UserID)
+
+	e.Status = model.DepositStatusConfirmed
+	got, err = translate(event.TopicDeposit, mustJSON(t, e))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, TypeDepositConfirmed, got[0].Type)
+	assert.Equal(t, DepositData{DepositID: 9, Currency: "ETH", TxHash: "0xabc", Amount: "0.5", Status: "confirmed", Confirmations: 1}, got[0].Data)
+	assert.Equal(t, TypeBalanceChanged, got[1].Type)
+	assert.Equal(t, BalanceData{Currency: "ETH"}, got[1].Data)
+}
+
+func TestTranslateInvoiceDepositSkipped(t *testing.T) {
+	// 商户账单地址 (UserID 0) 的付款不推送给用户
+	e := event.DepositEvent{DepositID: 10, UserID: 0, Chain: "ETH", TxHash: "0xdef", Amount: "1", Status: model.DepositStatusConfirmed}
+	got, err := translate(event.TopicDeposit, mustJSON(t, e))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestTranslateWithdrawal(t *testing.T) {
+	got, err := translate(event.TopicWithdrawal, mustJSON(t, event.WithdrawalCreatedEvent{WithdrawalID: 3, UserID: 42, Chain: "ETH", Amount: "1", Status: model.WithdrawalStatusRiskHold}))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, TypeWithdrawalStatus, got[0].Type)
+	assert.Equal(t, model.WithdrawalStatusRiskHold, got[0].Data.(WithdrawalData).Status)
+
+	got, err = translate(event.TopicWithdrawalConfirmed, mustJSON(t, event.WithdrawalConfirmedEvent{WithdrawalID: 3, UserID: 42, Chain: "ETH", TxHash: "0xdef"}))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, model.WithdrawalStatusCompleted, got[0].Data.(WithdrawalData).Status)
+	assert.Equal(t, TypeBalanceChanged, got[1].Type)
+
+	got, err = translate(event.TopicWithdrawalFailed, mustJSON(t, event.WithdrawalFailedEvent{WithdrawalID: 3, UserID: 42, Reason: "reverted"}))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "reverted", got[0].Data.(WithdrawalData).Reason)
+
+	// 不关心的 topic / 格式错误
+	got, err = translate(event.TopicCollectionConfirmed, []byte(`{}`))
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+	_, err = translate(event.TopicDeposit, []byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestTranslateKYCTier(t *testing.T) {
+	got, err := translate(event.TopicKYCTierChanged, mustJSON(t, event.KYCTierChangedEvent{UserID: 42, OldTier: model.KYCTierUnverified, NewTier: model.KYCTierBasic, AdminID: 1}))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, uint64(42), got[0].UserID)
+	assert.Equal(t, TypeKYCTierChanged, got[0].Type)
+	assert.Equal(t, KYCTierData{OldTier: "unverified", NewTier: "basic"}, got[0].Data)
+}
+
+func TestStreamID(t *testing.T) {
+	ms, seq, err := parseStreamID("1700000000000-3")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1700000000000), ms)
+	assert.Equal(t, uint64(3), seq)
+
+	_, seq, err = parseStreamID("1700000000000")
+	require.NoError(t, err)
+	assert.Zero(t, seq)
+
+	for _, bad := range []string{"", "abc", "1-x", "-1"} {
+		_, _, err := parseStreamID(bad)
+		assert.Error(t, err, bad)
+	}
+
+	assert.True(t, streamIDLess("1-5", "2-0"))
+	assert.True(t, streamIDLess("2-1", "2-10"))
+	assert.False(t, streamIDLess("2-0", "2-0"))
+	assert.False(t, streamIDLess("3-0", "2-9"))
+}
+
+func TestTranslateTransfer(t *testing.T) {
+	e := event.TransferEvent{TransferID: 5, FromUserID: 42, ToUserID: 43, Currency: "ETH", Amount: "0.3"}
+	got, err := translate(event.TopicTransfer, mustJSON(t, e))
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+	assert.Equal(t, userEvent{UserID: 43, Type: TypeTransferReceived, Data: TransferData{TransferID: 5, Currency: "ETH", Amount: "0.3"}}, got[0])
+	assert.Equal(t, TypeBalanceChanged, got[1].Type)
+	assert.Equal(t, uint64(42), got[2].UserID)
+	assert.Equal(t, TypeTransferSent, got[2].Type)
+	assert.Equal(t, TypeBalanceChanged, got[3].Type)
+
+	// 提现转站内结算: 转出方已经由提现事件通知
+	e.WithdrawalID = 9
+	got, err = translate(event.TopicTransfer, mustJSON(t, e))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, uint64(43), got[0].UserID)
+	assert.Equal(t, uint64(43), got[1].UserID)
+}
@@ -0,0 +1,218 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/logger"
+)
+
+const (
+	notifyChannel = "push:notify"
+	streamMaxLen  = 1000             // 每个用户保留的事件条数 (近似裁剪)
+	readBatch     = 100              // 单次 XRANGE 条数
+	pollInterval  = 30 * time.Second // 兜底轮询，防止漏掉 Pub/Sub 通知
+)
+
+func streamKey(userID uint64) string {
+	return "push:events:" + strconv.FormatUint(userID, 10)
+}
+
+// Hub 用户事件流的读写入口
+type Hub struct {
+	rdb *redis.Client
+
+	mu   sync.Mutex
+	subs map[uint64]map[chan struct{}]struct{}
+}
+
+func NewHub(rdb *redis.Client) *Hub {
+	return &Hub{rdb: rdb, subs: make(map[uint64]map[chan struct{}]struct{})}
+}
+
+// Publish 写入一条用户事件并通知订阅者，返回事件 ID
+func (h *Hub) Publish(ctx context.Context, userID uint64, typ string, data interface{}) (string, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	id, err := h.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamKey(userID),
+		MaxLen: streamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"type": typ,
+			"data": raw,
+			"ts":   time.Now().UnixMilli(),
+		},
+	}).Result()
+	if err != nil {
+		return "", err
+	}
+	// 通知失败不影响事件本身，订阅者会在兜底轮询时读到
+	if err := h.rdb.Publish(ctx, notifyChannel, strconv.FormatUint(userID, 10)).Err(); err != nil {
+		logger.Warn("推送通知发送失败", zap.Uint64("user_id", userID), zap.Error(err))
+	}
+	return id, nil
+}
+
+// Run 订阅 push:notify，把通知分发给本进程内对应用户的订阅者，阻塞直到 ctx 取消
+func (h *Hub) Run(ctx context.Context) {
+	ps := h.rdb.Subscribe(ctx, notifyChannel)
+	defer ps.Close()
+
+	ch := ps.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			userID, err := strconv.ParseUint(msg.Payload, 10, 64)
+			if err != nil {
+				continue
+			}
+			h.notify(userID)
+		}
+	}
+}
+
+func (h *Hub) notify(userID uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[userID] {
+		select {
+		case ch <- struct{}{}:
+		default: // 已有未处理的通知
+		}
+	}
+}
+
+func (h *Hub) register(userID uint64) chan struct{} {
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[chan struct{}]struct{})
+	}
+	h.subs[userID][ch] = struct{}{}
+	return ch
+}
+
+func (h *Hub) unregister(userID uint64, ch chan struct{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subs[userID], ch)
+	if len(h.subs[userID]) == 0 {
+		delete(h.subs, userID)
+	}
+}
+
+// Subscribe 订阅用户事件，lastID 为客户端最后收到的事件 ID，为空时只推送之后的新事件
+// types 非空时只推送指定类型 (resync 总是推送)
+// 返回的 channel 在 ctx 取消或读取出错时关闭
+func (h *Hub) Subscribe(ctx context.Context, userID uint64, lastID string, types ...string) (<-chan Event, error) {
+	if lastID != "" {
+		if _, _, err := parseStreamID(lastID); err != nil {
+			return nil, errno.ErrBind.WithMessage("invalid last_event_id")
+		}
+	}
+
+	key := streamKey(userID)
+	var resync bool
+	if lastID == "" {
+		latest, err := h.rdb.XRevRangeN(ctx, key, "+", "-", 1).Result()
+		if err != nil {
+			return nil, err
+		}
+		lastID = "0-0"
+		if len(latest) > 0 {
+			lastID = latest[0].ID
+		}
+	} else {
+		oldest, err := h.rdb.XRangeN(ctx, key, "-", "+", 1).Result()
+		if err != nil {
+			return nil, err
+		}
+		// 断点早于流中最早的事件: 中间的事件可能已被裁剪，无法保证续传完整
+		resync = len(oldest) == 0 || streamIDLess(lastID, oldest[0].ID)
+	}
+
+	want := make(map[string]bool, len(types))
+	for _, t := range types {
+		want[t] = true
+	}
+
+	notify := h.register(userID)
+	out := make(chan Event, 16)
+	go func() {
+		defer close(out)
+		defer h.unregister(userID, notify)
+
+		if resync {
+			select {
+			case out <- Event{ID: lastID, Type: TypeResync, Data: json.RawMessage("{}"), CreatedAt: time.Now()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			msgs, err := h.rdb.XRangeN(ctx, key, "("+lastID, "+", readBatch).Result()
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Warn("读取用户事件失败", zap.Uint64("user_id", userID), zap.Error(err))
+				}
+				return
+			}
+			for _, m := range msgs {
+				lastID = m.ID
+				e := decodeEvent(m)
+				if len(want) > 0 && !want[e.Type] {
+					continue
+				}
+				select {
+				case out <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if len(msgs) == readBatch {
+				continue // 还有积压
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-notify:
+			case <-ticker.C:
+			}
+		}
+	}()
+	return out, nil
+}
+
+func decodeEvent(m redis.XMessage) Event {
+	e := Event{ID: m.ID}
+	e.Type, _ = m.Values["type"].(string)
+	if data, ok := m.Values["data"].(string); ok {
+		e.Data = json.RawMessage(data)
+	}
+	if ts, ok := m.Values["ts"].(string); ok {
+		if ms, err := strconv.ParseInt(ts, 10, 64); err == nil {
+			e.CreatedAt = time.UnixMilli(ms)
+		}
+	}
+	return e
+}
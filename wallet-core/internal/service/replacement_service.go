@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"wallet-core/internal/model"
+	"wallet-core/internal/service/fee"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/wallet/ethtx"
+)
+
+var (
+	ErrWithdrawalNotReplaceable = errors.New("提现单不处于已广播待确认状态，无法替换")
+	ErrTxAlreadyMined           = errors.New("交易已上链，无需替换")
+	ErrReplaceNotSupported      = errors.New("当前链暂不支持交易替换")
+	ErrWithdrawalCancelling     = errors.New("提现已发起取消，只能继续加速取消交易")
+)
+
+// ReplacementService 处理卡单 (Stuck Transaction)，目前只支持 ETH (BTC 出款上线前不支持 RBF)
+// - 加速 (speed_up): 同 nonce、同内容，提高手续费重发
+// - 取消 (cancel): 同 nonce 发一笔 0 值自转账，抢在原交易之前上链
+// 既支持管理员手动触发，也会自动加速超过 stuck_after 仍未打包的交易
+type ReplacementService struct {
+	db          *gorm.DB
+	broadcaster *BroadcasterService // 持有热钱包私钥，负责签名与广播
+	interval    time.Duration
+}
+
+// Replacement 全局实例 (供 Admin Handler 使用)，在 main 中初始化
+var Replacement *ReplacementService
+
+func NewReplacementService(db *gorm.DB, broadcaster *BroadcasterService) *ReplacementService {
+	return &ReplacementService{
+		db:          db,
+		broadcaster: broadcaster,
+		interval:    time.Minute,
+	}
+}
+
+// Start 启动自动加速轮询 (阻塞，直到 ctx 取消)
+func (s *ReplacementService) Start(ctx context.Context) {
+	log.Println("[Replacement] 启动卡单自动加速服务...")
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("[Replacement] 停止服务")
+			return
+		case <-ticker.C:
+			s.autoSpeedUp(ctx)
+		}
+	}
+}
+
+// SpeedUp 提高手续费重发提现交易
+func (s *ReplacementService) SpeedUp(ctx context.Context, withdrawalID uint64, trigger string, adminID uint64) (*model.TxReplacement, error) {
+	return s.replace(ctx, withdrawalID, model.ReplaceActionSpeedUp, trigger, adminID)
+}
+
+// Cancel 用同 nonce 的 0 值自转账取消提现交易
+// 取消交易上链后，TxTracker 会把提现单置为 cancelled
+func (s *ReplacementService) Cancel(ctx context.Context, withdrawalID uint64, trigger string, adminID uint64) (*model.TxReplacement, error) {
+	return s.replace(ctx, withdrawalID, model.ReplaceActionCancel, trigger, adminID)
+}
+
+func (s *ReplacementService) autoSpeedUp(ctx context.Context) {
+	var withdrawals []model.Withdrawal
+	// block_number = 0: 还没有任何一笔交易被打包
+	if err := s.db.WithContext(ctx).
+		Where("status = ? AND block_number = 0", model.WithdrawalStatusBroadcasted).
+		Order("id").Limit(50).Find(&withdrawals).Error; err != nil {
+		log.Printf("[Replacement] 查询卡单失败: %v", err)
+		return
+	}
+
+	for _, w := range withdrawals {
+		cfg := config.Chain(w.Chain)
+		if cfg.StuckAfter <= 0 || w.BroadcastAt == nil || time.Since(*w.BroadcastAt) < cfg.StuckAfter {
+			continue
+		}
+		if w.ReplaceCount >= cfg.MaxReplacements {
+			continue // 自动加速次数用完，等待人工处理
+		}
+
+		r, err := s.SpeedUp(ctx, w.ID, model.ReplaceTriggerAuto, 0)
+		if err != nil {
+			log.Printf("[Replacement] 自动加速失败 ID=%d: %v", w.ID, err)
+			continue
+		}
+		log.Printf("[Replacement] ⏩ 自动加速 ID=%d, %s -> %s, Fee: %s -> %s", w.ID, r.OldTxHash, r.NewTxHash, r.OldFee, r.NewFee)
+	}
+}
+
+// replace 先在事务内签名替换交易并落库 (替换记录 + 提现单指向新交易)，提交后再广播
+// 广播失败时撤销这次替换; 进程在提交后、广播前退出时，提现单仍然卡住，会被自动加速重新签发
+func (s *ReplacementService) replace(ctx context.Context, withdrawalID uint64, action, trigger string, adminID uint64) (*model.TxReplacement, error) {
+	var (
+		r        *model.TxReplacement
+		signedTx *types.Transaction
+		prev     model.Withdrawal // 替换前的提现单，广播失败时恢复
+	)
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 悲观锁: 同一笔提现同一时间只允许一个替换操作
+		var w model.Withdrawal
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&w, withdrawalID).Error; err != nil {
+			return err
+		}
+		if w.Status != model.WithdrawalStatusBroadcasted {
+			return ErrWithdrawalNotReplaceable
+		}
+		action, err := replaceActionFor(w.ReplaceAction, action, trigger)
+		if err != nil {
+			return err
+		}
+		prev = w
+
+		switch strings.ToUpper(w.Chain) {
+		case "ETH":
+			r, signedTx, err = s.replaceEth(ctx, &w, action)
+		default:
+			// BTC 出款尚未接入签名器 (Broadcaster 只广播 ETH)，RBF 替换不在本期范围
+			err = fmt.Errorf("%w: %s", ErrReplaceNotSupported, w.Chain)
+		}
+		if err != nil {
+			return err
+		}
+
+		r.Trigger = trigger
+		r.AdminID = adminID
+		if err := tx.Create(r).Error; err != nil {
+			return err
+		}
+
+		// 提现单指向最新一笔交易，TxTracker 会同时跟踪历史交易
+		now := time.Now()
+		return tx.Model(&w).Updates(map[string]interface{}{
+			"tx_hash":        r.NewTxHash,
+			"gas_price":      r.NewFee,
+			"gas_tip_cap":    w.GasTipCap,
+			"gas_fee":        r.NewFee.Mul(decimal.NewFromInt(int64(w.GasLimit))),
+			"replace_count":  gorm.Expr("replace_count + 1"),
+			"replace_action": action,
+			"broadcast_at":   now,
+			"updated_at":     now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.broadcaster.ethClient.SendTransaction(ctx, signedTx); err != nil {
+		s.revert(ctx, &prev, r)
+		return nil, fmt.Errorf("广播替换交易失败: %w", err)
+	}
+	return r, nil
+}
+
+// revert 替换交易广播失败: 删除替换记录，提现单恢复为替换前的交易
+// 只在提现单仍指向这笔替换交易时恢复，期间被其他操作更新过则保持不变
+func (s *ReplacementService) revert(ctx context.Context, prev *model.Withdrawal, r *model.TxReplacement) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Withdrawal{}).
+			Where("id = ? AND tx_hash = ?", prev.ID, r.NewTxHash).
+			Updates(map[string]interface{}{
+				"tx_hash":        prev.TxHash,
+				"gas_price":      prev.GasPrice,
+				"gas_tip_cap":    prev.GasTipCap,
+				"gas_fee":        prev.GasFee,
+				"replace_count":  prev.ReplaceCount,
+				"replace_action": prev.ReplaceAction,
+				"broadcast_at":   prev.BroadcastAt,
+				"updated_at":     time.Now(),
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		return tx.Delete(r).Error
+	})
+	if err != nil {
+		log.Printf("[Replacement] ⚠️ 撤销未广播的替换交易失败 ID=%d, Tx=%s: %v", prev.ID, r.NewTxHash, err)
+	}
+}
+
+// replaceActionFor 决定本次替换实际执行的动作
+// 一旦发起取消，原收款交易就不能再被重发: 自动加速改为加速取消交易 (0 值自转账)，手动加速直接拒绝
+func replaceActionFor(current, action, trigger string) (string, error) {
+	if current != model.ReplaceActionCancel || action == model.ReplaceActionCancel {
+		return action, nil
+	}
+	if trigger == model.ReplaceTriggerAuto {
+		return model.ReplaceActionCancel, nil
+	}
+	return "", ErrWithdrawalCancelling
+}
+
+// replaceEth 签名同 nonce 的 ETH 替换交易 (由 replace 在事务提交后广播)
+func (s *ReplacementService) replaceEth(ctx context.Context, w *model.Withdrawal, action string) (*model.TxReplacement, *types.Transaction, error) {
+	if s.broadcaster == nil || s.broadcaster.ethClient == nil {
+		return nil, nil, fmt.Errorf("%w: RPC 未连接 (模拟模式)", ErrReplaceNotSupported)
+	}
+	if w.FromAddress == "" {
+		return nil, nil, fmt.Errorf("%w: 提现单缺少出款地址", ErrReplaceNotSupported)
+	}
+	client := s.broadcaster.ethClient
+
+	// 已经打包的交易不能再替换
+	if _, err := client.TransactionReceipt(ctx, common.HexToHash(w.TxHash)); err == nil {
+		return nil, nil, ErrTxAlreadyMined
+	}
+
+	cfg := config.Chain(w.Chain)
+	var ceiling *big.Int
+	if cfg.MaxGasPriceGwei > 0 {
+		ceiling = decimal.NewFromInt(cfg.MaxGasPriceGwei).Mul(decimal.New(1, 9)).BigInt()
+	}
+
+	// 替换交易沿用原交易的类型 (Legacy / EIP-1559)
+	oldFees := ethtx.Fees{GasPrice: w.GasPrice.BigInt()}
+	txType := ethtx.TxTypeLegacy
+	if w.TxType == types.DynamicFeeTxType {
+		oldFees = ethtx.Fees{Dynamic: true, GasTipCap: w.GasTipCap.BigInt(), GasFeeCap: w.GasPrice.BigInt()}
+		txType = ethtx.TxTypeDynamic
+	}
+	// 参考快速档，但类型不一致 (配置切换过) 时只按比例提价
+	var suggested ethtx.Fees
+	if estimate, err := s.broadcaster.fees.Estimate(ctx, w.Chain); err == nil && estimate.TxType == txType {
+		suggested = estimate.Quote(fee.LevelFast).EthFees()
+	}
+	newFees, err := bumpEthFees(oldFees, suggested, cfg.FeeBumpPercent, ceiling)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to := common.HexToAddress(w.ToAddress)
+	value := ethToWei(w.Amount)
+	if action == model.ReplaceActionCancel {
+		// 取消: 0 值转给自己
+		to = common.HexToAddress(w.FromAddress)
+		value = big.NewInt(0)
+	}
+
+	gasLimit := w.GasLimit
+	if gasLimit == 0 {
+		gasLimit = 21000
+	}
+	// 用原交易的出款账户签名 (租户热钱包配置变更后不能再替换)
+	key, fromAddr, err := s.broadcaster.payoutAccount(ctx, s.db, w.TenantID, w.Chain)
+	if err != nil {
+		return nil, nil, err
+	}
+	if fromAddr != common.HexToAddress(w.FromAddress) {
+		return nil, nil, fmt.Errorf("%w: 出款地址 %s 与当前热钱包 %s 不一致", ErrReplaceNotSupported, w.FromAddress, fromAddr.Hex())
+	}
+	signedTx, err := s.broadcaster.signEth(key, w.Nonce, to, value, gasLimit, newFees)
+	if err != nil {
+		return nil, nil, err
+	}
+	w.GasTipCap = decimalOrZero(newFees.GasTipCap)
+
+	return &model.TxReplacement{
+		WithdrawalID: w.ID,
+		Chain:        w.Chain,
+		Action:       action,
+		Nonce:        w.Nonce,
+		OldTxHash:    w.TxHash,
+		NewTxHash:    signedTx.Hash().Hex(),
+		OldFee:       w.GasPrice,
+		NewFee:       decimal.NewFromBigInt(newFees.MaxPrice(), 0),
+	}, signedTx, nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"wallet-core/internal/model"
+)
+
+func TestReplaceActionFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		action  string
+		trigger string
+		want    string
+		wantErr error
+	}{
+		{"首次加速", "", model.ReplaceActionSpeedUp, model.ReplaceTriggerAuto, model.ReplaceActionSpeedUp, nil},
+		{"加速后取消", model.ReplaceActionSpeedUp, model.ReplaceActionCancel, model.ReplaceTriggerAdmin, model.ReplaceActionCancel, nil},
+		{"取消后继续取消", model.ReplaceActionCancel, model.ReplaceActionCancel, model.ReplaceTriggerAdmin, model.ReplaceActionCancel, nil},
+		{"取消后自动加速只加速取消交易", model.ReplaceActionCancel, model.ReplaceActionSpeedUp, model.ReplaceTriggerAuto, model.ReplaceActionCancel, nil},
+		{"取消后手动加速被拒绝", model.ReplaceActionCancel, model.ReplaceActionSpeedUp, model.ReplaceTriggerAdmin, "", ErrWithdrawalCancelling},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := replaceActionFor(tt.current, tt.action, tt.trigger)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
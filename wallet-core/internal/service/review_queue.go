@@ -0,0 +1,452 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/database"
+	"wallet-core/pkg/errno"
+)
+
+// ReviewClaimTTL 审核认领的有效期，超时未审核视为放弃，其他管理员可以重新认领
+const ReviewClaimTTL = 30 * time.Minute
+
+// 审核队列每页条数 / 批量审核上限 / 详情中展示的近期记录条数
+const (
+	ReviewQueueDefaultLimit = 50
+	ReviewQueueMaxLimit     = 100
+	BulkReviewMaxItems      = 100
+	recentActivityLimit     = 10
+)
+
+// 审核队列的认领过滤
+const (
+	AssigneeAny        = ""           // 不过滤
+	AssigneeMine       = "mine"       // 当前管理员认领中的
+	AssigneeUnassigned = "unassigned" // 未认领或认领已过期
+)
+
+var errNotAwaitingReview = errno.ErrWithdrawalStateInvalid.WithMessage("withdrawal is not awaiting review")
+
+// Reviewable 是否处于需要人工审核的状态 (待审核 / 风控挂起)
+func Reviewable(status string) bool {
+	return status == model.WithdrawalStatusPendingReview || status == model.WithdrawalStatusRiskHold
+}
+
+// ReviewQueueFilter 审核队列查询条件，均为可选
+type ReviewQueueFilter struct {
+	Status       string // pending_review / risk_hold，为空时两者都查
+	Chain        string
+	MinAmount    *decimal.Decimal
+	MaxAmount    *decimal.Decimal
+	MinRiskScore *int
+	MaxRiskScore *int
+	Assignee     string // AssigneeAny / AssigneeMine / AssigneeUnassigned
+	Cursor       string // 上一页返回的 NextCursor，为空表示第一页
+	Limit        int
+}
+
+// ReviewQueuePage 一页审核任务，NextCursor 为空表示没有更多
+// 已过期的认领在返回前清空 (AssigneeID = 0)
+type ReviewQueuePage struct {
+	Items      []model.Withdrawal `json:"items"`
+	NextCursor string             `json:"next_cursor"`
+}
+
+// ListReviewQueue 审核队列，按风控分数从高到低、同分按先来后到排列
+// 游标为上一页最后一条的 (risk_score, id)，审核完成的提现离开队列不会导致翻页重复或遗漏
+func (s *AdminService) ListReviewQueue(ctx context.Context, adminID uint64, f ReviewQueueFilter) (*ReviewQueuePage, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = ReviewQueueDefaultLimit
+	}
+	if limit > ReviewQueueMaxLimit {
+		limit = ReviewQueueMaxLimit
+	}
+	cur, err := decodeReviewQueueCursor(f.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []string{model.WithdrawalStatusPendingReview, model.WithdrawalStatusRiskHold}
+	if f.Status != "" {
+		if !Reviewable(f.Status) {
+			return nil, errno.ErrBind.WithMessage("status must be pending_review or risk_hold")
+		}
+		statuses = []string{f.Status}
+	}
+	if f.MinAmount != nil && f.MaxAmount != nil && f.MinAmount.GreaterThan(*f.MaxAmount) {
+		return nil, errno.ErrBind.WithMessage("min_amount must not exceed max_amount")
+	}
+	if f.MinRiskScore != nil && f.MaxRiskScore != nil && *f.MinRiskScore > *f.MaxRiskScore {
+		return nil, errno.ErrBind.WithMessage("min_risk_score must not exceed max_risk_score")
+	}
+
+	q := database.DB.WithContext(ctx).Model(&model.Withdrawal{}).Where("status IN ?", statuses)
+	if f.Chain != "" {
+		q = q.Where("chain = ?", strings.ToUpper(f.Chain))
+	}
+	if f.MinAmount != nil {
+		q = q.Where("amount >= ?", *f.MinAmount)
+	}
+	if f.MaxAmount != nil {
+		q = q.Where("amount <= ?", *f.MaxAmount)
+	}
+	if f.MinRiskScore != nil {
+		q = q.Where("risk_score >= ?", *f.MinRiskScore)
+	}
+	if f.MaxRiskScore != nil {
+		q = q.Where("risk_score <= ?", *f.MaxRiskScore)
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-ReviewClaimTTL)
+	switch f.Assignee {
+	case AssigneeAny:
+	case AssigneeMine:
+		q = q.Where("assignee_id = ? AND claimed_at > ?", adminID, cutoff)
+	case AssigneeUnassigned:
+		q = q.Where("(assignee_id = 0 OR claimed_at IS NULL OR claimed_at <= ?)", cutoff)
+	default:
+		return nil, errno.ErrBind.WithMessage(`assignee must be "mine" or "unassigned"`)
+	}
+	if cur != nil {
+		q = q.Where("(risk_score < ? OR (risk_score = ? AND id > ?))", cur.RiskScore, cur.RiskScore, cur.ID)
+	}
+
+	var rows []model.Withdrawal
+	if err := q.Order("risk_score DESC, id ASC").Limit(limit + 1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	page := &ReviewQueuePage{Items: rows}
+	if len(rows) > limit {
+		page.Items = rows[:limit]
+		last := page.Items[limit-1]
+		page.NextCursor = reviewQueueCursor{RiskScore: last.RiskScore, ID: last.ID}.encode()
+	}
+	if page.Items == nil {
+		page.Items = []model.Withdrawal{}
+	}
+	for i := range page.Items {
+		expireClaim(&page.Items[i], now)
+	}
+	return page, nil
+}
+
+// ReviewRecord 审核记录及审核人用户名
+type ReviewRecord struct {
+	model.WithdrawalReview
+	AdminUsername string `json:"admin_username"`
+}
+
+// UserActivity 提现用户的账户概况和近期充提记录
+type UserActivity struct {
+	User              *model.User        `json:"user,omitempty"` // 用户已删除时仍返回
+	Balances          []model.Account    `json:"balances"`
+	RecentDeposits    []model.Deposit    `json:"recent_deposits"`
+	RecentWithdrawals []model.Withdrawal `json:"recent_withdrawals"` // 不含当前这笔
+}
+
+// WithdrawalDetail 审核详情: 提现单、已有的审核记录、用户近期活动
+type WithdrawalDetail struct {
+	Withdrawal model.Withdrawal `json:"withdrawal"`
+	Reviews    []ReviewRecord   `json:"reviews"`
+	Activity   UserActivity     `json:"activity"`
+}
+
+// GetWithdrawalDetail 审核详情 (任意状态的提现都可以查看)
+func (s *AdminService) GetWithdrawalDetail(ctx context.Context, id uint64) (*WithdrawalDetail, error) {
+	w, err := s.GetWithdrawal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	d := &WithdrawalDetail{Withdrawal: *w}
+
+	db := database.DB.WithContext(ctx)
+	reviews, err := loadReviewRecords(db, w.ID)
+	if err != nil {
+		return nil, err
+	}
+	d.Reviews = reviews
+
+	var user model.User
+	err = db.Unscoped().First(&user, "id = ?", w.UserID).Error
+	switch {
+	case err == nil:
+		d.Activity.User = &user
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+	if err := db.Where("user_id = ?", w.UserID).Order("currency").Find(&d.Activity.Balances).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("user_id = ?", w.UserID).
+		Order("created_at DESC, id DESC").Limit(recentActivityLimit).
+		Find(&d.Activity.RecentDeposits).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("user_id = ? AND id <> ?", w.UserID, w.ID).
+		Order("created_at DESC, id DESC").Limit(recentActivityLimit).
+		Find(&d.Activity.RecentWithdrawals).Error; err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// GetWithdrawal 查询单笔提现 (不限用户)，已过期的认领被清空
+func (s *AdminService) GetWithdrawal(ctx context.Context, id uint64) (*model.Withdrawal, error) {
+	var w model.Withdrawal
+	if err := database.DB.WithContext(ctx).First(&w, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrWithdrawalNotFound
+		}
+		return nil, err
+	}
+	expireClaim(&w, time.Now())
+	return &w, nil
+}
+
+// loadReviewRecords 按审核顺序返回审核记录，并补充审核人用户名
+func loadReviewRecords(db *gorm.DB, withdrawalID uint64) ([]ReviewRecord, error) {
+	var reviews []model.WithdrawalReview
+	if err := db.Where("withdrawal_id = ?", withdrawalID).Order("id").Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	records := make([]ReviewRecord, 0, len(reviews))
+	if len(reviews) == 0 {
+		return records, nil
+	}
+
+	ids := make([]uint64, 0, len(reviews))
+	for _, r := range reviews {
+		ids = append(ids, r.AdminID)
+	}
+	var admins []model.AdminUser
+	if err := db.Select("id", "username").Where("id IN ?", ids).Find(&admins).Error; err != nil {
+		return nil, err
+	}
+	names := make(map[uint64]string, len(admins))
+	for _, a := range admins {
+		names[a.ID] = a.Username
+	}
+	for _, r := range reviews {
+		records = append(records, ReviewRecord{WithdrawalReview: r, AdminUsername: names[r.AdminID]})
+	}
+	return records, nil
+}
+
+// BulkReviewResult 批量审核中单笔提现的结果
+type BulkReviewResult struct {
+	ID      uint64 `json:"id"`
+	Success bool   `json:"success"`
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+	Err     error  `json:"-"`
+}
+
+// BulkReview 批量通过 / 拒绝，每笔独立事务，单笔失败不影响其他提现
+// 与单笔审核规则相同: 被他人认领、已审核过、状态不对的提现会失败并返回原因
+func (s *AdminService) BulkReview(ctx context.Context, ids []uint64, adminID uint64, action, remark string) ([]BulkReviewResult, error) {
+	ids = uniqueIDs(ids)
+	if len(ids) == 0 || len(ids) > BulkReviewMaxItems {
+		return nil, errno.ErrBind.WithMessage("ids must contain 1 to " + strconv.Itoa(BulkReviewMaxItems) + " withdrawals")
+	}
+	if action != ReviewActionApprove && action != ReviewActionReject {
+		return nil, errno.ErrBind.WithMessage(`action must be "approve" or "reject"`)
+	}
+
+	results := make([]BulkReviewResult, 0, len(ids))
+	for _, id := range ids {
+		err := s.ReviewWithdrawal(ctx, strconv.FormatUint(id, 10), adminID, action, remark)
+		results = append(results, bulkReviewResult(id, err))
+	}
+	return results, nil
+}
+
+func bulkReviewResult(id uint64, err error) BulkReviewResult {
+	if err == nil {
+		return BulkReviewResult{ID: id, Success: true}
+	}
+	var e errno.Errno
+	if !errors.As(err, &e) {
+		e = errno.ErrDatabase // 不把内部错误细节返回给调用方
+	}
+	return BulkReviewResult{ID: id, Code: e.Code, Message: e.Message, Err: err}
+}
+
+// uniqueIDs 去重并去掉 0，保持原顺序
+func uniqueIDs(ids []uint64) []uint64 {
+	seen := make(map[uint64]bool, len(ids))
+	out := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
+
+// ClaimWithdrawal 认领审核任务，认领期间其他管理员不能审核；重复认领会刷新有效期
+func (s *AdminService) ClaimWithdrawal(ctx context.Context, id, adminID uint64) (*model.Withdrawal, error) {
+	return s.updateClaim(ctx, id, func(tx *gorm.DB, w *model.Withdrawal, now time.Time) error {
+		if claimActive(w, now) && w.AssigneeID != adminID {
+			return errno.ErrWithdrawalClaimed
+		}
+		reviewed, err := hasReviewed(tx, w.ID, adminID)
+		if err != nil {
+			return err
+		}
+		if reviewed {
+			return errno.ErrAlreadyReviewed
+		}
+		w.AssigneeID = adminID
+		w.ClaimedAt = &now
+		return nil
+	})
+}
+
+// ReleaseWithdrawal 释放自己认领的审核任务 (未认领或认领已过期时直接成功)
+func (s *AdminService) ReleaseWithdrawal(ctx context.Context, id, adminID uint64) (*model.Withdrawal, error) {
+	return s.updateClaim(ctx, id, func(tx *gorm.DB, w *model.Withdrawal, now time.Time) error {
+		if claimActive(w, now) && w.AssigneeID != adminID {
+			return errno.ErrWithdrawalClaimed
+		}
+		w.AssigneeID = 0
+		w.ClaimedAt = nil
+		return nil
+	})
+}
+
+// ReassignWithdrawal 把审核任务指派给其他管理员 (覆盖现有认领)，assigneeID 为 0 表示取消指派
+// 被指派人必须是有审核权限的有效管理员，且尚未审核过这笔提现
+func (s *AdminService) ReassignWithdrawal(ctx context.Context, id, assigneeID uint64) (*model.Withdrawal, error) {
+	return s.updateClaim(ctx, id, func(tx *gorm.DB, w *model.Withdrawal, now time.Time) error {
+		if assigneeID == 0 {
+			w.AssigneeID = 0
+			w.ClaimedAt = nil
+			return nil
+		}
+
+		var assignee model.AdminUser
+		err := tx.First(&assignee, "id = ?", assigneeID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && assignee.Disabled) {
+			return errno.ErrAdminNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !HasPermission(assignee.Role, PermWithdrawalReview) {
+			return errno.ErrPermissionDenied.WithMessage("assignee is not allowed to review withdrawals")
+		}
+		reviewed, err := hasReviewed(tx, w.ID, assigneeID)
+		if err != nil {
+			return err
+		}
+		if reviewed {
+			return errno.ErrAlreadyReviewed
+		}
+		w.AssigneeID = assigneeID
+		w.ClaimedAt = &now
+		return nil
+	})
+}
+
+// updateClaim 锁定待审核的提现单，由 fn 修改认领信息后保存
+func (s *AdminService) updateClaim(ctx context.Context, id uint64, fn func(tx *gorm.DB, w *model.Withdrawal, now time.Time) error) (*model.Withdrawal, error) {
+	var w model.Withdrawal
+	err := database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&w, "id = ?", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.ErrWithdrawalNotFound
+			}
+			return err
+		}
+		if !Reviewable(w.Status) {
+			return errNotAwaitingReview
+		}
+		if err := fn(tx, &w, time.Now()); err != nil {
+			return err
+		}
+		return tx.Model(&w).Select("assignee_id", "claimed_at").Updates(&w).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func hasReviewed(tx *gorm.DB, withdrawalID, adminID uint64) (bool, error) {
+	var count int64
+	err := tx.Model(&model.WithdrawalReview{}).
+		Where("withdrawal_id = ? AND admin_id = ?", withdrawalID, adminID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// claimActive 提现是否被认领且认领未过期
+func claimActive(w *model.Withdrawal, now time.Time) bool {
+	return w.AssigneeID != 0 && w.ClaimedAt != nil && now.Sub(*w.ClaimedAt) < ReviewClaimTTL
+}
+
+// expireClaim 清空已过期的认领，调用方只看到仍然有效的认领
+func expireClaim(w *model.Withdrawal, now time.Time) {
+	if !claimActive(w, now) {
+		w.AssigneeID = 0
+		w.ClaimedAt = nil
+	}
+}
+
+// ClaimExpiresAt 认领的失效时间，未认领时为 nil
+func ClaimExpiresAt(w *model.Withdrawal) *time.Time {
+	if w.AssigneeID == 0 || w.ClaimedAt == nil {
+		return nil
+	}
+	t := w.ClaimedAt.Add(ReviewClaimTTL)
+	return &t
+}
+
+// reviewQueueCursor 上一页最后一条的排序位置
+type reviewQueueCursor struct {
+	RiskScore int
+	ID        uint64
+}
+
+func (c reviewQueueCursor) encode() string {
+	raw := strconv.Itoa(c.RiskScore) + ":" + strconv.FormatUint(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeReviewQueueCursor(s string) (*reviewQueueCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	invalid := errno.ErrBind.WithMessage("invalid cursor")
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, invalid
+	}
+	score, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, invalid
+	}
+	c := &reviewQueueCursor{}
+	if c.RiskScore, err = strconv.Atoi(score); err != nil {
+		return nil, invalid
+	}
+	if c.ID, err = strconv.ParseUint(id, 10, 64); err != nil {
+		return nil, invalid
+	}
+	return c, nil
+}
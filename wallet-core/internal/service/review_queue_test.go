@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/errno"
+)
+
+func TestReviewQueueCursorRoundTrip(t *testing.T) {
+	c := reviewQueueCursor{RiskScore: 85, ID: 1234}
+	got, err := decodeReviewQueueCursor(c.encode())
+	require.NoError(t, err)
+	assert.Equal(t, c, *got)
+
+	got, err = decodeReviewQueueCursor("")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestDecodeReviewQueueCursorInvalid(t *testing.T) {
+	for _, s := range []string{"!!!", "MTIz", "YTox", "MTph"} { // "123", "a:1", "1:a"
+		_, err := decodeReviewQueueCursor(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestClaimActive(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-time.Minute)
+	stale := now.Add(-ReviewClaimTTL - time.Second)
+
+	assert.False(t, claimActive(&model.Withdrawal{}, now))
+	assert.False(t, claimActive(&model.Withdrawal{AssigneeID: 7}, now))
+	assert.True(t, claimActive(&model.Withdrawal{AssigneeID: 7, ClaimedAt: &recent}, now))
+	assert.False(t, claimActive(&model.Withdrawal{AssigneeID: 7, ClaimedAt: &stale}, now))
+
+	w := &model.Withdrawal{AssigneeID: 7, ClaimedAt: &stale}
+	expireClaim(w, now)
+	assert.Zero(t, w.AssigneeID)
+	assert.Nil(t, w.ClaimedAt)
+	assert.Nil(t, ClaimExpiresAt(w))
+
+	w = &model.Withdrawal{AssigneeID: 7, ClaimedAt: &recent}
+	expireClaim(w, now)
+	assert.Equal(t, uint64(7), w.AssigneeID)
+	assert.Equal(t, recent.Add(ReviewClaimTTL), *ClaimExpiresAt(w))
+}
+
+func TestReviewable(t *testing.T) {
+	assert.True(t, Reviewable(model.WithdrawalStatusPendingReview))
+	assert.True(t, Reviewable(model.WithdrawalStatusRiskHold))
+	assert.False(t, Reviewable(model.WithdrawalStatusTimeLocked))
+	assert.False(t, Reviewable(model.WithdrawalStatusRejected))
+}
+
+func TestUniqueIDs(t *testing.T) {
+	assert.Equal(t, []uint64{3, 1, 2}, uniqueIDs([]uint64{3, 1, 0, 3, 2, 1}))
+	assert.Empty(t, uniqueIDs(nil))
+}
+
+func TestBulkReviewResult(t *testing.T) {
+	assert.Equal(t, BulkReviewResult{ID: 1, Success: true}, bulkReviewResult(1, nil))
+
+	r := bulkReviewResult(2, errno.ErrWithdrawalClaimed)
+	assert.False(t, r.Success)
+	assert.Equal(t, errno.ErrWithdrawalClaimed.Code, r.Code)
+	assert.Equal(t, errno.ErrWithdrawalClaimed.Message, r.Message)
+
+	// 内部错误不返回细节
+	r = bulkReviewResult(3, errors.New("pq: connection refused"))
+	assert.Equal(t, errno.ErrDatabase.Code, r.Code)
+	assert.Equal(t, errno.ErrDatabase.Message, r.Message)
+}
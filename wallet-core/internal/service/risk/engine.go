@@ -0,0 +1,201 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/monitor"
+)
+
+// Params 规则参数 (来自配置或 risk_rules.params)
+type Params map[string]float64
+
+// Float 读取参数，未配置时返回默认值
+func (p Params) Float(key string, def float64) float64 {
+	if v, ok := p[key]; ok {
+		return v
+	}
+	return def
+}
+
+// Int 读取整数参数，未配置时返回默认值
+func (p Params) Int(key string, def int) int {
+	return int(p.Float(key, float64(def)))
+}
+
+// Evaluator 规则实现: 判断提现是否命中规则，命中时返回原因
+type Evaluator func(ctx context.Context, db *gorm.DB, w *model.Withdrawal, params Params) (hit bool, reason string, err error)
+
+var (
+	evaluatorsMu sync.RWMutex
+	evaluators   = map[string]Evaluator{}
+)
+
+// Register 注册规则类型，配置中的 type 字段引用这里的名字
+// 新增规则只需实现 Evaluator 并在 init 中注册，无需修改引擎
+func Register(ruleType string, fn Evaluator) {
+	evaluatorsMu.Lock()
+	defer evaluatorsMu.Unlock()
+	evaluators[ruleType] = fn
+}
+
+func lookup(ruleType string) (Evaluator, bool) {
+	evaluatorsMu.RLock()
+	defer evaluatorsMu.RUnlock()
+	fn, ok := evaluators[ruleType]
+	return fn, ok
+}
+
+// Policy 当前生效的规则与审批阈值
+type Policy struct {
+	ReviewThreshold   int
+	HoldThreshold     int
+	BaseApprovals     int
+	HighRiskApprovals int
+	Rules             []config.RiskRule
+}
+
+// Decide 根据风控分数决定提现的初始状态和所需审批人数
+func (p *Policy) Decide(score int) (status string, requiredApprovals int) {
+	switch {
+	case p.HoldThreshold > 0 && score >= p.HoldThreshold:
+		return model.WithdrawalStatusRiskHold, p.HighRiskApprovals
+	case p.ReviewThreshold > 0 && score >= p.ReviewThreshold:
+		return model.WithdrawalStatusPendingReview, p.HighRiskApprovals
+	default:
+		return model.WithdrawalStatusPendingReview, p.BaseApprovals
+	}
+}
+
+// Flagged 风控分数达到复审阈值 (需要加审或直接挂起)
+func (p *Policy) Flagged(score int) bool {
+	return (p.ReviewThreshold > 0 && score >= p.ReviewThreshold) ||
+		(p.HoldThreshold > 0 && score >= p.HoldThreshold)
+}
+
+// Result 风控评估结果
+type Result struct {
+	Score   int
+	Reasons []string
+}
+
+// Engine 提现风控引擎
+// 1. 创建提现时逐条执行启用的规则，命中的分数累加，原因写入提现单
+// 2. 总分通过 Policy.Decide 决定审批人数，超过挂起阈值直接进入 risk_hold
+// 3. 规则从 Source 加载，后台按 interval 热加载，加载失败时继续使用上一版规则
+type Engine struct {
+	db       *gorm.DB
+	source   Source
+	interval time.Duration
+	policy   atomic.Pointer[Policy]
+}
+
+func NewEngine(db *gorm.DB, source Source, interval time.Duration) (*Engine, error) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	e := &Engine{db: db, source: source, interval: interval}
+	if err := e.Reload(context.Background()); err != nil {
+		return nil, fmt.Errorf("加载风控规则失败: %w", err)
+	}
+	return e, nil
+}
+
+// Start 定时热加载规则 (阻塞，直到 ctx 取消)
+func (e *Engine) Start(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.Reload(ctx); err != nil {
+				log.Printf("[Risk] 热加载规则失败，继续使用旧规则: %v", err)
+			}
+		}
+	}
+}
+
+// Reload 重新加载规则
+func (e *Engine) Reload(ctx context.Context) error {
+	p, err := e.source.Load(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range p.Rules {
+		if _, ok := lookup(r.Type); !ok && r.Enabled {
+			log.Printf("[Risk] Warning: 规则 %s 的类型 %s 未注册，将被忽略", r.Name, r.Type)
+		}
+	}
+	e.policy.Store(p)
+	return nil
+}
+
+// Policy 返回当前生效的规则
+func (e *Engine) Policy() *Policy {
+	return e.policy.Load()
+}
+
+// Evaluate 对提现执行所有启用的规则
+// 规则执行出错时按命中处理 (fail-closed)，宁可多审一次也不能放过
+func (e *Engine) Evaluate(ctx context.Context, w *model.Withdrawal) (*Result, error) {
+	p := e.Policy()
+	if p == nil {
+		return nil, errors.New("风控规则未加载")
+	}
+
+	res := &Result{}
+	for _, rule := range p.Rules {
+		if !rule.Enabled {
+			continue
+		}
+		fn, ok := lookup(rule.Type)
+		if !ok {
+			continue
+		}
+
+		hit, reason, err := fn(ctx, e.db, w, Params(rule.Params))
+		if err != nil {
+			hit, reason = true, fmt.Sprintf("规则执行失败: %v", err)
+		}
+		if !hit {
+			continue
+		}
+
+		res.Score += rule.Score
+		res.Reasons = append(res.Reasons, fmt.Sprintf("[%s +%d] %s", rule.Name, rule.Score, reason))
+		if monitor.Business != nil {
+			monitor.Business.RiskRuleHitsTotal.WithLabelValues(rule.Name).Inc()
+		}
+	}
+	return res, nil
+}
+
+// Assess 评估提现并写入评分、原因、初始状态和所需审批人数 (调用方负责保存)
+func (e *Engine) Assess(ctx context.Context, w *model.Withdrawal) error {
+	res, err := e.Evaluate(ctx, w)
+	if err != nil {
+		return err
+	}
+
+	w.RiskScore = res.Score
+	w.RiskReasons = res.Reasons
+	w.Status, w.RequiredApprovals = e.Policy().Decide(res.Score)
+	w.CurrentApprovals = 0
+
+	if w.Status == model.WithdrawalStatusRiskHold {
+		log.Printf("[Risk] ⚠️ 提现被风控挂起 User=%d, Score=%d, Reasons=%v", w.UserID, res.Score, res.Reasons)
+	}
+	return nil
+}
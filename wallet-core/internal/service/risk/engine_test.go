@@ -0,0 +1,98 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/config"
+)
+
+// staticSource 测试用规则来源
+type staticSource struct {
+	policy *Policy
+	err    error
+}
+
+func (s *staticSource) Load(ctx context.Context) (*Policy, error) {
+	return s.policy, s.err
+}
+
+func fixed(hit bool, err error) Evaluator {
+	return func(ctx context.Context, db *gorm.DB, w *model.Withdrawal, p Params) (bool, string, error) {
+		return hit, "test", err
+	}
+}
+
+func TestPolicyDecide(t *testing.T) {
+	p := &Policy{ReviewThreshold: 30, HoldThreshold: 80, BaseApprovals: 2, HighRiskApprovals: 3}
+
+	cases := []struct {
+		score     int
+		status    string
+		approvals int
+		flagged   bool
+	}{
+		{0, model.WithdrawalStatusPendingReview, 2, false},
+		{29, model.WithdrawalStatusPendingReview, 2, false},
+		{30, model.WithdrawalStatusPendingReview, 3, true},
+		{80, model.WithdrawalStatusRiskHold, 3, true},
+	}
+	for _, c := range cases {
+		status, approvals := p.Decide(c.score)
+		assert.Equal(t, c.status, status, "score=%d", c.score)
+		assert.Equal(t, c.approvals, approvals, "score=%d", c.score)
+		assert.Equal(t, c.flagged, p.Flagged(c.score), "score=%d", c.score)
+	}
+}
+
+func TestEngineAssess(t *testing.T) {
+	Register("test_hit", fixed(true, nil))
+	Register("test_miss", fixed(false, nil))
+	Register("test_error", fixed(false, errors.New("db down")))
+
+	src := &staticSource{policy: &Policy{
+		ReviewThreshold: 30, HoldThreshold: 80, BaseApprovals: 2, HighRiskApprovals: 3,
+		Rules: []config.RiskRule{
+			{Name: "a", Type: "test_hit", Score: 20, Enabled: true},
+			{Name: "b", Type: "test_miss", Score: 50, Enabled: true},
+			{Name: "c", Type: "test_hit", Score: 40, Enabled: false},
+			{Name: "d", Type: "unknown", Score: 99, Enabled: true},
+		},
+	}}
+	e, err := NewEngine(nil, src, 0)
+	require.NoError(t, err)
+
+	w := &model.Withdrawal{UserID: 1}
+	require.NoError(t, e.Assess(context.Background(), w))
+	assert.Equal(t, 20, w.RiskScore)
+	assert.Len(t, w.RiskReasons, 1)
+	assert.Equal(t, model.WithdrawalStatusPendingReview, w.Status)
+	assert.Equal(t, 2, w.RequiredApprovals)
+
+	// 热加载: 规则执行失败按命中处理 (fail-closed)
+	src.policy = &Policy{
+		ReviewThreshold: 30, HoldThreshold: 80, BaseApprovals: 2, HighRiskApprovals: 3,
+		Rules: []config.RiskRule{
+			{Name: "a", Type: "test_hit", Score: 20, Enabled: true},
+			{Name: "e", Type: "test_error", Score: 60, Enabled: true},
+		},
+	}
+	require.NoError(t, e.Reload(context.Background()))
+
+	w = &model.Withdrawal{UserID: 1}
+	require.NoError(t, e.Assess(context.Background(), w))
+	assert.Equal(t, 80, w.RiskScore)
+	assert.Equal(t, model.WithdrawalStatusRiskHold, w.Status)
+	assert.Equal(t, 3, w.RequiredApprovals)
+
+	// 加载失败时保留旧规则
+	src.err = errors.New("bad config")
+	assert.Error(t, e.Reload(context.Background()))
+	assert.Len(t, e.Policy().Rules, 2)
+}
@@ -0,0 +1,131 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+)
+
+// 内置规则类型
+const (
+	RuleNewAddress           = "new_address"           // 首次向该地址提现
+	RuleAmountMultiple       = "amount_multiple"       // 金额远超历史平均
+	RulePasswordChange       = "password_change"       // 近期修改过密码
+	RuleDepositThenWithdraw  = "deposit_then_withdraw" // 充值后立即提现
+	RuleDistinctDestinations = "distinct_destinations" // 短时间内提现到大量不同地址
+)
+
+func init() {
+	Register(RuleNewAddress, newAddress)
+	Register(RuleAmountMultiple, amountMultiple)
+	Register(RulePasswordChange, passwordChange)
+	Register(RuleDepositThenWithdraw, depositThenWithdraw)
+	Register(RuleDistinctDestinations, distinctDestinations)
+}
+
+// newAddress 用户从未成功提现到该地址
+func newAddress(ctx context.Context, db *gorm.DB, w *model.Withdrawal, _ Params) (bool, string, error) {
+	var count int64
+	err := db.WithContext(ctx).Model(&model.Withdrawal{}).
+		Where("user_id = ? AND chain = ? AND to_address = ? AND status IN ?", w.UserID, w.Chain, w.ToAddress,
+			[]string{model.WithdrawalStatusBroadcasted, model.WithdrawalStatusCompleted}).
+		Count(&count).Error
+	if err != nil {
+		return false, "", err
+	}
+	if count > 0 {
+		return false, "", nil
+	}
+	return true, "首次向该地址提现", nil
+}
+
+// amountMultiple 金额超过历史平均提现金额的 multiple 倍
+// 参数: multiple (默认 5), min_history (历史笔数不足时不判断，默认 3)
+func amountMultiple(ctx context.Context, db *gorm.DB, w *model.Withdrawal, p Params) (bool, string, error) {
+	var stat struct {
+		Count int64
+		Avg   decimal.NullDecimal
+	}
+	err := db.WithContext(ctx).Model(&model.Withdrawal{}).
+		Select("COUNT(*) AS count, AVG(amount) AS avg").
+		Where("user_id = ? AND chain = ? AND status NOT IN ?", w.UserID, w.Chain,
+			[]string{model.WithdrawalStatusFailed, model.WithdrawalStatusRejected, model.WithdrawalStatusCancelled}).
+		Where("id <> ?", w.ID).
+		Scan(&stat).Error
+	if err != nil {
+		return false, "", err
+	}
+	if stat.Count < int64(p.Int("min_history", 3)) || !stat.Avg.Valid || !stat.Avg.Decimal.IsPositive() {
+		return false, "", nil
+	}
+
+	limit := stat.Avg.Decimal.Mul(decimal.NewFromFloat(p.Float("multiple", 5)))
+	if w.Amount.LessThanOrEqual(limit) {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("金额 %s 超过历史平均 %s 的 %v 倍", w.Amount, stat.Avg.Decimal.StringFixed(8), p.Float("multiple", 5)), nil
+}
+
+// passwordChange 最近 hours 小时内修改过密码 (默认 24)
+func passwordChange(ctx context.Context, db *gorm.DB, w *model.Withdrawal, p Params) (bool, string, error) {
+	var user model.User
+	if err := db.WithContext(ctx).Select("id", "password_changed_at").First(&user, w.UserID).Error; err != nil {
+		return false, "", err
+	}
+	if user.PasswordChangedAt == nil {
+		return false, "", nil
+	}
+
+	window := time.Duration(p.Float("hours", 24) * float64(time.Hour))
+	if time.Since(*user.PasswordChangedAt) > window {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("%s 内修改过密码", window), nil
+}
+
+// depositThenWithdraw 最近 minutes 分钟内有充值 (默认 30)，典型的过桥洗币特征
+func depositThenWithdraw(ctx context.Context, db *gorm.DB, w *model.Withdrawal, p Params) (bool, string, error) {
+	window := time.Duration(p.Float("minutes", 30) * float64(time.Minute))
+
+	var count int64
+	err := db.WithContext(ctx).Model(&model.Deposit{}).
+		Where("user_id = ? AND created_at > ?", w.UserID, time.Now().Add(-window)).
+		Count(&count).Error
+	if err != nil {
+		return false, "", err
+	}
+	if count == 0 {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("%s 内有充值入账", window), nil
+}
+
+// distinctDestinations 最近 hours 小时内 (默认 24) 提现的不同目标地址数 (含本次) 达到 count (默认 5)
+func distinctDestinations(ctx context.Context, db *gorm.DB, w *model.Withdrawal, p Params) (bool, string, error) {
+	window := time.Duration(p.Float("hours", 24) * float64(time.Hour))
+
+	var addrs []string
+	err := db.WithContext(ctx).Model(&model.Withdrawal{}).
+		Where("user_id = ? AND created_at > ? AND id <> ?", w.UserID, time.Now().Add(-window), w.ID).
+		Distinct().Pluck("to_address", &addrs).Error
+	if err != nil {
+		return false, "", err
+	}
+
+	distinct := len(addrs) + 1
+	for _, a := range addrs {
+		if a == w.ToAddress {
+			distinct--
+			break
+		}
+	}
+	if distinct < p.Int("count", 5) {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("%s 内提现到 %d 个不同地址", window, distinct), nil
+}
@@ -0,0 +1,96 @@
+package risk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/config"
+)
+
+// Source 风控规则来源
+type Source interface {
+	Load(ctx context.Context) (*Policy, error)
+}
+
+// NewSource 根据 risk.source 选择规则来源
+func NewSource(db *gorm.DB, cfg config.RiskConfig) Source {
+	if cfg.Source == "db" {
+		return &DBSource{db: db}
+	}
+	return &ConfigSource{}
+}
+
+// ConfigSource 从配置文件加载规则
+// 每次 Load 都重新读取配置文件 (使用独立的 viper 实例，不影响全局配置)，修改 config.yaml 即可热更新
+type ConfigSource struct{}
+
+func (s *ConfigSource) Load(ctx context.Context) (*Policy, error) {
+	cfg := config.Global.Risk
+	if file := viper.ConfigFileUsed(); file != "" {
+		v := viper.New()
+		v.SetConfigFile(file)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, err
+		}
+		if v.IsSet("risk") {
+			fresh := cfg // 文件中未配置的字段沿用启动时的值 (含默认值)
+			if err := v.UnmarshalKey("risk", &fresh); err != nil {
+				return nil, err
+			}
+			cfg = fresh
+		}
+	}
+	return policyFromConfig(cfg, cfg.Rules), nil
+}
+
+// DBSource 从 risk_rules 表加载规则，阈值仍使用配置
+type DBSource struct {
+	db *gorm.DB
+}
+
+func (s *DBSource) Load(ctx context.Context) (*Policy, error) {
+	var rows []model.RiskRule
+	if err := s.db.WithContext(ctx).Order("id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	rules := make([]config.RiskRule, 0, len(rows))
+	for _, r := range rows {
+		params := map[string]float64{}
+		if r.Params != "" {
+			if err := json.Unmarshal([]byte(r.Params), &params); err != nil {
+				return nil, fmt.Errorf("规则 %s 参数格式错误: %w", r.Name, err)
+			}
+		}
+		rules = append(rules, config.RiskRule{
+			Name:    r.Name,
+			Type:    r.Type,
+			Score:   r.Score,
+			Enabled: r.Enabled,
+			Params:  params,
+		})
+	}
+	return policyFromConfig(config.Global.Risk, rules), nil
+}
+
+func policyFromConfig(cfg config.RiskConfig, rules []config.RiskRule) *Policy {
+	p := &Policy{
+		ReviewThreshold:   cfg.ReviewThreshold,
+		HoldThreshold:     cfg.HoldThreshold,
+		BaseApprovals:     cfg.BaseApprovals,
+		HighRiskApprovals: cfg.HighRiskApprovals,
+		Rules:             rules,
+	}
+	if p.BaseApprovals <= 0 {
+		p.BaseApprovals = 2
+	}
+	if p.HighRiskApprovals < p.BaseApprovals {
+		p.HighRiskApprovals = p.BaseApprovals
+	}
+	return p
+}
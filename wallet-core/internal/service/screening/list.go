@@ -0,0 +1,88 @@
+package screening
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// 名单中可能出现的地址格式
+	evmAddrRe    = regexp.MustCompile(`^0[xX][0-9a-fA-F]{40}$`)
+	bech32AddrRe = regexp.MustCompile(`^(?i)(bc1|tb1)[02-9ac-hj-np-z]{8,87}$`)
+	base58AddrRe = regexp.MustCompile(`^[13mn2][1-9A-HJ-NP-Za-km-z]{25,34}$`)
+
+	// OFAC SDN CSV 中地址夹在备注里 ("Digital Currency Address - ETH 0x...;")，按分隔符切开后逐个匹配
+	tokenSep = regexp.MustCompile(`[\s,;"'|]+`)
+)
+
+// List 名单快照: 归一化地址 -> 来源文件
+type List struct {
+	entries map[string]string
+}
+
+// Len 名单中的地址数
+func (l *List) Len() int {
+	return len(l.entries)
+}
+
+// Lookup 查询地址是否在名单中，返回来源文件
+func (l *List) Lookup(addr string) (source string, ok bool) {
+	if l == nil || addr == "" {
+		return "", false
+	}
+	source, ok = l.entries[normalize(addr)]
+	return source, ok
+}
+
+// LoadFiles 读取所有名单文件，任一文件读取失败则整体失败 (避免加载到不完整的名单)
+func LoadFiles(files []string) (*List, error) {
+	l := &List{entries: make(map[string]string)}
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		err = l.parse(f, filepath.Base(file))
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// parse 解析一个名单文件: 纯文本每行一个地址，或 CSV (提取其中所有像地址的字段)
+func (l *List) parse(r io.Reader, source string) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024) // SDN CSV 单行备注可能很长
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, token := range tokenSep.Split(line, -1) {
+			if isAddress(token) {
+				l.entries[normalize(token)] = source
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func isAddress(s string) bool {
+	return evmAddrRe.MatchString(s) || bech32AddrRe.MatchString(s) || base58AddrRe.MatchString(s)
+}
+
+// normalize EVM 地址和 Bech32 地址大小写不敏感，统一转小写; Base58 地址大小写敏感，保持原样
+func normalize(addr string) string {
+	addr = strings.TrimSpace(addr)
+	lower := strings.ToLower(addr)
+	if strings.HasPrefix(lower, "0x") || strings.HasPrefix(lower, "bc1") || strings.HasPrefix(lower, "tb1") {
+		return lower
+	}
+	return addr
+}
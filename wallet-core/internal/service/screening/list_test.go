@@ -0,0 +1,56 @@
+package screening
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListParse(t *testing.T) {
+	const plain = `
+# comment
+0x8589427373D6D84E98730D7795D8f6f8731FDA16
+bc1qa5wkgaew2dkv56kfvj49j0av5nml45x9ek9hz6
+`
+	// OFAC SDN CSV: 地址藏在备注字段里
+	const sdn = `36216,"LAZARUS GROUP",-0- ,"individual","Digital Currency Address - XBT 12QtD5BFwRsdNsAZY76UVE1xyCGNTojH9h; Digital Currency Address - ETH 0x098B716B8Aaf21512996dC57EB0615e2383E2f96;"`
+
+	l := &List{entries: map[string]string{}}
+	require.NoError(t, l.parse(strings.NewReader(plain), "local.txt"))
+	require.NoError(t, l.parse(strings.NewReader(sdn), "sdn.csv"))
+	assert.Equal(t, 4, l.Len())
+
+	// EVM / Bech32 大小写不敏感
+	src, ok := l.Lookup("0x8589427373d6d84e98730d7795d8f6f8731fda16")
+	assert.True(t, ok)
+	assert.Equal(t, "local.txt", src)
+	_, ok = l.Lookup("BC1QA5WKGAEW2DKV56KFVJ49J0AV5NML45X9EK9HZ6")
+	assert.True(t, ok)
+
+	src, ok = l.Lookup("0x098b716b8aaf21512996dc57eb0615e2383e2f96")
+	assert.True(t, ok)
+	assert.Equal(t, "sdn.csv", src)
+
+	// Base58 大小写敏感
+	_, ok = l.Lookup("12QtD5BFwRsdNsAZY76UVE1xyCGNTojH9h")
+	assert.True(t, ok)
+	_, ok = l.Lookup("12qtd5bfwrsdnsazy76uve1xycgntojh9h")
+	assert.False(t, ok)
+
+	// 备注里的普通单词不会被当作地址
+	_, ok = l.Lookup("LAZARUS")
+	assert.False(t, ok)
+}
+
+func TestNilScreenerAllows(t *testing.T) {
+	var s *Screener
+	_, hit := s.Check("0x8589427373D6D84E98730D7795D8f6f8731FDA16")
+	assert.False(t, hit)
+}
+
+func TestLoadFilesMissing(t *testing.T) {
+	_, err := LoadFiles([]string{"does-not-exist.txt"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package screening
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+)
+
+// Screener 制裁名单筛查
+// 1. 提现: 创建时检查收款地址，命中直接拦截
+// 2. 充值: 入账前检查付款方地址，命中则隔离 (quarantined) 不入账
+// 每次命中都写入 screening_results 供合规审计
+// 名单按 reload_interval 定时重新加载，加载失败时继续使用上一版名单
+// nil *Screener 视为未启用筛查，所有检查都放行
+type Screener struct {
+	db       *gorm.DB
+	files    []string
+	interval time.Duration
+	list     atomic.Pointer[List]
+}
+
+func NewScreener(db *gorm.DB, cfg config.ScreeningConfig) (*Screener, error) {
+	interval := cfg.ReloadInterval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	s := &Screener{db: db, files: cfg.Files, interval: interval}
+	if err := s.Reload(); err != nil {
+		return nil, fmt.Errorf("加载制裁名单失败: %w", err)
+	}
+	return s, nil
+}
+
+// Start 定时重新加载名单 (阻塞，直到 ctx 取消)
+func (s *Screener) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Reload(); err != nil {
+				log.Printf("[Screening] 重新加载名单失败，继续使用旧名单: %v", err)
+			}
+		}
+	}
+}
+
+// Reload 重新读取名单文件
+func (s *Screener) Reload() error {
+	l, err := LoadFiles(s.files)
+	if err != nil {
+		return err
+	}
+	if old := s.list.Swap(l); old == nil || old.Len() != l.Len() {
+		log.Printf("[Screening] 名单已加载: %d 个地址 (%d 个文件)", l.Len(), len(s.files))
+	}
+	return nil
+}
+
+// Check 查询地址是否在名单中
+func (s *Screener) Check(addr string) (source string, hit bool) {
+	if s == nil {
+		return "", false
+	}
+	return s.list.Load().Lookup(addr)
+}
+
+// ScreenWithdrawal 检查提现收款地址，命中时记录并返回 errno.ErrAddressSanctioned
+func (s *Screener) ScreenWithdrawal(ctx context.Context, w *model.Withdrawal) error {
+	source, hit := s.Check(w.ToAddress)
+	if !hit {
+		return nil
+	}
+
+	log.Printf("[Screening] ⛔ 拦截提现: User=%d, To=%s, List=%s", w.UserID, w.ToAddress, source)
+	result := &model.ScreeningResult{
+		Direction:  model.ScreeningDirectionWithdrawal,
+		Chain:      w.Chain,
+		UserID:     w.UserID,
+		Address:    w.ToAddress,
+		Amount:     w.Amount.String(),
+		ListSource: source,
+		Action:     model.ScreeningActionBlocked,
+	}
+	if err := s.db.WithContext(ctx).Create(result).Error; err != nil {
+		// 审计记录写失败也必须拦截
+		log.Printf("[Screening] 写入筛查记录失败: %v", err)
+	}
+	return errno.ErrAddressSanctioned
+}
+
+// RecordDepositHit 记录被隔离的充值 (在调用方的充值事务中执行)
+func RecordDepositHit(tx *gorm.DB, d *model.Deposit, chain, source string) error {
+	return tx.Create(&model.ScreeningResult{
+		Direction:   model.ScreeningDirectionDeposit,
+		Chain:       chain,
+		UserID:      d.UserID,
+		Address:     d.FromAddress,
+		TxHash:      d.TxHash,
+		Amount:      d.Amount.String(),
+		ListSource:  source,
+		Action:      model.ScreeningActionQuarantined,
+		ReferenceID: d.ID,
+	}).Error
+}
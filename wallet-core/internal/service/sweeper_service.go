@@ -17,10 +17,12 @@ import (
 	"gorm.io/gorm"
 
 	"wallet-core/internal/model"
+	"wallet-core/internal/service/fee"
 	"wallet-core/internal/service/mq"
 	"wallet-core/pkg/bip32"
 	"wallet-core/pkg/monitor"
 	"wallet-core/pkg/utils/lock"
+	"wallet-core/pkg/wallet/ethtx"
 )
 
 // SweeperService 负责资金归集
@@ -31,6 +33,7 @@ type SweeperService struct {
 	masterKey bip32.ExtendedKey // Root XPrv
 	chainID   *big.Int
 	distLock  lock.DistributedLock // 分布式锁
+	fees      *fee.Service         // 手续费估算
 
 	// 固定的热钱包地址 (接收归集资金)
 	hotWalletAddr common.Address
@@ -38,13 +41,14 @@ type SweeperService struct {
 
 // DepositEvent 对应 MQ 中的 Payload
 type DepositEvent struct {
-	UserID uint   `json:"user_id"`
-	Amount string `json:"amount"`
-	TxHash string `json:"tx_hash"`
-	Chain  string `json:"chain"`
+	DepositID uint64 `json:"deposit_id"`
+	UserID    uint   `json:"user_id"`
+	Amount    string `json:"amount"`
+	TxHash    string `json:"tx_hash"`
+	Chain     string `json:"chain"`
 }
 
-func NewSweeperService(db *gorm.DB, consumer mq.Consumer, rpcURL string, masterKey bip32.ExtendedKey, hotWallet string, redisClient *redis.Client) (*SweeperService, error) {
+func NewSweeperService(db *gorm.DB, consumer mq.Consumer, rpcURL string, masterKey bip32.ExtendedKey, hotWallet string, redisClient *redis.Client, fees *fee.Service) (*SweeperService, error) {
 	if !masterKey.IsPrivate() {
 		return nil, fmt.Errorf("SweeperService 需要私钥")
 	}
@@ -72,6 +76,7 @@ func NewSweeperService(db *gorm.DB, consumer mq.Consumer, rpcURL string, masterK
 		chainID:       chainID,
 		hotWalletAddr: common.HexToAddress(hotWallet),
 		distLock:      lock.NewRedisLock(redisClient), // 初始化锁
+		fees:          fees,
 	}, nil
 }
 
@@ -132,17 +137,28 @@ func (s *SweeperService) sweepETH(ctx context.Context, event *DepositEvent) erro
 	timer := prometheus.NewTimer(monitor.Business.SweeperJobDuration.WithLabelValues("ETH"))
 	defer timer.ObserveDuration()
 
-	// A. 获取该用户的充值地址的 Path Index
+	// A. 获取收款地址的 Path Index
+	// 按充值记录关联的地址 (Deposit.BlockAppID) 查找，商户账单地址不属于任何用户，不能按 user_id 查
 	var addr model.Address
-	if err := s.db.Where("user_id = ? AND chain = ?", event.UserID, "ETH").First(&addr).Error; err != nil {
-		return fmt.Errorf("找不到用户地址: %v", err)
+	q := s.db.Where("user_id = ? AND chain = ?", event.UserID, "ETH")
+	if event.DepositID != 0 {
+		q = s.db.Where("id = (?)", s.db.Model(&model.Deposit{}).Select("block_app_id").Where("id = ?", event.DepositID))
+	}
+	if err := q.First(&addr).Error; err != nil {
+		return fmt.Errorf("找不到充值地址: %v", err)
+	}
+
+	// 租户的账户密钥和归集目标
+	accountKey, hotWallet, ok, err := s.tenantAccount(ctx, &addr)
+	if err != nil || !ok {
+		return err
 	}
 
-	log.Printf("[Sweeper] 准备从地址 %s 归集资金 (Path: m/0/%d)", addr.Address, addr.HDPathIndex)
+	log.Printf("[Sweeper] 准备从地址 %s 归集资金 (Tenant: %d, Path: 0/%d)", addr.Address, addr.TenantID, addr.HDPathIndex)
 
 	// B. 派生私钥 (Key Derivation) !! 核心安全 !!
-	// 路径: Master -> 0 (External) -> Index
-	chainKey, err := s.masterKey.Derive(0)
+	// 路径: Account -> 0 (External) -> Index
+	chainKey, err := accountKey.Derive(0)
 	if err != nil {
 		return err
 	}
@@ -162,7 +178,6 @@ func (s *SweeperService) sweepETH(ctx context.Context, event *DepositEvent) erro
 	// 如果是真实模式，查链
 	balanceWei := big.NewInt(0)
 	nonce := uint64(0)
-	gasPrice := big.NewInt(20000000000) // 20 Gwei default
 
 	if s.ethClient != nil {
 		// 真实查询
@@ -179,21 +194,24 @@ func (s *SweeperService) sweepETH(ctx context.Context, event *DepositEvent) erro
 			return err
 		}
 		nonce = n
-
-		gp, err := s.ethClient.SuggestGasPrice(ctx)
-		if err == nil {
-			gasPrice = gp
-		}
 	} else {
 		// 模拟: 余额 = 充值金额
 		amountDecimal, _ := decimal.NewFromString(event.Amount)
 		balanceWei = amountDecimal.Mul(decimal.New(1, 18)).BigInt()
 	}
 
+	// 归集不着急，使用慢速档 (模拟模式 / RPC 不可用时为兜底值)
+	estimate, err := s.fees.Estimate(ctx, "ETH")
+	if err != nil {
+		return err
+	}
+	fees := estimate.Quote(fee.LevelSlow).EthFees()
+
 	// D. 计算归集金额
 	// Amount = Balance - (GasLimit * GasPrice)
-	gasLimit := uint64(21000) // 标准转账
-	gasFee := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice)
+	// EIP-1559 交易按 MaxFeePerGas 预留，实际只扣 BaseFee + 小费，差额会留在充值地址上
+	gasLimit := fee.GasLimitTransfer // 标准转账
+	gasFee := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), fees.MaxPrice())
 
 	if balanceWei.Cmp(gasFee) <= 0 {
 		log.Printf("[Sweeper] 余额不足以支付 Gas，跳过归集 (Balance: %s, Fee: %s)", balanceWei, gasFee)
@@ -203,11 +221,10 @@ func (s *SweeperService) sweepETH(ctx context.Context, event *DepositEvent) erro
 	sweepAmount := new(big.Int).Sub(balanceWei, gasFee)
 
 	// E. 构造并签名交易
-	tx := types.NewTransaction(nonce, s.hotWalletAddr, sweepAmount, gasLimit, gasPrice, nil)
+	tx := ethtx.NewTx(s.chainID, nonce, hotWallet, sweepAmount, gasLimit, fees, nil)
 
-	// EIP-155 签名
-	signer := types.NewEIP155Signer(s.chainID)
-	signedTx, err := types.SignTx(tx, signer, ecdsaPrivateKey)
+	// EIP-155 / EIP-1559 签名
+	signedTx, err := types.SignTx(tx, ethtx.Signer(s.chainID), ecdsaPrivateKey)
 	if err != nil {
 		return fmt.Errorf("签名失败: %v", err)
 	}
@@ -230,13 +247,55 @@ func (s *SweeperService) sweepETH(ctx context.Context, event *DepositEvent) erro
 		DepositID:   0, // 暂时不关联，或者查出来关联
 		TxHash:      signedTx.Hash().Hex(),
 		FromAddress: addr.Address,
-		ToAddress:   s.hotWalletAddr.Hex(),
+		ToAddress:   hotWallet.Hex(),
 		Amount:      decimal.NewFromBigInt(sweepAmount, 0),
 		GasFee:      decimal.NewFromBigInt(gasFee, 0),
-		Status:      "pending",
+		Nonce:       nonce,
+		GasLimit:    gasLimit,
+		TxType:      signedTx.Type(),
+		GasPrice:    decimal.NewFromBigInt(fees.MaxPrice(), 0),
+		GasTipCap:   decimalOrZero(fees.GasTipCap),
+		Status:      model.CollectionStatusPending, // 由 TxTracker 跟踪确认
 		CreatedAt:   time.Now(),
 	}
 	s.db.Create(&collection)
 
 	return nil
 }
+
+// tenantAccount 充值地址所属租户的账户密钥 (私钥) 和归集目标热钱包
+// - 未配置 xpub 的租户 (默认租户) 沿用系统主密钥，热钱包未配置时使用全局热钱包
+// - internal 托管的租户: 账户密钥为 m/44'/60'/tenantID'，须与配置的 xpub 一致
+// - external 托管的租户: 私钥不在本系统，ok 为 false，不归集
+func (s *SweeperService) tenantAccount(ctx context.Context, addr *model.Address) (bip32.ExtendedKey, common.Address, bool, error) {
+	hotWallet := s.hotWalletAddr
+	tc, err := LoadTenantChain(ctx, s.db, addr.TenantID, addr.Chain)
+	if err != nil {
+		return nil, hotWallet, false, err
+	}
+	if tc != nil && tc.HotWallet != "" {
+		hotWallet = common.HexToAddress(tc.HotWallet)
+	}
+	if tc == nil || tc.XPub == "" {
+		return s.masterKey, hotWallet, true, nil
+	}
+	if tc.Custody != model.TenantCustodyInternal {
+		log.Printf("[Sweeper] 租户 %d 的私钥由租户自行保管，跳过归集: %s", addr.TenantID, addr.Address)
+		return nil, hotWallet, false, nil
+	}
+
+	account, err := TenantAccountKey(s.masterKey, addr.TenantID, addr.Chain)
+	if err != nil {
+		return nil, hotWallet, false, err
+	}
+	pub, err := account.Neuter()
+	if err != nil {
+		return nil, hotWallet, false, err
+	}
+	if pub.String() != tc.XPub {
+		// 配置的 xpub 不是本系统主密钥派生的，签出来的交易不会被链上接受
+		log.Printf("[Sweeper] ⚠️ 租户 %d 的 xpub 与主密钥派生结果不一致，跳过归集: %s", addr.TenantID, addr.Address)
+		return nil, hotWallet, false, nil
+	}
+	return account, hotWallet, true, nil
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/bip32"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/tenant"
+)
+
+// tenantLimitWindow 租户提现额度的统计窗口 (滚动 24 小时，同 kycLimitWindow)
+const tenantLimitWindow = 24 * time.Hour
+
+// tenantCoinTypes 支持按租户派生地址的链及其 BIP-44 coin_type
+var tenantCoinTypes = map[string]uint32{
+	"BTC": 0,
+	"ETH": 60,
+}
+
+// TenantAccountKey 租户在某条链上的账户密钥 m/44'/coin'/account'，account 即租户 ID
+// master 为系统根私钥，返回的私钥只用于归集签名，Neuter 后的 xpub 交给 AddressService 派生地址
+func TenantAccountKey(master bip32.ExtendedKey, tenantID uint64, chain string) (bip32.ExtendedKey, error) {
+	coin, ok := tenantCoinTypes[strings.ToUpper(chain)]
+	if !ok {
+		return nil, fmt.Errorf("不支持的链: %s", chain)
+	}
+	if tenantID == 0 || tenantID >= uint64(bip32.HardenedKeyStart) {
+		return nil, fmt.Errorf("租户 ID 超出范围: %d", tenantID)
+	}
+	key := master
+	for _, index := range []uint32{44, coin, uint32(tenantID)} {
+		next, err := key.Derive(bip32.HardenedKeyStart + index)
+		if err != nil {
+			return nil, err
+		}
+		key = next
+	}
+	return key, nil
+}
+
+// LoadTenantChain 租户的链配置，未配置时返回 nil
+func LoadTenantChain(ctx context.Context, db *gorm.DB, tenantID uint64, chain string) (*model.TenantChain, error) {
+	var tc model.TenantChain
+	err := db.WithContext(ctx).Where("tenant_id = ? AND chain = ?", tenantID, strings.ToUpper(chain)).First(&tc).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tc, nil
+}
+
+// UserTenantID 查询用户所属租户
+func UserTenantID(ctx context.Context, db *gorm.DB, userID uint64) (uint64, error) {
+	var u model.User
+	if err := db.WithContext(ctx).Select("id", "tenant_id").First(&u, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, errno.ErrUserNotFound
+		}
+		return 0, err
+	}
+	if u.TenantID == 0 {
+		return tenant.DefaultID, nil
+	}
+	return u.TenantID, nil
+}
+
+// CheckTenantWithdrawal 校验租户的提现限额 (单笔最小 / 最大、租户全部用户 24 小时合计)，返回用户所属租户
+// 租户未配置该链时不限制
+// 须在创建提现的事务中调用: 合计额度跨用户，账户行锁挡不住不同用户的并发提现，
+// 这里锁住租户的链配置行，同一租户同一链的提现串行校验，不会突破额度
+func CheckTenantWithdrawal(ctx context.Context, db *gorm.DB, userID uint64, chain string, amount decimal.Decimal) (uint64, error) {
+	tenantID, err := UserTenantID(ctx, db, userID)
+	if err != nil {
+		return 0, err
+	}
+	tc, err := LoadTenantChain(ctx, db.Clauses(clause.Locking{Strength: "UPDATE"}), tenantID, chain)
+	if err != nil || tc == nil {
+		return tenantID, err
+	}
+
+	used := decimal.Zero
+	if tc.DailyWithdrawal.IsPositive() {
+		if used, err = tenantWithdrawnSince(ctx, db, tenantID, chain, time.Now().Add(-tenantLimitWindow)); err != nil {
+			return 0, err
+		}
+	}
+	return tenantID, checkTenantLimits(tc, amount, used)
+}
+
+// checkTenantLimits 单笔限额和 24 小时合计额度，0 表示不限制
+func checkTenantLimits(tc *model.TenantChain, amount, used decimal.Decimal) error {
+	unit := " " + strings.ToUpper(tc.Chain)
+	if tc.MinWithdrawal.IsPositive() && amount.LessThan(tc.MinWithdrawal) {
+		return errno.ErrTenantLimitExceeded.WithMessage("amount is below the minimum withdrawal of " + tc.MinWithdrawal.String() + unit)
+	}
+	if tc.MaxWithdrawal.IsPositive() && amount.GreaterThan(tc.MaxWithdrawal) {
+		return errno.ErrTenantLimitExceeded.WithMessage("amount is above the maximum withdrawal of " + tc.MaxWithdrawal.String() + unit)
+	}
+	if tc.DailyWithdrawal.IsPositive() && used.Add(amount).GreaterThan(tc.DailyWithdrawal) {
+		return errno.ErrTenantLimitExceeded.WithMessage("daily withdrawal capacity is temporarily exhausted, try a smaller amount or later")
+	}
+	return nil
+}
+
+// tenantWithdrawnSince 租户某币种自 since 起的提现总额 (不含已拒绝 / 失败 / 取消的提现，不含站内结算的提现)
+func tenantWithdrawnSince(ctx context.Context, db *gorm.DB, tenantID uint64, chain string, since time.Time) (decimal.Decimal, error) {
+	var sum decimal.NullDecimal
+	err := db.WithContext(ctx).Model(&model.Withdrawal{}).
+		Scopes(tenant.Scope(tenantID)).
+		Select("SUM(amount)").
+		Where("UPPER(chain) = ? AND created_at >= ? AND transfer_id = 0", strings.ToUpper(chain), since).
+		Where("status NOT IN ?", []string{
+			model.WithdrawalStatusRejected,
+			model.WithdrawalStatusFailed,
+			model.WithdrawalStatusCancelled,
+			model.WithdrawalStatusUserCancelled,
+		}).
+		Scan(&sum).Error
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if !sum.Valid {
+		return decimal.Zero, nil
+	}
+	return sum.Decimal, nil
+}
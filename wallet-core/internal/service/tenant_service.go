@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/bip32"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/tenant"
+)
+
+// tenantCacheTTL X-Tenant 代码 -> 租户 ID 的本地缓存时间，停用租户最多延迟这么久生效
+const tenantCacheTTL = time.Minute
+
+// tenantCodePattern 租户代码: 小写字母 / 数字 / 连字符
+var tenantCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,31}$`)
+
+// TenantService 租户管理 (管理后台) 和未登录请求的租户解析
+type TenantService struct {
+	db      *gorm.DB
+	master  bip32.ExtendedKey // 系统根私钥，用于生成托管租户的 xpub；不持有私钥的进程 (user-service) 为 nil
+	network *chaincfg.Params
+
+	mu    sync.RWMutex
+	codes map[string]cachedTenant
+}
+
+type cachedTenant struct {
+	id        uint64
+	status    string
+	expiresAt time.Time
+}
+
+var Tenant *TenantService
+
+func NewTenantService(db *gorm.DB, master bip32.ExtendedKey, network *chaincfg.Params) *TenantService {
+	return &TenantService{db: db, master: master, network: network, codes: make(map[string]cachedTenant)}
+}
+
+// CreateTenant 创建租户
+func (s *TenantService) CreateTenant(ctx context.Context, adminID uint64, code, name string) (*model.Tenant, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if !tenantCodePattern.MatchString(code) {
+		return nil, errno.ErrTenantInvalid.WithMessage("code must be 2-32 lowercase letters, digits or hyphens")
+	}
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&model.Tenant{}).Where("code = ?", code).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errno.ErrTenantExists
+	}
+
+	t := &model.Tenant{Code: code, Name: name, Status: model.TenantStatusActive, CreatedBy: adminID}
+	if err := s.db.WithContext(ctx).Create(t).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errno.ErrTenantExists
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// ListTenants 租户列表
+func (s *TenantService) ListTenants(ctx context.Context) ([]model.Tenant, error) {
+	var list []model.Tenant
+	err := s.db.WithContext(ctx).Order("id ASC").Find(&list).Error
+	return list, err
+}
+
+// SetTenantStatus 启用 / 停用租户，停用后该租户的用户不能再登录和注册 (已签发的 Access Token 到期前仍有效)
+func (s *TenantService) SetTenantStatus(ctx context.Context, tenantID uint64, status string) error {
+	if status != model.TenantStatusActive && status != model.TenantStatusDisabled {
+		return errno.ErrTenantInvalid.WithMessage("status must be active or disabled")
+	}
+	if tenantID == tenant.DefaultID && status == model.TenantStatusDisabled {
+		return errno.ErrTenantInvalid.WithMessage("the default tenant cannot be disabled")
+	}
+	res := s.db.WithContext(ctx).Model(&model.Tenant{}).Where("id = ?", tenantID).
+		Updates(map[string]interface{}{"status": status, "updated_at": time.Now()})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errno.ErrTenantNotFound
+	}
+
+	s.mu.Lock()
+	s.codes = make(map[string]cachedTenant)
+	s.mu.Unlock()
+	return nil
+}
+
+// ResolveTenant 把 X-Tenant 代码解析为租户 ID，空代码为默认租户
+func (s *TenantService) ResolveTenant(ctx context.Context, code string) (uint64, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" {
+		return tenant.DefaultID, nil
+	}
+
+	s.mu.RLock()
+	c, ok := s.codes[code]
+	s.mu.RUnlock()
+	if !ok || time.Now().After(c.expiresAt) {
+		var t model.Tenant
+		if err := s.db.WithContext(ctx).Where("code = ?", code).First(&t).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, errno.ErrTenantNotFound
+			}
+			return 0, err
+		}
+		c = cachedTenant{id: t.ID, status: t.Status, expiresAt: time.Now().Add(tenantCacheTTL)}
+		s.mu.Lock()
+		s.codes[code] = c
+		s.mu.Unlock()
+	}
+
+	if c.status != model.TenantStatusActive {
+		return 0, errno.ErrTenantDisabled
+	}
+	return c.id, nil
+}
+
+// TenantChainInput 租户链配置，XPub 为空时由系统主密钥生成托管 xpub
+type TenantChainInput struct {
+	Chain           string
+	XPub            string
+	HotWallet       string
+	MinWithdrawal   decimal.Decimal
+	MaxWithdrawal   decimal.Decimal
+	DailyWithdrawal decimal.Decimal
+}
+
+// SetTenantChain 新增或修改租户的链配置
+// xpub 一经设置不能修改 (已分配的地址都是从它派生的)；默认租户沿用系统主密钥，不能设置 xpub
+func (s *TenantService) SetTenantChain(ctx context.Context, adminID, tenantID uint64, in TenantChainInput) (*model.TenantChain, error) {
+	in.Chain = strings.ToUpper(in.Chain)
+	if err := validateTenantChainInput(tenantID, in); err != nil {
+		return nil, err
+	}
+	var t model.Tenant
+	if err := s.db.WithContext(ctx).First(&t, tenantID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrTenantNotFound
+		}
+		return nil, err
+	}
+
+	tc, err := LoadTenantChain(ctx, s.db, tenantID, in.Chain)
+	if err != nil {
+		return nil, err
+	}
+	if tc == nil {
+		tc = &model.TenantChain{TenantID: tenantID, Chain: in.Chain}
+	}
+
+	if tenantID != tenant.DefaultID {
+		xpub, custody, err := s.resolveXPub(tenantID, in.Chain, in.XPub)
+		if err != nil {
+			return nil, err
+		}
+		if tc.XPub != "" && tc.XPub != xpub {
+			return nil, errno.ErrTenantXPubLocked
+		}
+		tc.XPub, tc.Custody = xpub, custody
+	}
+	tc.HotWallet = in.HotWallet
+	tc.MinWithdrawal = in.MinWithdrawal
+	tc.MaxWithdrawal = in.MaxWithdrawal
+	tc.DailyWithdrawal = in.DailyWithdrawal
+	tc.UpdatedBy = adminID
+
+	if err := s.db.WithContext(ctx).Save(tc).Error; err != nil {
+		return nil, err
+	}
+	return tc, nil
+}
+
+// ListTenantChains 租户的全部链配置
+func (s *TenantService) ListTenantChains(ctx context.Context, tenantID uint64) ([]model.TenantChain, error) {
+	var list []model.TenantChain
+	err := s.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("chain ASC").Find(&list).Error
+	return list, err
+}
+
+// resolveXPub 确定租户的 xpub 和托管方式
+// 未提供 xpub: 从系统主密钥派生 m/44'/coin'/tenantID' (internal，归集服务可以签名)
+// 提供了 xpub: 与系统派生结果一致为 internal，否则为 external (私钥由租户持有)
+func (s *TenantService) resolveXPub(tenantID uint64, chain, xpub string) (string, string, error) {
+	var internal string
+	if s.master != nil {
+		account, err := TenantAccountKey(s.master, tenantID, chain)
+		if err != nil {
+			return "", "", err
+		}
+		pub, err := account.Neuter()
+		if err != nil {
+			return "", "", err
+		}
+		internal = pub.String()
+	}
+
+	xpub = strings.TrimSpace(xpub)
+	if xpub == "" {
+		if internal == "" {
+			return "", "", errno.ErrTenantInvalid.WithMessage("xpub is required")
+		}
+		return internal, model.TenantCustodyInternal, nil
+	}
+	key, err := bip32.ParseExtendedKey(xpub, s.network)
+	if err != nil || key.IsPrivate() {
+		return "", "", errno.ErrTenantInvalid.WithMessage("xpub must be an account-level extended public key for this network")
+	}
+	if xpub == internal {
+		return xpub, model.TenantCustodyInternal, nil
+	}
+	return xpub, model.TenantCustodyExternal, nil
+}
+
+// validateTenantChainInput 链 / 热钱包地址 / 限额的基本校验
+func validateTenantChainInput(tenantID uint64, in TenantChainInput) error {
+	if _, ok := tenantCoinTypes[in.Chain]; !ok {
+		return errno.ErrTenantInvalid.WithMessage("chain must be BTC or ETH")
+	}
+	if tenantID == tenant.DefaultID && in.XPub != "" {
+		return errno.ErrTenantInvalid.WithMessage("the default tenant derives addresses from the master key")
+	}
+	if in.HotWallet != "" && in.Chain == "ETH" && !common.IsHexAddress(in.HotWallet) {
+		return errno.ErrTenantInvalid.WithMessage("hot_wallet is not a valid ETH address")
+	}
+	for _, v := range []decimal.Decimal{in.MinWithdrawal, in.MaxWithdrawal, in.DailyWithdrawal} {
+		if v.IsNegative() {
+			return errno.ErrTenantInvalid.WithMessage("limits must not be negative")
+		}
+	}
+	if in.MaxWithdrawal.IsPositive() && in.MinWithdrawal.GreaterThan(in.MaxWithdrawal) {
+		return errno.ErrTenantInvalid.WithMessage("min_withdrawal must not exceed max_withdrawal")
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/bip32"
+	"wallet-core/pkg/database"
+	"wallet-core/pkg/errno"
+)
+
+func TestTenantAccountKey(t *testing.T) {
+	w, err := bip32.NewMasterKeyFromSeed(bytes.Repeat([]byte{1}, 32), &chaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	key, err := TenantAccountKey(w.MasterKey(), 2, "eth")
+	require.NoError(t, err)
+	expected, err := w.DerivePath("m/44'/60'/2'")
+	require.NoError(t, err)
+	assert.Equal(t, expected.String(), key.String())
+
+	// 不同租户 / 不同链的账户互不相同
+	other, err := TenantAccountKey(w.MasterKey(), 3, "ETH")
+	require.NoError(t, err)
+	assert.NotEqual(t, key.String(), other.String())
+
+	// 交给 AddressService 的 xpub 能被重新解析为公钥
+	pub, err := key.Neuter()
+	require.NoError(t, err)
+	parsed, err := bip32.ParseExtendedKey(pub.String(), &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	assert.False(t, parsed.IsPrivate())
+
+	_, err = TenantAccountKey(w.MasterKey(), 2, "TRX")
+	assert.Error(t, err)
+	_, err = TenantAccountKey(w.MasterKey(), 0, "ETH")
+	assert.Error(t, err)
+}
+
+func TestCheckTenantLimits(t *testing.T) {
+	tc := &model.TenantChain{
+		Chain:           "ETH",
+		MinWithdrawal:   decimal.RequireFromString("0.01"),
+		MaxWithdrawal:   decimal.NewFromInt(5),
+		DailyWithdrawal: decimal.NewFromInt(10),
+	}
+
+	assert.NoError(t, checkTenantLimits(tc, decimal.NewFromInt(1), decimal.Zero))
+	assert.NoError(t, checkTenantLimits(tc, decimal.NewFromInt(5), decimal.NewFromInt(5)))
+
+	for _, c := range []struct{ amount, used string }{
+		{"0.001", "0"}, // 低于单笔下限
+		{"6", "0"},     // 超过单笔上限
+		{"2", "9"},     // 超过 24 小时合计
+	} {
+		err := checkTenantLimits(tc, decimal.RequireFromString(c.amount), decimal.RequireFromString(c.used))
+		var e errno.Errno
+		require.True(t, errors.As(err, &e), "amount %s used %s", c.amount, c.used)
+		assert.Equal(t, errno.ErrTenantLimitExceeded.Code, e.Code)
+	}
+
+	// 0 表示不限制
+	assert.NoError(t, checkTenantLimits(&model.TenantChain{Chain: "ETH"}, decimal.NewFromInt(1000), decimal.NewFromInt(1000)))
+}
+
+func TestValidateTenantChainInput(t *testing.T) {
+	valid := TenantChainInput{Chain: "ETH", HotWallet: "0x00000000000000000000000000000000000000aa", MaxWithdrawal: decimal.NewFromInt(5)}
+	assert.NoError(t, validateTenantChainInput(2, valid))
+
+	cases := map[string]func(in *TenantChainInput) uint64{
+		"unsupported chain": func(in *TenantChainInput) uint64 { in.Chain = "TRX"; return 2 },
+		"default xpub":      func(in *TenantChainInput) uint64 { in.XPub = "xpub"; return 1 },
+		"bad hot wallet":    func(in *TenantChainInput) uint64 { in.HotWallet = "0x123"; return 2 },
+		"negative limit":    func(in *TenantChainInput) uint64 { in.DailyWithdrawal = decimal.NewFromInt(-1); return 2 },
+		"min above max":     func(in *TenantChainInput) uint64 { in.MinWithdrawal = decimal.NewFromInt(6); return 2 },
+	}
+	for name, mutate := range cases {
+		in := valid
+		tenantID := mutate(&in)
+		assert.Error(t, validateTenantChainInput(tenantID, in), name)
+	}
+}
+
+func TestCheckTenantWithdrawalLocksTenantChain(t *testing.T) {
+	db := dryRunDB(t)
+	var tenantSQL string
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:fill", func(tx *gorm.DB) {
+		switch dest := tx.Statement.Dest.(type) {
+		case *model.User:
+			dest.TenantID = 2
+			tx.RowsAffected = 1
+		case *model.TenantChain:
+			tenantSQL = tx.Statement.SQL.String()
+			*dest = model.TenantChain{ID: 1, TenantID: 2, Chain: "ETH", MaxWithdrawal: decimal.NewFromInt(5)}
+			tx.RowsAffected = 1
+		}
+	}))
+
+	tenantID, err := CheckTenantWithdrawal(context.Background(), db, 7, "eth", decimal.NewFromInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), tenantID)
+	// 不同用户的并发提现在租户链配置行上串行
+	assert.Contains(t, tenantSQL, `FROM "tenant_chains" WHERE tenant_id = $1 AND chain = $2`)
+	assert.Contains(t, tenantSQL, "FOR UPDATE")
+
+	_, err = CheckTenantWithdrawal(context.Background(), db, 7, "ETH", decimal.NewFromInt(6))
+	var e errno.Errno
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, errno.ErrTenantLimitExceeded.Code, e.Code)
+}
+
+func TestCreateTenantDuplicateCode(t *testing.T) {
+	// 与 ConnectPostgres 相同的配置 (TranslateError)，只生成 SQL
+	cfg := database.GormConfig()
+	cfg.DryRun, cfg.DisableAutomaticPing, cfg.SkipDefaultTransaction = true, true, true
+	cfg.Logger = logger.Discard
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), cfg)
+	require.NoError(t, err)
+	// 并发创建同一代码: 查重通过，插入时撞上唯一索引
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:duplicate", func(tx *gorm.DB) {
+		tx.AddError(&pgconn.PgError{Code: "23505", Message: `duplicate key value violates unique constraint "idx_tenants_code"`})
+	}))
+
+	_, err = NewTenantService(db, nil, &chaincfg.MainNetParams).CreateTenant(context.Background(), 1, "acme", "Acme")
+	var e errno.Errno
+	require.True(t, errors.As(err, &e), "got %v", err)
+	assert.Equal(t, errno.ErrTenantExists.Code, e.Code)
+}
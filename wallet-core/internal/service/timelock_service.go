@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"wallet-core/internal/model"
+	"wallet-core/internal/worker"
+	"wallet-core/internal/worker/tasks"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/mail"
+)
+
+// TimeLockService 定时 / 时间锁提现
+// 1. 创建时计算 ExecuteAfter: 用户预约时间，或大额提现的强制延迟 (取较晚者)
+// 2. 审批通过后若未到期进入 time_locked，到期由 asynq 延时任务放行到 pending_broadcast
+// 3. 锁定开始和执行前各发一封邮件到用户的注册邮箱，执行前用户可以取消
+// 4. 后台定时扫描已到期但仍为 time_locked 的提现 (asynq 任务丢失时兜底)
+type TimeLockService struct {
+	db       *gorm.DB
+	client   *worker.Client // nil 时不投递异步任务，只依赖定时扫描放行
+	mailer   mail.Sender    // 通知邮件发送器，只有运行 Asynq Worker 的进程需要; nil 时不发邮件
+	interval time.Duration
+}
+
+var TimeLock *TimeLockService
+
+func NewTimeLockService(db *gorm.DB, client *worker.Client, mailer mail.Sender) *TimeLockService {
+	return &TimeLockService{
+		db:       db,
+		client:   client,
+		mailer:   mailer,
+		interval: 1 * time.Minute,
+	}
+}
+
+// 用户可以取消的状态 (尚未进入广播队列)
+var userCancelableStatuses = []string{
+	model.WithdrawalStatusPendingReview,
+	model.WithdrawalStatusRiskHold,
+	model.WithdrawalStatusTimeLocked,
+}
+
+// LockUntil 计算提现最早执行时间，返回 nil 表示审批后立即执行
+// - requested: 用户预约的执行时间，必须在 (now, now+maxAhead] 之间
+// - 金额达到链配置的 time_lock_amount 时，至少延迟 time_lock_delay
+func LockUntil(chain config.ChainConfig, amount decimal.Decimal, requested *time.Time, maxAhead time.Duration, now time.Time) (*time.Time, error) {
+	var until *time.Time
+	if requested != nil {
+		if !requested.After(now) {
+			return nil, errno.ErrScheduleInvalid.WithMessage("execute_after must be in the future")
+		}
+		if maxAhead > 0 && requested.Sub(now) > maxAhead {
+			return nil, errno.ErrScheduleInvalid.WithMessage(fmt.Sprintf("execute_after cannot be more than %s ahead", maxAhead))
+		}
+		t := *requested
+		until = &t
+	}
+
+	if chain.TimeLockAmount > 0 && chain.TimeLockDelay > 0 &&
+		amount.GreaterThanOrEqual(decimal.NewFromFloat(chain.TimeLockAmount)) {
+		earliest := now.Add(chain.TimeLockDelay)
+		if until == nil || until.Before(earliest) {
+			until = &earliest
+		}
+	}
+	return until, nil
+}
+
+// ApplyTimeLock 创建提现前写入 ExecuteAfter
+func ApplyTimeLock(w *model.Withdrawal, requested *time.Time) error {
+	until, err := LockUntil(config.Chain(w.Chain), w.Amount, requested, config.Global.Withdrawal.MaxScheduleAhead, time.Now())
+	if err != nil {
+		return err
+	}
+	w.ExecuteAfter = until
+	return nil
+}
+
+// ApprovedStatus 审批通过后的状态: 时间锁未到期进入 time_locked，否则直接进入广播队列
+func ApprovedStatus(w *model.Withdrawal, now time.Time) string {
+	if w.ExecuteAfter != nil && w.ExecuteAfter.After(now) {
+		return model.WithdrawalStatusTimeLocked
+	}
+	return model.WithdrawalStatusPendingBroadcast
+}
+
+// Schedule 提现创建后投递时间锁相关任务: 锁定通知、执行前提醒、到期放行
+// 投递失败只记录日志，到期放行由定时扫描兜底
+func (s *TimeLockService) Schedule(ctx context.Context, w *model.Withdrawal) {
+	if s == nil || s.client == nil || w.ExecuteAfter == nil {
+		return
+	}
+
+	s.enqueue(tasks.NewWithdrawalLockNoticeTask(w.ID))
+	if remindAt := w.ExecuteAfter.Add(-config.Global.Withdrawal.ReminderBefore); remindAt.After(time.Now()) {
+		s.enqueue(tasks.NewWithdrawalReminderTask(w.ID, remindAt))
+	}
+	s.ScheduleRelease(w)
+}
+
+// ScheduleRelease 投递到期放行任务 (同一笔提现重复投递会被 TaskID 去重)
+func (s *TimeLockService) ScheduleRelease(w *model.Withdrawal) {
+	if s == nil || s.client == nil || w.ExecuteAfter == nil {
+		return
+	}
+	s.enqueue(tasks.NewWithdrawalReleaseTask(w.ID, *w.ExecuteAfter))
+}
+
+func (s *TimeLockService) enqueue(task *asynq.Task, err error) {
+	if err != nil {
+		log.Printf("[TimeLock] 创建任务失败: %v", err)
+		return
+	}
+	if _, err := s.client.Enqueue(task); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		log.Printf("[TimeLock] 投递任务 %s 失败: %v", task.Type(), err)
+	}
+}
+
+// HandleReleaseTask asynq 处理器: 时间锁到期放行
+func (s *TimeLockService) HandleReleaseTask(ctx context.Context, t *asynq.Task) error {
+	p, err := tasks.ParseWithdrawalPayload(t)
+	if err != nil {
+		return err
+	}
+	_, err = s.release(ctx, p.WithdrawalID)
+	return err
+}
+
+// HandleLockNoticeTask asynq 处理器: 锁定开始通知
+func (s *TimeLockService) HandleLockNoticeTask(ctx context.Context, t *asynq.Task) error {
+	return s.sendNotice(ctx, t, lockNoticeMessage)
+}
+
+// HandleReminderTask asynq 处理器: 执行前提醒
+func (s *TimeLockService) HandleReminderTask(ctx context.Context, t *asynq.Task) error {
+	return s.sendNotice(ctx, t, reminderMessage)
+}
+
+// sendNotice 把时间锁通知发到用户的注册邮箱 (提现已取消 / 已拒绝 / 已放行则不发)
+// 发送失败返回错误，由 asynq 重试
+func (s *TimeLockService) sendNotice(ctx context.Context, t *asynq.Task, render func(*model.User, *model.Withdrawal) mail.Message) error {
+	p, err := tasks.ParseWithdrawalPayload(t)
+	if err != nil {
+		return err
+	}
+
+	var w model.Withdrawal
+	if err := s.db.WithContext(ctx).First(&w, p.WithdrawalID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("withdrawal %d not found: %w", p.WithdrawalID, asynq.SkipRetry)
+		}
+		return err
+	}
+	if !isUserCancelable(w.Status) || w.ExecuteAfter == nil {
+		return nil
+	}
+
+	var user model.User
+	if err := s.db.WithContext(ctx).First(&user, w.UserID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("user %d not found: %w", w.UserID, asynq.SkipRetry)
+		}
+		return err
+	}
+	if s.mailer == nil || user.Email == "" {
+		log.Printf("[TimeLock] 提现 #%d 的通知邮件未发送 (未配置发送器或用户没有邮箱)", w.ID)
+		return nil
+	}
+	return s.mailer.Send(ctx, render(&user, &w))
+}
+
+func lockNoticeMessage(u *model.User, w *model.Withdrawal) mail.Message {
+	return mail.Message{
+		To:      u.Email,
+		Subject: fmt.Sprintf("提现 #%d 已锁定", w.ID),
+		Body: fmt.Sprintf("%s，您好:\n\n您的提现 #%d (%s %s，收款地址 %s) 已锁定，将于 %s 之后执行。\n执行前您可以随时在提现记录中取消。如果这不是您本人的操作，请立即取消并修改密码。\n",
+			u.Username, w.ID, w.Amount, w.Chain, w.ToAddress, w.ExecuteAfter.Format(time.RFC3339)),
+	}
+}
+
+func reminderMessage(u *model.User, w *model.Withdrawal) mail.Message {
+	return mail.Message{
+		To:      u.Email,
+		Subject: fmt.Sprintf("提现 #%d 即将执行", w.ID),
+		Body: fmt.Sprintf("%s，您好:\n\n您的提现 #%d (%s %s，收款地址 %s) 将于 %s 执行。\n如果这不是您本人的操作，请在执行前立即取消并修改密码。\n",
+			u.Username, w.ID, w.Amount, w.Chain, w.ToAddress, w.ExecuteAfter.Format(time.RFC3339)),
+	}
+}
+
+// release 已到期的 time_locked 提现进入广播队列 (条件更新，重复执行无副作用)
+func (s *TimeLockService) release(ctx context.Context, id uint64) (bool, error) {
+	res := s.db.WithContext(ctx).Model(&model.Withdrawal{}).
+		Where("id = ? AND status = ? AND execute_after <= ?", id, model.WithdrawalStatusTimeLocked, time.Now()).
+		Update("status", model.WithdrawalStatusPendingBroadcast)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected > 0 {
+		log.Printf("[TimeLock] 提现 #%d 时间锁到期，进入广播队列", id)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// Start 定时扫描已到期的 time_locked 提现 (阻塞，直到 ctx 取消)
+func (s *TimeLockService) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			var ids []uint64
+			if err := s.db.WithContext(ctx).Model(&model.Withdrawal{}).
+				Where("status = ? AND execute_after <= ?", model.WithdrawalStatusTimeLocked, time.Now()).
+				Limit(100).Pluck("id", &ids).Error; err != nil {
+				log.Printf("[TimeLock] 扫描到期提现失败: %v", err)
+				continue
+			}
+			for _, id := range ids {
+				if _, err := s.release(ctx, id); err != nil {
+					log.Printf("[TimeLock] 放行提现 #%d 失败: %v", id, err)
+				}
+			}
+		}
+	}
+}
+
+// Cancel 用户取消尚未执行的提现
+func (s *TimeLockService) Cancel(ctx context.Context, userID, id uint64) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var w model.Withdrawal
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", id, userID).
+			First(&w).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.ErrWithdrawalNotFound
+			}
+			return err
+		}
+
+		if !isUserCancelable(w.Status) {
+			return errno.ErrWithdrawalNotCancelable
+		}
+
+		log.Printf("[TimeLock] 用户 %d 取消提现 #%d (原状态 %s)", userID, w.ID, w.Status)
+		if err := tx.Model(&w).Update("status", model.WithdrawalStatusUserCancelled).Error; err != nil {
+			return err
+		}
+		// 冻结资金退回可用余额
+		return ReleaseWithdrawalFunds(tx, &w)
+	})
+}
+
+func isUserCancelable(status string) bool {
+	for _, st := range userCancelableStatuses {
+		if status == st {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/mail"
+)
+
+func TestLockUntil(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	chain := config.ChainConfig{TimeLockAmount: 10, TimeLockDelay: 24 * time.Hour}
+	maxAhead := 30 * 24 * time.Hour
+	at := func(d time.Duration) *time.Time { t := now.Add(d); return &t }
+
+	// 小额、未预约: 审批后立即执行
+	until, err := LockUntil(chain, decimal.NewFromInt(1), nil, maxAhead, now)
+	require.NoError(t, err)
+	assert.Nil(t, until)
+
+	// 预约时间
+	until, err = LockUntil(chain, decimal.NewFromInt(1), at(2*time.Hour), maxAhead, now)
+	require.NoError(t, err)
+	assert.Equal(t, *at(2 * time.Hour), *until)
+
+	// 大额: 强制延迟，预约时间更早时以强制延迟为准
+	until, err = LockUntil(chain, decimal.NewFromInt(10), at(2*time.Hour), maxAhead, now)
+	require.NoError(t, err)
+	assert.Equal(t, *at(24 * time.Hour), *until)
+
+	// 大额 + 更晚的预约时间
+	until, err = LockUntil(chain, decimal.NewFromInt(10), at(48*time.Hour), maxAhead, now)
+	require.NoError(t, err)
+	assert.Equal(t, *at(48 * time.Hour), *until)
+
+	// 非法预约: 过去的时间 / 超过最大预约范围
+	_, err = LockUntil(chain, decimal.NewFromInt(1), at(-time.Minute), maxAhead, now)
+	assert.Equal(t, errno.ErrScheduleInvalid.Code, err.(errno.Errno).Code)
+	_, err = LockUntil(chain, decimal.NewFromInt(1), at(31*24*time.Hour), maxAhead, now)
+	assert.Error(t, err)
+}
+
+func TestApprovedStatus(t *testing.T) {
+	now := time.Now()
+	later, earlier := now.Add(time.Hour), now.Add(-time.Hour)
+
+	assert.Equal(t, model.WithdrawalStatusPendingBroadcast, ApprovedStatus(&model.Withdrawal{}, now))
+	assert.Equal(t, model.WithdrawalStatusTimeLocked, ApprovedStatus(&model.Withdrawal{ExecuteAfter: &later}, now))
+	assert.Equal(t, model.WithdrawalStatusPendingBroadcast, ApprovedStatus(&model.Withdrawal{ExecuteAfter: &earlier}, now))
+}
+
+func TestTimeLockNoticeGoesToUserEmail(t *testing.T) {
+	at := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	u := &model.User{Username: "alice", Email: "alice@example.com"}
+	w := &model.Withdrawal{ID: 7, Amount: decimal.NewFromInt(3), Chain: "ETH", ToAddress: "0xabc", ExecuteAfter: &at}
+
+	for _, msg := range []mail.Message{lockNoticeMessage(u, w), reminderMessage(u, w)} {
+		assert.Equal(t, "alice@example.com", msg.To)
+		assert.Contains(t, msg.Subject, "#7")
+		assert.Contains(t, msg.Body, "0xabc")
+		assert.Contains(t, msg.Body, at.Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,479 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"wallet-core/internal/event"
+	"wallet-core/internal/model"
+	"wallet-core/internal/service/mfa"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/tenant"
+)
+
+const (
+	transferLimitWindow     = 24 * time.Hour
+	transferMaxMemo         = 255
+	transferMaxKey          = 64
+	transferDefaultRetries  = 3
+	transferDefaultPageSize = 20
+)
+
+// errVersionConflict 账户在读取之后被并发修改 (乐观锁)，整个事务重试
+var errVersionConflict = errors.New("account version conflict")
+
+// TransferService 站内转账
+// 余额变更使用 Account.Version 乐观锁: 读账户不加锁，更新时带上读到的版本号，
+// 影响行数为 0 说明账户已被其他请求修改，回滚后重新读取重试 (最多 MaxRetries 次)
+type TransferService struct {
+	db  *gorm.DB
+	mfa *mfa.Service // 两步验证 (用户发起转账)
+	cfg config.TransferConfig
+}
+
+var Transfer *TransferService
+
+func NewTransferService(db *gorm.DB, mfaSvc *mfa.Service, cfg config.TransferConfig) *TransferService {
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = transferDefaultRetries
+	}
+	return &TransferService{db: db, mfa: mfaSvc, cfg: cfg}
+}
+
+// TransferInput 发起站内转账的参数
+type TransferInput struct {
+	FromUserID     uint64
+	ToUserID       uint64
+	Currency       string
+	Amount         decimal.Decimal
+	Memo           string
+	IdempotencyKey string // 可选，同一用户同一个键只会转账一次
+}
+
+// Send 用户发起站内转账: 两步验证 -> 按用户名或邮箱查找同租户的收款用户 -> Create
+// 已开启两步验证的用户必须提供 totpCode
+func (s *TransferService) Send(ctx context.Context, in TransferInput, to, totpCode string) (*model.Transfer, error) {
+	if err := s.mfa.Require(ctx, in.FromUserID, totpCode); err != nil {
+		return nil, err
+	}
+	tenantID, err := UserTenantID(ctx, s.db, in.FromUserID)
+	if err != nil {
+		return nil, err
+	}
+	if in.ToUserID, err = s.ResolveRecipient(ctx, tenantID, to); err != nil {
+		return nil, err
+	}
+	return s.Create(ctx, in)
+}
+
+// Create 发起站内转账 (调用方已完成两步验证)
+// 带幂等键的重复请求返回首次创建的记录；同一个键用于不同参数时返回 ErrIdempotencyKeyReused
+func (s *TransferService) Create(ctx context.Context, in TransferInput) (*model.Transfer, error) {
+	in.Currency = strings.ToUpper(in.Currency)
+	in.Memo = strings.TrimSpace(in.Memo)
+	if err := checkTransferInput(in); err != nil {
+		return nil, err
+	}
+	hash := transferRequestHash(in)
+
+	if in.IdempotencyKey != "" {
+		if t, err := s.findIdempotent(ctx, in.FromUserID, in.IdempotencyKey, hash); t != nil || err != nil {
+			return t, err
+		}
+	}
+
+	tenantID, err := s.sameTenant(ctx, in.FromUserID, in.ToUserID)
+	if err != nil {
+		return nil, err
+	}
+	// 实名认证等级: 转出方需允许提现，双方都可用该币种
+	if err := CheckKYCTransfer(ctx, s.db, in.FromUserID, in.ToUserID, in.Currency); err != nil {
+		return nil, err
+	}
+
+	t := &model.Transfer{
+		TenantID:       tenantID,
+		FromUserID:     in.FromUserID,
+		ToUserID:       in.ToUserID,
+		Currency:       in.Currency,
+		Amount:         in.Amount,
+		Memo:           in.Memo,
+		Source:         model.TransferSourceTransfer,
+		IdempotencyKey: in.IdempotencyKey,
+		RequestHash:    hash,
+	}
+	err = s.execute(ctx, t, nil)
+	if errors.Is(err, gorm.ErrDuplicatedKey) && in.IdempotencyKey != "" {
+		// 同一个键的并发请求，另一个已经成功
+		if existing, ferr := s.findIdempotent(ctx, in.FromUserID, in.IdempotencyKey, hash); existing != nil || ferr != nil {
+			return existing, ferr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SettleWithdrawal 提现的收款地址属于同租户的另一个用户时，不上链，改为站内转账
+// w 需已填好 UserID / ToAddress / Amount / Chain，与转账记录在同一事务内以 completed 状态创建
+// 收款地址不属于本平台用户 (或属于其他租户 / 商户账单) 时 settled 为 false，调用方照常走链上提现
+// 调用方须先完成时间锁和风控评分，只对 SettleInstantly 的提现调用
+func (s *TransferService) SettleWithdrawal(ctx context.Context, w *model.Withdrawal) (settled bool, err error) {
+	currency := strings.ToUpper(w.Chain)
+	toUserID, err := s.addressOwner(ctx, currency, w.ToAddress)
+	if err != nil || toUserID == 0 {
+		return false, err
+	}
+	if toUserID == w.UserID {
+		return false, errno.ErrTransferSelf.WithMessage("destination address belongs to your own account")
+	}
+
+	fromTenant, err := UserTenantID(ctx, s.db, w.UserID)
+	if err != nil {
+		return false, err
+	}
+	toTenant, err := UserTenantID(ctx, s.db, toUserID)
+	if err != nil || toTenant != fromTenant {
+		// 其他租户 (品牌) 的地址按外部地址处理
+		return false, nil
+	}
+
+	in := TransferInput{FromUserID: w.UserID, ToUserID: toUserID, Currency: currency, Amount: w.Amount}
+	if err := checkTransferInput(in); err != nil {
+		return false, err
+	}
+	// 站内结算同样要满足实名认证等级 (不经过链上提现的 KYC 校验)
+	if err := CheckKYCTransfer(ctx, s.db, w.UserID, toUserID, currency); err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	w.TenantID = fromTenant
+	w.Status = model.WithdrawalStatusCompleted
+	w.RequiredApprovals = 0
+	w.ConfirmedAt = &now
+	t := &model.Transfer{
+		TenantID:   fromTenant,
+		FromUserID: w.UserID,
+		ToUserID:   toUserID,
+		Currency:   currency,
+		Amount:     w.Amount,
+		Source:     model.TransferSourceWithdrawal,
+		ToAddress:  w.ToAddress,
+	}
+	if err := s.execute(ctx, t, w); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Get 查询转账记录，只有转出方和转入方可以查看
+func (s *TransferService) Get(ctx context.Context, userID, id uint64) (*model.Transfer, error) {
+	var t model.Transfer
+	err := s.db.WithContext(ctx).
+		Where("id = ? AND (from_user_id = ? OR to_user_id = ?)", id, userID, userID).
+		First(&t).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// List 用户的转账记录 (转出和转入)，按 ID 倒序，翻页时 beforeID 传上一页最后一条的 ID
+func (s *TransferService) List(ctx context.Context, userID uint64, currency string, beforeID uint64, limit int) ([]model.Transfer, error) {
+	if limit <= 0 || limit > 100 {
+		limit = transferDefaultPageSize
+	}
+	q := s.db.WithContext(ctx).Where("from_user_id = ? OR to_user_id = ?", userID, userID)
+	if currency != "" {
+		q = q.Where("currency = ?", strings.ToUpper(currency))
+	}
+	if beforeID > 0 {
+		q = q.Where("id < ?", beforeID)
+	}
+	var list []model.Transfer
+	err := q.Order("id DESC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+// ResolveRecipient 按用户名或邮箱在租户内查找收款用户
+func (s *TransferService) ResolveRecipient(ctx context.Context, tenantID uint64, to string) (uint64, error) {
+	to = strings.TrimSpace(to)
+	if to == "" {
+		return 0, errno.ErrTransferRecipientNotFound
+	}
+	var u model.User
+	err := s.db.WithContext(ctx).Select("id").Scopes(tenant.Scope(tenantID)).
+		Where("username = ? OR email = ?", to, to).First(&u).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, errno.ErrTransferRecipientNotFound
+	}
+	return u.ID, err
+}
+
+// execute 执行转账，版本号冲突时整个事务重试
+// w 不为 nil 时 (提现转站内结算) 提现记录与转账记录在同一事务内创建并互相关联
+func (s *TransferService) execute(ctx context.Context, t *model.Transfer, w *model.Withdrawal) error {
+	var err error
+	for attempt := 0; attempt <= s.cfg.MaxRetries; attempt++ {
+		t.ID = 0
+		if w != nil {
+			w.ID, w.TransferID = 0, 0
+		}
+		err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return s.apply(tx, t, w)
+		})
+		if !errors.Is(err, errVersionConflict) {
+			return err
+		}
+	}
+	return errno.ErrTransferConflict
+}
+
+// apply 一次转账事务: 校验额度 -> 扣减转出账户 -> 增加转入账户 -> 写转账记录 / 提现记录 / 事件
+func (s *TransferService) apply(tx *gorm.DB, t *model.Transfer, w *model.Withdrawal) error {
+	from, err := loadAccount(tx, t.FromUserID, t.Currency)
+	if err != nil {
+		return err
+	}
+	if from == nil {
+		return errno.ErrAccountNotFound
+	}
+	if from.Balance.LessThan(t.Amount) {
+		return errno.ErrInsufficientBalance
+	}
+	to, err := s.ensureAccount(tx, t.ToUserID, t.Currency)
+	if err != nil {
+		return err
+	}
+
+	// 24 小时额度在事务内统计: 同一用户的并发转账都要更新转出账户，版本号保证只有一个先提交，
+	// 另一个冲突重试时能看到已提交的转账
+	used, err := transferredSince(tx, t.FromUserID, t.Currency, time.Now().Add(-transferLimitWindow))
+	if err != nil {
+		return err
+	}
+	if err := s.checkLimits(t.Currency, t.Amount, used); err != nil {
+		return err
+	}
+
+	// 按账户 ID 顺序更新，两个用户互相转账时不会死锁
+	debit := func() error { return updateBalance(tx, from, t.Amount.Neg()) }
+	credit := func() error { return updateBalance(tx, to, t.Amount) }
+	first, second := debit, credit
+	if to.ID < from.ID {
+		first, second = credit, debit
+	}
+	if err := first(); err != nil {
+		return err
+	}
+	if err := second(); err != nil {
+		return err
+	}
+
+	if w != nil {
+		if err := tx.Create(w).Error; err != nil {
+			return err
+		}
+		t.WithdrawalID = w.ID
+	}
+	if err := tx.Create(t).Error; err != nil {
+		return err
+	}
+	if w != nil {
+		w.TransferID = t.ID
+		if err := tx.Model(w).Update("transfer_id", t.ID).Error; err != nil {
+			return err
+		}
+		// 对提现方而言提现已完成，沿用链上提现的完成事件 (推送 / 商户通知)
+		if err := model.CreateOutboxMessage(tx, event.TopicWithdrawalConfirmed, event.WithdrawalConfirmedEvent{
+			WithdrawalID: w.ID,
+			UserID:       w.UserID,
+			Chain:        w.Chain,
+			Amount:       w.Amount.String(),
+		}); err != nil {
+			return err
+		}
+	}
+	return model.CreateOutboxMessage(tx, event.TopicTransfer, event.TransferEvent{
+		TransferID:   t.ID,
+		FromUserID:   t.FromUserID,
+		ToUserID:     t.ToUserID,
+		Currency:     t.Currency,
+		Amount:       t.Amount.String(),
+		WithdrawalID: t.WithdrawalID,
+	})
+}
+
+// checkLimits 单笔最小 / 最大金额和 24 小时转出合计，未配置的币种不限制
+func (s *TransferService) checkLimits(currency string, amount, used decimal.Decimal) error {
+	key := strings.ToLower(currency)
+	unit := " " + strings.ToUpper(currency)
+	if v := s.cfg.MinAmount[key]; v > 0 && amount.LessThan(decimal.NewFromFloat(v)) {
+		return errno.ErrTransferLimitExceeded.WithMessage("amount is below the minimum transfer of " + decimal.NewFromFloat(v).String() + unit)
+	}
+	if v := s.cfg.MaxAmount[key]; v > 0 && amount.GreaterThan(decimal.NewFromFloat(v)) {
+		return errno.ErrTransferLimitExceeded.WithMessage("amount is above the maximum transfer of " + decimal.NewFromFloat(v).String() + unit)
+	}
+	if v := s.cfg.DailyLimit[key]; v > 0 && used.Add(amount).GreaterThan(decimal.NewFromFloat(v)) {
+		remaining := decimal.Max(decimal.NewFromFloat(v).Sub(used), decimal.Zero)
+		return errno.ErrTransferLimitExceeded.WithMessage("daily transfer limit exceeded, remaining " + remaining.String() + unit)
+	}
+	return nil
+}
+
+// ensureAccount 读取收款账户，不存在时创建 (并发创建由唯一索引兜底)
+func (s *TransferService) ensureAccount(tx *gorm.DB, userID uint64, currency string) (*model.Account, error) {
+	acc, err := loadAccount(tx, userID, currency)
+	if err != nil || acc != nil {
+		return acc, err
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.Account{UserID: userID, Currency: currency}).Error; err != nil {
+		return nil, err
+	}
+	acc, err = loadAccount(tx, userID, currency)
+	if err == nil && acc == nil {
+		err = fmt.Errorf("account for user %d %s not created", userID, currency)
+	}
+	return acc, err
+}
+
+func (s *TransferService) findIdempotent(ctx context.Context, fromUserID uint64, key, hash string) (*model.Transfer, error) {
+	var t model.Transfer
+	err := s.db.WithContext(ctx).
+		Where("from_user_id = ? AND idempotency_key = ?", fromUserID, key).
+		First(&t).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if t.RequestHash != hash {
+		return nil, errno.ErrIdempotencyKeyReused
+	}
+	return &t, nil
+}
+
+// sameTenant 转出 / 转入用户必须属于同一租户，返回租户 ID
+// 其他租户的用户按不存在处理，不暴露账号信息
+func (s *TransferService) sameTenant(ctx context.Context, fromUserID, toUserID uint64) (uint64, error) {
+	fromTenant, err := UserTenantID(ctx, s.db, fromUserID)
+	if err != nil {
+		return 0, err
+	}
+	toTenant, err := UserTenantID(ctx, s.db, toUserID)
+	if errors.Is(err, errno.ErrUserNotFound) || (err == nil && toTenant != fromTenant) {
+		return 0, errno.ErrTransferRecipientNotFound
+	}
+	return fromTenant, err
+}
+
+// addressOwner 本平台分配给用户的充值地址的所属用户，不是本平台地址或是商户账单地址 (UserID 0) 时返回 0
+func (s *TransferService) addressOwner(ctx context.Context, chain, address string) (uint64, error) {
+	var addr model.Address
+	err := s.db.WithContext(ctx).Select("id", "user_id").
+		Where("chain = ? AND address = ?", chain, address).First(&addr).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	return addr.UserID, err
+}
+
+// checkTransferInput 参数校验: 金额为正且不超过币种精度、不能转给自己、备注 / 幂等键长度
+func checkTransferInput(in TransferInput) error {
+	if in.FromUserID == in.ToUserID {
+		return errno.ErrTransferSelf
+	}
+	if !in.Amount.IsPositive() {
+		return errno.ErrAmountInvalid
+	}
+	a, ok := LookupAsset(in.Currency)
+	if !ok {
+		return errno.ErrTransferInvalid.WithMessage("unsupported currency " + in.Currency)
+	}
+	if a.Decimals > 0 && !in.Amount.Equal(in.Amount.Truncate(a.Decimals)) {
+		return errno.ErrAmountInvalid.WithMessage("amount has more than " + decimal.NewFromInt32(a.Decimals).String() + " decimal places")
+	}
+	if len(in.Memo) > transferMaxMemo {
+		return errno.ErrTransferInvalid.WithMessage("memo is too long")
+	}
+	if len(in.IdempotencyKey) > transferMaxKey {
+		return errno.ErrTransferInvalid.WithMessage("idempotency key is too long")
+	}
+	return nil
+}
+
+// transferRequestHash 请求参数摘要，用于判断同一个幂等键是否被用于不同的请求
+// 金额按数值比较 (1.50 与 1.5 视为相同)
+func transferRequestHash(in TransferInput) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%s|%s", in.ToUserID, strings.ToUpper(in.Currency), in.Amount.String(), in.Memo)))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadAccount 读取账户 (不加锁，更新时由版本号判断是否被修改)，不存在时返回 nil
+func loadAccount(tx *gorm.DB, userID uint64, currency string) (*model.Account, error) {
+	var acc model.Account
+	err := tx.Where("user_id = ? AND currency = ?", userID, currency).First(&acc).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &acc, nil
+}
+
+// updateBalance 带版本号的条件更新，delta 为负时同时要求余额足够
+// 影响行数为 0 说明读取之后账户已被修改，返回 errVersionConflict
+func updateBalance(tx *gorm.DB, acc *model.Account, delta decimal.Decimal) error {
+	q := tx.Model(&model.Account{}).Where("id = ? AND version = ?", acc.ID, acc.Version)
+	if delta.IsNegative() {
+		q = q.Where("balance >= ?", delta.Neg())
+	}
+	res := q.Updates(map[string]interface{}{
+		"balance":    gorm.Expr("balance + ?", delta),
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": time.Now(),
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errVersionConflict
+	}
+	acc.Balance = acc.Balance.Add(delta)
+	acc.Version++
+	return nil
+}
+
+// transferredSince 用户某币种在 since 之后的转出合计 (含提现转站内结算)
+func transferredSince(tx *gorm.DB, userID uint64, currency string, since time.Time) (decimal.Decimal, error) {
+	var sum decimal.NullDecimal
+	err := tx.Model(&model.Transfer{}).
+		Select("SUM(amount)").
+		Where("from_user_id = ? AND currency = ? AND created_at >= ?", userID, currency, since).
+		Scan(&sum).Error
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if !sum.Valid {
+		return decimal.Zero, nil
+	}
+	return sum.Decimal, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/internal/model"
+	"wallet-core/internal/service/risk"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+)
+
+func TestCheckTransferInput(t *testing.T) {
+	old := config.Global.Chains
+	defer func() { config.Global.Chains = old }()
+	config.Global.Chains = map[string]config.ChainConfig{
+		"btc": {Decimals: 8, MinWithdrawal: 0.001},
+	}
+
+	in := TransferInput{FromUserID: 1, ToUserID: 2, Currency: "BTC", Amount: decimal.RequireFromString("0.00000001")}
+	// 站内转账不受最小提现金额限制
+	assert.NoError(t, checkTransferInput(in))
+
+	self := in
+	self.ToUserID = 1
+	assert.ErrorIs(t, checkTransferInput(self), errno.ErrTransferSelf)
+
+	bad := in
+	bad.Amount = decimal.Zero
+	assert.ErrorIs(t, checkTransferInput(bad), errno.ErrAmountInvalid)
+
+	var e errno.Errno
+	bad.Amount = decimal.RequireFromString("0.000000001")
+	assert.ErrorAs(t, checkTransferInput(bad), &e)
+	assert.Equal(t, errno.ErrAmountInvalid.Code, e.Code)
+
+	bad = in
+	bad.Currency = "DOGE"
+	assert.ErrorAs(t, checkTransferInput(bad), &e)
+	assert.Equal(t, errno.ErrTransferInvalid.Code, e.Code)
+}
+
+func TestTransferLimits(t *testing.T) {
+	s := NewTransferService(nil, nil, config.TransferConfig{
+		MinAmount:  map[string]float64{"eth": 0.001},
+		MaxAmount:  map[string]float64{"eth": 10},
+		DailyLimit: map[string]float64{"eth": 20},
+	})
+	assert.Equal(t, transferDefaultRetries, s.cfg.MaxRetries)
+
+	d := decimal.RequireFromString
+	assert.NoError(t, s.checkLimits("ETH", d("10"), d("10")))
+	// 未配置的币种不限制
+	assert.NoError(t, s.checkLimits("BTC", d("1000"), d("1000")))
+
+	var e errno.Errno
+	for _, c := range []struct{ amount, used string }{
+		{"0.0001", "0"}, // 低于单笔最小
+		{"10.5", "0"},   // 超过单笔最大
+		{"5", "15.5"},   // 超过 24 小时合计
+	} {
+		if assert.ErrorAs(t, s.checkLimits("ETH", d(c.amount), d(c.used)), &e, c) {
+			assert.Equal(t, errno.ErrTransferLimitExceeded.Code, e.Code)
+		}
+	}
+}
+
+func TestTransferRequestHash(t *testing.T) {
+	in := TransferInput{FromUserID: 1, ToUserID: 2, Currency: "eth", Amount: decimal.RequireFromString("1.50"), Memo: "rent"}
+	same := in
+	same.Currency = "ETH"
+	same.Amount = decimal.RequireFromString("1.5")
+	same.IdempotencyKey = "k1" // 幂等键本身不参与摘要
+	assert.Equal(t, transferRequestHash(in), transferRequestHash(same))
+
+	for _, change := range []func(*TransferInput){
+		func(i *TransferInput) { i.ToUserID = 3 },
+		func(i *TransferInput) { i.Amount = decimal.RequireFromString("1.51") },
+		func(i *TransferInput) { i.Currency = "BTC" },
+		func(i *TransferInput) { i.Memo = "" },
+	} {
+		other := in
+		change(&other)
+		assert.NotEqual(t, transferRequestHash(in), transferRequestHash(other))
+	}
+}
+
+func TestNormalizeWithdrawalAddress(t *testing.T) {
+	// 充值地址以 EIP-55 格式存储，全小写输入必须规范化后才能匹配到平台地址
+	const stored = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	got, err := NormalizeWithdrawalAddress("eth", " "+strings.ToLower(stored)+" ", &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	assert.Equal(t, stored, got)
+
+	_, err = NormalizeWithdrawalAddress("ETH", "0x5aaeb6053F3E94C9b9A09f33669435E7Ef1BeAed", &chaincfg.MainNetParams)
+	var e errno.Errno
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, errno.ErrAddressInvalid.Code, e.Code)
+}
+
+// policySource 固定的风控规则来源
+type policySource struct{ policy *risk.Policy }
+
+func (s policySource) Load(context.Context) (*risk.Policy, error) { return s.policy, nil }
+
+func TestSettleInstantly(t *testing.T) {
+	engine, err := risk.NewEngine(nil, policySource{&risk.Policy{ReviewThreshold: 30, HoldThreshold: 80}}, 0)
+	require.NoError(t, err)
+
+	assert.True(t, SettleInstantly(nil, &model.Withdrawal{}))
+	assert.True(t, SettleInstantly(engine, &model.Withdrawal{RiskScore: 29}))
+
+	// 被风控标记 / 有时间锁 (大额强制延迟、预约) 的提现不能绕过审核和等待
+	assert.False(t, SettleInstantly(engine, &model.Withdrawal{RiskScore: 30}))
+	later := time.Now().Add(time.Hour)
+	assert.False(t, SettleInstantly(nil, &model.Withdrawal{ExecuteAfter: &later}))
+}
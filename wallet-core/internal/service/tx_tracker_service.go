@@ -0,0 +1,455 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/event"
+	"wallet-core/internal/model"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/monitor"
+)
+
+// txState 链上交易的跟踪结果
+type txState int
+
+const (
+	txPending   txState = iota // 还在内存池 / 确认数不足
+	txConfirmed                // 执行成功且达到确认数
+	txFailed                   // 执行失败 (revert) 且达到确认数
+	txDropped                  // 被替换 (同 nonce 已上链) 或被节点丢弃
+)
+
+// txStatus 单笔交易的查询结果
+type txStatus struct {
+	state         txState
+	blockNumber   uint64
+	confirmations uint64
+	gasUsed       uint64
+	gasFee        *big.Int // 实际 Gas 费 = GasUsed * EffectiveGasPrice
+	reason        string
+}
+
+// TxTrackerService 跟踪已广播的出款交易 (提现 & 归集)，直到达到确认数
+// 核心流程:
+// 1. 轮询 broadcasted 提现 / pending 归集
+// 2. 查询 Receipt: 有 Receipt -> 计算确认数; 无 Receipt -> 判断是否被替换/丢弃
+// 3. 达到终态后，在同一个事务中更新状态并写入 Outbox 事件
+type TxTrackerService struct {
+	db        *gorm.DB
+	ethClient ethTxReader // nil 表示模拟模式
+	interval  time.Duration
+
+	// 交易既没有 Receipt 也不在节点内存池中超过该时长，视为被丢弃
+	dropTimeout time.Duration
+}
+
+// ethTxReader TxTracker 用到的节点查询接口 (*ethclient.Client)
+type ethTxReader interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+func NewTxTrackerService(db *gorm.DB, rpcURL string) (*TxTrackerService, error) {
+	s := &TxTrackerService{
+		db:          db,
+		interval:    15 * time.Second, // 约等于 ETH 出块间隔
+		dropTimeout: time.Hour,
+	}
+
+	// 连接失败时保持 nil 接口 (不能赋值 nil 的 *ethclient.Client，否则接口不为 nil)
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		log.Printf("[TxTracker] Warning: RPC 无法连接，将运行在模拟模式")
+	} else {
+		s.ethClient = client
+	}
+	return s, nil
+}
+
+// Start 启动轮询 (阻塞，直到 ctx 取消)
+func (s *TxTrackerService) Start(ctx context.Context) {
+	log.Println("[TxTracker] 启动交易确认跟踪服务...")
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("[TxTracker] 停止服务")
+			return
+		case <-ticker.C:
+			s.trackWithdrawals(ctx)
+			s.trackCollections(ctx)
+		}
+	}
+}
+
+func (s *TxTrackerService) trackWithdrawals(ctx context.Context) {
+	var withdrawals []model.Withdrawal
+	if err := s.db.WithContext(ctx).
+		Where("status = ?", model.WithdrawalStatusBroadcasted).
+		Order("id").Limit(50).Find(&withdrawals).Error; err != nil {
+		log.Printf("[TxTracker] 查询提现失败: %v", err)
+		return
+	}
+
+	for i := range withdrawals {
+		w := &withdrawals[i]
+		if !strings.EqualFold(w.Chain, "ETH") {
+			continue // 目前只有 ETH 出款
+		}
+
+		txHash, action, st, err := s.checkWithdrawalTx(ctx, w)
+		if err != nil {
+			log.Printf("[TxTracker] 查询提现交易失败 ID=%d: %v", w.ID, err)
+			continue
+		}
+		if err := s.applyWithdrawal(ctx, w, txHash, action, st); err != nil {
+			log.Printf("[TxTracker] 更新提现状态失败 ID=%d: %v", w.ID, err)
+		}
+	}
+}
+
+func (s *TxTrackerService) trackCollections(ctx context.Context) {
+	var collections []model.Collection
+	if err := s.db.WithContext(ctx).
+		Where("status = ?", model.CollectionStatusPending).
+		Order("id").Limit(50).Find(&collections).Error; err != nil {
+		log.Printf("[TxTracker] 查询归集失败: %v", err)
+		return
+	}
+
+	for i := range collections {
+		c := &collections[i]
+		st, err := s.checkEthTx(ctx, c.TxHash, c.FromAddress, c.Nonce, c.GasLimit, c.CreatedAt)
+		if err != nil {
+			log.Printf("[TxTracker] 查询归集交易失败 ID=%d: %v", c.ID, err)
+			continue
+		}
+		if err := s.applyCollection(ctx, c, st); err != nil {
+			log.Printf("[TxTracker] 更新归集状态失败 ID=%d: %v", c.ID, err)
+		}
+	}
+}
+
+// checkWithdrawalTx 查询提现的链上状态
+// 提现被加速/取消过时，同一个 nonce 下有多笔候选交易，最终只有一笔会上链:
+// 返回已上链的那一笔 (及其替换动作); 都没上链时返回最新一笔的状态
+func (s *TxTrackerService) checkWithdrawalTx(ctx context.Context, w *model.Withdrawal) (string, string, *txStatus, error) {
+	var replacements []model.TxReplacement
+	if w.ReplaceCount > 0 {
+		if err := s.db.WithContext(ctx).Where("withdrawal_id = ?", w.ID).
+			Order("id DESC").Find(&replacements).Error; err != nil {
+			return "", "", nil, err
+		}
+	}
+	hashes, actions := candidateHashes(w.TxHash, replacements)
+	return s.pickWithdrawalTx(ctx, w, hashes, actions)
+}
+
+// candidateHashes 提现的候选交易: 当前交易在前，之后按替换顺序从新到旧列出被替换的历史交易 (replacements 按 ID 倒序)
+// actions 记录每笔替换交易的动作 (speed_up / cancel)，原始交易没有动作
+func candidateHashes(current string, replacements []model.TxReplacement) ([]string, map[string]string) {
+	hashes := []string{current}
+	actions := map[string]string{}
+	seen := map[string]bool{current: true}
+	for _, r := range replacements {
+		actions[r.NewTxHash] = r.Action
+		if !seen[r.OldTxHash] {
+			seen[r.OldTxHash] = true
+			hashes = append(hashes, r.OldTxHash)
+		}
+	}
+	return hashes, actions
+}
+
+// pickWithdrawalTx 依次查询候选交易，返回第一笔已上链的; 都没上链时返回当前交易的状态
+func (s *TxTrackerService) pickWithdrawalTx(ctx context.Context, w *model.Withdrawal, hashes []string, actions map[string]string) (string, string, *txStatus, error) {
+	broadcastAt := w.UpdatedAt
+	if w.BroadcastAt != nil {
+		broadcastAt = *w.BroadcastAt
+	}
+
+	var latest *txStatus
+	for _, h := range hashes {
+		st, err := s.checkEthTx(ctx, h, w.FromAddress, w.Nonce, w.GasLimit, broadcastAt)
+		if err != nil {
+			return "", "", nil, err
+		}
+		if st.blockNumber > 0 || st.state == txConfirmed {
+			return h, actions[h], st, nil
+		}
+		if latest == nil {
+			latest = st
+		}
+	}
+	return w.TxHash, actions[w.TxHash], latest, nil
+}
+
+// checkEthTx 查询一笔 ETH 交易的当前状态
+func (s *TxTrackerService) checkEthTx(ctx context.Context, txHash, from string, nonce, gasLimit uint64, broadcastAt time.Time) (*txStatus, error) {
+	required := config.Chain("ETH").Confirmations
+
+	// 模拟模式: 没有节点可查，直接视为按预估 Gas 成功确认
+	if s.ethClient == nil {
+		return &txStatus{state: txConfirmed, confirmations: required, gasUsed: gasLimit}, nil
+	}
+
+	hash := common.HexToHash(txHash)
+	receipt, err := s.ethClient.TransactionReceipt(ctx, hash)
+	if err != nil {
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+		return s.checkMissingTx(ctx, hash, from, nonce, broadcastAt)
+	}
+
+	head, err := s.ethClient.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &txStatus{
+		state:       txPending,
+		blockNumber: receipt.BlockNumber.Uint64(),
+		gasUsed:     receipt.GasUsed,
+	}
+	if head >= st.blockNumber {
+		st.confirmations = head - st.blockNumber + 1
+	}
+	if receipt.EffectiveGasPrice != nil {
+		st.gasFee = new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	}
+
+	// 确认数不足时即使 revert 也先不下结论 (可能被重组)
+	if st.confirmations < required {
+		return st, nil
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		st.state = txFailed
+		st.reason = "交易执行失败 (reverted)"
+		return st, nil
+	}
+	st.state = txConfirmed
+	return st, nil
+}
+
+// checkMissingTx 处理查不到 Receipt 的交易: 仍在内存池 / 被替换 / 被丢弃
+func (s *TxTrackerService) checkMissingTx(ctx context.Context, hash common.Hash, from string, nonce uint64, broadcastAt time.Time) (*txStatus, error) {
+	_, _, err := s.ethClient.TransactionByHash(ctx, hash)
+	if err == nil {
+		return &txStatus{state: txPending}, nil // 还在内存池里
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return nil, err
+	}
+
+	// 节点里已经没有这笔交易了
+	// 如果同一个 nonce 已经被其他交易占用并上链，说明它被替换了
+	if from != "" {
+		latestNonce, err := s.ethClient.NonceAt(ctx, common.HexToAddress(from), nil)
+		if err != nil {
+			return nil, err
+		}
+		if latestNonce > nonce {
+			return &txStatus{state: txDropped, reason: "nonce 已被其他交易占用 (交易被替换)"}, nil
+		}
+	}
+
+	if time.Since(broadcastAt) > s.dropTimeout {
+		return &txStatus{state: txDropped, reason: "交易长时间未出现在节点中 (已被丢弃)"}, nil
+	}
+	return &txStatus{state: txPending}, nil
+}
+
+func (s *TxTrackerService) applyWithdrawal(ctx context.Context, w *model.Withdrawal, txHash, action string, st *txStatus) error {
+	now := time.Now()
+	cancelled := st.state == txConfirmed && action == model.ReplaceActionCancel
+	if cancelled {
+		st.reason = "提现已取消 (同 nonce 的 0 值自转账已上链)"
+	}
+
+	if st.state == txPending {
+		// 只更新确认进度 (已上链的可能是替换前的旧交易，tx_hash 跟着切过去)
+		if st.blockNumber == w.BlockNumber && st.confirmations == w.Confirmations && txHash == w.TxHash {
+			return nil
+		}
+		return s.db.WithContext(ctx).Model(&model.Withdrawal{}).
+			Where("id = ? AND status = ?", w.ID, model.WithdrawalStatusBroadcasted).
+			Updates(map[string]interface{}{
+				"tx_hash":       txHash,
+				"block_number":  st.blockNumber,
+				"confirmations": st.confirmations,
+			}).Error
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"tx_hash":       txHash,
+			"block_number":  st.blockNumber,
+			"confirmations": st.confirmations,
+			"gas_used":      st.gasUsed,
+			"updated_at":    now,
+		}
+		if st.gasFee != nil {
+			updates["gas_fee"] = decimal.NewFromBigInt(st.gasFee, 0)
+		}
+		switch {
+		case cancelled:
+			updates["status"] = model.WithdrawalStatusCancelled
+			updates["fail_reason"] = st.reason
+			updates["confirmed_at"] = now
+		case st.state == txConfirmed:
+			updates["status"] = model.WithdrawalStatusCompleted
+			updates["confirmed_at"] = now
+		default:
+			updates["status"] = model.WithdrawalStatusFailed
+			updates["fail_reason"] = st.reason
+		}
+
+		// 条件更新: 防止多个实例重复处理、重复发事件
+		res := tx.Model(&model.Withdrawal{}).
+			Where("id = ? AND status = ?", w.ID, model.WithdrawalStatusBroadcasted).
+			Updates(updates)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		if st.state == txConfirmed && !cancelled {
+			// 已出款: 扣除冻结资金
+			if err := DeductWithdrawalFunds(tx, w); err != nil {
+				return err
+			}
+			gasFee := w.GasFee.String()
+			if st.gasFee != nil {
+				gasFee = st.gasFee.String()
+			}
+			observeGasUsed("withdrawal", "confirmed", w.GasLimit, st.gasUsed)
+			log.Printf("[TxTracker] ✅ 提现已确认 ID=%d, Tx=%s, Gas: %d/%d", w.ID, txHash, st.gasUsed, w.GasLimit)
+			return model.CreateOutboxMessage(tx, event.TopicWithdrawalConfirmed, event.WithdrawalConfirmedEvent{
+				WithdrawalID:  w.ID,
+				UserID:        w.UserID,
+				Chain:         w.Chain,
+				TxHash:        txHash,
+				Amount:        w.Amount.String(),
+				BlockNumber:   st.blockNumber,
+				Confirmations: st.confirmations,
+				GasLimit:      w.GasLimit,
+				GasUsed:       st.gasUsed,
+				GasFee:        gasFee,
+			})
+		}
+
+		// 失败 / 已取消: 资金没有离开热钱包，冻结资金退回可用余额
+		if err := ReleaseWithdrawalFunds(tx, w); err != nil {
+			return err
+		}
+		result := "failed"
+		if cancelled {
+			result = "cancelled"
+		}
+		observeGasUsed("withdrawal", result, w.GasLimit, st.gasUsed)
+		log.Printf("[TxTracker] ❌ 提现终止 (%s) ID=%d, Tx=%s: %s", result, w.ID, txHash, st.reason)
+		return model.CreateOutboxMessage(tx, event.TopicWithdrawalFailed, event.WithdrawalFailedEvent{
+			WithdrawalID: w.ID,
+			UserID:       w.UserID,
+			Chain:        w.Chain,
+			TxHash:       txHash,
+			Amount:       w.Amount.String(),
+			Reason:       st.reason,
+		})
+	})
+}
+
+func (s *TxTrackerService) applyCollection(ctx context.Context, c *model.Collection, st *txStatus) error {
+	now := time.Now()
+
+	if st.state == txPending {
+		if st.blockNumber == c.BlockNumber && st.confirmations == c.Confirmations {
+			return nil
+		}
+		return s.db.WithContext(ctx).Model(&model.Collection{}).
+			Where("id = ? AND status = ?", c.ID, model.CollectionStatusPending).
+			Updates(map[string]interface{}{
+				"block_number":  st.blockNumber,
+				"confirmations": st.confirmations,
+			}).Error
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"block_number":  st.blockNumber,
+			"confirmations": st.confirmations,
+			"gas_used":      st.gasUsed,
+			"updated_at":    now,
+		}
+		if st.gasFee != nil {
+			updates["gas_fee"] = decimal.NewFromBigInt(st.gasFee, 0)
+		}
+		if st.state == txConfirmed {
+			updates["status"] = model.CollectionStatusConfirmed
+			updates["confirmed_at"] = now
+		} else {
+			updates["status"] = model.CollectionStatusFailed
+			updates["fail_reason"] = st.reason
+		}
+
+		res := tx.Model(&model.Collection{}).
+			Where("id = ? AND status = ?", c.ID, model.CollectionStatusPending).
+			Updates(updates)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+
+		if st.state != txConfirmed {
+			// 归集失败不影响用户资金 (钱还在充值地址上)，下次充值事件会重新归集
+			observeGasUsed("collection", "failed", c.GasLimit, st.gasUsed)
+			log.Printf("[TxTracker] ❌ 归集失败 ID=%d, Tx=%s: %s", c.ID, c.TxHash, st.reason)
+			return nil
+		}
+
+		gasFee := c.GasFee.String()
+		if st.gasFee != nil {
+			gasFee = st.gasFee.String()
+		}
+		observeGasUsed("collection", "confirmed", c.GasLimit, st.gasUsed)
+		log.Printf("[TxTracker] ✅ 归集已确认 ID=%d, Tx=%s, Gas: %d/%d", c.ID, c.TxHash, st.gasUsed, c.GasLimit)
+		return model.CreateOutboxMessage(tx, event.TopicCollectionConfirmed, event.CollectionConfirmedEvent{
+			CollectionID: c.ID,
+			DepositID:    c.DepositID,
+			TxHash:       c.TxHash,
+			Amount:       c.Amount.String(),
+			GasLimit:     c.GasLimit,
+			GasUsed:      st.gasUsed,
+			GasFee:       gasFee,
+		})
+	})
+}
+
+// observeGasUsed 记录终态指标，以及实际 Gas 与预估 Gas 的比例
+func observeGasUsed(kind, result string, gasLimit, gasUsed uint64) {
+	if monitor.Business == nil {
+		return
+	}
+	monitor.Business.TxConfirmedTotal.WithLabelValues(kind, result).Inc()
+	if gasLimit > 0 && gasUsed > 0 {
+		monitor.Business.TxGasUsedRatio.WithLabelValues(kind).Observe(float64(gasUsed) / float64(gasLimit))
+	}
+}
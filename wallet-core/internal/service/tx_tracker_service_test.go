@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/config"
+)
+
+// fakeEthReader 模拟节点: receipts 为已打包的交易，mempool 为内存池中的交易，nonce 为出款地址已上链的 nonce
+type fakeEthReader struct {
+	head     uint64
+	receipts map[string]*types.Receipt
+	mempool  map[string]bool
+	nonce    uint64
+}
+
+func (f *fakeEthReader) TransactionReceipt(_ context.Context, h common.Hash) (*types.Receipt, error) {
+	if r, ok := f.receipts[h.Hex()]; ok {
+		return r, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (f *fakeEthReader) TransactionByHash(_ context.Context, h common.Hash) (*types.Transaction, bool, error) {
+	if f.mempool[h.Hex()] {
+		return types.NewTx(&types.LegacyTx{}), true, nil
+	}
+	return nil, false, ethereum.NotFound
+}
+
+func (f *fakeEthReader) BlockNumber(context.Context) (uint64, error) {
+	return f.head, nil
+}
+
+func (f *fakeEthReader) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	return f.nonce, nil
+}
+
+func receipt(block uint64, status uint64) *types.Receipt {
+	return &types.Receipt{
+		Status:            status,
+		BlockNumber:       new(big.Int).SetUint64(block),
+		GasUsed:           21000,
+		EffectiveGasPrice: big.NewInt(2_000_000_000),
+	}
+}
+
+func hashOf(n int64) string {
+	return common.BigToHash(big.NewInt(n)).Hex()
+}
+
+func withEthConfirmations(t *testing.T, n uint64) {
+	old := config.Global.Chains
+	t.Cleanup(func() { config.Global.Chains = old })
+	config.Global.Chains = map[string]config.ChainConfig{"eth": {Confirmations: n}}
+}
+
+const trackerFrom = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+
+func TestCheckEthTxConfirmations(t *testing.T) {
+	withEthConfirmations(t, 12)
+	tx := hashOf(1)
+
+	tests := []struct {
+		name          string
+		head          uint64
+		receipt       *types.Receipt
+		wantState     txState
+		wantConfirmed uint64
+	}{
+		{"刚打包: 1 个确认", 100, receipt(100, types.ReceiptStatusSuccessful), txPending, 1},
+		{"确认数不足", 110, receipt(100, types.ReceiptStatusSuccessful), txPending, 11},
+		{"达到确认数", 111, receipt(100, types.ReceiptStatusSuccessful), txConfirmed, 12},
+		{"确认数不足时 revert 先不下结论", 105, receipt(100, types.ReceiptStatusFailed), txPending, 6},
+		{"达到确认数且 revert", 120, receipt(100, types.ReceiptStatusFailed), txFailed, 21},
+		{"节点落后于打包区块", 99, receipt(100, types.ReceiptStatusSuccessful), txPending, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TxTrackerService{ethClient: &fakeEthReader{head: tt.head, receipts: map[string]*types.Receipt{tx: tt.receipt}}, dropTimeout: time.Hour}
+			st, err := s.checkEthTx(context.Background(), tx, trackerFrom, 7, 21000, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantState, st.state)
+			assert.Equal(t, tt.wantConfirmed, st.confirmations)
+			assert.Equal(t, uint64(100), st.blockNumber)
+			assert.Equal(t, big.NewInt(42_000_000_000_000), st.gasFee)
+		})
+	}
+}
+
+func TestCheckEthTxMissing(t *testing.T) {
+	withEthConfirmations(t, 12)
+	tx := hashOf(1)
+
+	tests := []struct {
+		name        string
+		node        *fakeEthReader
+		broadcastAt time.Time
+		wantState   txState
+	}{
+		{"仍在内存池", &fakeEthReader{mempool: map[string]bool{tx: true}, nonce: 7}, time.Now().Add(-2 * time.Hour), txPending},
+		{"同 nonce 已被其他交易占用: 被替换", &fakeEthReader{nonce: 8}, time.Now(), txDropped},
+		{"不在节点中但未超时: 继续等待", &fakeEthReader{nonce: 7}, time.Now().Add(-10 * time.Minute), txPending},
+		{"长时间不在节点中: 被丢弃", &fakeEthReader{nonce: 7}, time.Now().Add(-2 * time.Hour), txDropped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TxTrackerService{ethClient: tt.node, dropTimeout: time.Hour}
+			st, err := s.checkEthTx(context.Background(), tx, trackerFrom, 7, 21000, tt.broadcastAt)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantState, st.state)
+			assert.Zero(t, st.blockNumber)
+		})
+	}
+}
+
+func TestCheckEthTxReorg(t *testing.T) {
+	withEthConfirmations(t, 12)
+	tx := hashOf(1)
+	node := &fakeEthReader{head: 105, receipts: map[string]*types.Receipt{tx: receipt(100, types.ReceiptStatusSuccessful)}, nonce: 7}
+	s := &TxTrackerService{ethClient: node, dropTimeout: time.Hour}
+
+	st, err := s.checkEthTx(context.Background(), tx, trackerFrom, 7, 21000, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, txPending, st.state)
+	assert.Equal(t, uint64(6), st.confirmations)
+
+	// 区块被重组: Receipt 消失，交易回到内存池，确认进度清零
+	delete(node.receipts, tx)
+	node.mempool = map[string]bool{tx: true}
+	st, err = s.checkEthTx(context.Background(), tx, trackerFrom, 7, 21000, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, txPending, st.state)
+	assert.Zero(t, st.blockNumber)
+	assert.Zero(t, st.confirmations)
+
+	// 重新打包到另一个区块
+	node.head = 120
+	node.receipts[tx] = receipt(110, types.ReceiptStatusSuccessful)
+	st, err = s.checkEthTx(context.Background(), tx, trackerFrom, 7, 21000, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, txPending, st.state)
+	assert.Equal(t, uint64(110), st.blockNumber)
+	assert.Equal(t, uint64(11), st.confirmations)
+}
+
+func TestCandidateHashes(t *testing.T) {
+	// 原交易 h1 -> 加速 h2 -> 取消 h3，按 ID 倒序
+	replacements := []model.TxReplacement{
+		{OldTxHash: "h2", NewTxHash: "h3", Action: model.ReplaceActionCancel},
+		{OldTxHash: "h1", NewTxHash: "h2", Action: model.ReplaceActionSpeedUp},
+	}
+	hashes, actions := candidateHashes("h3", replacements)
+	assert.Equal(t, []string{"h3", "h2", "h1"}, hashes)
+	assert.Equal(t, map[string]string{"h3": model.ReplaceActionCancel, "h2": model.ReplaceActionSpeedUp}, actions)
+
+	hashes, actions = candidateHashes("h1", nil)
+	assert.Equal(t, []string{"h1"}, hashes)
+	assert.Empty(t, actions)
+}
+
+func TestPickWithdrawalTx(t *testing.T) {
+	withEthConfirmations(t, 12)
+	h1, h2, h3 := hashOf(1), hashOf(2), hashOf(3)
+	hashes := []string{h3, h2, h1}
+	actions := map[string]string{h3: model.ReplaceActionCancel, h2: model.ReplaceActionSpeedUp}
+	now := time.Now()
+	w := &model.Withdrawal{TxHash: h3, FromAddress: trackerFrom, Nonce: 7, GasLimit: 21000, BroadcastAt: &now}
+
+	tests := []struct {
+		name       string
+		node       *fakeEthReader
+		wantHash   string
+		wantAction string
+		wantState  txState
+	}{
+		{"都在内存池: 返回当前交易", &fakeEthReader{mempool: map[string]bool{h1: true, h2: true, h3: true}, nonce: 7}, h3, model.ReplaceActionCancel, txPending},
+		{"原交易抢先上链", &fakeEthReader{head: 111, receipts: map[string]*types.Receipt{h1: receipt(100, types.ReceiptStatusSuccessful)}, nonce: 8}, h1, "", txConfirmed},
+		{"加速交易上链但确认数不足", &fakeEthReader{head: 101, receipts: map[string]*types.Receipt{h2: receipt(100, types.ReceiptStatusSuccessful)}, nonce: 8}, h2, model.ReplaceActionSpeedUp, txPending},
+		{"取消交易上链", &fakeEthReader{head: 111, receipts: map[string]*types.Receipt{h3: receipt(100, types.ReceiptStatusSuccessful)}, nonce: 8}, h3, model.ReplaceActionCancel, txConfirmed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TxTrackerService{ethClient: tt.node, dropTimeout: time.Hour}
+			hash, action, st, err := s.pickWithdrawalTx(context.Background(), w, hashes, actions)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHash, hash)
+			assert.Equal(t, tt.wantAction, action)
+			assert.Equal(t, tt.wantState, st.state)
+		})
+	}
+}
+
+func TestCheckEthTxSimulated(t *testing.T) {
+	withEthConfirmations(t, 12)
+	// 模拟模式 (没有节点): 按预估 Gas 直接视为确认
+	s := &TxTrackerService{dropTimeout: time.Hour}
+	st, err := s.checkEthTx(context.Background(), hashOf(1), trackerFrom, 7, 21000, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, txConfirmed, st.state)
+	assert.Equal(t, uint64(12), st.confirmations)
+	assert.Equal(t, uint64(21000), st.gasUsed)
+}
@@ -6,27 +6,41 @@ import (
 	"time"
 
 	"wallet-core/internal/model"
+	"wallet-core/internal/service/auth"
+	"wallet-core/internal/service/mfa"
+	"wallet-core/internal/worker"
+	"wallet-core/internal/worker/tasks"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/logger"
 
-	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+// 业务错误统一使用 errno，经 gRPC 返回时会带上业务错误码 (见 errno.GRPCStatus)
 var (
-	ErrUserNotFound      = errors.New("user not found")
-	ErrUserAlreadyExists = errors.New("username or email already exists")
-	ErrInvalidPassword   = errors.New("invalid password")
+	ErrUserNotFound      = errno.ErrUserNotFound
+	ErrUserAlreadyExists = errno.ErrUserAlreadyExist
+	ErrInvalidPassword   = errno.ErrPasswordIncorrect
 )
 
 type Service struct {
-	db *gorm.DB
+	db    *gorm.DB
+	mfa   *mfa.Service   // 两步验证 (TOTP)
+	auth  *auth.Service  // 登录态 (JWT + Refresh Token)
+	tasks *worker.Client // 投递验证 / 重置密码邮件，由 wallet-server 的 worker 发送
+	cfg   config.AccountConfig
 }
 
-func NewService(db *gorm.DB) *Service {
-	return &Service{db: db}
+func NewService(db *gorm.DB, mfaSvc *mfa.Service, authSvc *auth.Service, taskClient *worker.Client, cfg config.AccountConfig) *Service {
+	return &Service{db: db, mfa: mfaSvc, auth: authSvc, tasks: taskClient, cfg: cfg}
 }
 
-// Register 创建新用户
+// Register 创建新用户，并发送邮箱验证邮件
+// 用户归属 context 中的租户 (x-tenant)，用户名 / 邮箱只需在租户内唯一
 func (s *Service) Register(ctx context.Context, username, email, password string) (int64, error) {
 	// 1. Hash Password
 	hashedPwd, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -43,37 +57,78 @@ func (s *Service) Register(ctx context.Context, username, email, password string
 		UpdatedAt:    time.Now(),
 	}
 
-	// 3. Insert to DB
-	if err := s.db.WithContext(ctx).Create(&user).Error; err != nil {
-		if errors.Is(err, gorm.ErrDuplicatedKey) {
-			return 0, ErrUserAlreadyExists
+	// 3. Insert to DB (用户和验证令牌同一事务)
+	var token string
+	var expiresAt time.Time
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return ErrUserAlreadyExists
+			}
+			return err
 		}
+		token, expiresAt, err = issueToken(tx, user.ID, model.TokenPurposeEmailVerify, s.cfg.VerifyTokenTTL, user.CreatedAt)
+		return err
+	})
+	if err != nil {
 		return 0, err
 	}
 
+	// 4. 投递验证邮件，失败不影响注册 (用户可以重新发送)
+	s.enqueue(tasks.NewEmailVerificationTask(accountEmail(&user, token, expiresAt)))
+
 	return int64(user.ID), nil
 }
 
 // Login 用户登录
-func (s *Service) Login(ctx context.Context, email, password string) (string, int64, string, error) {
+// 已开启两步验证的用户必须同时提供 totpCode (验证码或备用码)，否则返回 errno.ErrMFARequired
+func (s *Service) Login(ctx context.Context, email, password, totpCode string) (*auth.TokenPair, string, error) {
 	var user model.User
-	// 1. Find User
+	// 1. Find User (按 context 中的租户查找，同一个邮箱可以在多个租户注册)
 	if err := s.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", 0, "", ErrUserNotFound
+			return nil, "", ErrUserNotFound
 		}
-		return "", 0, "", err
+		return nil, "", err
 	}
 
 	// 2. Compare Password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return "", 0, "", ErrInvalidPassword
+		return nil, "", ErrInvalidPassword
+	}
+
+	// 3. 邮箱未验证不能登录 (密码正确后才提示，避免泄露账户状态)
+	if s.cfg.RequireVerifiedEmail && user.EmailVerifiedAt == nil {
+		return nil, "", errno.ErrEmailNotVerified
 	}
 
-	// 3. Generate Token (Simple UUID for Phase 1)
-	token := uuid.New().String()
+	// 4. Two-Factor (TOTP)
+	if err := s.mfa.Require(ctx, user.ID, totpCode); err != nil {
+		return nil, "", err
+	}
+
+	// 5. Issue Access Token (JWT) + Refresh Token
+	tokens, err := s.auth.Issue(ctx, user.ID, user.TenantID)
+	if err != nil {
+		return nil, "", err
+	}
 
-	return token, int64(user.ID), user.Username, nil
+	return tokens, user.Username, nil
+}
+
+// RefreshToken 用 Refresh Token 换一组新凭证，旧的 Refresh Token 立即作废
+func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (*auth.TokenPair, error) {
+	return s.auth.Refresh(ctx, refreshToken)
+}
+
+// Logout 退出当前设备 (吊销该 Refresh Token)
+func (s *Service) Logout(ctx context.Context, refreshToken string) error {
+	return s.auth.Logout(ctx, refreshToken)
+}
+
+// LogoutAll 退出所有设备
+func (s *Service) LogoutAll(ctx context.Context, userID uint64) error {
+	return s.auth.LogoutAll(ctx, userID)
 }
 
 // GetUserInfo 获取用户信息
@@ -87,3 +142,132 @@ func (s *Service) GetUserInfo(ctx context.Context, userID int64) (*model.User, e
 	}
 	return &user, nil
 }
+
+// EnrollTOTP 绑定验证器: 返回密钥和 otpauth URI，需调用 ActivateTOTP 校验第一个验证码后才生效
+func (s *Service) EnrollTOTP(ctx context.Context, userID int64) (string, string, error) {
+	u, err := s.GetUserInfo(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	return s.mfa.Enroll(ctx, u.ID, u.Email)
+}
+
+// ActivateTOTP 校验第一个验证码并开启两步验证，返回一次性备用码
+func (s *Service) ActivateTOTP(ctx context.Context, userID int64, code string) ([]string, error) {
+	return s.mfa.Activate(ctx, uint64(userID), code)
+}
+
+// SendVerificationEmail 重新发送邮箱验证邮件，之前发出的链接作废
+func (s *Service) SendVerificationEmail(ctx context.Context, userID uint64) error {
+	u, err := s.GetUserInfo(ctx, int64(userID))
+	if err != nil {
+		return err
+	}
+	if u.EmailVerifiedAt != nil {
+		return errno.ErrEmailVerified
+	}
+
+	token, expiresAt, err := issueToken(s.db.WithContext(ctx), u.ID, model.TokenPurposeEmailVerify, s.cfg.VerifyTokenTTL, time.Now())
+	if err != nil {
+		return err
+	}
+	return s.enqueue(tasks.NewEmailVerificationTask(accountEmail(u, token, expiresAt)))
+}
+
+// VerifyEmail 使用邮件中的令牌完成邮箱验证
+func (s *Service) VerifyEmail(ctx context.Context, token string) error {
+	now := time.Now()
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		t, err := consumeToken(tx, token, model.TokenPurposeEmailVerify, now)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model.User{}).
+			Where("id = ? AND email_verified_at IS NULL", t.UserID).
+			Updates(map[string]interface{}{"email_verified_at": now, "updated_at": now}).Error
+	})
+}
+
+// RequestPasswordReset 发送重置密码邮件
+// 邮箱未注册时同样返回成功，不能用来探测邮箱是否注册
+func (s *Service) RequestPasswordReset(ctx context.Context, email string) error {
+	var u model.User
+	if err := s.db.WithContext(ctx).Where("email = ?", email).First(&u).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var token string
+	var expiresAt time.Time
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		token, expiresAt, err = issueToken(tx, u.ID, model.TokenPurposePasswordReset, s.cfg.ResetTokenTTL, time.Now())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return s.enqueue(tasks.NewPasswordResetTask(accountEmail(&u, token, expiresAt)))
+}
+
+// ResetPassword 使用邮件中的令牌设置新密码，并退出该用户所有设备
+// 能收到重置邮件说明邮箱属于本人，未验证的邮箱同时标记为已验证
+func (s *Service) ResetPassword(ctx context.Context, token, newPassword string) error {
+	hashedPwd, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var userID uint64
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		t, err := consumeToken(tx, token, model.TokenPurposePasswordReset, now)
+		if err != nil {
+			return err
+		}
+		userID = t.UserID
+
+		if err := tx.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"password_hash":       string(hashedPwd),
+			"password_changed_at": now,
+			"updated_at":          now,
+			"email_verified_at":   gorm.Expr("COALESCE(email_verified_at, ?)", now),
+		}).Error; err != nil {
+			return err
+		}
+		return revokeTokens(tx, userID, model.TokenPurposePasswordReset, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	// 旧密码可能已泄露，吊销所有 Refresh Token 和已签发的 Access Token
+	return s.auth.LogoutAll(ctx, userID)
+}
+
+// enqueue 投递邮件任务，失败只记日志并返回错误，由调用方决定是否忽略
+func (s *Service) enqueue(task *asynq.Task, err error) error {
+	if err == nil {
+		if s.tasks == nil {
+			err = errors.New("task client not configured")
+		} else {
+			_, err = s.tasks.Enqueue(task)
+		}
+	}
+	if err != nil {
+		logger.Error("Failed to enqueue account email", zap.Error(err))
+	}
+	return err
+}
+
+func accountEmail(u *model.User, token string, expiresAt time.Time) tasks.AccountEmailPayload {
+	return tasks.AccountEmailPayload{
+		UserID:    u.ID,
+		Email:     u.Email,
+		Username:  u.Username,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}
+}
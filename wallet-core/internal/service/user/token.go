@@ -0,0 +1,93 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"wallet-core/internal/model"
+	"wallet-core/pkg/errno"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tokenBytes 令牌随机字节数 (256 bit)
+const tokenBytes = 32
+
+// newToken 生成明文令牌 (base64url，可直接放进链接) 及其哈希
+func newToken() (string, string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	raw := base64.RawURLEncoding.EncodeToString(b)
+	return raw, hashToken(raw), nil
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// issueToken 生成一次性令牌并保存哈希，同一用户同一用途未使用的旧令牌同时作废
+// 返回明文令牌 (只出现在邮件里) 和过期时间
+func issueToken(tx *gorm.DB, userID uint64, purpose string, ttl time.Duration, now time.Time) (string, time.Time, error) {
+	raw, hash, err := newToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if err := revokeTokens(tx, userID, purpose, now); err != nil {
+		return "", time.Time{}, err
+	}
+	t := model.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	if err := tx.Create(&t).Error; err != nil {
+		return "", time.Time{}, err
+	}
+	return raw, t.ExpiresAt, nil
+}
+
+// consumeToken 校验并使用令牌: 不存在 / 用途不符 / 已使用 / 已过期都返回 ErrUserTokenInvalid
+// 行锁保证并发请求中只有一个能用掉同一个令牌
+func consumeToken(tx *gorm.DB, raw, purpose string, now time.Time) (*model.UserToken, error) {
+	if raw == "" {
+		return nil, errno.ErrUserTokenInvalid
+	}
+	var t model.UserToken
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ? AND purpose = ?", hashToken(raw), purpose).
+		First(&t).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrUserTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !tokenUsable(&t, now) {
+		return nil, errno.ErrUserTokenInvalid
+	}
+	if err := tx.Model(&t).Update("used_at", now).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// revokeTokens 作废用户某用途所有未使用的令牌
+func revokeTokens(tx *gorm.DB, userID uint64, purpose string, now time.Time) error {
+	return tx.Model(&model.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", now).Error
+}
+
+func tokenUsable(t *model.UserToken, now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,32 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/internal/model"
+)
+
+func TestNewToken(t *testing.T) {
+	raw, hash, err := newToken()
+	require.NoError(t, err)
+	assert.Len(t, raw, 43) // 32 字节 base64url (无填充)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hashToken(raw), hash)
+
+	other, _, err := newToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, raw, other)
+}
+
+func TestTokenUsable(t *testing.T) {
+	now := time.Now()
+	used := now.Add(-time.Minute)
+
+	assert.True(t, tokenUsable(&model.UserToken{ExpiresAt: now.Add(time.Minute)}, now))
+	assert.False(t, tokenUsable(&model.UserToken{ExpiresAt: now}, now))
+	assert.False(t, tokenUsable(&model.UserToken{ExpiresAt: now.Add(time.Minute), UsedAt: &used}, now))
+}
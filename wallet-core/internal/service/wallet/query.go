@@ -0,0 +1,95 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"wallet-core/internal/model"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/address"
+	"wallet-core/pkg/errno"
+
+	"gorm.io/gorm"
+)
+
+// ListAddresses 用户的充值地址，currency 为空时返回全部
+func (s *Service) ListAddresses(ctx context.Context, userID int64, currency string) ([]model.Address, error) {
+	q := s.db.WithContext(ctx).Where("user_id = ?", userID)
+	if currency != "" {
+		q = q.Where("chain = ?", strings.ToUpper(currency))
+	}
+	var addrs []model.Address
+	if err := q.Order("created_at DESC").Find(&addrs).Error; err != nil {
+		return nil, err
+	}
+	return addrs, nil
+}
+
+// GetDeposit 查询用户自己的一笔充值
+func (s *Service) GetDeposit(ctx context.Context, userID int64, id uint64) (*model.Deposit, error) {
+	var d model.Deposit
+	err := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&d).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrDepositNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// GetWithdrawal 查询用户自己的一笔提现
+func (s *Service) GetWithdrawal(ctx context.Context, userID int64, id uint64) (*model.Withdrawal, error) {
+	var w model.Withdrawal
+	err := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&w).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrWithdrawalNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// CancelWithdrawal 用户取消尚未执行的提现 (待审核 / 风控挂起 / 时间锁定中)，返回取消后的提现单
+func (s *Service) CancelWithdrawal(ctx context.Context, userID int64, id uint64) (*model.Withdrawal, error) {
+	if err := s.timeLock.Cancel(ctx, uint64(userID), id); err != nil {
+		return nil, err
+	}
+	return s.GetWithdrawal(ctx, userID, id)
+}
+
+// AddressCheck 地址校验结果
+type AddressCheck struct {
+	Valid      bool
+	Normalized string // 规范化后的地址 (ETH 为 EIP-55 格式)
+	Reason     string // 不合法的原因
+}
+
+// ValidateAddress 校验地址格式，不合法时返回原因而不是错误；币种不支持时返回错误
+func (s *Service) ValidateAddress(currency, addr string) (*AddressCheck, error) {
+	if _, ok := service.LookupAsset(currency); !ok {
+		return nil, errno.ErrBind.WithMessage("unsupported currency " + currency)
+	}
+	normalized, err := address.Validate(currency, strings.TrimSpace(addr), s.network)
+	if errors.Is(err, address.ErrUnsupportedChain) {
+		return nil, errno.ErrBind.WithMessage("unsupported currency " + currency)
+	}
+	if err != nil {
+		return &AddressCheck{Reason: err.Error()}, nil
+	}
+	return &AddressCheck{Valid: true, Normalized: normalized}, nil
+}
+
+// normalizeAddress 提现前校验收款地址，返回规范化后的地址
+func (s *Service) normalizeAddress(currency, addr string) (string, error) {
+	check, err := s.ValidateAddress(currency, addr)
+	if err != nil {
+		return "", err
+	}
+	if !check.Valid {
+		return "", errno.ErrAddressInvalid.WithMessage(check.Reason)
+	}
+	return check.Normalized, nil
+}
@@ -0,0 +1,80 @@
+package wallet
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"wallet-core/internal/service/fee"
+	"wallet-core/pkg/errno"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// btcTypicalTxVSize 典型的 P2WPKH 1 输入 2 输出交易大小 (vB)，用于估算 BTC 提现手续费
+const btcTypicalTxVSize = 141
+
+// WithdrawalQuote 提现手续费报价
+type WithdrawalQuote struct {
+	Currency   string
+	Level      fee.Level
+	NetworkFee decimal.Decimal // 预估网络手续费 (币本位: ETH / BTC)
+	GasLimit   uint64          // EVM
+	Fees       fee.Quote       // 原始报价 (Gas 单价 / 费率)
+	Fallback   bool            // 节点不可用，使用了兜底值
+}
+
+// QuoteWithdrawal 估算提现的网络手续费
+// toAddr / amountStr 可选，用于 eth_estimateGas; level 为空时使用 standard
+func (s *Service) QuoteWithdrawal(ctx context.Context, currency, toAddr, amountStr, level string) (*WithdrawalQuote, error) {
+	lv, err := fee.ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	currency = strings.ToUpper(currency)
+
+	estimate, err := s.fees.Estimate(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+	q := estimate.Quote(lv)
+	quote := &WithdrawalQuote{
+		Currency: currency,
+		Level:    lv,
+		Fees:     q,
+		Fallback: estimate.Fallback,
+	}
+
+	switch currency {
+	case "BTC":
+		// sat/vB * vB -> sat -> BTC
+		quote.NetworkFee = decimal.NewFromInt(q.FeeRate * btcTypicalTxVSize).Shift(-8)
+	case "ETH":
+		msg := ethereum.CallMsg{}
+		if toAddr != "" {
+			if !common.IsHexAddress(toAddr) {
+				return nil, errno.ErrAddressInvalid
+			}
+			to := common.HexToAddress(toAddr)
+			msg.To = &to
+		}
+		if amount, err := decimal.NewFromString(amountStr); err == nil {
+			msg.Value = amount.Shift(18).BigInt()
+		}
+		gasLimit, err := s.fees.EstimateGas(ctx, currency, msg)
+		if err != nil {
+			return nil, err
+		}
+		quote.GasLimit = gasLimit
+
+		// 按 MaxPrice 报价: 用户看到的是上限，实际扣费只会更少
+		maxFee := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), q.EthFees().MaxPrice())
+		quote.NetworkFee = decimal.NewFromBigInt(maxFee, -18)
+	default:
+		return nil, fee.ErrUnsupportedChain
+	}
+
+	return quote, nil
+}
@@ -5,38 +5,66 @@ import (
 	"encoding/json"
 	"errors"
 	"strconv"
+	"strings"
 	"time"
 
 	"wallet-core/internal/event"
 	"wallet-core/internal/model"
 	"wallet-core/internal/service"
+	"wallet-core/internal/service/fee"
+	"wallet-core/internal/service/mfa"
 	"wallet-core/internal/service/mq"
+	"wallet-core/internal/service/risk"
+	"wallet-core/internal/service/screening"
+	"wallet-core/pkg/errno"
 
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// 业务错误统一使用 errno，经 gRPC 返回时会带上业务错误码 (见 errno.GRPCStatus)
 var (
-	ErrAccountNotFound = errors.New("账户不存在")
-	ErrInsufficient    = errors.New("余额不足")
+	ErrAccountNotFound = errno.ErrAccountNotFound
+	ErrInsufficient    = errno.ErrInsufficientBalance
 )
 
 type Service struct {
 	db       *gorm.DB
-	addrSvc  service.AddressService // 依赖 AddressService 生成地址
-	producer mq.Producer            // 依赖 MQ Producer 发送提现事件
+	addrSvc  service.AddressService   // 依赖 AddressService 生成地址
+	producer mq.Producer              // 依赖 MQ Producer 发送提现事件
+	fees     *fee.Service             // 依赖手续费估算 (提现报价)
+	risk     *risk.Engine             // 依赖风控引擎 (提现评分)
+	screener *screening.Screener      // 依赖制裁名单筛查 (拦截提现)
+	timeLock *service.TimeLockService // 依赖时间锁任务投递 (定时 / 大额提现)
+	mfa      *mfa.Service             // 依赖两步验证 (提现确认)
+	transfer *service.TransferService // 依赖站内转账 (收款地址属于本平台用户时站内结算)
+	network  *chaincfg.Params         // BTC 网络 (地址校验)
 }
 
-func NewService(db *gorm.DB, addrSvc service.AddressService, producer mq.Producer) *Service {
+func NewService(db *gorm.DB, addrSvc service.AddressService, producer mq.Producer, fees *fee.Service, riskEngine *risk.Engine, screener *screening.Screener, timeLock *service.TimeLockService, mfaSvc *mfa.Service, transfer *service.TransferService, network *chaincfg.Params) *Service {
 	return &Service{
 		db:       db,
 		addrSvc:  addrSvc,
 		producer: producer,
+		fees:     fees,
+		risk:     riskEngine,
+		screener: screener,
+		timeLock: timeLock,
+		mfa:      mfaSvc,
+		transfer: transfer,
+		network:  network,
 	}
 }
 
 // CreateAddress 为用户生成充值地址
 func (s *Service) CreateAddress(ctx context.Context, userID int64, currency string) (string, error) {
+	// 实名认证等级限制可用币种
+	if err := service.CheckKYCAsset(ctx, s.db, uint64(userID), currency); err != nil {
+		return "", err
+	}
+
 	// 调用 AddressService 生成地址 (这里复用现有逻辑，未来可以将 AddressService 也拆分)
 	// 注意: 这里的 userID 转为 uint64 适配旧接口
 	addr, _, err := s.addrSvc.GetDepositAddress(uint64(userID), currency)
@@ -69,21 +97,71 @@ func (s *Service) GetBalance(ctx context.Context, userID int64, currency string)
 }
 
 // CreateWithdrawal 创建提现申请
-func (s *Service) CreateWithdrawal(ctx context.Context, userID int64, toAddr, amountStr, currency string) (int64, error) {
+// 返回的提现单已经过风控评分，Status 为 pending_review 或 risk_hold
+// executeAfter 非空时为定时提现，审批通过后等到该时间才执行
+// 已开启两步验证的用户必须提供 totpCode
+func (s *Service) CreateWithdrawal(ctx context.Context, userID int64, toAddr, amountStr, currency string, executeAfter *time.Time, totpCode string) (*model.Withdrawal, error) {
+	currency = strings.ToUpper(currency)
 	amount, err := decimal.NewFromString(amountStr)
 	if err != nil {
-		return 0, errors.New("金额格式错误")
+		return nil, errno.ErrAmountInvalid
+	}
+	if err := service.CheckWithdrawalAmount(currency, amount); err != nil {
+		return nil, err
+	}
+	if toAddr, err = s.normalizeAddress(currency, toAddr); err != nil {
+		return nil, err
+	}
+
+	// 两步验证
+	if err := s.mfa.Require(ctx, uint64(userID), totpCode); err != nil {
+		return nil, err
+	}
+
+	withdrawal := &model.Withdrawal{
+		UserID:            uint64(userID),
+		ToAddress:         toAddr,
+		Amount:            amount,
+		Chain:             currency, // Assuming currency maps to chain for now
+		Status:            model.WithdrawalStatusPendingReview,
+		RequiredApprovals: 2,
+	}
+
+	// 时间锁: 预约时间 / 大额强制延迟
+	if err := service.ApplyTimeLock(withdrawal, executeAfter); err != nil {
+		return nil, err
+	}
+
+	// 风控评分 (在事务外执行，规则只读历史数据)
+	if s.risk != nil {
+		if err := s.risk.Assess(ctx, withdrawal); err != nil {
+			return nil, err
+		}
 	}
 
-	if amount.LessThanOrEqual(decimal.Zero) {
-		return 0, errors.New("提现金额必须大于0")
+	// 收款地址属于本平台 (同租户) 的其他用户: 不上链，站内结算后直接完成，不经过筛查
+	// 有时间锁或被风控标记的提现不即时结算，照常审核 / 等待，审批通过后按链上提现出款
+	if service.SettleInstantly(s.risk, withdrawal) {
+		settled, err := s.transfer.SettleWithdrawal(ctx, withdrawal)
+		if err != nil {
+			return nil, err
+		}
+		if settled {
+			s.publishWithdrawalCreated(withdrawal)
+			return withdrawal, nil
+		}
+	}
+
+	// 制裁名单筛查: 收款地址命中直接拦截
+	if err := s.screener.ScreenWithdrawal(ctx, withdrawal); err != nil {
+		return nil, err
 	}
 
 	// 开启事务 (检查余额 -> 扣除余额 -> 创建提现记录)
 	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var account model.Account
-		// 悲观锁: SELECT ... FOR UPDATE
-		if err := tx.Set("gorm:query_option", "FOR UPDATE").
+		// 悲观锁: SELECT ... FOR UPDATE (GORM v2 不再支持 gorm:query_option，必须用 Locking 子句)
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 			Where("user_id = ? AND currency = ?", userID, currency).
 			First(&account).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -96,43 +174,54 @@ func (s *Service) CreateWithdrawal(ctx context.Context, userID int64, toAddr, am
 			return ErrInsufficient
 		}
 
-		// 扣钱 (Balance -> LockedBalance)
-		// 简化: 直接扣除 Balance，生成 Withdrawal 记录 (状态 pending)
-		// 严谨做法: Balance -= amount, LockedBalance += amount
+		// 实名认证等级 (额度按用户 + 币种计算，持有上面的账户行锁，并发提现不会突破 24 小时额度)
+		if err := service.CheckKYCWithdrawal(ctx, tx, uint64(userID), currency, amount); err != nil {
+			return err
+		}
+
+		// 租户限额 (单笔 / 租户 24 小时合计，锁租户链配置行)，提现记在用户所属租户下
+		tenantID, err := service.CheckTenantWithdrawal(ctx, tx, uint64(userID), currency, amount)
+		if err != nil {
+			return err
+		}
+		withdrawal.TenantID = tenantID
+
+		// 冻结资金 (Balance -> LockedBalance)
 		account.Balance = account.Balance.Sub(amount)
 		account.LockedBalance = account.LockedBalance.Add(amount)
+		account.Version++ // 站内转账按版本号乐观更新，这里同样递增
 
 		if err := tx.Save(&account).Error; err != nil {
 			return err
 		}
 
-		// TODO: 创建 withdraw_records 记录 (这里仅演示核心逻辑，假设 withdrawal 表存在)
-		// withdrawal := model.Withdrawal{...}
-		// tx.Create(&withdrawal)
-
-		return nil
+		return tx.Create(withdrawal).Error
 	})
 
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	// 返回模拟的 ID (实际中应该是 withdrawal.ID)
-	withdrawalID := time.Now().UnixNano()
+	// 投递时间锁任务 (锁定通知 / 执行前提醒 / 到期放行)
+	s.timeLock.Schedule(ctx, withdrawal)
+
+	s.publishWithdrawalCreated(withdrawal)
+	return withdrawal, nil
+}
 
-	// 发送提现创建事件 (Async)
-	// Topic: wallet_events_withdrawal
+// publishWithdrawalCreated 发送提现创建事件 (Async)
+// Topic: wallet_events_withdrawal
+func (s *Service) publishWithdrawalCreated(w *model.Withdrawal) {
 	go func() {
 		payload, _ := json.Marshal(event.WithdrawalCreatedEvent{
-			WithdrawalID: uint64(withdrawalID), // Mock ID
-			UserID:       uint64(userID),
-			ToAddress:    toAddr,
-			Amount:       amountStr,
-			Chain:        currency, // Assuming currency maps to chain for now
+			WithdrawalID: w.ID,
+			UserID:       w.UserID,
+			ToAddress:    w.ToAddress,
+			Amount:       w.Amount.String(),
+			Chain:        w.Chain,
+			Status:       w.Status,
 		})
 		// 使用 UserID 作为 Partition Key 保证顺序
-		_ = s.producer.Publish(context.Background(), "wallet_events_withdrawal", strconv.FormatInt(userID, 10), payload)
+		_ = s.producer.Publish(context.Background(), event.TopicWithdrawal, strconv.FormatUint(w.UserID, 10), payload)
 	}()
-
-	return withdrawalID, nil
 }
@@ -0,0 +1,37 @@
+package wallet
+
+import (
+	"context"
+
+	"wallet-core/internal/model"
+	"wallet-core/internal/service"
+	"wallet-core/pkg/errno"
+
+	"github.com/shopspring/decimal"
+)
+
+// CreateTransfer 站内转账给同租户的另一个用户 (按用户名或邮箱指定)，不上链
+// 与提现一样需要两步验证；带 idempotencyKey 的重试返回首次创建的转账
+func (s *Service) CreateTransfer(ctx context.Context, userID int64, to, amountStr, currency, memo, idempotencyKey, totpCode string) (*model.Transfer, error) {
+	amount, err := decimal.NewFromString(amountStr)
+	if err != nil {
+		return nil, errno.ErrAmountInvalid
+	}
+	return s.transfer.Send(ctx, service.TransferInput{
+		FromUserID:     uint64(userID),
+		Currency:       currency,
+		Amount:         amount,
+		Memo:           memo,
+		IdempotencyKey: idempotencyKey,
+	}, to, totpCode)
+}
+
+// GetTransfer 查询用户转出或转入的一笔站内转账
+func (s *Service) GetTransfer(ctx context.Context, userID int64, id uint64) (*model.Transfer, error) {
+	return s.transfer.Get(ctx, uint64(userID), id)
+}
+
+// ListTransfers 用户的站内转账记录 (转出和转入)，按 ID 倒序
+func (s *Service) ListTransfers(ctx context.Context, userID int64, currency string, beforeID uint64, limit int) ([]model.Transfer, error) {
+	return s.transfer.List(ctx, uint64(userID), currency, beforeID, limit)
+}
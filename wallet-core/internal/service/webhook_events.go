@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+
+	"wallet-core/internal/event"
+	"wallet-core/internal/model"
+	"wallet-core/internal/service/mq"
+)
+
+// 充值 / 提现通知事件 (账单事件见 InvoiceEvent*)
+const (
+	WebhookEventDepositDetected     = "deposit.detected"
+	WebhookEventDepositConfirmed    = "deposit.confirmed"
+	WebhookEventWithdrawalCreated   = "withdrawal.created"
+	WebhookEventWithdrawalCompleted = "withdrawal.completed"
+	WebhookEventWithdrawalFailed    = "withdrawal.failed"
+)
+
+// WebhookTopics 商户通知需要订阅的 MQ 主题 (账单事件由 InvoiceService 直接记录)
+var WebhookTopics = []string{
+	event.TopicDeposit,
+	event.TopicWithdrawal,
+	event.TopicWithdrawalConfirmed,
+	event.TopicWithdrawalFailed,
+}
+
+// DepositWebhookData deposit.* 事件数据
+type DepositWebhookData struct {
+	DepositID     uint64 `json:"deposit_id"`
+	Currency      string `json:"currency"`
+	TxHash        string `json:"tx_hash"`
+	Amount        string `json:"amount"`
+	Status        string `json:"status"`
+	Confirmations uint64 `json:"confirmations"`
+}
+
+// WithdrawalWebhookData withdrawal.* 事件数据
+type WithdrawalWebhookData struct {
+	WithdrawalID uint64 `json:"withdrawal_id"`
+	Currency     string `json:"currency"`
+	ToAddress    string `json:"to_address,omitempty"`
+	TxHash       string `json:"tx_hash,omitempty"`
+	Amount       string `json:"amount"`
+	Status       string `json:"status"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// walletWebhook 一条钱包事件对应的商户通知
+// UserID 为结算账户；UserID 为 0 的充值是账单地址收到的付款，按 DepositID 找到账单所属商户
+type walletWebhook struct {
+	UserID    uint64
+	DepositID uint64
+	Event     string
+	EventID   string
+	Data      interface{}
+}
+
+// translateWalletEvent 把一条 MQ 消息转换为商户通知，不关心的 topic 返回 nil
+// 事件 ID 由 topic + 业务 ID + 状态确定，MQ 重复投递时不会产生重复通知
+func translateWalletEvent(topic string, payload []byte) (*walletWebhook, error) {
+	switch topic {
+	case event.TopicDeposit:
+		var e event.DepositEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		ev := WebhookEventDepositDetected
+		if e.Status == model.DepositStatusConfirmed {
+			ev = WebhookEventDepositConfirmed
+		}
+		return &walletWebhook{
+			UserID:    e.UserID,
+			DepositID: e.DepositID,
+			Event:     ev,
+			EventID:   webhookEventID(ev, e.DepositID),
+			Data: DepositWebhookData{
+				DepositID:     e.DepositID,
+				Currency:      e.Chain,
+				TxHash:        e.TxHash,
+				Amount:        e.Amount,
+				Status:        e.Status,
+				Confirmations: e.Confirmations,
+			},
+		}, nil
+
+	case event.TopicWithdrawal:
+		var e event.WithdrawalCreatedEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		status := e.Status
+		if status == "" {
+			status = model.WithdrawalStatusPendingReview
+		}
+		return &walletWebhook{
+			UserID:  e.UserID,
+			Event:   WebhookEventWithdrawalCreated,
+			EventID: webhookEventID(WebhookEventWithdrawalCreated, e.WithdrawalID),
+			Data: WithdrawalWebhookData{
+				WithdrawalID: e.WithdrawalID,
+				Currency:     e.Chain,
+				ToAddress:    e.ToAddress,
+				Amount:       e.Amount,
+				Status:       status,
+			},
+		}, nil
+
+	case event.TopicWithdrawalConfirmed:
+		var e event.WithdrawalConfirmedEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		return &walletWebhook{
+			UserID:  e.UserID,
+			Event:   WebhookEventWithdrawalCompleted,
+			EventID: webhookEventID(WebhookEventWithdrawalCompleted, e.WithdrawalID),
+			Data: WithdrawalWebhookData{
+				WithdrawalID: e.WithdrawalID,
+				Currency:     e.Chain,
+				TxHash:       e.TxHash,
+				Amount:       e.Amount,
+				Status:       model.WithdrawalStatusCompleted,
+			},
+		}, nil
+
+	case event.TopicWithdrawalFailed:
+		var e event.WithdrawalFailedEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, err
+		}
+		// 同一笔提现可能多次失败 (替换交易)，按交易哈希区分
+		return &walletWebhook{
+			UserID:  e.UserID,
+			Event:   WebhookEventWithdrawalFailed,
+			EventID: webhookEventID(WebhookEventWithdrawalFailed+":"+e.TxHash, e.WithdrawalID),
+			Data: WithdrawalWebhookData{
+				WithdrawalID: e.WithdrawalID,
+				Currency:     e.Chain,
+				TxHash:       e.TxHash,
+				Amount:       e.Amount,
+				Status:       model.WithdrawalStatusFailed,
+				Reason:       e.Reason,
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
+// webhookEventID 由事件类型和业务 ID 确定的事件 ID
+func webhookEventID(kind string, id uint64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d", kind, id)))
+	return "evt_" + hex.EncodeToString(sum[:12])
+}
+
+// ConsumeEvents 订阅 WebhookTopics 中的所有主题，newConsumer 为每个主题创建独立的消费者 (同一消费组)
+// Redis Stream 消费者的 Subscribe 会阻塞，因此每个主题一个 goroutine
+func (s *WebhookService) ConsumeEvents(ctx context.Context, newConsumer func(topic string) mq.Consumer) {
+	for _, topic := range WebhookTopics {
+		topic := topic
+		consumer := newConsumer(topic)
+		go func() {
+			if err := consumer.Subscribe(ctx, topic, func(msg *mq.Message) error {
+				return s.handleWalletEvent(ctx, msg)
+			}); err != nil {
+				log.Printf("[Webhook] 订阅 %s 失败: %v", topic, err)
+			}
+		}()
+	}
+}
+
+func (s *WebhookService) handleWalletEvent(ctx context.Context, msg *mq.Message) error {
+	w, err := translateWalletEvent(msg.Topic, msg.Payload)
+	if err != nil {
+		log.Printf("[Webhook] 解析 %s 消息失败: %v", msg.Topic, err)
+		return nil // 格式错误，不再重试
+	}
+	if w == nil {
+		return nil
+	}
+	merchantID, err := s.eventMerchant(ctx, w)
+	if err != nil || merchantID == 0 {
+		return err
+	}
+	return s.Notify(ctx, merchantID, w.Event, w.EventID, w.Data)
+}
+
+// eventMerchant 事件所属的商户: 结算账户绑定的商户，或收到付款的账单地址所属的商户；都不是时返回 0
+func (s *WebhookService) eventMerchant(ctx context.Context, w *walletWebhook) (uint64, error) {
+	if w.UserID != 0 {
+		var m model.Merchant
+		err := s.db.WithContext(ctx).Select("id").
+			Where("user_id = ? AND status = ?", w.UserID, model.MerchantStatusActive).First(&m).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return m.ID, err
+	}
+	if w.DepositID == 0 {
+		return 0, nil
+	}
+
+	var dep model.Deposit
+	err := s.db.WithContext(ctx).Select("id", "user_id", "block_app_id").First(&dep, w.DepositID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil || dep.UserID != 0 || dep.BlockAppID == 0 {
+		return 0, err
+	}
+	var inv model.Invoice
+	err = s.db.WithContext(ctx).Select("merchant_id").Where("address_id = ?", dep.BlockAppID).Order("id").First(&inv).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	return inv.MerchantID, err
+}
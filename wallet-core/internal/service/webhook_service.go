@@ -0,0 +1,705 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"wallet-core/internal/model"
+	"wallet-core/internal/worker"
+	"wallet-core/internal/worker/tasks"
+	"wallet-core/pkg/apisign"
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/errno"
+	"wallet-core/pkg/kms"
+	"wallet-core/pkg/safe_random"
+)
+
+// 商户 API Key 权限范围: 通知
+const (
+	ScopeWebhookRead  = "webhook:read"  // 查询通知地址 / 投递记录
+	ScopeWebhookWrite = "webhook:write" // 管理通知地址、轮换密钥、重放投递
+)
+
+// 通知请求头 (签名头与商户 API 相同: X-Timestamp / X-Nonce / X-Signature，见 pkg/apisign)
+const (
+	WebhookHeaderEvent   = "X-Webhook-Event"
+	WebhookHeaderID      = "X-Webhook-Id"       // 投递 ID，重试时不变
+	WebhookHeaderEventID = "X-Webhook-Event-Id" // 事件 ID，重放时不变，商户据此去重
+)
+
+// redeliverAfter 超过该时长仍未投递成功的通知由定时扫描重新入队 (asynq 任务丢失兜底)
+const redeliverAfter = 5 * time.Minute
+
+// webhookEvents 可以订阅的事件
+var webhookEvents = map[string]bool{
+	WebhookEventDepositDetected:     true,
+	WebhookEventDepositConfirmed:    true,
+	WebhookEventWithdrawalCreated:   true,
+	WebhookEventWithdrawalCompleted: true,
+	WebhookEventWithdrawalFailed:    true,
+	InvoiceEventUnderpaid:           true,
+	InvoiceEventPaid:                true,
+	InvoiceEventOverpaid:            true,
+	InvoiceEventExpired:             true,
+	InvoiceEventLatePayment:         true,
+}
+
+// WebhookEvent 通知请求体
+type WebhookEvent struct {
+	ID        string      `json:"id"` // 事件 ID
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookService 商户通知
+// 1. 每个商户可配置多个通知地址，各自独立的签名密钥，按事件类型订阅
+// 2. 事件发生时为每个订阅的地址写入一条投递记录 (账单事件与状态变更同一事务)，由 asynq 任务签名后 POST 给商户
+// 3. 非 2xx 按 asynq 指数退避重试，最多 webhook_max_retry 次；每次尝试的响应码记入 webhook_attempts
+// 4. 商户可以查询投递记录、重放任意一次投递 (事件 ID 不变)、轮换签名密钥
+type WebhookService struct {
+	db       *gorm.DB
+	keys     kms.KeyManager
+	client   *worker.Client
+	cfg      config.InvoiceConfig
+	http     *http.Client
+	interval time.Duration
+}
+
+var Webhook *WebhookService
+
+func NewWebhookService(db *gorm.DB, keys kms.KeyManager, client *worker.Client, cfg config.InvoiceConfig) *WebhookService {
+	if cfg.WebhookTimeout <= 0 {
+		cfg.WebhookTimeout = 10 * time.Second
+	}
+	if cfg.WebhookMaxRetry <= 0 {
+		cfg.WebhookMaxRetry = 10
+	}
+	if cfg.WebhookMaxEndpoints <= 0 {
+		cfg.WebhookMaxEndpoints = 5
+	}
+	return &WebhookService{
+		db:       db,
+		keys:     keys,
+		client:   client,
+		cfg:      cfg,
+		http:     newWebhookClient(cfg.WebhookTimeout, cfg.WebhookAllowPrivate),
+		interval: 30 * time.Second,
+	}
+}
+
+// WebhookInput 通知地址参数，修改时 Enabled 为空表示不变
+type WebhookInput struct {
+	URL         string
+	Description string
+	Events      []string
+	Enabled     *bool
+}
+
+// CreateEndpoint 新增通知地址并生成签名密钥，密钥明文只返回这一次
+func (s *WebhookService) CreateEndpoint(ctx context.Context, merchantID uint64, in WebhookInput) (*model.MerchantWebhook, string, error) {
+	if err := s.validateInput(in); err != nil {
+		return nil, "", err
+	}
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&model.MerchantWebhook{}).Where("merchant_id = ?", merchantID).Count(&count).Error; err != nil {
+		return nil, "", err
+	}
+	if count >= int64(s.cfg.WebhookMaxEndpoints) {
+		return nil, "", errno.ErrWebhookLimitExceeded
+	}
+
+	hook := &model.MerchantWebhook{
+		MerchantID:  merchantID,
+		URL:         in.URL,
+		Description: in.Description,
+		Events:      model.StringList(in.Events),
+		Enabled:     in.Enabled == nil || *in.Enabled,
+	}
+	secret, err := s.sealSecret(hook)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.db.WithContext(ctx).Create(hook).Error; err != nil {
+		return nil, "", err
+	}
+	return hook, secret, nil
+}
+
+// UpdateEndpoint 修改通知地址 / 订阅的事件 / 启用状态，密钥不变
+func (s *WebhookService) UpdateEndpoint(ctx context.Context, merchantID, id uint64, in WebhookInput) (*model.MerchantWebhook, error) {
+	if err := s.validateInput(in); err != nil {
+		return nil, err
+	}
+	hook, err := s.getEndpoint(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+	hook.URL = in.URL
+	hook.Description = in.Description
+	hook.Events = model.StringList(in.Events)
+	if in.Enabled != nil {
+		hook.Enabled = *in.Enabled
+	}
+	if err := s.db.WithContext(ctx).Save(hook).Error; err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// DeleteEndpoint 删除通知地址，尚未投递的记录在下次尝试时标记为 failed
+func (s *WebhookService) DeleteEndpoint(ctx context.Context, merchantID, id uint64) error {
+	res := s.db.WithContext(ctx).Where("id = ? AND merchant_id = ?", id, merchantID).Delete(&model.MerchantWebhook{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errno.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// ListEndpoints 商户的全部通知地址
+func (s *WebhookService) ListEndpoints(ctx context.Context, merchantID uint64) ([]model.MerchantWebhook, error) {
+	list := []model.MerchantWebhook{}
+	err := s.db.WithContext(ctx).Where("merchant_id = ?", merchantID).Order("id").Find(&list).Error
+	return list, err
+}
+
+// RotateSecret 为通知地址生成新的签名密钥，立即生效 (包括正在重试的投递)，密钥明文只返回这一次
+func (s *WebhookService) RotateSecret(ctx context.Context, merchantID, id uint64) (*model.MerchantWebhook, string, error) {
+	hook, err := s.getEndpoint(ctx, merchantID, id)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := s.sealSecret(hook)
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	hook.SecretRotatedAt = &now
+	if err := s.db.WithContext(ctx).Save(hook).Error; err != nil {
+		return nil, "", err
+	}
+	return hook, secret, nil
+}
+
+// SetPrimary 设置商户最早创建的通知地址 (没有时新建，订阅全部事件) 并生成新的签名密钥
+// 兼容多地址之前的 PUT /merchant/webhook
+func (s *WebhookService) SetPrimary(ctx context.Context, merchantID uint64, rawURL string) (*model.MerchantWebhook, string, error) {
+	hook, err := s.GetPrimary(ctx, merchantID)
+	if errors.Is(err, errno.ErrWebhookNotConfigured) {
+		return s.CreateEndpoint(ctx, merchantID, WebhookInput{URL: rawURL})
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.validateInput(WebhookInput{URL: rawURL}); err != nil {
+		return nil, "", err
+	}
+	hook.URL = rawURL
+	secret, err := s.sealSecret(hook)
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	hook.SecretRotatedAt = &now
+	if err := s.db.WithContext(ctx).Save(hook).Error; err != nil {
+		return nil, "", err
+	}
+	return hook, secret, nil
+}
+
+// GetPrimary 商户最早创建的通知地址
+func (s *WebhookService) GetPrimary(ctx context.Context, merchantID uint64) (*model.MerchantWebhook, error) {
+	var hook model.MerchantWebhook
+	if err := s.db.WithContext(ctx).Where("merchant_id = ?", merchantID).Order("id").First(&hook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrWebhookNotConfigured
+		}
+		return nil, err
+	}
+	return &hook, nil
+}
+
+func (s *WebhookService) getEndpoint(ctx context.Context, merchantID, id uint64) (*model.MerchantWebhook, error) {
+	var hook model.MerchantWebhook
+	if err := s.db.WithContext(ctx).Where("id = ? AND merchant_id = ?", id, merchantID).First(&hook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &hook, nil
+}
+
+func (s *WebhookService) validateInput(in WebhookInput) error {
+	if err := validateWebhookURL(in.URL, config.Global.App.Env == "production", s.cfg.WebhookAllowPrivate); err != nil {
+		return err
+	}
+	return validateWebhookEvents(in.Events)
+}
+
+// sealSecret 生成新的签名密钥，经 KMS 加密后写入 hook (不保存)，返回明文
+func (s *WebhookService) sealSecret(hook *model.MerchantWebhook) (string, error) {
+	secret, err := safe_random.GenerateRandomHexString(32)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := s.keys.Encrypt(WebhookKeyID, []byte(secret))
+	if err != nil {
+		return "", fmt.Errorf("加密通知密钥失败: %w", err)
+	}
+	sum := sha256.Sum256([]byte(secret))
+	hook.KMSKeyID = WebhookKeyID
+	hook.SecretCiphertext = base64.StdEncoding.EncodeToString(ciphertext)
+	hook.SecretHash = hex.EncodeToString(sum[:])
+	return secret, nil
+}
+
+// Record 为商户订阅了该事件的每个启用的通知地址写入一条投递记录，返回新写入的记录
+// eventID 为空时随机生成；同一 eventID 在同一地址上重复写入会被忽略 (MQ 重复消费)
+// 账单事件在状态变更的事务中调用 (tx)，提交后再调用 Enqueue
+func (s *WebhookService) Record(tx *gorm.DB, merchantID uint64, ev, eventID string, invoiceID uint64, data interface{}) ([]model.WebhookDelivery, error) {
+	var hooks []model.MerchantWebhook
+	if err := tx.Where("merchant_id = ? AND enabled = ?", merchantID, true).Order("id").Find(&hooks).Error; err != nil {
+		return nil, err
+	}
+	var targets []uint64
+	for i := range hooks {
+		if hooks[i].Subscribed(ev) {
+			targets = append(targets, hooks[i].ID)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	if eventID == "" {
+		random, err := safe_random.GenerateRandomHexString(12)
+		if err != nil {
+			return nil, err
+		}
+		eventID = "evt_" + random
+	}
+	payload, err := json.Marshal(WebhookEvent{
+		ID:        eventID,
+		Event:     ev,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var created []model.WebhookDelivery
+	for _, endpointID := range targets {
+		d := model.WebhookDelivery{
+			MerchantID: merchantID,
+			EndpointID: endpointID,
+			EventID:    eventID,
+			InvoiceID:  invoiceID,
+			Event:      ev,
+			Payload:    string(payload),
+			Status:     model.WebhookDeliveryPending,
+		}
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&d)
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		if res.RowsAffected > 0 {
+			created = append(created, d)
+		}
+	}
+	return created, nil
+}
+
+// Enqueue 事务提交后投递通知任务，失败由定时扫描兜底
+func (s *WebhookService) Enqueue(deliveries []model.WebhookDelivery) {
+	for _, d := range deliveries {
+		s.enqueue(tasks.NewMerchantWebhookTask(d.ID, s.cfg.WebhookMaxRetry))
+	}
+}
+
+// Notify 在独立事务中记录并投递一个事件 (充值 / 提现事件)
+func (s *WebhookService) Notify(ctx context.Context, merchantID uint64, ev, eventID string, data interface{}) error {
+	var deliveries []model.WebhookDelivery
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		deliveries, err = s.Record(tx, merchantID, ev, eventID, 0, data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.Enqueue(deliveries)
+	return nil
+}
+
+// DeliveryQuery 投递记录查询条件，零值表示不过滤
+type DeliveryQuery struct {
+	EndpointID uint64
+	InvoiceID  uint64
+	Event      string
+	Status     string
+	BeforeID   uint64 // 上一页最后一条的 ID
+	Limit      int
+}
+
+// DeliveryDetail 投递记录、请求体和每次尝试的结果
+type DeliveryDetail struct {
+	Delivery *model.WebhookDelivery `json:"delivery"`
+	Payload  json.RawMessage        `json:"payload"`
+	Attempts []model.WebhookAttempt `json:"attempts"`
+}
+
+// ListDeliveries 商户的投递记录，按 ID 倒序
+func (s *WebhookService) ListDeliveries(ctx context.Context, merchantID uint64, q DeliveryQuery) ([]model.WebhookDelivery, error) {
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+	db := s.db.WithContext(ctx).Where("merchant_id = ?", merchantID)
+	if q.EndpointID > 0 {
+		db = db.Where("endpoint_id = ?", q.EndpointID)
+	}
+	if q.InvoiceID > 0 {
+		db = db.Where("invoice_id = ?", q.InvoiceID)
+	}
+	if q.Event != "" {
+		db = db.Where("event = ?", q.Event)
+	}
+	if q.Status != "" {
+		db = db.Where("status = ?", q.Status)
+	}
+	if q.BeforeID > 0 {
+		db = db.Where("id < ?", q.BeforeID)
+	}
+	list := []model.WebhookDelivery{}
+	err := db.Order("id DESC").Limit(q.Limit).Find(&list).Error
+	return list, err
+}
+
+// GetDelivery 投递详情
+func (s *WebhookService) GetDelivery(ctx context.Context, merchantID, id uint64) (*DeliveryDetail, error) {
+	d, err := s.getDelivery(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+	attempts := []model.WebhookAttempt{}
+	if err := s.db.WithContext(ctx).Where("delivery_id = ?", d.ID).Order("id").Find(&attempts).Error; err != nil {
+		return nil, err
+	}
+	return &DeliveryDetail{Delivery: d, Payload: json.RawMessage(d.Payload), Attempts: attempts}, nil
+}
+
+// Replay 重新投递一次 (不论原投递成功与否)，请求体和事件 ID 不变，使用通知地址当前的密钥签名
+// 同一事件在同一地址上还有未完成的投递时拒绝，避免重复堆积
+func (s *WebhookService) Replay(ctx context.Context, merchantID, id uint64) (*model.WebhookDelivery, error) {
+	orig, err := s.getDelivery(ctx, merchantID, id)
+	if err != nil {
+		return nil, err
+	}
+	hook, err := s.getEndpoint(ctx, merchantID, orig.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	if !hook.Enabled {
+		return nil, errno.ErrWebhookInvalid.WithMessage("webhook endpoint is disabled")
+	}
+
+	var pending int64
+	if err := s.db.WithContext(ctx).Model(&model.WebhookDelivery{}).
+		Where("endpoint_id = ? AND event_id = ? AND status = ?", orig.EndpointID, orig.EventID, model.WebhookDeliveryPending).
+		Count(&pending).Error; err != nil {
+		return nil, err
+	}
+	if pending > 0 {
+		return nil, errno.ErrWebhookInvalid.WithMessage("a delivery of this event is still pending")
+	}
+
+	d := &model.WebhookDelivery{
+		MerchantID: orig.MerchantID,
+		EndpointID: orig.EndpointID,
+		EventID:    orig.EventID,
+		InvoiceID:  orig.InvoiceID,
+		Event:      orig.Event,
+		Payload:    orig.Payload,
+		Status:     model.WebhookDeliveryPending,
+		ReplayOf:   replayRoot(orig),
+	}
+	if err := s.db.WithContext(ctx).Create(d).Error; err != nil {
+		return nil, err
+	}
+	s.Enqueue([]model.WebhookDelivery{*d})
+	return d, nil
+}
+
+// replayRoot 重放记录指向最初的那次投递
+func replayRoot(d *model.WebhookDelivery) uint64 {
+	if d.ReplayOf != 0 {
+		return d.ReplayOf
+	}
+	return d.ID
+}
+
+func (s *WebhookService) getDelivery(ctx context.Context, merchantID, id uint64) (*model.WebhookDelivery, error) {
+	var d model.WebhookDelivery
+	if err := s.db.WithContext(ctx).Where("id = ? AND merchant_id = ?", id, merchantID).First(&d).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Start 定时重新投递积压的通知 (阻塞，直到 ctx 取消)
+func (s *WebhookService) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.redeliverPending(ctx)
+		}
+	}
+}
+
+func (s *WebhookService) redeliverPending(ctx context.Context) {
+	var ids []uint64
+	if err := s.db.WithContext(ctx).Model(&model.WebhookDelivery{}).
+		Where("status = ? AND updated_at <= ?", model.WebhookDeliveryPending, time.Now().Add(-redeliverAfter)).
+		Limit(100).Pluck("id", &ids).Error; err != nil {
+		log.Printf("[Webhook] 扫描待投递通知失败: %v", err)
+		return
+	}
+	for _, id := range ids {
+		s.enqueue(tasks.NewMerchantWebhookTask(id, s.cfg.WebhookMaxRetry))
+	}
+}
+
+// HandleTask asynq 处理器: 签名并投递一条通知，非 2xx 返回错误触发重试，最后一次失败后标记为 failed
+func (s *WebhookService) HandleTask(ctx context.Context, t *asynq.Task) error {
+	p, err := tasks.ParseMerchantWebhookPayload(t)
+	if err != nil {
+		return err
+	}
+
+	var d model.WebhookDelivery
+	if err := s.db.WithContext(ctx).First(&d, p.DeliveryID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("webhook delivery %d not found: %w", p.DeliveryID, asynq.SkipRetry)
+		}
+		return err
+	}
+	if d.Status != model.WebhookDeliveryPending {
+		return nil
+	}
+
+	var hook model.MerchantWebhook
+	err = s.db.WithContext(ctx).First(&hook, d.EndpointID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !hook.Enabled) {
+		return s.finishDelivery(ctx, d.ID, nil, map[string]interface{}{
+			"status":     model.WebhookDeliveryFailed,
+			"last_error": "webhook endpoint removed or disabled",
+		})
+	}
+	if err != nil {
+		return err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(hook.SecretCiphertext)
+	if err != nil {
+		return err
+	}
+	secret, err := s.keys.Decrypt(hook.KMSKeyID, ciphertext)
+	if err != nil {
+		return fmt.Errorf("解密通知密钥失败: %w", err)
+	}
+
+	started := time.Now()
+	code, sendErr := s.send(ctx, hook.URL, string(secret), &d)
+	attempt := &model.WebhookAttempt{
+		DeliveryID:   d.ID,
+		ResponseCode: code,
+		DurationMs:   time.Since(started).Milliseconds(),
+	}
+	updates := map[string]interface{}{
+		"attempts":      gorm.Expr("attempts + 1"),
+		"response_code": code,
+	}
+	if sendErr == nil {
+		updates["status"] = model.WebhookDeliveryDelivered
+		updates["delivered_at"] = time.Now()
+		updates["last_error"] = ""
+	} else {
+		attempt.Error = sendErr.Error()
+		updates["last_error"] = sendErr.Error()
+		if lastAttempt(ctx) {
+			updates["status"] = model.WebhookDeliveryFailed
+			log.Printf("[Webhook] 通知 #%d (%s) 重试次数用尽: %v", d.ID, d.Event, sendErr)
+		}
+	}
+	if err := s.finishDelivery(ctx, d.ID, attempt, updates); err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// finishDelivery 写入尝试记录并更新投递状态 (只更新仍为 pending 的记录)
+func (s *WebhookService) finishDelivery(ctx context.Context, id uint64, attempt *model.WebhookAttempt, updates map[string]interface{}) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if attempt != nil {
+			if err := tx.Create(attempt).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&model.WebhookDelivery{}).
+			Where("id = ? AND status = ?", id, model.WebhookDeliveryPending).
+			Updates(updates).Error
+	})
+}
+
+// send POST 通知并按 pkg/apisign 签名 (path 为通知地址的 RequestURI)，返回响应码
+func (s *WebhookService) send(ctx context.Context, rawURL, secret string, d *model.WebhookDelivery) (int, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, err
+	}
+	nonce, err := safe_random.GenerateRandomHexString(16)
+	if err != nil {
+		return 0, err
+	}
+	body := []byte(d.Payload)
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookHeaderEvent, d.Event)
+	req.Header.Set(WebhookHeaderID, strconv.FormatUint(d.ID, 10))
+	req.Header.Set(WebhookHeaderEventID, d.EventID)
+	req.Header.Set(apisign.HeaderTimestamp, ts)
+	req.Header.Set(apisign.HeaderNonce, nonce)
+	req.Header.Set(apisign.HeaderSignature, apisign.Sign(secret, http.MethodPost, u.RequestURI(), ts, nonce, body))
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (s *WebhookService) enqueue(task *asynq.Task, err error) {
+	if s.client == nil {
+		return
+	}
+	if err != nil {
+		log.Printf("[Webhook] 创建任务失败: %v", err)
+		return
+	}
+	if _, err := s.client.Enqueue(task); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		log.Printf("[Webhook] 投递任务 %s 失败: %v", task.Type(), err)
+	}
+}
+
+// lastAttempt 当前是否为 asynq 的最后一次重试
+func lastAttempt(ctx context.Context) bool {
+	retried, ok := asynq.GetRetryCount(ctx)
+	if !ok {
+		return false
+	}
+	max, ok := asynq.GetMaxRetry(ctx)
+	return ok && retried >= max
+}
+
+// newWebhookClient 通知用的 HTTP 客户端: 不跟随重定向，默认拒绝连接内网 / 回环地址 (防 SSRF，按实际解析出的 IP 判断)
+func newWebhookClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return fmt.Errorf("webhook address %s is not public", host)
+			}
+			return nil
+		}
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConnsPerHost: 4,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// validateWebhookURL 通知地址: 绝对 http(s) URL，不带用户名密码；生产环境必须 https；主机为 IP 时不能是内网地址
+func validateWebhookURL(raw string, requireHTTPS, allowPrivate bool) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || u.User != nil {
+		return errno.ErrWebhookURLInvalid
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if requireHTTPS {
+			return errno.ErrWebhookURLInvalid.WithMessage("webhook URL must use https")
+		}
+	default:
+		return errno.ErrWebhookURLInvalid
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !allowPrivate && !publicIP(ip) {
+		return errno.ErrWebhookURLInvalid.WithMessage("webhook URL must not point to a private address")
+	}
+	return nil
+}
+
+// validateWebhookEvents 订阅的事件均为已定义的事件，为空表示订阅全部
+func validateWebhookEvents(events []string) error {
+	for _, e := range events {
+		if !webhookEvents[e] {
+			return errno.ErrWebhookInvalid.WithMessage("unknown event: " + e)
+		}
+	}
+	return nil
+}
+
+func publicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsMulticast())
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/internal/event"
+	"wallet-core/internal/model"
+	"wallet-core/pkg/apisign"
+	"wallet-core/pkg/errno"
+)
+
+func TestTranslateWalletEvent(t *testing.T) {
+	w, err := translateWalletEvent(event.TopicDeposit, []byte(`{"deposit_id":7,"user_id":3,"chain":"ETH","tx_hash":"0xabc","amount":"1.5","status":"pending","confirmations":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, WebhookEventDepositDetected, w.Event)
+	assert.Equal(t, uint64(3), w.UserID)
+	assert.Equal(t, uint64(7), w.DepositID)
+	assert.Equal(t, "1.5", w.Data.(DepositWebhookData).Amount)
+
+	confirmed, err := translateWalletEvent(event.TopicDeposit, []byte(`{"deposit_id":7,"user_id":3,"status":"confirmed"}`))
+	require.NoError(t, err)
+	assert.Equal(t, WebhookEventDepositConfirmed, confirmed.Event)
+	// 同一笔充值的检测 / 入账是两个事件，重复消费同一条消息得到相同的事件 ID
+	assert.NotEqual(t, w.EventID, confirmed.EventID)
+	again, _ := translateWalletEvent(event.TopicDeposit, []byte(`{"deposit_id":7,"user_id":3,"status":"confirmed"}`))
+	assert.Equal(t, confirmed.EventID, again.EventID)
+
+	w, err = translateWalletEvent(event.TopicWithdrawal, []byte(`{"withdrawal_id":9,"user_id":3,"to_address":"0xdef","amount":"2","chain":"ETH"}`))
+	require.NoError(t, err)
+	assert.Equal(t, WebhookEventWithdrawalCreated, w.Event)
+	assert.Equal(t, model.WithdrawalStatusPendingReview, w.Data.(WithdrawalWebhookData).Status)
+
+	w, err = translateWalletEvent(event.TopicWithdrawalConfirmed, []byte(`{"withdrawal_id":9,"user_id":3,"tx_hash":"0x1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, WebhookEventWithdrawalCompleted, w.Event)
+	assert.Equal(t, model.WithdrawalStatusCompleted, w.Data.(WithdrawalWebhookData).Status)
+
+	// 替换交易再次失败是新的事件
+	first, err := translateWalletEvent(event.TopicWithdrawalFailed, []byte(`{"withdrawal_id":9,"user_id":3,"tx_hash":"0x1","reason":"reverted"}`))
+	require.NoError(t, err)
+	second, _ := translateWalletEvent(event.TopicWithdrawalFailed, []byte(`{"withdrawal_id":9,"user_id":3,"tx_hash":"0x2","reason":"dropped"}`))
+	assert.Equal(t, WebhookEventWithdrawalFailed, first.Event)
+	assert.NotEqual(t, first.EventID, second.EventID)
+
+	w, err = translateWalletEvent(event.TopicKYCTierChanged, []byte(`{"user_id":3}`))
+	assert.NoError(t, err)
+	assert.Nil(t, w)
+
+	_, err = translateWalletEvent(event.TopicDeposit, []byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestValidateWebhookEvents(t *testing.T) {
+	assert.NoError(t, validateWebhookEvents(nil))
+	assert.NoError(t, validateWebhookEvents([]string{WebhookEventDepositConfirmed, InvoiceEventPaid}))
+
+	var e errno.Errno
+	assert.ErrorAs(t, validateWebhookEvents([]string{"deposit.*"}), &e)
+	assert.Equal(t, errno.ErrWebhookInvalid.Code, e.Code)
+}
+
+func TestWebhookSubscribed(t *testing.T) {
+	all := &model.MerchantWebhook{}
+	assert.True(t, all.Subscribed(InvoiceEventPaid))
+
+	some := &model.MerchantWebhook{Events: model.StringList{WebhookEventDepositConfirmed}}
+	assert.True(t, some.Subscribed(WebhookEventDepositConfirmed))
+	assert.False(t, some.Subscribed(InvoiceEventPaid))
+}
+
+func TestReplayRoot(t *testing.T) {
+	assert.Equal(t, uint64(5), replayRoot(&model.WebhookDelivery{ID: 5}))
+	assert.Equal(t, uint64(5), replayRoot(&model.WebhookDelivery{ID: 8, ReplayOf: 5}))
+}
+
+func TestWebhookSend(t *testing.T) {
+	const secret = "s3cret"
+	var got *http.Request
+	var body []byte
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	s := &WebhookService{http: newWebhookClient(time.Second, true)}
+	d := &model.WebhookDelivery{ID: 42, EventID: "evt_1", Event: InvoiceEventPaid, Payload: `{"id":"evt_1"}`}
+
+	code, err := s.send(context.Background(), srv.URL+"/hooks?src=wallet", secret, d)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, InvoiceEventPaid, got.Header.Get(WebhookHeaderEvent))
+	assert.Equal(t, "42", got.Header.Get(WebhookHeaderID))
+	assert.Equal(t, "evt_1", got.Header.Get(WebhookHeaderEventID))
+	// 商户用自己的密钥按 API 签名规则验签
+	assert.True(t, apisign.Verify(secret, got.Header.Get(apisign.HeaderSignature), http.MethodPost, "/hooks?src=wallet",
+		got.Header.Get(apisign.HeaderTimestamp), got.Header.Get(apisign.HeaderNonce), body))
+
+	status = http.StatusServiceUnavailable
+	code, err = s.send(context.Background(), srv.URL+"/hooks", secret, d)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	// 默认拒绝连接回环地址
+	s.http = newWebhookClient(time.Second, false)
+	code, err = s.send(context.Background(), srv.URL+"/hooks", secret, d)
+	assert.Error(t, err)
+	assert.Zero(t, code)
+}
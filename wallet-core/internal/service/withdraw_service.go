@@ -2,33 +2,168 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"wallet-core/internal/model"
-	"wallet-core/pkg/database"
+	"wallet-core/internal/service/mfa"
+	"wallet-core/internal/service/risk"
+	"wallet-core/internal/service/screening"
+	"wallet-core/pkg/address"
+	"wallet-core/pkg/errno"
 
+	"github.com/btcsuite/btcd/chaincfg"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-type WithdrawService struct{}
+type WithdrawService struct {
+	db       *gorm.DB
+	risk     *risk.Engine        // 风控引擎 (nil 时使用默认审批人数)
+	screener *screening.Screener // 制裁名单筛查 (nil 时不筛查)
+	mfa      *mfa.Service        // 两步验证
+	transfer *TransferService    // 站内转账 (收款地址属于本平台用户时站内结算)
+	network  *chaincfg.Params    // BTC 地址校验网络
+}
+
+var Withdraw *WithdrawService
 
-var Withdraw = &WithdrawService{}
+func NewWithdrawService(db *gorm.DB, riskEngine *risk.Engine, screener *screening.Screener, mfaSvc *mfa.Service, transfer *TransferService, network *chaincfg.Params) *WithdrawService {
+	return &WithdrawService{db: db, risk: riskEngine, screener: screener, mfa: mfaSvc, transfer: transfer, network: network}
+}
 
 // CreateWithdrawal 创建提现申请
-func (s *WithdrawService) CreateWithdrawal(ctx context.Context, userID uint64, req *model.Withdrawal) error {
-	return database.DB.Transaction(func(tx *gorm.DB) error {
-		// 1. 检查余额 (这里暂时略过，假设足够)
-		// ...
-
-		// 2. 设置初始状态 (关键点: pending_review)
-		req.Status = "pending_review"
-		req.RequiredApprovals = 2 // 从 Config 读取，这里硬编码演示
+// 已开启两步验证的用户必须提供 totpCode
+func (s *WithdrawService) CreateWithdrawal(ctx context.Context, userID uint64, req *model.Withdrawal, totpCode string) error {
+	req.UserID = userID
+
+	if err := CheckWithdrawalAmount(req.Chain, req.Amount); err != nil {
+		return err
+	}
+	// 收款地址规范化 (ETH 为 EIP-55)，与充值地址表的存储格式一致，站内结算才能识别平台地址
+	toAddr, err := NormalizeWithdrawalAddress(req.Chain, req.ToAddress, s.network)
+	if err != nil {
+		return err
+	}
+	req.ToAddress = toAddr
+
+	if err := s.mfa.Require(ctx, userID, totpCode); err != nil {
+		return err
+	}
+
+	// 1. 时间锁: 用户预约时间 / 大额强制延迟，审批通过后需等到 ExecuteAfter 才能广播
+	if err := ApplyTimeLock(req, req.ExecuteAfter); err != nil {
+		return err
+	}
+
+	// 2. 风控评分: 决定初始状态 (pending_review / risk_hold) 和所需审批人数
+	if s.risk != nil {
+		if err := s.risk.Assess(ctx, req); err != nil {
+			return err
+		}
+	} else {
+		req.Status = model.WithdrawalStatusPendingReview
+		req.RequiredApprovals = 2
 		req.CurrentApprovals = 0
+	}
+
+	// 3. 收款地址属于本平台 (同租户) 的其他用户: 不上链，站内结算后直接完成 (按站内转账限额，不占用提现额度)
+	// 有时间锁或被风控标记的提现不即时结算，照常审核 / 等待，审批通过后按链上提现出款
+	if SettleInstantly(s.risk, req) {
+		settled, err := s.transfer.SettleWithdrawal(ctx, req)
+		if err != nil || settled {
+			return err
+		}
+	}
 
-		// 3. 创建记录
-		if err := tx.Create(req).Error; err != nil {
+	// 4. 制裁名单筛查: 收款地址命中直接拦截 (errno.ErrAddressSanctioned)
+	if err := s.screener.ScreenWithdrawal(ctx, req); err != nil {
+		return err
+	}
+
+	// 5. 检查余额 -> 实名认证 / 租户限额 -> 冻结资金 -> 创建记录
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var account model.Account
+		// 悲观锁: SELECT ... FOR UPDATE
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND currency = ?", userID, req.Chain).
+			First(&account).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.ErrAccountNotFound
+			}
 			return err
 		}
+		if account.Balance.LessThan(req.Amount) {
+			return errno.ErrInsufficientBalance
+		}
 
-		return nil
+		// 实名认证等级: 提现开关 / 可用币种 / 24 小时额度 (持有账户行锁，并发提现不会突破额度)
+		if err := CheckKYCWithdrawal(ctx, tx, userID, req.Chain, req.Amount); err != nil {
+			return err
+		}
+
+		// 租户限额 (单笔 / 租户 24 小时合计，锁租户链配置行)，提现记在用户所属租户下
+		tenantID, err := CheckTenantWithdrawal(ctx, tx, userID, req.Chain, req.Amount)
+		if err != nil {
+			return err
+		}
+		req.TenantID = tenantID
+
+		// 冻结资金 (Balance -> LockedBalance)
+		account.Balance = account.Balance.Sub(req.Amount)
+		account.LockedBalance = account.LockedBalance.Add(req.Amount)
+		account.Version++ // 站内转账按版本号乐观更新，这里同样递增
+		if err := tx.Save(&account).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(req).Error
 	})
+	if err != nil {
+		return err
+	}
+
+	// 6. 投递时间锁任务 (锁定通知 / 执行前提醒 / 到期放行)
+	TimeLock.Schedule(ctx, req)
+	return nil
+}
+
+// SettleInstantly 已完成时间锁和风控评分的提现能否即时站内结算
+// 站内结算创建即完成，会绕过大额强制延迟和人工审核，只用于没有时间锁、风控分数未达到复审阈值的提现
+func SettleInstantly(riskEngine *risk.Engine, w *model.Withdrawal) bool {
+	if w.ExecuteAfter != nil {
+		return false
+	}
+	if riskEngine == nil {
+		return true
+	}
+	p := riskEngine.Policy()
+	return p == nil || !p.Flagged(w.RiskScore)
+}
+
+// NormalizeWithdrawalAddress 校验收款地址格式，返回规范化后的地址 (见 address.Validate)
+func NormalizeWithdrawalAddress(chain, addr string, network *chaincfg.Params) (string, error) {
+	normalized, err := address.Validate(chain, strings.TrimSpace(addr), network)
+	if errors.Is(err, address.ErrUnsupportedChain) {
+		return "", errno.ErrBind.WithMessage("unsupported currency " + chain)
+	}
+	if err != nil {
+		return "", errno.ErrAddressInvalid.WithMessage(err.Error())
+	}
+	return normalized, nil
+}
+
+// ListPendingWithdrawals 待审核提现列表 (含风控挂起)，按风控分数从高到低排列
+func (s *WithdrawService) ListPendingWithdrawals(ctx context.Context, limit int) ([]model.Withdrawal, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	var list []model.Withdrawal
+	err := s.db.WithContext(ctx).
+		Where("status IN ?", []string{model.WithdrawalStatusPendingReview, model.WithdrawalStatusRiskHold}).
+		Order("risk_score DESC, id ASC").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
 }
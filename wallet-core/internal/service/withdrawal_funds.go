@@ -0,0 +1,50 @@
+package service
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+)
+
+// ReleaseWithdrawalFunds 提现未出款就终止 (用户取消 / 审核拒绝 / 链上失败 / 已取消): 冻结资金退回可用余额
+// 必须在更新提现状态的同一事务内调用
+func ReleaseWithdrawalFunds(tx *gorm.DB, w *model.Withdrawal) error {
+	return settleLockedFunds(tx, w, true)
+}
+
+// DeductWithdrawalFunds 提现链上确认: 扣除冻结资金
+func DeductWithdrawalFunds(tx *gorm.DB, w *model.Withdrawal) error {
+	return settleLockedFunds(tx, w, false)
+}
+
+func settleLockedFunds(tx *gorm.DB, w *model.Withdrawal, release bool) error {
+	// 站内结算的提现创建时已直接划转余额，没有冻结资金
+	if w.TransferID != 0 {
+		return nil
+	}
+	result := lockedFundsUpdate(tx, w, release)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// 早期单体版本创建的提现没有冻结资金，这里只记录，不阻断状态流转
+		log.Printf("[Funds] ⚠️ 提现 #%d 冻结资金不足，跳过 (user=%d %s %s)", w.ID, w.UserID, w.Chain, w.Amount)
+	}
+	return nil
+}
+
+// lockedFundsUpdate locked_balance -= amount (退回时 balance += amount)，版本号递增让站内转账的乐观更新失效
+func lockedFundsUpdate(tx *gorm.DB, w *model.Withdrawal, release bool) *gorm.DB {
+	updates := map[string]interface{}{
+		"locked_balance": gorm.Expr("locked_balance - ?", w.Amount),
+		"version":        gorm.Expr("version + 1"),
+	}
+	if release {
+		updates["balance"] = gorm.Expr("balance + ?", w.Amount)
+	}
+	return tx.Model(&model.Account{}).
+		Where("user_id = ? AND currency = ? AND locked_balance >= ?", w.UserID, w.Chain, w.Amount).
+		Updates(updates)
+}
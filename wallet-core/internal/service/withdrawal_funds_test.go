@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"wallet-core/internal/model"
+)
+
+// dryRunDB 只生成 SQL，不连接数据库
+func dryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestLockedFundsUpdate(t *testing.T) {
+	db := dryRunDB(t)
+	amount := decimal.RequireFromString("1.5")
+	w := &model.Withdrawal{ID: 9, UserID: 7, Chain: "ETH", Amount: amount}
+
+	// 退回: 可用余额 + amount，冻结余额 - amount
+	stmt := lockedFundsUpdate(db, w, true).Statement
+	sql := stmt.SQL.String()
+	assert.Contains(t, sql, `UPDATE "accounts" SET "balance"=balance + $1,"locked_balance"=locked_balance - $2,`)
+	assert.Contains(t, sql, `"version"=version + 1`)
+	assert.Contains(t, sql, `WHERE user_id = $4 AND currency = $5 AND locked_balance >= $6`)
+	assert.Equal(t, amount, stmt.Vars[0])
+	assert.Equal(t, amount, stmt.Vars[1])
+	assert.Equal(t, []interface{}{uint64(7), "ETH", amount}, stmt.Vars[len(stmt.Vars)-3:])
+
+	// 扣除: 只减冻结余额
+	stmt = lockedFundsUpdate(db, w, false).Statement
+	sql = stmt.SQL.String()
+	assert.NotContains(t, sql, `"balance"=`)
+	assert.Contains(t, sql, `"locked_balance"=locked_balance - $1`)
+	assert.Equal(t, amount, stmt.Vars[0])
+}
+
+func TestSettleLockedFundsSkipsInternalTransfer(t *testing.T) {
+	// 站内结算的提现不访问数据库 (nil tx 不会被使用)
+	w := &model.Withdrawal{ID: 9, UserID: 7, Chain: "ETH", Amount: decimal.NewFromInt(1), TransferID: 3}
+	assert.NoError(t, ReleaseWithdrawalFunds(nil, w))
+	assert.NoError(t, DeductWithdrawalFunds(nil, w))
+}
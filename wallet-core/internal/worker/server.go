@@ -47,6 +47,12 @@ func NewServer(addr string, password string, db int, concurrency int) *Server {
 	}
 }
 
+// Handle 注册任务处理器 (需在 Start / Run 之前调用)
+// 依赖数据库等业务对象的处理器由调用方注册，避免 worker 包反向依赖 service
+func (s *Server) Handle(taskType string, handler asynq.HandlerFunc) {
+	s.mux.HandleFunc(taskType, handler)
+}
+
 // Run 启动 Worker (阻塞)
 func (s *Server) Run() error {
 	logger.Info("Worker Server starting...")
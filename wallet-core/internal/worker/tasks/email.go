@@ -4,17 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/hibiken/asynq"
 	"go.uber.org/zap"
 
+	"wallet-core/pkg/config"
 	"wallet-core/pkg/logger"
+	"wallet-core/pkg/mail"
 )
 
 // 任务类型常量
 const (
-	TypeEmailDelivery = "email:deliver"
+	TypeEmailDelivery     = "email:deliver"
+	TypeEmailVerification = "email:verify"         // 邮箱验证链接
+	TypePasswordReset     = "email:password_reset" // 重置密码链接
 )
 
 // EmailDeliveryPayload 邮件任务参数
@@ -66,3 +72,88 @@ func HandleEmailDeliveryTask(ctx context.Context, t *asynq.Task) error {
 	logger.Info("邮件发送成功", zap.Uint64("user_id", p.UserID))
 	return nil
 }
+
+// ---------------------------------------------------------------------
+// 3. 账户邮件 (邮箱验证 / 重置密码)
+// ---------------------------------------------------------------------
+
+// AccountEmailPayload 账户邮件参数
+// Token 为明文令牌 (数据库只保存哈希)；任务未设置 Retention，完成后不会留在 Redis 中
+type AccountEmailPayload struct {
+	UserID    uint64    `json:"user_id"`
+	Email     string    `json:"email"`
+	Username  string    `json:"username"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewEmailVerificationTask 创建邮箱验证邮件任务
+func NewEmailVerificationTask(p AccountEmailPayload) (*asynq.Task, error) {
+	return newAccountEmailTask(TypeEmailVerification, p)
+}
+
+// NewPasswordResetTask 创建重置密码邮件任务
+func NewPasswordResetTask(p AccountEmailPayload) (*asynq.Task, error) {
+	return newAccountEmailTask(TypePasswordReset, p)
+}
+
+// 令牌过期后链接已无效，不再重试
+func newAccountEmailTask(typ string, p AccountEmailPayload) (*asynq.Task, error) {
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(typ, payload, asynq.MaxRetry(5), asynq.Timeout(time.Minute), asynq.Deadline(p.ExpiresAt)), nil
+}
+
+// AccountMailer 渲染并发送账户邮件
+// 处理器依赖邮件发送器，由调用方通过 worker.Server.Handle 注册
+type AccountMailer struct {
+	sender mail.Sender
+	cfg    config.AccountConfig
+}
+
+func NewAccountMailer(sender mail.Sender, cfg config.AccountConfig) *AccountMailer {
+	return &AccountMailer{sender: sender, cfg: cfg}
+}
+
+// HandleEmailVerificationTask 发送邮箱验证邮件
+func (m *AccountMailer) HandleEmailVerificationTask(ctx context.Context, t *asynq.Task) error {
+	p, err := parseAccountEmailPayload(t)
+	if err != nil {
+		return err
+	}
+	return m.sender.Send(ctx, mail.Message{
+		To:      p.Email,
+		Subject: "请验证您的邮箱",
+		Body: fmt.Sprintf("%s，您好:\n\n请打开以下链接完成邮箱验证:\n%s\n\n链接在 %s 前有效。如果这不是您本人的操作，请忽略本邮件。\n",
+			p.Username, tokenLink(m.cfg.VerifyURL, p.Token), p.ExpiresAt.Format(time.RFC3339)),
+	})
+}
+
+// HandlePasswordResetTask 发送重置密码邮件
+func (m *AccountMailer) HandlePasswordResetTask(ctx context.Context, t *asynq.Task) error {
+	p, err := parseAccountEmailPayload(t)
+	if err != nil {
+		return err
+	}
+	return m.sender.Send(ctx, mail.Message{
+		To:      p.Email,
+		Subject: "重置密码",
+		Body: fmt.Sprintf("%s，您好:\n\n我们收到了重置密码的请求，请打开以下链接设置新密码:\n%s\n\n链接在 %s 前有效且只能使用一次，重置后所有设备需要重新登录。如果这不是您本人的操作，请忽略本邮件，您的密码不会改变。\n",
+			p.Username, tokenLink(m.cfg.ResetURL, p.Token), p.ExpiresAt.Format(time.RFC3339)),
+	})
+}
+
+func parseAccountEmailPayload(t *asynq.Task) (AccountEmailPayload, error) {
+	var p AccountEmailPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		return p, fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+	return p, nil
+}
+
+// tokenLink 把令牌填入链接模板的 {token}
+func tokenLink(tmpl, token string) string {
+	return strings.ReplaceAll(tmpl, "{token}", url.QueryEscape(token))
+}
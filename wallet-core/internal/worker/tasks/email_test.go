@@ -0,0 +1,44 @@
+package tasks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/pkg/config"
+	"wallet-core/pkg/mail"
+)
+
+type recordingSender struct {
+	sent []mail.Message
+}
+
+func (s *recordingSender) Send(ctx context.Context, msg mail.Message) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func TestAccountMailer(t *testing.T) {
+	sender := &recordingSender{}
+	m := NewAccountMailer(sender, config.AccountConfig{
+		VerifyURL: "https://wallet.example.com/verify?token={token}",
+		ResetURL:  "https://wallet.example.com/reset?token={token}",
+	})
+	p := AccountEmailPayload{UserID: 1, Email: "alice@example.com", Username: "alice", Token: "abc+/=", ExpiresAt: time.Now().Add(time.Hour)}
+
+	task, err := NewEmailVerificationTask(p)
+	require.NoError(t, err)
+	require.NoError(t, m.HandleEmailVerificationTask(context.Background(), task))
+
+	task, err = NewPasswordResetTask(p)
+	require.NoError(t, err)
+	require.NoError(t, m.HandlePasswordResetTask(context.Background(), task))
+
+	require.Len(t, sender.sent, 2)
+	assert.Equal(t, "alice@example.com", sender.sent[0].To)
+	assert.Contains(t, sender.sent[0].Body, "https://wallet.example.com/verify?token=abc%2B%2F%3D")
+	assert.Contains(t, sender.sent[1].Body, "https://wallet.example.com/reset?token=abc%2B%2F%3D")
+}
@@ -0,0 +1,40 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+)
+
+// 商户 Webhook 投递任务 (处理器为 service.WebhookService.HandleTask，需要访问数据库和 KMS)
+const (
+	TypeMerchantWebhook = "merchant:webhook" // 投递一条 Webhook 通知 (账单 / 充值 / 提现等事件)
+)
+
+// MerchantWebhookPayload 通知任务参数，内容保存在 webhook_deliveries 表中
+type MerchantWebhookPayload struct {
+	DeliveryID uint64 `json:"delivery_id"`
+}
+
+// NewMerchantWebhookTask 创建通知投递任务，失败按 asynq 默认的指数退避重试 maxRetry 次
+// TaskID 固定为投递 ID，定时扫描重复投递时会返回 asynq.ErrTaskIDConflict
+func NewMerchantWebhookTask(deliveryID uint64, maxRetry int) (*asynq.Task, error) {
+	payload, err := json.Marshal(MerchantWebhookPayload{DeliveryID: deliveryID})
+	if err != nil {
+		return nil, err
+	}
+	return asynq.NewTask(TypeMerchantWebhook, payload,
+		asynq.TaskID(fmt.Sprintf("%s:%d", TypeMerchantWebhook, deliveryID)),
+		asynq.MaxRetry(maxRetry),
+	), nil
+}
+
+// ParseMerchantWebhookPayload 解析通知任务参数，格式错误时返回 SkipRetry
+func ParseMerchantWebhookPayload(t *asynq.Task) (MerchantWebhookPayload, error) {
+	var p MerchantWebhookPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		return p, fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+	return p, nil
+}
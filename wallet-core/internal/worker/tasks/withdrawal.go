@@ -0,0 +1,59 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// 提现定时任务 (处理器在 service.TimeLockService 中实现，需要访问数据库)
+const (
+	TypeWithdrawalRelease    = "withdrawal:release"     // 时间锁到期，放行到广播队列
+	TypeWithdrawalLockNotice = "withdrawal:lock_notice" // 锁定开始通知邮件
+	TypeWithdrawalReminder   = "withdrawal:reminder"    // 执行前提醒邮件
+)
+
+// WithdrawalPayload 提现定时任务参数
+type WithdrawalPayload struct {
+	WithdrawalID uint64 `json:"withdrawal_id"`
+}
+
+// NewWithdrawalReleaseTask 创建时间锁到期任务，在 at 时刻执行
+// TaskID 固定为提现 ID，重复投递会返回 asynq.ErrTaskIDConflict，保证同一笔提现只有一个放行任务
+func NewWithdrawalReleaseTask(withdrawalID uint64, at time.Time) (*asynq.Task, error) {
+	return newWithdrawalTask(TypeWithdrawalRelease, withdrawalID, at, asynq.Queue("critical"))
+}
+
+// NewWithdrawalLockNoticeTask 创建锁定开始通知任务，立即执行
+func NewWithdrawalLockNoticeTask(withdrawalID uint64) (*asynq.Task, error) {
+	return newWithdrawalTask(TypeWithdrawalLockNotice, withdrawalID, time.Now())
+}
+
+// NewWithdrawalReminderTask 创建执行前提醒任务，在 at 时刻执行
+func NewWithdrawalReminderTask(withdrawalID uint64, at time.Time) (*asynq.Task, error) {
+	return newWithdrawalTask(TypeWithdrawalReminder, withdrawalID, at)
+}
+
+func newWithdrawalTask(typ string, withdrawalID uint64, at time.Time, opts ...asynq.Option) (*asynq.Task, error) {
+	payload, err := json.Marshal(WithdrawalPayload{WithdrawalID: withdrawalID})
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts,
+		asynq.ProcessAt(at),
+		asynq.TaskID(fmt.Sprintf("%s:%d", typ, withdrawalID)),
+		asynq.MaxRetry(10),
+	)
+	return asynq.NewTask(typ, payload, opts...), nil
+}
+
+// ParseWithdrawalPayload 解析提现定时任务参数，格式错误时返回 SkipRetry
+func ParseWithdrawalPayload(t *asynq.Task) (WithdrawalPayload, error) {
+	var p WithdrawalPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		return p, fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+	return p, nil
+}
@@ -0,0 +1,25 @@
+DROP INDEX IF EXISTS idx_collections_status;
+DROP INDEX IF EXISTS idx_withdrawals_status;
+
+ALTER TABLE collections
+DROP COLUMN IF EXISTS nonce,
+DROP COLUMN IF EXISTS gas_limit,
+DROP COLUMN IF EXISTS gas_price,
+DROP COLUMN IF EXISTS gas_used,
+DROP COLUMN IF EXISTS block_number,
+DROP COLUMN IF EXISTS confirmations,
+DROP COLUMN IF EXISTS fail_reason,
+DROP COLUMN IF EXISTS confirmed_at;
+
+ALTER TABLE withdrawals
+DROP COLUMN IF EXISTS from_address,
+DROP COLUMN IF EXISTS nonce,
+DROP COLUMN IF EXISTS gas_limit,
+DROP COLUMN IF EXISTS gas_price,
+DROP COLUMN IF EXISTS gas_used,
+DROP COLUMN IF EXISTS gas_fee,
+DROP COLUMN IF EXISTS block_number,
+DROP COLUMN IF EXISTS confirmations,
+DROP COLUMN IF EXISTS fail_reason,
+DROP COLUMN IF EXISTS broadcast_at,
+DROP COLUMN IF EXISTS confirmed_at;
@@ -0,0 +1,29 @@
+-- 1. 提现: 记录出款交易的 nonce / gas 与确认进度
+ALTER TABLE withdrawals
+ADD COLUMN from_address VARCHAR(255),
+ADD COLUMN nonce BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN gas_limit BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN gas_price NUMERIC(30,0) NOT NULL DEFAULT 0,
+ADD COLUMN gas_used BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN gas_fee NUMERIC(30,0) NOT NULL DEFAULT 0,
+ADD COLUMN block_number BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN confirmations BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN fail_reason TEXT,
+ADD COLUMN broadcast_at TIMESTAMPTZ,
+ADD COLUMN confirmed_at TIMESTAMPTZ;
+
+-- TxTracker 按状态轮询
+CREATE INDEX IF NOT EXISTS idx_withdrawals_status ON withdrawals(status);
+
+-- 2. 归集: 记录预估 vs 实际 Gas
+ALTER TABLE collections
+ADD COLUMN nonce BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN gas_limit BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN gas_price NUMERIC(30,0) NOT NULL DEFAULT 0,
+ADD COLUMN gas_used BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN block_number BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN confirmations BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN fail_reason TEXT,
+ADD COLUMN confirmed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_collections_status ON collections(status);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS tx_replacements;
+
+ALTER TABLE withdrawals DROP COLUMN IF EXISTS replace_count;
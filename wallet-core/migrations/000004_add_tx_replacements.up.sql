@@ -0,0 +1,22 @@
+-- 1. 提现: 记录被加速/取消的次数 (自动加速上限)
+ALTER TABLE withdrawals
+ADD COLUMN replace_count INT NOT NULL DEFAULT 0;
+
+-- 2. 同 nonce 替换交易的审计记录 (加速 / 取消)
+CREATE TABLE IF NOT EXISTS tx_replacements (
+    id BIGSERIAL PRIMARY KEY,
+    withdrawal_id BIGINT NOT NULL,
+    chain VARCHAR(20) NOT NULL,
+    action VARCHAR(16) NOT NULL,  -- speed_up, cancel
+    trigger VARCHAR(16) NOT NULL, -- auto, admin
+    admin_id BIGINT NOT NULL DEFAULT 0,
+    nonce BIGINT NOT NULL,
+    old_tx_hash VARCHAR(255) NOT NULL,
+    new_tx_hash VARCHAR(255) NOT NULL,
+    old_fee NUMERIC(30,0) NOT NULL DEFAULT 0,
+    new_fee NUMERIC(30,0) NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tx_replacements_withdrawal_id ON tx_replacements(withdrawal_id);
+CREATE INDEX IF NOT EXISTS idx_tx_replacements_new_tx_hash ON tx_replacements(new_tx_hash);
@@ -0,0 +1,7 @@
+ALTER TABLE collections
+DROP COLUMN IF EXISTS tx_type,
+DROP COLUMN IF EXISTS gas_tip_cap;
+
+ALTER TABLE withdrawals
+DROP COLUMN IF EXISTS tx_type,
+DROP COLUMN IF EXISTS gas_tip_cap;
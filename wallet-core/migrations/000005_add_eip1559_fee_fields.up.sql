@@ -0,0 +1,8 @@
+-- EIP-1559 (type-2) 交易: gas_price 存 MaxFeePerGas，另存 MaxPriorityFeePerGas
+ALTER TABLE withdrawals
+ADD COLUMN tx_type SMALLINT NOT NULL DEFAULT 0,
+ADD COLUMN gas_tip_cap NUMERIC(30,0) NOT NULL DEFAULT 0;
+
+ALTER TABLE collections
+ADD COLUMN tx_type SMALLINT NOT NULL DEFAULT 0,
+ADD COLUMN gas_tip_cap NUMERIC(30,0) NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS risk_rules;
+
+ALTER TABLE users DROP COLUMN IF EXISTS password_changed_at;
+
+DROP INDEX IF EXISTS idx_withdrawals_risk_score;
+
+ALTER TABLE withdrawals
+DROP COLUMN IF EXISTS risk_score,
+DROP COLUMN IF EXISTS risk_reasons;
@@ -0,0 +1,22 @@
+-- 1. 提现风控评分
+ALTER TABLE withdrawals
+ADD COLUMN risk_score INT NOT NULL DEFAULT 0,
+ADD COLUMN risk_reasons TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_withdrawals_risk_score ON withdrawals(risk_score);
+
+-- 2. 风控规则: 改密后短时间内提现
+ALTER TABLE users
+ADD COLUMN password_changed_at TIMESTAMPTZ;
+
+-- 3. 风控规则表 (risk.source=db)
+CREATE TABLE IF NOT EXISTS risk_rules (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL UNIQUE,
+    type VARCHAR(64) NOT NULL,
+    score INT NOT NULL,
+    params TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS screening_results;
+
+ALTER TABLE deposits DROP COLUMN IF EXISTS from_address;
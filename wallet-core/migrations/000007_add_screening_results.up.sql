@@ -0,0 +1,22 @@
+-- 1. 充值付款方地址 (制裁名单筛查)
+ALTER TABLE deposits
+ADD COLUMN from_address VARCHAR(255);
+
+-- 2. 筛查命中记录
+CREATE TABLE IF NOT EXISTS screening_results (
+    id BIGSERIAL PRIMARY KEY,
+    direction VARCHAR(16) NOT NULL,
+    chain VARCHAR(20) NOT NULL,
+    user_id BIGINT NOT NULL,
+    address VARCHAR(255) NOT NULL,
+    tx_hash VARCHAR(255),
+    amount VARCHAR(64),
+    list_source VARCHAR(255) NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    reference_id BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_screening_results_direction ON screening_results(direction);
+CREATE INDEX IF NOT EXISTS idx_screening_results_user_id ON screening_results(user_id);
+CREATE INDEX IF NOT EXISTS idx_screening_results_address ON screening_results(address);
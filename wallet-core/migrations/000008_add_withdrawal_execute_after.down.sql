@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_withdrawals_execute_after;
+
+ALTER TABLE withdrawals DROP COLUMN IF EXISTS execute_after;
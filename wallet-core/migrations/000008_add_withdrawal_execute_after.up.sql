@@ -0,0 +1,5 @@
+-- 定时 / 时间锁提现
+ALTER TABLE withdrawals
+ADD COLUMN execute_after TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_withdrawals_execute_after ON withdrawals(execute_after);
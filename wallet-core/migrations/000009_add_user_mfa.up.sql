@@ -0,0 +1,13 @@
+-- 用户两步验证 (TOTP)
+CREATE TABLE IF NOT EXISTS user_mfa (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL UNIQUE,
+    key_id VARCHAR(64) NOT NULL,
+    secret_ciphertext TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    backup_codes TEXT,
+    enabled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
@@ -0,0 +1,4 @@
+ALTER TABLE withdrawal_reviews DROP CONSTRAINT IF EXISTS fk_withdrawal_reviews_admin;
+ALTER TABLE withdrawal_reviews ALTER COLUMN admin_id TYPE INT;
+DROP TABLE IF EXISTS admin_audit_logs;
+DROP TABLE IF EXISTS admin_users;
@@ -0,0 +1,40 @@
+-- 后台管理员 (与终端用户 users 独立)
+CREATE TABLE IF NOT EXISTS admin_users (
+    id BIGSERIAL PRIMARY KEY,
+    username VARCHAR(64) NOT NULL UNIQUE,
+    email VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    key_id VARCHAR(64) NOT NULL,
+    secret_ciphertext TEXT NOT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    last_login_at TIMESTAMPTZ,
+    created_by BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- 管理员操作审计日志
+CREATE TABLE IF NOT EXISTS admin_audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    admin_id BIGINT NOT NULL,
+    username VARCHAR(64) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    resource VARCHAR(64),
+    resource_id VARCHAR(64),
+    success BOOLEAN NOT NULL,
+    detail TEXT,
+    ip VARCHAR(64),
+    user_agent VARCHAR(255),
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_logs_admin_id ON admin_audit_logs(admin_id);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_logs_action ON admin_audit_logs(action);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_logs_created_at ON admin_audit_logs(created_at);
+
+-- 审核记录关联到已认证的管理员
+-- 历史记录中的 admin_id 来自未校验的 X-Admin-ID 请求头，NOT VALID 只约束新插入的记录
+ALTER TABLE withdrawal_reviews ALTER COLUMN admin_id TYPE BIGINT;
+ALTER TABLE withdrawal_reviews
+    ADD CONSTRAINT fk_withdrawal_reviews_admin FOREIGN KEY (admin_id) REFERENCES admin_users(id) NOT VALID;
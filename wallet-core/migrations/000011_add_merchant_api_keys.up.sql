@@ -0,0 +1,30 @@
+-- B2B 商户
+CREATE TABLE IF NOT EXISTS merchants (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(128) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    created_by BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- 商户 API Key (HMAC-SHA256 请求签名)
+CREATE TABLE IF NOT EXISTS merchant_api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id),
+    key_id VARCHAR(64) NOT NULL UNIQUE,
+    name VARCHAR(128),
+    kms_key_id VARCHAR(64) NOT NULL,
+    secret_ciphertext TEXT NOT NULL,
+    secret_hash VARCHAR(64) NOT NULL,
+    scopes TEXT,
+    ip_allowlist TEXT,
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    created_by BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_merchant_api_keys_merchant_id ON merchant_api_keys(merchant_id);
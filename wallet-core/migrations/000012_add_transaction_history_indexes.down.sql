@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_withdrawals_tx_hash;
+DROP INDEX IF EXISTS idx_withdrawals_user_created;
+DROP INDEX IF EXISTS idx_deposits_user_created;
+
+ALTER TABLE deposits
+DROP COLUMN IF EXISTS confirmations,
+DROP COLUMN IF EXISTS chain;
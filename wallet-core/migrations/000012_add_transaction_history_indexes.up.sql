@@ -0,0 +1,17 @@
+-- 1. 充值记录补充链和确认数 (交易记录接口展示)
+ALTER TABLE deposits
+ADD COLUMN IF NOT EXISTS chain VARCHAR(20) NOT NULL DEFAULT '',
+ADD COLUMN IF NOT EXISTS confirmations BIGINT NOT NULL DEFAULT 0;
+
+-- 历史数据: 链取自充值地址
+UPDATE deposits d
+SET chain = a.chain
+FROM addresses a
+WHERE d.block_app_id = a.id AND d.chain = '';
+
+-- 2. 游标分页索引: WHERE user_id = ? AND (created_at, id) < (?, ?) ORDER BY created_at DESC, id DESC
+CREATE INDEX IF NOT EXISTS idx_deposits_user_created ON deposits(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_withdrawals_user_created ON withdrawals(user_id, created_at DESC, id DESC);
+
+-- 3. 按交易 Hash 查询 (deposits 已有 (tx_hash, block_app_id) 唯一索引)
+CREATE INDEX IF NOT EXISTS idx_withdrawals_tx_hash ON withdrawals(tx_hash);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_withdrawals_review_queue;
+DROP INDEX IF EXISTS idx_withdrawals_assignee_id;
+
+ALTER TABLE withdrawals
+DROP COLUMN IF EXISTS claimed_at,
+DROP COLUMN IF EXISTS assignee_id;
@@ -0,0 +1,9 @@
+-- 1. 审核认领: 认领人及认领时间 (认领超时由应用层判断，不需要清理任务)
+ALTER TABLE withdrawals
+ADD COLUMN IF NOT EXISTS assignee_id BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_withdrawals_assignee_id ON withdrawals(assignee_id);
+
+-- 2. 审核队列: WHERE status IN (...) ORDER BY risk_score DESC, id ASC 游标分页
+CREATE INDEX IF NOT EXISTS idx_withdrawals_review_queue ON withdrawals(status, risk_score DESC, id);
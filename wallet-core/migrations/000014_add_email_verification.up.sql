@@ -0,0 +1,17 @@
+-- 1. 邮箱验证时间 (已有用户视为已验证，避免开启 account.require_verified_email 后无法登录)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+
+-- 2. 一次性令牌 (邮箱验证 / 重置密码)，只保存 SHA-256
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    purpose VARCHAR(32) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_tokens_token_hash ON user_tokens(token_hash);
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user_id ON user_tokens(user_id);
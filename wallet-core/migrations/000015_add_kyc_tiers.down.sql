@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS kyc_documents;
+DROP TABLE IF EXISTS kyc_applications;
+ALTER TABLE users DROP COLUMN IF EXISTS kyc_tier;
@@ -0,0 +1,42 @@
+-- 1. 用户实名认证等级 (已有用户从 unverified 开始)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS kyc_tier VARCHAR(16) NOT NULL DEFAULT 'unverified';
+
+-- 2. 等级申请 (管理员审核)，证件号码只保存脱敏值
+CREATE TABLE IF NOT EXISTS kyc_applications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    current_tier VARCHAR(16) NOT NULL,
+    tier VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    full_name VARCHAR(128) NOT NULL,
+    country VARCHAR(2) NOT NULL,
+    document_type VARCHAR(32) NOT NULL,
+    document_number_masked VARCHAR(64) NOT NULL,
+    document_expiry TIMESTAMPTZ,
+    reviewer_id BIGINT NOT NULL DEFAULT 0,
+    review_remark TEXT,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kyc_applications_user_id ON kyc_applications(user_id);
+CREATE INDEX IF NOT EXISTS idx_kyc_applications_status ON kyc_applications(status);
+-- 每个用户同时只能有一个待审核的申请
+CREATE UNIQUE INDEX IF NOT EXISTS idx_kyc_applications_pending ON kyc_applications(user_id) WHERE status = 'pending';
+
+-- 3. 证件文件元数据 (文件经 KMS 加密后存放在 kyc.document_dir)
+CREATE TABLE IF NOT EXISTS kyc_documents (
+    id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL REFERENCES kyc_applications(id),
+    user_id BIGINT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(64) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    kms_key_id VARCHAR(64) NOT NULL,
+    storage_path VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kyc_documents_application_id ON kyc_documents(application_id);
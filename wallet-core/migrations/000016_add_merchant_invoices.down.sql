@@ -0,0 +1,7 @@
+-- 已分配给账单的地址 (user_id = 0) 不满足外键，只对新数据生效
+ALTER TABLE addresses ADD CONSTRAINT fk_users_addresses FOREIGN KEY(user_id) REFERENCES users(id) NOT VALID;
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS merchant_webhooks;
+DROP TABLE IF EXISTS invoice_payments;
+DROP TABLE IF EXISTS invoices;
@@ -0,0 +1,83 @@
+-- 1. 商户收款账单
+CREATE TABLE IF NOT EXISTS invoices (
+    id BIGSERIAL PRIMARY KEY,
+    public_id VARCHAR(40) NOT NULL,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id),
+    order_id VARCHAR(64) NOT NULL,
+    description VARCHAR(255),
+    currency VARCHAR(20) NOT NULL,
+    amount DECIMAL(32, 18) NOT NULL,
+    paid_amount DECIMAL(32, 18) NOT NULL DEFAULT 0,
+    price_currency VARCHAR(8),
+    price_amount DECIMAL(32, 8) NOT NULL DEFAULT 0,
+    exchange_rate DECIMAL(32, 8) NOT NULL DEFAULT 0,
+    address_mode VARCHAR(16) NOT NULL,
+    address_id BIGINT NOT NULL,
+    address VARCHAR(255) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    redirect_url VARCHAR(512),
+    expires_at TIMESTAMPTZ NOT NULL,
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_public_id ON invoices(public_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_merchant_order ON invoices(merchant_id, order_id);
+CREATE INDEX IF NOT EXISTS idx_invoices_address_id ON invoices(address_id);
+CREATE INDEX IF NOT EXISTS idx_invoices_status ON invoices(status);
+CREATE INDEX IF NOT EXISTS idx_invoices_expires_at ON invoices(expires_at);
+-- 共享地址上待付款的账单金额必须唯一，付款按金额匹配
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_shared_amount ON invoices(address_id, amount)
+    WHERE address_mode = 'shared' AND status = 'pending';
+
+-- 2. 匹配到账单的充值 (一笔充值只计入一张账单)
+CREATE TABLE IF NOT EXISTS invoice_payments (
+    id BIGSERIAL PRIMARY KEY,
+    invoice_id BIGINT NOT NULL REFERENCES invoices(id),
+    deposit_id BIGINT NOT NULL,
+    tx_hash VARCHAR(255) NOT NULL,
+    amount DECIMAL(32, 18) NOT NULL,
+    late BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoice_payments_invoice_id ON invoice_payments(invoice_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoice_payments_deposit_id ON invoice_payments(deposit_id);
+
+-- 3. 商户通知地址 (签名密钥经 KMS 加密)
+CREATE TABLE IF NOT EXISTS merchant_webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id),
+    url VARCHAR(512) NOT NULL,
+    kms_key_id VARCHAR(64) NOT NULL,
+    secret_ciphertext TEXT NOT NULL,
+    secret_hash VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_merchant_webhooks_merchant_id ON merchant_webhooks(merchant_id);
+
+-- 4. 通知投递记录
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    merchant_id BIGINT NOT NULL,
+    invoice_id BIGINT NOT NULL,
+    event VARCHAR(32) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    response_code INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_merchant_id ON webhook_deliveries(merchant_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_invoice_id ON webhook_deliveries(invoice_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries(status);
+
+-- 5. 账单收款地址不属于任何用户 (user_id = 0)，去掉 addresses 到 users 的外键
+ALTER TABLE addresses DROP CONSTRAINT IF EXISTS fk_users_addresses;
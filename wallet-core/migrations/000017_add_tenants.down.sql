@@ -0,0 +1,18 @@
+-- 回滚前需要先清理非默认租户的数据，否则全局唯一约束会冲突
+DROP INDEX IF EXISTS idx_tenant_chain_path;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chain_path ON addresses(chain, hd_path_index);
+
+DROP INDEX IF EXISTS idx_users_tenant_username;
+DROP INDEX IF EXISTS idx_users_tenant_email;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+DROP INDEX IF EXISTS idx_deposits_tenant_id;
+DROP INDEX IF EXISTS idx_withdrawals_tenant_id;
+ALTER TABLE withdrawals DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE deposits DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE addresses DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+
+DROP TABLE IF EXISTS tenant_chains;
+DROP TABLE IF EXISTS tenants;
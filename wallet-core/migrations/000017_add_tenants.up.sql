@@ -0,0 +1,55 @@
+-- 1. 租户 (品牌)
+CREATE TABLE IF NOT EXISTS tenants (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(32) NOT NULL,
+    name VARCHAR(128) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    created_by BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenants_code ON tenants(code);
+
+-- 默认租户: 已有数据全部归属它，ID 固定为 1 (pkg/tenant.DefaultID)
+INSERT INTO tenants (id, code, name) VALUES (1, 'default', 'Default') ON CONFLICT (id) DO NOTHING;
+SELECT setval(pg_get_serial_sequence('tenants', 'id'), GREATEST((SELECT MAX(id) FROM tenants), 1));
+
+-- 2. 租户链配置: 账户级 xpub / 热钱包 / 提现限额
+CREATE TABLE IF NOT EXISTS tenant_chains (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL REFERENCES tenants(id),
+    chain VARCHAR(20) NOT NULL,
+    xpub VARCHAR(128) NOT NULL DEFAULT '',
+    custody VARCHAR(16) NOT NULL DEFAULT 'internal',
+    hot_wallet VARCHAR(255) NOT NULL DEFAULT '',
+    min_withdrawal DECIMAL(32, 18) NOT NULL DEFAULT 0,
+    max_withdrawal DECIMAL(32, 18) NOT NULL DEFAULT 0,
+    daily_withdrawal DECIMAL(32, 18) NOT NULL DEFAULT 0,
+    updated_by BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenant_chain ON tenant_chains(tenant_id, chain);
+
+-- 3. 业务表加 tenant_id，已有数据归默认租户
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS tenant_id BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE deposits ADD COLUMN IF NOT EXISTS tenant_id BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE withdrawals ADD COLUMN IF NOT EXISTS tenant_id BIGINT NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS idx_deposits_tenant_id ON deposits(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_withdrawals_tenant_id ON withdrawals(tenant_id);
+
+-- 4. 用户名 / 邮箱改为租户内唯一 (同一个邮箱可以在不同品牌分别注册)
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS uni_users_username;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS uni_users_email;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_tenant_username ON users(tenant_id, username);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_tenant_email ON users(tenant_id, email);
+
+-- 5. HD index 按租户独立计数；地址本身仍全局唯一 (扫块按地址匹配)
+DROP INDEX IF EXISTS idx_chain_path;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenant_chain_path ON addresses(tenant_id, chain, hd_path_index);
@@ -0,0 +1,19 @@
+DROP INDEX IF EXISTS idx_merchants_settlement_user;
+DROP INDEX IF EXISTS idx_merchants_user_id;
+ALTER TABLE merchants DROP COLUMN IF EXISTS user_id;
+
+DROP TABLE IF EXISTS webhook_attempts;
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_event;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS replay_of;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS event_id;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS endpoint_id;
+
+-- 恢复每个商户一个通知地址: 只保留最早创建的
+DELETE FROM merchant_webhooks w USING merchant_webhooks o WHERE w.merchant_id = o.merchant_id AND w.id > o.id;
+ALTER TABLE merchant_webhooks DROP COLUMN IF EXISTS secret_rotated_at;
+ALTER TABLE merchant_webhooks DROP COLUMN IF EXISTS enabled;
+ALTER TABLE merchant_webhooks DROP COLUMN IF EXISTS events;
+ALTER TABLE merchant_webhooks DROP COLUMN IF EXISTS description;
+DROP INDEX IF EXISTS idx_merchant_webhooks_merchant_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_merchant_webhooks_merchant_id ON merchant_webhooks(merchant_id);
@@ -0,0 +1,38 @@
+-- 1. 商户可以配置多个通知地址，按事件订阅
+DROP INDEX IF EXISTS idx_merchant_webhooks_merchant_id;
+CREATE INDEX IF NOT EXISTS idx_merchant_webhooks_merchant_id ON merchant_webhooks(merchant_id);
+
+ALTER TABLE merchant_webhooks ADD COLUMN IF NOT EXISTS description VARCHAR(255);
+ALTER TABLE merchant_webhooks ADD COLUMN IF NOT EXISTS events TEXT; -- JSON 数组，为空订阅全部
+ALTER TABLE merchant_webhooks ADD COLUMN IF NOT EXISTS enabled BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE merchant_webhooks ADD COLUMN IF NOT EXISTS secret_rotated_at TIMESTAMPTZ;
+
+-- 2. 投递记录关联通知地址和事件 ID，重放时新建记录并指向原始投递
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS endpoint_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS event_id VARCHAR(40) NOT NULL DEFAULT '';
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS replay_of BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE webhook_deliveries ALTER COLUMN invoice_id SET DEFAULT 0;
+
+-- 已有的投递记录属于商户唯一的通知地址，事件 ID 取自请求体
+UPDATE webhook_deliveries d SET endpoint_id = w.id FROM merchant_webhooks w WHERE w.merchant_id = d.merchant_id AND d.endpoint_id = 0;
+UPDATE webhook_deliveries SET event_id = COALESCE(payload::jsonb->>'id', 'evt_legacy_' || id) WHERE event_id = '';
+
+-- 同一事件在同一地址上只有一条原始投递 (MQ 重复消费时忽略)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries(endpoint_id, event_id) WHERE replay_of = 0;
+
+-- 3. 投递日志: 每次尝试的响应码 / 错误 / 耗时
+CREATE TABLE IF NOT EXISTS webhook_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT NOT NULL,
+    response_code INT NOT NULL DEFAULT 0,
+    error TEXT,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_attempts_delivery_id ON webhook_attempts(delivery_id);
+
+-- 4. 商户结算账户: 该钱包用户的充值 / 提现通知商户，一个用户只能绑定一个商户
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS user_id BIGINT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_merchants_user_id ON merchants(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_merchants_settlement_user ON merchants(user_id) WHERE user_id <> 0;
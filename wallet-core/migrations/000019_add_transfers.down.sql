@@ -0,0 +1,3 @@
+ALTER TABLE withdrawals DROP COLUMN IF EXISTS transfer_id;
+
+DROP TABLE IF EXISTS transfers;
@@ -0,0 +1,26 @@
+-- 1. 站内转账: 两个账户之间直接划转余额，不上链
+CREATE TABLE IF NOT EXISTS transfers (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL DEFAULT 1,
+    from_user_id BIGINT NOT NULL,
+    to_user_id BIGINT NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    amount DECIMAL(32,18) NOT NULL,
+    memo VARCHAR(255),
+    source VARCHAR(16) NOT NULL DEFAULT 'transfer', -- transfer | withdrawal (提现到本平台用户的地址)
+    withdrawal_id BIGINT NOT NULL DEFAULT 0,
+    to_address VARCHAR(255),
+    idempotency_key VARCHAR(64) NOT NULL DEFAULT '',
+    request_hash VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_transfers_tenant_id ON transfers(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_transfers_to_user_id ON transfers(to_user_id);
+CREATE INDEX IF NOT EXISTS idx_transfers_withdrawal_id ON transfers(withdrawal_id);
+-- 转出记录 / 24 小时转出额度
+CREATE INDEX IF NOT EXISTS idx_transfers_from_created ON transfers(from_user_id, created_at DESC, id DESC);
+-- 同一转出用户的幂等键唯一
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transfers_idempotency ON transfers(from_user_id, idempotency_key) WHERE idempotency_key <> '';
+
+-- 2. 站内结算的提现关联转账记录，不计入实名认证 / 租户的提现额度
+ALTER TABLE withdrawals ADD COLUMN IF NOT EXISTS transfer_id BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+-- 提现: 记录最近一次替换动作，已发起取消的提现不能再被加速回原收款地址
+ALTER TABLE withdrawals ADD COLUMN IF NOT EXISTS replace_action VARCHAR(16) NOT NULL DEFAULT '';
+
+-- 历史数据: 已有取消记录的提现回填为 cancel
+UPDATE withdrawals SET replace_action = 'cancel'
+WHERE id IN (SELECT withdrawal_id FROM tx_replacements WHERE action = 'cancel');
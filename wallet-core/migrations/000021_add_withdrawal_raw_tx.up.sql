@@ -0,0 +1,2 @@
+-- 提现: 广播前先落库已签名交易，广播失败 / 进程退出后原样重发，不会换 nonce 重复出款
+ALTER TABLE withdrawals ADD COLUMN IF NOT EXISTS raw_tx TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,60 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+var ethAddressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// ErrUnsupportedChain 不支持校验的链
+var ErrUnsupportedChain = errors.New("unsupported chain")
+
+// Validate 校验地址格式，返回规范化后的地址
+// - ETH: 0x + 40 位十六进制；全小写 / 全大写视为未带校验和，混合大小写必须符合 EIP-55，返回 EIP-55 格式
+// - BTC: network 网络下的标准地址 (P2PKH / P2SH / SegWit / Taproot)，返回规范编码 (bech32 为小写)
+// 校验失败的 error 说明原因，可直接返回给用户
+func Validate(chain, addr string, network *chaincfg.Params) (string, error) {
+	switch strings.ToUpper(chain) {
+	case "ETH":
+		return validateETH(addr)
+	case "BTC":
+		return validateBTC(addr, network)
+	}
+	return "", ErrUnsupportedChain
+}
+
+func validateETH(addr string) (string, error) {
+	if !ethAddressPattern.MatchString(addr) {
+		return "", errors.New("address must be 0x followed by 40 hex characters")
+	}
+	body := addr[2:]
+	checksummed := "0x" + toChecksumAddress(body)
+	mixedCase := strings.ToLower(body) != body && strings.ToUpper(body) != body
+	if mixedCase && addr != checksummed {
+		return "", errors.New("address checksum (EIP-55) mismatch")
+	}
+	return checksummed, nil
+}
+
+func validateBTC(addr string, network *chaincfg.Params) (string, error) {
+	decoded, err := btcutil.DecodeAddress(addr, network)
+	if err != nil {
+		return "", fmt.Errorf("invalid bitcoin address: %v", err)
+	}
+	if !decoded.IsForNet(network) {
+		return "", fmt.Errorf("address is not a %s address", network.Name)
+	}
+	switch decoded.(type) {
+	case *btcutil.AddressPubKeyHash, *btcutil.AddressScriptHash,
+		*btcutil.AddressWitnessPubKeyHash, *btcutil.AddressWitnessScriptHash, *btcutil.AddressTaproot:
+	default:
+		return "", errors.New("unsupported bitcoin address type")
+	}
+	return decoded.EncodeAddress(), nil
+}
@@ -0,0 +1,51 @@
+package address
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateETH(t *testing.T) {
+	const checksummed = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed" // EIP-55 示例
+
+	got, err := Validate("eth", checksummed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, checksummed, got)
+
+	// 全小写 / 全大写不带校验和，返回 EIP-55 格式
+	got, err = Validate("ETH", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", nil)
+	require.NoError(t, err)
+	assert.Equal(t, checksummed, got)
+
+	for _, bad := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", // 校验和错误
+		"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",   // 缺少 0x
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA",   // 长度不对
+		"0xZZAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	} {
+		_, err := Validate("ETH", bad, nil)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestValidateBTC(t *testing.T) {
+	got, err := Validate("BTC", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	assert.Equal(t, "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", got)
+
+	got, err = Validate("BTC", "BC1QAR0SRRR7XFKVY5L643LYDNW9RE59GTZZWF5MDQ", &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	assert.Equal(t, "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", got)
+
+	// 主网地址不能用于测试网
+	_, err = Validate("BTC", "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", &chaincfg.TestNet3Params)
+	assert.Error(t, err)
+	_, err = Validate("BTC", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3", &chaincfg.MainNetParams)
+	assert.Error(t, err)
+
+	_, err = Validate("DOGE", "D8vFz4p1L37jdg47HXKtSHA5uYLYxbGgPD", &chaincfg.MainNetParams)
+	assert.ErrorIs(t, err, ErrUnsupportedChain)
+}
@@ -0,0 +1,53 @@
+// Package apisign 商户 API 请求签名 (HMAC-SHA256)
+//
+// 待签名串 (以 \n 连接):
+//
+//	METHOD
+//	PATH (含 query，即 URL.RequestURI())
+//	TIMESTAMP (unix 秒)
+//	NONCE (每个请求唯一)
+//	hex(SHA256(BODY))
+//
+// 签名 = hex(HMAC-SHA256(secret, 待签名串))，通过以下请求头传递:
+// X-Api-Key / X-Timestamp / X-Nonce / X-Signature
+package apisign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// 请求头
+const (
+	HeaderKeyID     = "X-Api-Key"
+	HeaderTimestamp = "X-Timestamp"
+	HeaderNonce     = "X-Nonce"
+	HeaderSignature = "X-Signature"
+)
+
+// CanonicalString 构造待签名串
+func CanonicalString(method, path, timestamp, nonce string, body []byte) string {
+	sum := sha256.Sum256(body)
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		timestamp,
+		nonce,
+		hex.EncodeToString(sum[:]),
+	}, "\n")
+}
+
+// Sign 计算请求签名 (商户 SDK 与服务端共用)
+func Sign(secret, method, path, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(CanonicalString(method, path, timestamp, nonce, body)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 常量时间比较签名
+func Verify(secret, signature, method, path, timestamp, nonce string, body []byte) bool {
+	expected := Sign(secret, method, path, timestamp, nonce, body)
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
@@ -0,0 +1,33 @@
+package apisign
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalString(t *testing.T) {
+	got := CanonicalString("post", "/api/v1/merchant/me?x=1", "1700000000", "n1", []byte(`{"a":1}`))
+	assert.Equal(t, "POST\n/api/v1/merchant/me?x=1\n1700000000\nn1\n"+
+		"015abd7f5cc57a2dd94b7590f04ad8084273905ee33ec5cebeae62276a97f862", got)
+
+	// 空 body 使用空串的 SHA-256
+	assert.Contains(t, CanonicalString("GET", "/", "1", "n", nil), "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+}
+
+func TestSignAndVerify(t *testing.T) {
+	sig := Sign("secret", "POST", "/p", "1700000000", "n1", []byte("body"))
+	assert.Len(t, sig, 64)
+
+	assert.True(t, Verify("secret", sig, "POST", "/p", "1700000000", "n1", []byte("body")))
+	assert.True(t, Verify("secret", strings.ToUpper(sig), "POST", "/p", "1700000000", "n1", []byte("body")))
+
+	// 任何一部分被篡改都不能通过
+	assert.False(t, Verify("other", sig, "POST", "/p", "1700000000", "n1", []byte("body")))
+	assert.False(t, Verify("secret", sig, "GET", "/p", "1700000000", "n1", []byte("body")))
+	assert.False(t, Verify("secret", sig, "POST", "/p?x=1", "1700000000", "n1", []byte("body")))
+	assert.False(t, Verify("secret", sig, "POST", "/p", "1700000001", "n1", []byte("body")))
+	assert.False(t, Verify("secret", sig, "POST", "/p", "1700000000", "n2", []byte("body")))
+	assert.False(t, Verify("secret", sig, "POST", "/p", "1700000000", "n1", []byte("body2")))
+}
@@ -58,6 +58,25 @@ func (k *BTCKeychain) Neuter() (ExtendedKey, error) {
 	return &BTCKeychain{key: neuterKey, network: k.network}, nil
 }
 
+// HardenedKeyStart 硬化派生的起始索引 (index + HardenedKeyStart 即 index')
+const HardenedKeyStart = hdkeychain.HardenedKeyStart
+
+// ParseExtendedKey 解析 Base58 编码的扩展密钥 (xpub... / xprv...)，并校验所属网络
+// network 为 nil 时按主网校验
+func ParseExtendedKey(s string, network *chaincfg.Params) (ExtendedKey, error) {
+	if network == nil {
+		network = &chaincfg.MainNetParams
+	}
+	key, err := hdkeychain.NewKeyFromString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("解析扩展密钥失败: %v", err)
+	}
+	if !key.IsForNet(network) {
+		return nil, fmt.Errorf("扩展密钥不属于 %s 网络", network.Name)
+	}
+	return &BTCKeychain{key: key, network: network}, nil
+}
+
 // Wallet 实现 HDWallet 接口
 type Wallet struct {
 	masterKey *BTCKeychain
@@ -78,3 +78,32 @@ func TestDerivePath(t *testing.T) {
 		t.Errorf("Neuter() 应该返回公钥，但 IsPrivate() 返回 true")
 	}
 }
+
+func TestParseExtendedKey(t *testing.T) {
+	seed, _ := hex.DecodeString("fffcf9f6da3247d8a846f4b6113e6173")
+	wallet, err := NewMasterKeyFromSeed(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("生成主密钥失败: %v", err)
+	}
+	account, err := wallet.DerivePath("m/44'/60'/2'")
+	if err != nil {
+		t.Fatalf("派生账户密钥失败: %v", err)
+	}
+	xpub, _ := account.Neuter()
+
+	parsed, err := ParseExtendedKey(xpub.String(), nil)
+	if err != nil {
+		t.Fatalf("解析 xpub 失败: %v", err)
+	}
+	if parsed.IsPrivate() || parsed.String() != xpub.String() {
+		t.Errorf("解析结果不一致: %s", parsed.String())
+	}
+
+	// 主网的 xpub 不能用在测试网
+	if _, err := ParseExtendedKey(xpub.String(), &chaincfg.TestNet3Params); err == nil {
+		t.Errorf("网络不匹配时应该返回错误")
+	}
+	if _, err := ParseExtendedKey("xpub-invalid", nil); err == nil {
+		t.Errorf("非法字符串应该返回错误")
+	}
+}
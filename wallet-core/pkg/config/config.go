@@ -3,22 +3,42 @@ package config
 import (
 	"log"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	App    AppConfig    `mapstructure:"app"`
-	DB     DBConfig     `mapstructure:"db"`
-	Redis  RedisConfig  `mapstructure:"redis"`
-	Kafka  KafkaConfig  `mapstructure:"kafka"`
-	Wallet WalletConfig `mapstructure:"wallet"`
+	App        AppConfig              `mapstructure:"app"`
+	DB         DBConfig               `mapstructure:"db"`
+	Redis      RedisConfig            `mapstructure:"redis"`
+	Kafka      KafkaConfig            `mapstructure:"kafka"`
+	Wallet     WalletConfig           `mapstructure:"wallet"`
+	Chains     map[string]ChainConfig `mapstructure:"chains"` // key 为小写链名 (eth, btc)
+	Risk       RiskConfig             `mapstructure:"risk"`
+	Screening  ScreeningConfig        `mapstructure:"screening"`
+	Withdrawal WithdrawalConfig       `mapstructure:"withdrawal"`
+	MFA        MFAConfig              `mapstructure:"mfa"`
+	Auth       AuthConfig             `mapstructure:"auth"`
+	Account    AccountConfig          `mapstructure:"account"`
+	Mail       MailConfig             `mapstructure:"mail"`
+	KYC        KYCConfig              `mapstructure:"kyc"`
+	Admin      AdminConfig            `mapstructure:"admin"`
+	Merchant   MerchantConfig         `mapstructure:"merchant"`
+	Invoice    InvoiceConfig          `mapstructure:"invoice"`
+	Transfer   TransferConfig         `mapstructure:"transfer"`
+	RateLimit  RateLimitConfig        `mapstructure:"ratelimit"`
+	GRPC       GRPCConfig             `mapstructure:"grpc"`
+	Health     HealthConfig           `mapstructure:"health"`
 }
 
 type AppConfig struct {
 	Env      string `mapstructure:"env"`
 	HttpPort string `mapstructure:"http_port"`
 	GrpcPort string `mapstructure:"grpc_port"`
+	// 受信任的反向代理 (IP / CIDR)，只有来自这些地址的请求才采信 X-Forwarded-For / X-Real-IP
+	// 为空时不信任任何代理，客户端 IP 即连接对端地址
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 type DBConfig struct {
@@ -48,8 +68,212 @@ type WalletConfig struct {
 	Password     string `mapstructure:"password"`      // [NEW] Keystore 密码 (通常通过环境变量 WALLET_PASSWORD 传入)
 }
 
+// ChainConfig 单条链的参数
+type ChainConfig struct {
+	Confirmations uint64 `mapstructure:"confirmations"` // 达到该确认数才视为最终确认
+	TxType        string `mapstructure:"tx_type"`       // EVM: legacy | dynamic (EIP-1559)
+
+	// 资产参数 (对外展示，并用于校验提现金额)
+	Name          string  `mapstructure:"name"`           // 展示名称
+	Decimals      int32   `mapstructure:"decimals"`       // 最小单位精度 (ETH 18, BTC 8)，金额小数位不能超过该值
+	MinDeposit    float64 `mapstructure:"min_deposit"`    // 低于该金额的充值不入账 (币本位)
+	MinWithdrawal float64 `mapstructure:"min_withdrawal"` // 单笔最小提现金额 (币本位)
+
+	// 卡单处理 (Fee Replacement)
+	StuckAfter      time.Duration `mapstructure:"stuck_after"`        // 广播后超过该时长仍未打包，自动加速
+	FeeBumpPercent  int64         `mapstructure:"fee_bump_percent"`   // 每次替换提高的手续费百分比
+	MaxReplacements int           `mapstructure:"max_replacements"`   // 自动加速次数上限 (管理员手动操作不受限)
+	MaxGasPriceGwei int64         `mapstructure:"max_gas_price_gwei"` // EVM: Gas 单价上限
+	MaxFeeRate      int64         `mapstructure:"max_fee_rate"`       // BTC: 费率上限 (sat/vB)
+
+	// 手续费估算 (Fee Estimation): 估算结果会被限制在 [min, max] 之间，RPC 不可用时使用 fallback
+	MinGasPriceGwei      int64  `mapstructure:"min_gas_price_gwei"`      // EVM: Gas 单价下限
+	FallbackGasPriceGwei int64  `mapstructure:"fallback_gas_price_gwei"` // EVM: 兜底 Gas 单价
+	MinFeeRate           int64  `mapstructure:"min_fee_rate"`            // BTC: 费率下限 (sat/vB)
+	FallbackFeeRate      int64  `mapstructure:"fallback_fee_rate"`       // BTC: 兜底费率 (sat/vB)
+	FeeAPI               string `mapstructure:"fee_api"`                 // BTC: mempool.space 兼容的费率接口
+
+	// 区块浏览器交易链接模板，{tx_hash} 会被替换为交易 Hash
+	ExplorerTxURL string `mapstructure:"explorer_tx_url"`
+
+	// 大额提现时间锁: 金额 >= time_lock_amount 时强制延迟 time_lock_delay 才能执行 (0 表示不启用)
+	TimeLockAmount float64       `mapstructure:"time_lock_amount"`
+	TimeLockDelay  time.Duration `mapstructure:"time_lock_delay"`
+}
+
+// RiskConfig 提现风控
+// 每条规则命中后累加分数，总分决定审批策略:
+// - score >= hold_threshold: 自动挂起 (risk_hold)，需要 high_risk_approvals 个审批
+// - score >= review_threshold: 需要 high_risk_approvals 个审批
+// - 其他: 需要 base_approvals 个审批
+type RiskConfig struct {
+	Source            string        `mapstructure:"source"`          // 规则来源: config | db
+	ReloadInterval    time.Duration `mapstructure:"reload_interval"` // 规则热加载间隔
+	ReviewThreshold   int           `mapstructure:"review_threshold"`
+	HoldThreshold     int           `mapstructure:"hold_threshold"`
+	BaseApprovals     int           `mapstructure:"base_approvals"`
+	HighRiskApprovals int           `mapstructure:"high_risk_approvals"`
+	Rules             []RiskRule    `mapstructure:"rules"`
+}
+
+// RiskRule 一条声明式风控规则
+type RiskRule struct {
+	Name    string             `mapstructure:"name" json:"name"`
+	Type    string             `mapstructure:"type" json:"type"` // 规则类型，见 internal/service/risk
+	Score   int                `mapstructure:"score" json:"score"`
+	Enabled bool               `mapstructure:"enabled" json:"enabled"`
+	Params  map[string]float64 `mapstructure:"params" json:"params"`
+}
+
+// ScreeningConfig 制裁名单 / 黑名单地址筛查
+// 名单文件每行一个地址，或 OFAC SDN 导出的 CSV (自动提取其中的数字货币地址)，# 开头为注释
+type ScreeningConfig struct {
+	Files          []string      `mapstructure:"files"`           // 名单文件路径
+	ReloadInterval time.Duration `mapstructure:"reload_interval"` // 名单重新加载间隔 (无需重启)
+}
+
+// WithdrawalConfig 定时 / 时间锁提现
+type WithdrawalConfig struct {
+	MaxScheduleAhead time.Duration `mapstructure:"max_schedule_ahead"` // 定时提现最多可以预约多久之后
+	ReminderBefore   time.Duration `mapstructure:"reminder_before"`    // 执行前多久发送提醒邮件
+}
+
+// MFAConfig 两步验证 (TOTP)
+type MFAConfig struct {
+	Issuer        string        `mapstructure:"issuer"`         // 验证器 App 中显示的发行方
+	EncryptionKey string        `mapstructure:"encryption_key"` // TOTP 密钥加密用的 AES-256 密钥 (hex)，生产环境通过环境变量 MFA_ENCRYPTION_KEY 传入
+	MaxAttempts   int           `mapstructure:"max_attempts"`   // 窗口期内最多校验失败次数
+	AttemptWindow time.Duration `mapstructure:"attempt_window"` // 失败次数统计窗口 (超限后锁定到窗口结束)
+}
+
+// AuthConfig 登录态 (JWT Access Token + Redis Refresh Token)
+type AuthConfig struct {
+	JWTSecret  string        `mapstructure:"jwt_secret"`  // HS256 签名密钥，所有服务必须一致，生产环境通过环境变量 AUTH_JWT_SECRET 传入
+	Issuer     string        `mapstructure:"issuer"`      // JWT iss
+	AccessTTL  time.Duration `mapstructure:"access_ttl"`  // Access Token 有效期 (短)
+	RefreshTTL time.Duration `mapstructure:"refresh_ttl"` // Refresh Token 有效期，每次刷新都会轮换
+}
+
+// AccountConfig 邮箱验证 / 找回密码
+// 链接模板中的 {token} 替换为一次性令牌，由前端页面取出后调用验证 / 重置接口
+type AccountConfig struct {
+	VerifyURL            string        `mapstructure:"verify_url"`             // 邮箱验证链接模板
+	ResetURL             string        `mapstructure:"reset_url"`              // 重置密码链接模板
+	VerifyTokenTTL       time.Duration `mapstructure:"verify_token_ttl"`       // 邮箱验证令牌有效期
+	ResetTokenTTL        time.Duration `mapstructure:"reset_token_ttl"`        // 重置密码令牌有效期 (短)
+	RequireVerifiedEmail bool          `mapstructure:"require_verified_email"` // 邮箱未验证时禁止登录
+}
+
+// MailConfig 邮件发送 (见 pkg/mail)
+type MailConfig struct {
+	Driver string     `mapstructure:"driver"` // smtp | file | log
+	From   string     `mapstructure:"from"`   // 发件人，如 "WalletCore <no-reply@example.com>"
+	Dir    string     `mapstructure:"dir"`    // driver=file 时邮件 (.eml) 写入的目录
+	SMTP   SMTPConfig `mapstructure:"smtp"`
+}
+
+// SMTPConfig SMTP 服务器，587 端口使用 STARTTLS，465 端口使用隐式 TLS
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"` // 生产环境通过环境变量 MAIL_SMTP_PASSWORD 传入
+}
+
+// KYCConfig 实名认证 (KYC) 等级
+// 用户等级决定能否提现、可用币种和每日提现额度 (见 service.TierPolicy)
+type KYCConfig struct {
+	EncryptionKey   string                   `mapstructure:"encryption_key"`    // 证件文件加密用的 AES-256 密钥 (hex)，生产环境通过环境变量 KYC_ENCRYPTION_KEY 传入
+	DocumentDir     string                   `mapstructure:"document_dir"`      // 证件文件目录，文件经 KMS 加密后落盘
+	MaxDocumentSize int64                    `mapstructure:"max_document_size"` // 单个证件文件上限 (字节)
+	Tiers           map[string]KYCTierConfig `mapstructure:"tiers"`             // key 为等级: unverified | basic | full
+}
+
+// KYCTierConfig 一个等级的权限
+type KYCTierConfig struct {
+	WithdrawalEnabled bool               `mapstructure:"withdrawal_enabled"` // 是否允许提现
+	Assets            []string           `mapstructure:"assets"`             // 可用币种 (充值地址 / 提现)，为空表示全部已配置的币种
+	DailyWithdrawal   map[string]float64 `mapstructure:"daily_withdrawal"`   // 24 小时内提现额度 (币本位，key 为小写币种)，未配置的币种不限额
+}
+
+// AdminConfig 后台管理员登录 (密码 + TOTP，与终端用户使用不同的签名密钥)
+type AdminConfig struct {
+	JWTSecret     string        `mapstructure:"jwt_secret"`     // 管理员会话签名密钥，生产环境通过环境变量 ADMIN_JWT_SECRET 传入
+	EncryptionKey string        `mapstructure:"encryption_key"` // 管理员 TOTP 密钥加密用的 AES-256 密钥 (hex)，生产环境通过环境变量 ADMIN_ENCRYPTION_KEY 传入
+	SessionTTL    time.Duration `mapstructure:"session_ttl"`    // 会话有效期，到期后需重新登录 (含 TOTP)
+	MaxAttempts   int           `mapstructure:"max_attempts"`   // 窗口期内同一用户名在同一 IP 上最多登录失败次数 (按 IP 的总频率见 ratelimit.rules.admin_login)
+	AttemptWindow time.Duration `mapstructure:"attempt_window"` // 失败次数统计窗口
+}
+
+// MerchantConfig 商户 API (HMAC 签名)
+type MerchantConfig struct {
+	ClockSkew     time.Duration `mapstructure:"clock_skew"`     // X-Timestamp 与服务器时间允许的最大偏差，nonce 缓存时长为其 2 倍
+	EncryptionKey string        `mapstructure:"encryption_key"` // API 密钥加密用的 AES-256 密钥 (hex)，生产环境通过环境变量 MERCHANT_ENCRYPTION_KEY 传入
+}
+
+// InvoiceConfig 商户收款账单与通知 (Webhook，账单 / 充值 / 提现事件共用)
+type InvoiceConfig struct {
+	DefaultTTL           time.Duration                 `mapstructure:"default_ttl"`            // 未指定有效期时账单的有效期
+	MaxTTL               time.Duration                 `mapstructure:"max_ttl"`                // 有效期上限 (法币计价的汇率锁定时长)
+	CheckoutURL          string                        `mapstructure:"checkout_url"`           // 托管收银台页面，{id} 替换为账单 public_id
+	Rates                map[string]map[string]float64 `mapstructure:"rates"`                  // 法币汇率: rates.<法币>.<币种> = 1 个币值多少法币 (key 均为小写)
+	WebhookTimeout       time.Duration                 `mapstructure:"webhook_timeout"`        // 单次通知请求超时
+	WebhookMaxRetry      int                           `mapstructure:"webhook_max_retry"`      // 通知失败重试次数 (asynq 指数退避)
+	WebhookAllowPrivate  bool                          `mapstructure:"webhook_allow_private"`  // 允许通知内网 / 回环地址 (仅限开发环境)
+	WebhookMaxEndpoints  int                           `mapstructure:"webhook_max_endpoints"`  // 每个商户最多配置的通知地址数
+	WebhookEncryptionKey string                        `mapstructure:"webhook_encryption_key"` // 通知签名密钥加密用的 AES-256 密钥 (hex)，生产环境通过环境变量 INVOICE_WEBHOOK_ENCRYPTION_KEY 传入
+}
+
+// TransferConfig 站内转账 (用户之间不上链划转余额，包括收款地址属于本平台用户的提现)
+// 限额 key 均为小写币种，未配置的币种不限制
+type TransferConfig struct {
+	MinAmount  map[string]float64 `mapstructure:"min_amount"`  // 单笔最小金额
+	MaxAmount  map[string]float64 `mapstructure:"max_amount"`  // 单笔最大金额
+	DailyLimit map[string]float64 `mapstructure:"daily_limit"` // 每个用户 24 小时内转出合计
+	MaxRetries int                `mapstructure:"max_retries"` // 账户版本冲突 (乐观锁) 时的重试次数
+}
+
+// RateLimitConfig 分布式限流 (Redis 令牌桶)
+// 规则按名字挂到路由 / gRPC 方法上，未配置的规则不限流
+type RateLimitConfig struct {
+	Enabled  bool                     `mapstructure:"enabled"`
+	FailOpen bool                     `mapstructure:"fail_open"` // Redis 不可用时: true 放行，false 拒绝 (503 / Unavailable)
+	Rules    map[string]RateLimitRule `mapstructure:"rules"`     // key 为规则名 (login, withdraw ...)
+}
+
+// RateLimitRule 一条限流规则: 每个维度 (by) 的主体在 window 内最多 limit 次，令牌匀速补充
+type RateLimitRule struct {
+	Limit  int           `mapstructure:"limit"`
+	Window time.Duration `mapstructure:"window"`
+	By     string        `mapstructure:"by"` // ip | user | api_key | route (整条路由共享一个桶)
+}
+
+// GRPCConfig gRPC 服务端 / 客户端公共参数 (见 internal/interceptor)
+type GRPCConfig struct {
+	DefaultTimeout time.Duration `mapstructure:"default_timeout"` // 调用方未设置 deadline 时服务端使用的超时
+	MaxTimeout     time.Duration `mapstructure:"max_timeout"`     // 调用方 deadline 超过该值时截断
+	ClientTimeout  time.Duration `mapstructure:"client_timeout"`  // 客户端未设置 deadline 时的默认超时
+	MetricsPort    string        `mapstructure:"metrics_port"`    // 独立 gRPC 服务暴露 /metrics 的端口，空表示不暴露
+	// 受信任的转发方 (网关，IP / CIDR)，只有它们的调用才采信 metadata 中的 x-forwarded-for
+	TrustedForwarders []string `mapstructure:"trusted_forwarders"`
+}
+
+// HealthConfig 健康检查 (见 pkg/health)
+type HealthConfig struct {
+	Port           string        `mapstructure:"port"`             // 没有 HTTP 服务的进程 (user-service / wallet-service / broadcaster-worker) 暴露 /livez /readyz 的端口，空表示不暴露
+	Timeout        time.Duration `mapstructure:"timeout"`          // 单项检查超时
+	Interval       time.Duration `mapstructure:"interval"`         // gRPC 健康状态刷新间隔
+	ObserverMaxLag time.Duration `mapstructure:"observer_max_lag"` // 区块扫描器超过该时长没有处理新区块视为卡死
+}
+
 var Global Config
 
+// Chain 返回指定链的配置 (链名大小写不敏感)
+// viper 会把 map key 统一转成小写，所以这里也按小写查找
+func Chain(chain string) ChainConfig {
+	return Global.Chains[strings.ToLower(chain)]
+}
+
 func Init() {
 	viper.SetConfigName("config") // name of config file (without extension)
 	viper.SetConfigType("yaml")   // REQUIRED if the config file does not have the extension in the name
@@ -98,4 +322,132 @@ func setDefaults() {
 	viper.SetDefault("kafka.brokers", []string{"localhost:9092"})
 
 	viper.SetDefault("wallet.keystore_path", "wallet.json")
+
+	viper.SetDefault("chains.eth.confirmations", 12)
+	viper.SetDefault("chains.eth.name", "Ethereum")
+	viper.SetDefault("chains.eth.decimals", 18)
+	viper.SetDefault("chains.eth.min_deposit", 0.001)
+	viper.SetDefault("chains.eth.min_withdrawal", 0.01)
+	viper.SetDefault("chains.eth.tx_type", "dynamic")
+	viper.SetDefault("chains.eth.stuck_after", "10m")
+	viper.SetDefault("chains.eth.fee_bump_percent", 20)
+	viper.SetDefault("chains.eth.max_replacements", 3)
+	viper.SetDefault("chains.eth.max_gas_price_gwei", 500)
+	viper.SetDefault("chains.eth.min_gas_price_gwei", 1)
+	viper.SetDefault("chains.eth.fallback_gas_price_gwei", 20)
+	viper.SetDefault("chains.eth.explorer_tx_url", "https://etherscan.io/tx/{tx_hash}")
+	viper.SetDefault("chains.btc.confirmations", 6)
+	viper.SetDefault("chains.btc.name", "Bitcoin")
+	viper.SetDefault("chains.btc.decimals", 8)
+	viper.SetDefault("chains.btc.min_deposit", 0.0001)
+	viper.SetDefault("chains.btc.min_withdrawal", 0.001)
+	viper.SetDefault("chains.btc.stuck_after", "1h")
+	viper.SetDefault("chains.btc.fee_bump_percent", 50)
+	viper.SetDefault("chains.btc.max_replacements", 3)
+	viper.SetDefault("chains.btc.max_fee_rate", 300)
+	viper.SetDefault("chains.btc.min_fee_rate", 1)
+	viper.SetDefault("chains.btc.fallback_fee_rate", 10)
+	viper.SetDefault("chains.btc.explorer_tx_url", "https://mempool.space/tx/{tx_hash}")
+
+	viper.SetDefault("risk.source", "config")
+	viper.SetDefault("risk.reload_interval", "30s")
+	viper.SetDefault("risk.review_threshold", 30)
+	viper.SetDefault("risk.hold_threshold", 80)
+	viper.SetDefault("risk.base_approvals", 2)
+	viper.SetDefault("risk.high_risk_approvals", 3)
+
+	viper.SetDefault("screening.reload_interval", "5m")
+
+	viper.SetDefault("withdrawal.max_schedule_ahead", "720h")
+	viper.SetDefault("withdrawal.reminder_before", "1h")
+
+	viper.SetDefault("mfa.issuer", "WalletCore")
+	viper.SetDefault("mfa.encryption_key", "")
+	viper.SetDefault("mfa.max_attempts", 5)
+	viper.SetDefault("mfa.attempt_window", "15m")
+
+	viper.SetDefault("auth.jwt_secret", "")
+	viper.SetDefault("auth.issuer", "wallet-core")
+	viper.SetDefault("auth.access_ttl", "15m")
+	viper.SetDefault("auth.refresh_ttl", "720h")
+
+	viper.SetDefault("account.verify_url", "http://localhost:3000/verify-email?token={token}")
+	viper.SetDefault("account.reset_url", "http://localhost:3000/reset-password?token={token}")
+	viper.SetDefault("account.verify_token_ttl", "24h")
+	viper.SetDefault("account.reset_token_ttl", "30m")
+	viper.SetDefault("account.require_verified_email", true)
+
+	viper.SetDefault("mail.driver", "log")
+	viper.SetDefault("mail.from", "WalletCore <no-reply@localhost>")
+	viper.SetDefault("mail.dir", "mail")
+	viper.SetDefault("mail.smtp.port", 587)
+
+	viper.SetDefault("kyc.encryption_key", "")
+	viper.SetDefault("kyc.document_dir", "kyc_documents")
+	viper.SetDefault("kyc.max_document_size", 5<<20)
+	viper.SetDefault("kyc.tiers.unverified.withdrawal_enabled", false)
+	viper.SetDefault("kyc.tiers.basic.withdrawal_enabled", true)
+	viper.SetDefault("kyc.tiers.basic.daily_withdrawal.eth", 5)
+	viper.SetDefault("kyc.tiers.basic.daily_withdrawal.btc", 0.2)
+	viper.SetDefault("kyc.tiers.full.withdrawal_enabled", true)
+
+	viper.SetDefault("admin.jwt_secret", "")
+	viper.SetDefault("admin.encryption_key", "")
+	viper.SetDefault("admin.session_ttl", "8h")
+	viper.SetDefault("admin.max_attempts", 5)
+	viper.SetDefault("admin.attempt_window", "15m")
+
+	viper.SetDefault("merchant.clock_skew", "5m")
+	viper.SetDefault("merchant.encryption_key", "")
+
+	viper.SetDefault("invoice.default_ttl", "15m")
+	viper.SetDefault("invoice.max_ttl", "24h")
+	viper.SetDefault("invoice.checkout_url", "http://localhost:3000/checkout/{id}")
+	viper.SetDefault("invoice.webhook_timeout", "10s")
+	viper.SetDefault("invoice.webhook_encryption_key", "")
+	viper.SetDefault("invoice.webhook_max_retry", 10)
+	viper.SetDefault("invoice.webhook_allow_private", false)
+	viper.SetDefault("invoice.webhook_max_endpoints", 5)
+
+	viper.SetDefault("transfer.max_retries", 3)
+
+	viper.SetDefault("grpc.default_timeout", "10s")
+	viper.SetDefault("grpc.max_timeout", "30s")
+	viper.SetDefault("grpc.client_timeout", "5s")
+	viper.SetDefault("grpc.metrics_port", "")
+
+	viper.SetDefault("health.port", "8081")
+	viper.SetDefault("health.timeout", "2s")
+	viper.SetDefault("health.interval", "10s")
+	viper.SetDefault("health.observer_max_lag", "2m")
+
+	viper.SetDefault("ratelimit.enabled", true)
+	viper.SetDefault("ratelimit.fail_open", true)
+	viper.SetDefault("ratelimit.rules.login.limit", 10)
+	viper.SetDefault("ratelimit.rules.login.window", "1m")
+	viper.SetDefault("ratelimit.rules.login.by", "ip")
+	viper.SetDefault("ratelimit.rules.register.limit", 5)
+	viper.SetDefault("ratelimit.rules.register.window", "1h")
+	viper.SetDefault("ratelimit.rules.register.by", "ip")
+	viper.SetDefault("ratelimit.rules.admin_login.limit", 5)
+	viper.SetDefault("ratelimit.rules.admin_login.window", "1m")
+	viper.SetDefault("ratelimit.rules.admin_login.by", "ip")
+	viper.SetDefault("ratelimit.rules.address_create.limit", 20)
+	viper.SetDefault("ratelimit.rules.address_create.window", "1h")
+	viper.SetDefault("ratelimit.rules.address_create.by", "user")
+	viper.SetDefault("ratelimit.rules.withdraw.limit", 10)
+	viper.SetDefault("ratelimit.rules.withdraw.window", "1h")
+	viper.SetDefault("ratelimit.rules.withdraw.by", "user")
+	viper.SetDefault("ratelimit.rules.transfer.limit", 30)
+	viper.SetDefault("ratelimit.rules.transfer.window", "1h")
+	viper.SetDefault("ratelimit.rules.transfer.by", "user")
+	viper.SetDefault("ratelimit.rules.account_email.limit", 5)
+	viper.SetDefault("ratelimit.rules.account_email.window", "1h")
+	viper.SetDefault("ratelimit.rules.account_email.by", "ip")
+	viper.SetDefault("ratelimit.rules.merchant.limit", 600)
+	viper.SetDefault("ratelimit.rules.merchant.window", "1m")
+	viper.SetDefault("ratelimit.rules.merchant.by", "api_key")
+	viper.SetDefault("ratelimit.rules.checkout.limit", 120)
+	viper.SetDefault("ratelimit.rules.checkout.window", "1m")
+	viper.SetDefault("ratelimit.rules.checkout.by", "ip")
 }
@@ -8,21 +8,35 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+
+	"wallet-core/pkg/tenant"
 )
 
 // DB 全局数据库连接对象
 var DB *gorm.DB
 
+// GormConfig ConnectPostgres 使用的 GORM 配置
+func GormConfig() *gorm.Config {
+	return &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info), // 打印 SQL 语句方便调试
+		// 把驱动错误转换为 gorm.ErrDuplicatedKey / gorm.ErrForeignKeyViolated，业务代码用 errors.Is 判断唯一约束冲突
+		TranslateError: true,
+	}
+}
+
 // ConnectPostgres 连接到 PostgreSQL 数据库
 // dsn: "host=localhost user=gorm password=gorm dbname=gorm port=9920 sslmode=disable"
 func ConnectPostgres(dsn string) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info), // 打印 SQL 语句方便调试
-	})
+	db, err := gorm.Open(postgres.Open(dsn), GormConfig())
 	if err != nil {
 		return nil, fmt.Errorf("无法连接到数据库: %w", err)
 	}
 
+	// 多租户隔离: 按 context 中的租户自动限定 users / addresses / deposits / withdrawals
+	if err := db.Use(tenant.Plugin{}); err != nil {
+		return nil, fmt.Errorf("注册租户插件失败: %w", err)
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, err
@@ -42,6 +42,15 @@ var (
 	ErrBind             = Errno{Code: 10002, Message: "Error occurred while binding the request body to the struct"}
 	ErrTokenInvalid     = Errno{Code: 10003, Message: "Token invalid"}
 	ErrDatabase         = Errno{Code: 10004, Message: "Database error"}
+	ErrTokenExpired     = Errno{Code: 10005, Message: "Token expired"}
+	ErrUnauthorized     = Errno{Code: 10006, Message: "Authentication required"}
+	ErrPermissionDenied = Errno{Code: 10007, Message: "Permission denied"}
+	ErrSignatureInvalid = Errno{Code: 10008, Message: "Request signature invalid"}
+	ErrAPIKeyInvalid    = Errno{Code: 10009, Message: "API key invalid, expired or revoked"}
+	ErrRequestReplayed  = Errno{Code: 10010, Message: "Request timestamp out of range or nonce already used"}
+	ErrIPNotAllowed     = Errno{Code: 10011, Message: "Client IP is not allowed for this API key"}
+	ErrTooManyRequests  = Errno{Code: 10012, Message: "Too many requests, try again later"}
+	ErrUnavailable      = Errno{Code: 10013, Message: "Service temporarily unavailable"}
 )
 
 // Business Errors (20000+)
@@ -49,5 +58,76 @@ var (
 	ErrUserNotFound      = Errno{Code: 20101, Message: "User not found"}
 	ErrPasswordIncorrect = Errno{Code: 20102, Message: "Password incorrect"}
 	ErrUserAlreadyExist  = Errno{Code: 20103, Message: "User already exists"}
+	ErrMFARequired       = Errno{Code: 20104, Message: "Two-factor authentication code required"}
+	ErrMFAInvalid        = Errno{Code: 20105, Message: "Two-factor authentication code invalid"}
+	ErrMFALocked         = Errno{Code: 20106, Message: "Too many failed verification attempts, try again later"}
+	ErrMFANotEnrolled    = Errno{Code: 20107, Message: "Two-factor authentication is not enrolled"}
+	ErrMFAAlreadyEnabled = Errno{Code: 20108, Message: "Two-factor authentication is already enabled"}
+	ErrEmailNotVerified  = Errno{Code: 20109, Message: "Email address is not verified"}
+	ErrUserTokenInvalid  = Errno{Code: 20110, Message: "Link is invalid or has expired"}
+	ErrEmailVerified     = Errno{Code: 20111, Message: "Email address is already verified"}
 	ErrAddressNotFound   = Errno{Code: 20201, Message: "Address not found"}
+	ErrAddressInvalid    = Errno{Code: 20202, Message: "Address format invalid"}
+	ErrDepositNotFound   = Errno{Code: 20203, Message: "Deposit not found"}
+
+	ErrWithdrawalNotFound      = Errno{Code: 20301, Message: "Withdrawal not found"}
+	ErrWithdrawalStateInvalid  = Errno{Code: 20302, Message: "Withdrawal is not in a replaceable state"}
+	ErrFeeCeilingReached       = Errno{Code: 20303, Message: "Fee ceiling reached, cannot bump further"}
+	ErrAddressSanctioned       = Errno{Code: 20304, Message: "Destination address is on the sanctions blocklist"}
+	ErrScheduleInvalid         = Errno{Code: 20305, Message: "Withdrawal execution time is invalid"}
+	ErrWithdrawalNotCancelable = Errno{Code: 20306, Message: "Withdrawal can no longer be cancelled"}
+	ErrInsufficientBalance     = Errno{Code: 20307, Message: "Insufficient balance"}
+	ErrAccountNotFound         = Errno{Code: 20308, Message: "Account not found"}
+	ErrAmountInvalid           = Errno{Code: 20309, Message: "Amount must be a positive number"}
+	ErrWithdrawalClaimed       = Errno{Code: 20310, Message: "Withdrawal is claimed by another reviewer"}
+	ErrAlreadyReviewed         = Errno{Code: 20311, Message: "Admin has already reviewed this withdrawal"}
+
+	ErrAdminLoginFailed   = Errno{Code: 20401, Message: "Invalid username, password or verification code"}
+	ErrAdminRoleInvalid   = Errno{Code: 20402, Message: "Invalid admin role"}
+	ErrAdminAlreadyExists = Errno{Code: 20403, Message: "Admin username already exists"}
+	ErrAdminNotFound      = Errno{Code: 20404, Message: "Admin not found or disabled"}
+
+	ErrMerchantNotFound = Errno{Code: 20501, Message: "Merchant not found"}
+	ErrAPIKeyNotFound   = Errno{Code: 20502, Message: "API key not found"}
+	ErrScopeInvalid     = Errno{Code: 20503, Message: "Invalid API key scope"}
+	ErrMerchantAccount  = Errno{Code: 20504, Message: "Invalid merchant settlement account"}
+
+	ErrKYCApplicationNotFound = Errno{Code: 20601, Message: "KYC application not found"}
+	ErrKYCApplicationPending  = Errno{Code: 20602, Message: "A KYC application is already pending review"}
+	ErrKYCApplicationReviewed = Errno{Code: 20603, Message: "KYC application has already been reviewed"}
+	ErrKYCTierInvalid         = Errno{Code: 20604, Message: "Invalid KYC tier"}
+	ErrKYCDocumentInvalid     = Errno{Code: 20605, Message: "KYC document is invalid"}
+	ErrKYCDocumentNotFound    = Errno{Code: 20606, Message: "KYC document not found"}
+	ErrKYCWithdrawalDisabled  = Errno{Code: 20607, Message: "Withdrawals require a higher KYC tier"}
+	ErrKYCAssetNotAllowed     = Errno{Code: 20608, Message: "Currency is not available for your KYC tier"}
+	ErrKYCLimitExceeded       = Errno{Code: 20609, Message: "Daily withdrawal limit for your KYC tier exceeded"}
+
+	ErrInvoiceNotFound      = Errno{Code: 20701, Message: "Invoice not found"}
+	ErrInvoiceInvalid       = Errno{Code: 20702, Message: "Invoice parameters invalid"}
+	ErrInvoiceOrderExists   = Errno{Code: 20703, Message: "An invoice for this order already exists"}
+	ErrRateUnavailable      = Errno{Code: 20704, Message: "Exchange rate unavailable for this currency pair"}
+	ErrInvoiceAmountBusy    = Errno{Code: 20705, Message: "Too many open invoices for this amount on the shared address, try again later"}
+	ErrWebhookURLInvalid    = Errno{Code: 20706, Message: "Webhook URL invalid"}
+	ErrWebhookNotConfigured = Errno{Code: 20707, Message: "Webhook is not configured"}
+	ErrWebhookInvalid       = Errno{Code: 20708, Message: "Webhook parameters invalid"}
+	ErrWebhookNotFound      = Errno{Code: 20709, Message: "Webhook endpoint not found"}
+	ErrWebhookLimitExceeded = Errno{Code: 20710, Message: "Too many webhook endpoints"}
+	ErrDeliveryNotFound     = Errno{Code: 20711, Message: "Webhook delivery not found"}
+
+	ErrTenantNotFound      = Errno{Code: 20801, Message: "Tenant not found"}
+	ErrTenantInvalid       = Errno{Code: 20802, Message: "Tenant parameters invalid"}
+	ErrTenantExists        = Errno{Code: 20803, Message: "Tenant code already exists"}
+	ErrTenantDisabled      = Errno{Code: 20804, Message: "Tenant is disabled"}
+	ErrTenantMismatch      = Errno{Code: 20805, Message: "Record belongs to another tenant"}
+	ErrTenantChainNotReady = Errno{Code: 20806, Message: "Chain is not configured for this tenant"}
+	ErrTenantXPubLocked    = Errno{Code: 20807, Message: "Tenant xpub cannot be changed once set"}
+	ErrTenantLimitExceeded = Errno{Code: 20808, Message: "Withdrawal exceeds the tenant limit"}
+
+	ErrTransferInvalid           = Errno{Code: 20901, Message: "Transfer parameters invalid"}
+	ErrTransferSelf              = Errno{Code: 20902, Message: "Cannot transfer to yourself"}
+	ErrTransferRecipientNotFound = Errno{Code: 20903, Message: "Transfer recipient not found"}
+	ErrTransferLimitExceeded     = Errno{Code: 20904, Message: "Transfer exceeds the limit"}
+	ErrTransferConflict          = Errno{Code: 20905, Message: "Account is busy, please retry"}
+	ErrIdempotencyKeyReused      = Errno{Code: 20906, Message: "Idempotency key was already used with different parameters"}
+	ErrTransferNotFound          = Errno{Code: 20907, Message: "Transfer not found"}
 )
@@ -0,0 +1,151 @@
+package errno
+
+import (
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain errdetails.ErrorInfo 中标识本系统业务错误的 domain
+const Domain = "wallet-core"
+
+// grpcCodes 业务错误码 -> gRPC 状态码
+// 未列出的: 10xxx 视为 Internal，20xxx 视为 FailedPrecondition
+var grpcCodes = map[int]codes.Code{
+	OK.Code:                  codes.OK,
+	InternalServerError.Code: codes.Internal,
+	ErrBind.Code:             codes.InvalidArgument,
+	ErrTokenInvalid.Code:     codes.Unauthenticated,
+	ErrDatabase.Code:         codes.Internal,
+	ErrTokenExpired.Code:     codes.Unauthenticated,
+	ErrUnauthorized.Code:     codes.Unauthenticated,
+	ErrPermissionDenied.Code: codes.PermissionDenied,
+	ErrSignatureInvalid.Code: codes.Unauthenticated,
+	ErrAPIKeyInvalid.Code:    codes.Unauthenticated,
+	ErrRequestReplayed.Code:  codes.Unauthenticated,
+	ErrIPNotAllowed.Code:     codes.PermissionDenied,
+	ErrTooManyRequests.Code:  codes.ResourceExhausted,
+	ErrUnavailable.Code:      codes.Unavailable,
+
+	ErrUserNotFound.Code:      codes.NotFound,
+	ErrPasswordIncorrect.Code: codes.Unauthenticated,
+	ErrUserAlreadyExist.Code:  codes.AlreadyExists,
+	ErrMFARequired.Code:       codes.Unauthenticated,
+	ErrMFAInvalid.Code:        codes.Unauthenticated,
+	ErrMFALocked.Code:         codes.ResourceExhausted,
+	ErrMFANotEnrolled.Code:    codes.FailedPrecondition,
+	ErrMFAAlreadyEnabled.Code: codes.AlreadyExists,
+	ErrEmailNotVerified.Code:  codes.FailedPrecondition,
+	ErrUserTokenInvalid.Code:  codes.InvalidArgument,
+	ErrEmailVerified.Code:     codes.AlreadyExists,
+	ErrAddressNotFound.Code:   codes.NotFound,
+	ErrAddressInvalid.Code:    codes.InvalidArgument,
+	ErrDepositNotFound.Code:   codes.NotFound,
+
+	ErrWithdrawalNotFound.Code:      codes.NotFound,
+	ErrWithdrawalStateInvalid.Code:  codes.FailedPrecondition,
+	ErrFeeCeilingReached.Code:       codes.FailedPrecondition,
+	ErrAddressSanctioned.Code:       codes.PermissionDenied,
+	ErrScheduleInvalid.Code:         codes.InvalidArgument,
+	ErrWithdrawalNotCancelable.Code: codes.FailedPrecondition,
+	ErrInsufficientBalance.Code:     codes.FailedPrecondition,
+	ErrAccountNotFound.Code:         codes.NotFound,
+	ErrAmountInvalid.Code:           codes.InvalidArgument,
+	ErrWithdrawalClaimed.Code:       codes.Aborted,
+	ErrAlreadyReviewed.Code:         codes.AlreadyExists,
+
+	ErrAdminLoginFailed.Code:   codes.Unauthenticated,
+	ErrAdminRoleInvalid.Code:   codes.InvalidArgument,
+	ErrAdminAlreadyExists.Code: codes.AlreadyExists,
+	ErrAdminNotFound.Code:      codes.NotFound,
+
+	ErrMerchantNotFound.Code: codes.NotFound,
+	ErrAPIKeyNotFound.Code:   codes.NotFound,
+	ErrScopeInvalid.Code:     codes.InvalidArgument,
+	ErrMerchantAccount.Code:  codes.InvalidArgument,
+
+	ErrKYCApplicationNotFound.Code: codes.NotFound,
+	ErrKYCApplicationPending.Code:  codes.AlreadyExists,
+	ErrKYCApplicationReviewed.Code: codes.FailedPrecondition,
+	ErrKYCTierInvalid.Code:         codes.InvalidArgument,
+	ErrKYCDocumentInvalid.Code:     codes.InvalidArgument,
+	ErrKYCDocumentNotFound.Code:    codes.NotFound,
+	ErrKYCWithdrawalDisabled.Code:  codes.PermissionDenied,
+	ErrKYCAssetNotAllowed.Code:     codes.PermissionDenied,
+	ErrKYCLimitExceeded.Code:       codes.FailedPrecondition,
+
+	ErrInvoiceNotFound.Code:      codes.NotFound,
+	ErrInvoiceInvalid.Code:       codes.InvalidArgument,
+	ErrInvoiceOrderExists.Code:   codes.AlreadyExists,
+	ErrRateUnavailable.Code:      codes.FailedPrecondition,
+	ErrInvoiceAmountBusy.Code:    codes.ResourceExhausted,
+	ErrWebhookURLInvalid.Code:    codes.InvalidArgument,
+	ErrWebhookNotConfigured.Code: codes.NotFound,
+	ErrWebhookInvalid.Code:       codes.InvalidArgument,
+	ErrWebhookNotFound.Code:      codes.NotFound,
+	ErrWebhookLimitExceeded.Code: codes.ResourceExhausted,
+	ErrDeliveryNotFound.Code:     codes.NotFound,
+
+	ErrTenantNotFound.Code:      codes.NotFound,
+	ErrTenantInvalid.Code:       codes.InvalidArgument,
+	ErrTenantExists.Code:        codes.AlreadyExists,
+	ErrTenantDisabled.Code:      codes.PermissionDenied,
+	ErrTenantMismatch.Code:      codes.PermissionDenied,
+	ErrTenantChainNotReady.Code: codes.FailedPrecondition,
+	ErrTenantXPubLocked.Code:    codes.FailedPrecondition,
+	ErrTenantLimitExceeded.Code: codes.FailedPrecondition,
+
+	ErrTransferInvalid.Code:           codes.InvalidArgument,
+	ErrTransferSelf.Code:              codes.InvalidArgument,
+	ErrTransferRecipientNotFound.Code: codes.NotFound,
+	ErrTransferLimitExceeded.Code:     codes.FailedPrecondition,
+	ErrTransferConflict.Code:          codes.Aborted,
+	ErrIdempotencyKeyReused.Code:      codes.AlreadyExists,
+	ErrTransferNotFound.Code:          codes.NotFound,
+}
+
+// GRPCCode 业务错误对应的 gRPC 状态码
+func (e Errno) GRPCCode() codes.Code {
+	if c, ok := grpcCodes[e.Code]; ok {
+		return c
+	}
+	if e.Code >= 20000 {
+		return codes.FailedPrecondition
+	}
+	return codes.Internal
+}
+
+// GRPCStatus 实现 gRPC 的 status 转换接口: handler 直接返回 (或 %w 包装) Errno 即可得到对应的状态码
+// 业务错误码放在 ErrorInfo 详情中 (metadata["code"])，调用方用 FromGRPC 取回
+func (e Errno) GRPCStatus() *status.Status {
+	st := status.New(e.GRPCCode(), e.Message)
+	code := strconv.Itoa(e.Code)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   "ERRNO_" + code,
+		Domain:   Domain,
+		Metadata: map[string]string{"code": code},
+	}); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// FromGRPC 从 gRPC 错误中取回业务错误，对端不是通过 Errno 返回时 ok 为 false
+func FromGRPC(err error) (Errno, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return Errno{}, false
+	}
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != Domain {
+			continue
+		}
+		if code, err := strconv.Atoi(info.GetMetadata()["code"]); err == nil {
+			return Errno{Code: code, Message: st.Message()}, true
+		}
+	}
+	return Errno{}, false
+}
@@ -0,0 +1,50 @@
+package errno
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCCode(t *testing.T) {
+	assert.Equal(t, codes.InvalidArgument, ErrBind.GRPCCode())
+	assert.Equal(t, codes.Unauthenticated, ErrTokenExpired.GRPCCode())
+	assert.Equal(t, codes.NotFound, ErrWithdrawalNotFound.GRPCCode())
+	assert.Equal(t, codes.ResourceExhausted, ErrTooManyRequests.GRPCCode())
+
+	// 未登记的错误码按区间兜底
+	assert.Equal(t, codes.FailedPrecondition, Errno{Code: 29999}.GRPCCode())
+	assert.Equal(t, codes.Internal, Errno{Code: 19999}.GRPCCode())
+}
+
+func TestGRPCRoundTrip(t *testing.T) {
+	st := status.Convert(ErrMFARequired)
+	assert.Equal(t, codes.Unauthenticated, st.Code())
+	assert.Equal(t, ErrMFARequired.Message, st.Message())
+
+	// 经过 wire 编码 (Proto) 后仍能取回业务码
+	err := status.ErrorProto(st.Proto())
+	e, ok := FromGRPC(err)
+	require.True(t, ok)
+	assert.Equal(t, ErrMFARequired, e)
+
+	// WithMessage 保留业务码
+	e, ok = FromGRPC(status.Convert(ErrBind.WithMessage("amount is required")).Err())
+	require.True(t, ok)
+	assert.Equal(t, ErrBind.Code, e.Code)
+	assert.Equal(t, "amount is required", e.Message)
+
+	// 被包装的 Errno 同样映射到对应状态码
+	assert.Equal(t, codes.NotFound, status.Code(fmt.Errorf("load: %w", ErrUserNotFound)))
+
+	// 非 Errno 产生的状态没有业务码
+	_, ok = FromGRPC(status.Error(codes.Internal, "boom"))
+	assert.False(t, ok)
+	_, ok = FromGRPC(errors.New("plain"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,132 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"wallet-core/pkg/kms"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+	"gorm.io/gorm"
+)
+
+// panicError 检查函数 panic 时转成失败结果，不影响其他检查
+type panicError struct {
+	value interface{}
+}
+
+func (e panicError) Error() string {
+	return fmt.Sprintf("check panicked: %v", e.value)
+}
+
+// DB 数据库连通性 (PING)
+func DB(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// Redis Redis 连通性 (PING)
+func Redis(rdb redis.UniversalClient) CheckFunc {
+	return func(ctx context.Context) error {
+		return rdb.Ping(ctx).Err()
+	}
+}
+
+// Kafka 至少一个 broker 可以建立连接
+func Kafka(brokers []string) CheckFunc {
+	return func(ctx context.Context) error {
+		if len(brokers) == 0 {
+			return errors.New("no kafka brokers configured")
+		}
+		var lastErr error
+		for _, b := range brokers {
+			conn, err := kafka.DialContext(ctx, "tcp", b)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			_ = conn.Close()
+			return nil
+		}
+		return fmt.Errorf("all kafka brokers unreachable: %w", lastErr)
+	}
+}
+
+// MQ 消息队列连通性: mqType 为 kafka 时检查 broker，否则检查 Redis Streams 所在的 Redis
+func MQ(mqType string, brokers []string, rdb redis.UniversalClient) CheckFunc {
+	if mqType == "kafka" {
+		return Kafka(brokers)
+	}
+	return Redis(rdb)
+}
+
+// EthNode 节点可达且已完成同步 (eth_syncing 返回 false)
+func EthNode(client *ethclient.Client) CheckFunc {
+	return func(ctx context.Context) error {
+		if client == nil {
+			return errors.New("rpc client not initialized")
+		}
+		progress, err := client.SyncProgress(ctx)
+		if err != nil {
+			return err
+		}
+		if progress != nil && !progress.Done() {
+			return fmt.Errorf("node syncing: block %d of %d", progress.CurrentBlock, progress.HighestBlock)
+		}
+		return nil
+	}
+}
+
+// Lag last 返回最近一次处理的时间，超过 maxLag 没有进展视为卡死
+// 启动后尚未处理过 (零值) 时从 since 开始计算，给首次处理留出时间
+func Lag(last func() time.Time, since time.Time, maxLag time.Duration) CheckFunc {
+	return func(ctx context.Context) error {
+		t := last()
+		if t.IsZero() {
+			t = since
+		}
+		if lag := time.Since(t); lag > maxLag {
+			return fmt.Errorf("no progress for %s (max %s)", lag.Truncate(time.Second), maxLag)
+		}
+		return nil
+	}
+}
+
+// KeyLoaded 密钥已加载到内存
+func KeyLoaded(loaded func() bool) CheckFunc {
+	return func(ctx context.Context) error {
+		if !loaded() {
+			return errors.New("key not loaded")
+		}
+		return nil
+	}
+}
+
+// KMSKey KMS 中的密钥可用: 加密后能解密回原文
+func KMSKey(km kms.KeyManager, keyID string) CheckFunc {
+	probe := []byte("health")
+	return func(ctx context.Context) error {
+		ciphertext, err := km.Encrypt(keyID, probe)
+		if err != nil {
+			return err
+		}
+		plaintext, err := km.Decrypt(keyID, ciphertext)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(plaintext, probe) {
+			return errors.New("key round trip mismatch")
+		}
+		return nil
+	}
+}
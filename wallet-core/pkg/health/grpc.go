@@ -0,0 +1,76 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// GRPCMethods gRPC 健康检查协议的方法，探针不带登录态，需要加入各服务的 PublicMethods
+var GRPCMethods = []string{
+	healthpb.Health_Check_FullMethodName,
+	healthpb.Health_List_FullMethodName,
+	healthpb.Health_Watch_FullMethodName,
+}
+
+// RegisterGRPC 在 gRPC Server 上注册 grpc.health.v1.Health，并每隔 interval 用 Ready 的结果刷新状态
+// 状态同时设置在空服务名 "" (整个进程) 和 s 上已注册的各个服务名上，所以要在业务服务注册完之后调用
+// ctx 结束后状态置为 NOT_SERVING，调用方应在 GracefulStop 之前取消 ctx
+func (r *Registry) RegisterGRPC(ctx context.Context, s *grpc.Server, interval time.Duration) *grpchealth.Server {
+	names := []string{""}
+	for name := range s.GetServiceInfo() {
+		names = append(names, name)
+	}
+
+	hs := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(s, hs)
+
+	update := func() {
+		status := healthpb.HealthCheckResponse_SERVING
+		if !r.Ready(ctx).Up() {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		for _, name := range names {
+			hs.SetServingStatus(name, status)
+		}
+	}
+	update()
+
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				hs.Shutdown()
+				return
+			case <-ticker.C:
+				update()
+			}
+		}
+	}()
+	return hs
+}
+
+// GRPCUpstream 下游 gRPC 服务可用: grpc.health.v1 Check 返回 SERVING
+// service 为空时检查整个进程
+func GRPCUpstream(conn grpc.ClientConnInterface, service string) CheckFunc {
+	client := healthpb.NewHealthClient(conn)
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return err
+		}
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("upstream status %s", resp.GetStatus())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,156 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Kind 检查类型
+// Liveness: 只检查进程自身 (私钥已加载、扫块未卡死)，失败时 k8s 会重启容器
+// Readiness: 检查外部依赖 (DB / Redis / MQ / 节点)，失败时只摘除流量，重启也解决不了依赖故障
+type Kind int
+
+const (
+	Liveness Kind = iota
+	Readiness
+)
+
+const (
+	StatusUp   = "UP"
+	StatusDown = "DOWN"
+)
+
+// defaultTimeout 未配置超时时单项检查的超时
+const defaultTimeout = 2 * time.Second
+
+// CheckFunc 单项检查，返回 nil 表示正常
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name string
+	kind Kind
+	fn   CheckFunc
+}
+
+// Result 单项检查结果
+type Result struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMS int64  `json:"latency_ms"`
+}
+
+// Report 一次探测的汇总结果，任一检查失败则整体为 DOWN
+type Report struct {
+	Service string   `json:"service"`
+	Status  string   `json:"status"`
+	Checks  []Result `json:"checks"`
+}
+
+// Up 是否全部检查通过
+func (r Report) Up() bool {
+	return r.Status == StatusUp
+}
+
+// Registry 健康检查注册表，各组件启动时注册自己的检查
+type Registry struct {
+	service string
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	checks []check
+}
+
+// NewRegistry 创建注册表，timeout 为单项检查超时 (<= 0 使用默认 2s)
+func NewRegistry(service string, timeout time.Duration) *Registry {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Registry{service: service, timeout: timeout}
+}
+
+// Register 注册一项检查，同名检查会被替换
+func (r *Registry) Register(name string, kind Kind, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, c := range r.checks {
+		if c.name == name {
+			r.checks[i] = check{name: name, kind: kind, fn: fn}
+			return
+		}
+	}
+	r.checks = append(r.checks, check{name: name, kind: kind, fn: fn})
+}
+
+// Live 执行 Liveness 检查
+func (r *Registry) Live(ctx context.Context) Report {
+	return r.run(ctx, func(c check) bool { return c.kind == Liveness })
+}
+
+// Ready 执行全部检查: 进程存活且依赖可用才接流量
+func (r *Registry) Ready(ctx context.Context) Report {
+	return r.run(ctx, func(check) bool { return true })
+}
+
+// run 并发执行选中的检查，结果按注册顺序返回
+func (r *Registry) run(ctx context.Context, match func(check) bool) Report {
+	r.mu.RLock()
+	var selected []check
+	for _, c := range r.checks {
+		if match(c) {
+			selected = append(selected, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	report := Report{Service: r.service, Status: StatusUp, Checks: make([]Result, len(selected))}
+	var wg sync.WaitGroup
+	for i, c := range selected {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			report.Checks[i] = r.runOne(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	for _, res := range report.Checks {
+		if res.Status != StatusUp {
+			report.Status = StatusDown
+			break
+		}
+	}
+	return report
+}
+
+// runOne 带超时执行单项检查，检查本身不响应 ctx 时按超时判定失败
+func (r *Registry) runOne(ctx context.Context, c check) Result {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- panicError{p}
+			}
+		}()
+		done <- c.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	res := Result{Name: c.name, Status: StatusUp, LatencyMS: time.Since(start).Milliseconds()}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
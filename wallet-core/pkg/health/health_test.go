@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ok(context.Context) error { return nil }
+
+func TestRegistryLiveAndReady(t *testing.T) {
+	r := NewRegistry("test", time.Second)
+	r.Register("key", Liveness, ok)
+	r.Register("db", Readiness, func(context.Context) error { return errors.New("connection refused") })
+
+	// 依赖故障不影响存活
+	live := r.Live(context.Background())
+	assert.True(t, live.Up())
+	require.Len(t, live.Checks, 1)
+	assert.Equal(t, "key", live.Checks[0].Name)
+
+	ready := r.Ready(context.Background())
+	assert.False(t, ready.Up())
+	require.Len(t, ready.Checks, 2)
+	assert.Equal(t, StatusUp, ready.Checks[0].Status)
+	assert.Equal(t, StatusDown, ready.Checks[1].Status)
+	assert.Equal(t, "connection refused", ready.Checks[1].Error)
+}
+
+func TestRegistryReplacesSameName(t *testing.T) {
+	r := NewRegistry("test", time.Second)
+	r.Register("db", Readiness, func(context.Context) error { return errors.New("down") })
+	r.Register("db", Readiness, ok)
+
+	report := r.Ready(context.Background())
+	require.Len(t, report.Checks, 1)
+	assert.True(t, report.Up())
+}
+
+func TestRegistryTimeoutAndPanic(t *testing.T) {
+	r := NewRegistry("test", 20*time.Millisecond)
+	block := make(chan struct{})
+	defer close(block)
+	// 不响应 ctx 的检查按超时判定失败
+	r.Register("stuck", Readiness, func(context.Context) error { <-block; return nil })
+	r.Register("panic", Readiness, func(context.Context) error { panic("boom") })
+
+	report := r.Ready(context.Background())
+	assert.False(t, report.Up())
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks[0].Error)
+	assert.Contains(t, report.Checks[1].Error, "boom")
+}
+
+func TestHandlerStatusCode(t *testing.T) {
+	r := NewRegistry("test", time.Second)
+	r.Register("key", Liveness, ok)
+	r.Register("mq", Readiness, func(context.Context) error { return errors.New("unreachable") })
+
+	rec := httptest.NewRecorder()
+	r.LiveHandler()(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	r.ReadyHandler()(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.Equal(t, "test", report.Service)
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Len(t, report.Checks, 2)
+}
+
+func TestLag(t *testing.T) {
+	now := time.Now()
+	never := func() time.Time { return time.Time{} }
+	recent := func() time.Time { return now.Add(-time.Second) }
+	stale := func() time.Time { return now.Add(-time.Hour) }
+
+	assert.NoError(t, Lag(recent, now, time.Minute)(context.Background()))
+	assert.Error(t, Lag(stale, now, time.Minute)(context.Background()))
+	// 尚未处理过时从启动时间算起
+	assert.NoError(t, Lag(never, now, time.Minute)(context.Background()))
+	assert.Error(t, Lag(never, now.Add(-time.Hour), time.Minute)(context.Background()))
+}
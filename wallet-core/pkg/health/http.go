@@ -0,0 +1,39 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// LiveHandler /livez: 全部 Liveness 检查通过返回 200，否则 503，响应体为各项检查结果
+func (r *Registry) LiveHandler() http.HandlerFunc {
+	return r.handler(r.Live)
+}
+
+// ReadyHandler /readyz: 全部检查通过返回 200，否则 503
+func (r *Registry) ReadyHandler() http.HandlerFunc {
+	return r.handler(r.Ready)
+}
+
+func (r *Registry) handler(probe func(ctx context.Context) Report) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		report := probe(req.Context())
+		status := http.StatusOK
+		if !report.Up() {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(report)
+	}
+}
+
+// Serve 在独立端口暴露 /livez /readyz (给没有 HTTP 服务的进程使用)，阻塞运行
+func Serve(addr string, r *Registry) error {
+	mux := http.NewServeMux()
+	mux.Handle("/livez", r.LiveHandler())
+	mux.Handle("/readyz", r.ReadyHandler())
+	return http.ListenAndServe(addr, mux)
+}
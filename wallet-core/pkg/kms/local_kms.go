@@ -106,6 +106,36 @@ func (kms *LocalKMS) CreateKey(kType KeyType) (string, error) {
 	return keyID, nil
 }
 
+// ImportKey 以指定的 KeyID 导入外部生成的对称密钥 (BYOK)。
+// LocalKMS 不持久化，重启后需要重新导入同一份密钥，否则之前加密的数据将无法解密。
+// 目前仅支持 AES-256 (32 字节)。
+func (kms *LocalKMS) ImportKey(keyID string, kType KeyType, material []byte) error {
+	if kType != KeyTypeAES {
+		return fmt.Errorf("不支持导入的密钥类型: %s", kType)
+	}
+	if len(material) != 32 {
+		return fmt.Errorf("AES 密钥长度必须为 32 字节，实际 %d", len(material))
+	}
+
+	kms.mu.Lock()
+	defer kms.mu.Unlock()
+
+	if _, exists := kms.keys[keyID]; exists {
+		return fmt.Errorf("密钥 %s 已存在", keyID)
+	}
+
+	kms.keys[keyID] = &keyEntry{
+		Metadata: KeyMetadata{
+			KeyID:     keyID,
+			Type:      kType,
+			CreatedAt: time.Now().Unix(),
+			Enabled:   true,
+		},
+		PrivateKey: append([]byte(nil), material...),
+	}
+	return nil
+}
+
 // GetPublicKey 获取指定密钥 ID 的公钥。
 func (kms *LocalKMS) GetPublicKey(keyID string) (any, error) {
 	kms.mu.RLock()
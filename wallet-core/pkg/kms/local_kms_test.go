@@ -33,6 +33,35 @@ func TestLocalKMS_AES(t *testing.T) {
 	}
 }
 
+func TestLocalKMS_ImportKey(t *testing.T) {
+	material := bytes.Repeat([]byte{0x42}, 32)
+
+	// 两个实例导入同一份密钥，模拟服务重启
+	kms1, kms2 := NewLocalKMS(), NewLocalKMS()
+	if err := kms1.ImportKey("mfa", KeyTypeAES, material); err != nil {
+		t.Fatalf("导入密钥失败: %v", err)
+	}
+	if err := kms2.ImportKey("mfa", KeyTypeAES, material); err != nil {
+		t.Fatalf("导入密钥失败: %v", err)
+	}
+
+	ciphertext, err := kms1.Encrypt("mfa", []byte("secret"))
+	if err != nil {
+		t.Fatalf("加密失败: %v", err)
+	}
+	decrypted, err := kms2.Decrypt("mfa", ciphertext)
+	if err != nil || string(decrypted) != "secret" {
+		t.Fatalf("重启后解密失败: %v", err)
+	}
+
+	if err := kms1.ImportKey("mfa", KeyTypeAES, material); err == nil {
+		t.Error("重复导入应该失败")
+	}
+	if err := kms1.ImportKey("short", KeyTypeAES, material[:16]); err == nil {
+		t.Error("密钥长度错误应该失败")
+	}
+}
+
 func TestLocalKMS_RSA(t *testing.T) {
 	kms := NewLocalKMS()
 
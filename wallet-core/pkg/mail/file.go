@@ -0,0 +1,58 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wallet-core/pkg/logger"
+)
+
+// FileSender 把邮件写成 dir 下的 .eml 文件 (可直接用邮件客户端打开)，用于开发和集成测试
+type FileSender struct {
+	dir  string
+	from string
+}
+
+func NewFileSender(dir, from string) (*FileSender, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileSender{dir: dir, from: from}, nil
+}
+
+func (s *FileSender) Send(ctx context.Context, msg Message) error {
+	now := time.Now()
+	data, err := Build(s.from, msg, now)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.dir, now.Format("20060102T150405")+"-*.eml")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LogSender 只把邮件打印到日志，不真正发送
+// 正文中包含验证 / 重置链接，仅限开发环境使用
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(ctx context.Context, msg Message) error {
+	logger.Info("邮件 (未发送，mail.driver=log)",
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+		zap.String("body", msg.Body),
+	)
+	return nil
+}
@@ -0,0 +1,91 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"wallet-core/pkg/config"
+)
+
+// Message 一封纯文本邮件
+type Message struct {
+	To      string // 收件人地址
+	Subject string
+	Body    string
+}
+
+// Sender 邮件发送器，实现: SMTPSender (生产)、FileSender / LogSender (开发 / 测试)
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New 按配置 (mail.driver) 创建发送器
+func New(cfg config.MailConfig) (Sender, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPSender(cfg.SMTP, cfg.From)
+	case "file":
+		return NewFileSender(cfg.Dir, cfg.From)
+	case "", "log":
+		return NewLogSender(), nil
+	}
+	return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+}
+
+// Build 生成 RFC 5322 格式的邮件: UTF-8 纯文本，主题按 RFC 2047 编码，正文 quoted-printable
+// 地址和主题中不允许出现换行，防止邮件头注入
+func Build(from string, msg Message, now time.Time) ([]byte, error) {
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient address: %w", err)
+	}
+	if strings.ContainsAny(msg.Subject, "\r\n") {
+		return nil, errors.New("subject must not contain line breaks")
+	}
+
+	var buf bytes.Buffer
+	header := func(k, v string) { buf.WriteString(k + ": " + v + "\r\n") }
+	header("From", sender.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("Message-ID", messageID(sender.Address))
+	header("MIME-Version", "1.0")
+	header("Content-Type", `text/plain; charset="utf-8"`)
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	body := strings.ReplaceAll(msg.Body, "\r\n", "\n")
+	if _, err := qp.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// messageID <随机串@发件人域名>
+func messageID(from string) string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	domain := "localhost"
+	if i := strings.LastIndex(from, "@"); i >= 0 && i < len(from)-1 {
+		domain = from[i+1:]
+	}
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
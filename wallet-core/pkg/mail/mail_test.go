@@ -0,0 +1,62 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/pkg/config"
+)
+
+func TestBuild(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	data, err := Build("WalletCore <no-reply@example.com>", Message{
+		To:      "alice@example.com",
+		Subject: "验证邮箱",
+		Body:    "line 1\nline 2",
+	}, now)
+	require.NoError(t, err)
+
+	s := string(data)
+	assert.Contains(t, s, "From: \"WalletCore\" <no-reply@example.com>\r\n")
+	assert.Contains(t, s, "To: <alice@example.com>\r\n")
+	assert.Contains(t, s, "Subject: =?utf-8?q?")
+	assert.Contains(t, s, "Date: Fri, 02 Jan 2026 03:04:05 +0000\r\n")
+	assert.Contains(t, s, "@example.com>\r\n")
+	assert.True(t, strings.HasSuffix(s, "\r\n\r\nline 1\r\nline 2"), s)
+}
+
+func TestBuildRejectsHeaderInjection(t *testing.T) {
+	from := "no-reply@example.com"
+	_, err := Build(from, Message{To: "alice@example.com\r\nBcc: eve@example.com", Subject: "hi"}, time.Now())
+	assert.Error(t, err)
+	_, err = Build(from, Message{To: "alice@example.com", Subject: "hi\r\nBcc: eve@example.com"}, time.Now())
+	assert.Error(t, err)
+	_, err = Build("not an address", Message{To: "alice@example.com", Subject: "hi"}, time.Now())
+	assert.Error(t, err)
+}
+
+func TestFileSender(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	s, err := NewFileSender(dir, "no-reply@example.com")
+	require.NoError(t, err)
+	require.NoError(t, s.Send(context.Background(), Message{To: "bob@example.com", Subject: "Reset", Body: "token"}))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "To: <bob@example.com>")
+}
+
+func TestNewUnknownDriver(t *testing.T) {
+	_, err := New(config.MailConfig{Driver: "pigeon"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"wallet-core/pkg/config"
+)
+
+// implicitTLSPort SMTPS 端口，连接建立即 TLS；其他端口在服务器支持时升级 STARTTLS
+const implicitTLSPort = 465
+
+const dialTimeout = 10 * time.Second
+
+// SMTPSender 通过 SMTP 服务器发送
+// 配置了用户名时使用 PLAIN 认证 (net/smtp 只允许在 TLS 连接或 localhost 上使用)
+type SMTPSender struct {
+	cfg      config.SMTPConfig
+	from     string
+	fromAddr string // 信封发件人 (MAIL FROM)
+}
+
+func NewSMTPSender(cfg config.SMTPConfig, from string) (*SMTPSender, error) {
+	if cfg.Host == "" || cfg.Port <= 0 {
+		return nil, errors.New("mail.smtp.host and mail.smtp.port are required")
+	}
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, errors.New("mail.from is not a valid address")
+	}
+	return &SMTPSender{cfg: cfg, from: from, fromAddr: addr.Address}, nil
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	data, err := Build(s.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+	to, _ := mail.ParseAddress(msg.To) // Build 已校验
+
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if s.cfg.Port != implicitTLSPort {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+				return err
+			}
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.fromAddr); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (s *SMTPSender) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	if s.cfg.Port == implicitTLSPort {
+		td := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: s.cfg.Host}}
+		return td.DialContext(ctx, "tcp", addr)
+	}
+	return dialer.DialContext(ctx, "tcp", addr)
+}
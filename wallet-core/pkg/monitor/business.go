@@ -13,6 +13,11 @@ type BusinessMetrics struct {
 	SweeperJobDuration     *prometheus.HistogramVec
 	AddressPoolRemaining   *prometheus.GaugeVec
 	WithdrawalSuccessTotal *prometheus.CounterVec
+	TxConfirmedTotal       *prometheus.CounterVec
+	TxGasUsedRatio         *prometheus.HistogramVec
+	RiskRuleHitsTotal      *prometheus.CounterVec
+	MerchantAPIRequests    *prometheus.CounterVec
+	MerchantAPILatency     *prometheus.HistogramVec
 }
 
 // Global Metrics Instance
@@ -46,5 +51,27 @@ func InitBusinessMetrics() {
 			Name: "wallet_withdraw_success_total",
 			Help: "Total number of successful withdrawals",
 		}, []string{"chain"}),
+		TxConfirmedTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "wallet_tx_confirmed_total",
+			Help: "Outgoing transactions that reached a final state",
+		}, []string{"kind", "result"}), // kind: withdrawal/collection, result: confirmed/failed
+		TxGasUsedRatio: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "wallet_tx_gas_used_ratio",
+			Help:    "Actual gas used divided by the estimated gas limit",
+			Buckets: []float64{0.25, 0.5, 0.75, 0.9, 1.0},
+		}, []string{"kind"}),
+		RiskRuleHitsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "wallet_risk_rule_hits_total",
+			Help: "Number of withdrawals that triggered each risk rule",
+		}, []string{"rule"}),
+		MerchantAPIRequests: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "wallet_merchant_api_requests_total",
+			Help: "Merchant API requests by API key and authentication result",
+		}, []string{"merchant_id", "key_id", "result"}), // result: ok, invalid_signature, invalid_key, replayed, ip_denied, scope_denied
+		MerchantAPILatency: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "wallet_merchant_api_request_duration_seconds",
+			Help:    "Latency of authenticated merchant API requests by API key",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"merchant_id", "key_id"}),
 	}
 }
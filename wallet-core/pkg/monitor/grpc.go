@@ -0,0 +1,42 @@
+package monitor
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// gRPC 调用指标 (由 internal/interceptor 记录)
+// 服务端和客户端分开统计，method 为完整方法名 (/user.v1.UserService/Login)
+var (
+	GRPCServerHandledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, by method and status code.",
+	}, []string{"method", "code"})
+
+	GRPCServerHandlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "RPC handling latency on the server.",
+		Buckets: []float64{0.005, 0.01, 0.05, 0.1, 0.3, 0.5, 1.0, 2.0, 5.0},
+	}, []string{"method"})
+
+	GRPCClientHandledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_handled_total",
+		Help: "Total number of RPCs completed by the client, by method and status code.",
+	}, []string{"method", "code"})
+
+	GRPCClientHandlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "RPC latency observed by the client.",
+		Buckets: []float64{0.005, 0.01, 0.05, 0.1, 0.3, 0.5, 1.0, 2.0, 5.0},
+	}, []string{"method"})
+)
+
+// ServeMetrics 在独立端口暴露 /metrics (给没有 HTTP 服务的 gRPC 进程使用)，阻塞运行
+func ServeMetrics(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return http.ListenAndServe(addr, mux)
+}
@@ -0,0 +1,222 @@
+// Package ratelimit 基于 Redis 的分布式限流 (令牌桶)
+//
+// 每条规则定义容量 limit 和补满时间 window: 桶满时允许突发 limit 次请求，
+// 之后每 window/limit 补充一个令牌。桶的状态保存在 Redis hash 中，
+// 由 Lua 脚本原子地完成 "补充 + 扣减"，多个实例共享同一份计数。
+//
+// 限流维度 (by):
+//   - ip: 按客户端 IP
+//   - user: 按登录用户，未登录时退化为 IP
+//   - api_key: 按商户 API Key，缺失时退化为 IP
+//   - route: 整条路由共享一个桶
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"wallet-core/pkg/config"
+)
+
+// 限流维度
+const (
+	ByIP     = "ip"
+	ByUser   = "user"
+	ByAPIKey = "api_key"
+	ByRoute  = "route"
+)
+
+// 标准响应头 (draft-ietf-httpapi-ratelimit-headers)
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// tokenBucket KEYS[1]=桶, ARGV: 容量, 补满时间(ms), 当前时间(ms)
+// 返回 {是否放行, 剩余令牌}，剩余令牌是小数，以字符串返回避免被截断
+var tokenBucket = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+
+local elapsed = math.max(0, now - ts)
+tokens = math.min(capacity, tokens + elapsed * capacity / window)
+
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tokens, 'ts', now)
+redis.call('PEXPIRE', KEYS[1], window)
+return {allowed, tostring(tokens)}
+`)
+
+// Rule 一条限流规则
+type Rule struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+	By     string
+}
+
+// Identity 请求方的身份信息，调用方尽量填全，由规则决定使用哪一项
+type Identity struct {
+	IP     string
+	UserID uint64
+	APIKey string
+}
+
+// Result 一次限流判定的结果
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // 桶补满所需时间
+	RetryAfter time.Duration // 被限流时，下一个令牌可用的时间
+}
+
+// Limited 该结果是否需要写入限流响应头 (未配置规则时为 false)
+func (r Result) Limited() bool {
+	return r.Limit > 0
+}
+
+// Headers 标准限流响应头，gRPC 下以小写形式放入 header metadata
+func (r Result) Headers() map[string]string {
+	if !r.Limited() {
+		return nil
+	}
+	h := map[string]string{
+		HeaderLimit:     strconv.Itoa(r.Limit),
+		HeaderRemaining: strconv.Itoa(r.Remaining),
+		HeaderReset:     strconv.FormatInt(ceilSeconds(r.Reset), 10),
+	}
+	if !r.Allowed {
+		h[HeaderRetryAfter] = strconv.FormatInt(ceilSeconds(r.RetryAfter), 10)
+	}
+	return h
+}
+
+// Limiter 分布式限流器
+type Limiter struct {
+	rdb       *redis.Client
+	namespace string
+	enabled   bool
+	failOpen  bool
+	rules     map[string]Rule
+}
+
+// New 创建限流器
+// namespace 区分不同进程 (如 gateway / user-service)，避免同一请求在网关和后端各扣一次同一个桶
+func New(rdb *redis.Client, namespace string, cfg config.RateLimitConfig) (*Limiter, error) {
+	rules := make(map[string]Rule, len(cfg.Rules))
+	for name, r := range cfg.Rules {
+		if r.Limit <= 0 || r.Window <= 0 {
+			return nil, fmt.Errorf("ratelimit: rule %q: limit and window must be positive", name)
+		}
+		switch r.By {
+		case ByIP, ByUser, ByAPIKey, ByRoute:
+		default:
+			return nil, fmt.Errorf("ratelimit: rule %q: unknown dimension %q", name, r.By)
+		}
+		rules[name] = Rule{Name: name, Limit: r.Limit, Window: r.Window, By: r.By}
+	}
+	return &Limiter{
+		rdb:       rdb,
+		namespace: namespace,
+		enabled:   cfg.Enabled,
+		failOpen:  cfg.FailOpen,
+		rules:     rules,
+	}, nil
+}
+
+// Rule 查询规则，未配置时 ok 为 false
+func (l *Limiter) Rule(name string) (Rule, bool) {
+	r, ok := l.rules[name]
+	return r, ok
+}
+
+// Allow 对规则 name 扣减一个令牌
+// 限流关闭或规则未配置时直接放行；Redis 出错时按 fail_open 决定是否放行，并返回错误供调用方记录
+func (l *Limiter) Allow(ctx context.Context, name string, id Identity) (Result, error) {
+	rule, ok := l.rules[name]
+	if !l.enabled || !ok {
+		return Result{Allowed: true}, nil
+	}
+
+	now := time.Now().UnixMilli()
+	res, err := tokenBucket.Run(ctx, l.rdb, []string{l.key(rule, id)},
+		rule.Limit, rule.Window.Milliseconds(), now).Slice()
+	if err != nil {
+		return Result{Allowed: l.failOpen, Limit: rule.Limit, Remaining: rule.Limit}, fmt.Errorf("ratelimit: %w", err)
+	}
+	if len(res) != 2 {
+		return Result{Allowed: l.failOpen, Limit: rule.Limit, Remaining: rule.Limit}, fmt.Errorf("ratelimit: unexpected script result %v", res)
+	}
+	allowed, _ := res[0].(int64)
+	tokens, err := strconv.ParseFloat(fmt.Sprint(res[1]), 64)
+	if err != nil {
+		return Result{Allowed: l.failOpen, Limit: rule.Limit, Remaining: rule.Limit}, fmt.Errorf("ratelimit: %w", err)
+	}
+	return newResult(rule, allowed == 1, tokens), nil
+}
+
+// key 桶的 Redis key: ratelimit:<namespace>:<rule>:<subject>
+func (l *Limiter) key(rule Rule, id Identity) string {
+	return "ratelimit:" + l.namespace + ":" + rule.Name + ":" + subject(rule.By, id)
+}
+
+// subject 按维度选出限流主体，身份缺失时退化为按 IP
+func subject(by string, id Identity) string {
+	switch by {
+	case ByRoute:
+		return "all"
+	case ByUser:
+		if id.UserID != 0 {
+			return "user:" + strconv.FormatUint(id.UserID, 10)
+		}
+	case ByAPIKey:
+		if id.APIKey != "" {
+			return "key:" + id.APIKey
+		}
+	}
+	return "ip:" + id.IP
+}
+
+// newResult 根据扣减后的剩余令牌计算结果
+func newResult(rule Rule, allowed bool, tokens float64) Result {
+	perToken := rule.Window / time.Duration(rule.Limit)
+	res := Result{
+		Allowed:   allowed,
+		Limit:     rule.Limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((float64(rule.Limit) - tokens) * float64(perToken)),
+	}
+	if !allowed {
+		res.RetryAfter = time.Duration((1 - tokens) * float64(perToken))
+	}
+	return res
+}
+
+func ceilSeconds(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(d.Seconds()))
+}
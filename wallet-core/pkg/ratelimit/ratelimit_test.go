@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wallet-core/pkg/config"
+)
+
+func TestNewValidatesRules(t *testing.T) {
+	_, err := New(nil, "test", config.RateLimitConfig{Rules: map[string]config.RateLimitRule{
+		"login": {Limit: 0, Window: time.Minute, By: ByIP},
+	}})
+	assert.Error(t, err)
+
+	_, err = New(nil, "test", config.RateLimitConfig{Rules: map[string]config.RateLimitRule{
+		"login": {Limit: 10, Window: time.Minute, By: "country"},
+	}})
+	assert.Error(t, err)
+
+	l, err := New(nil, "test", config.RateLimitConfig{Rules: map[string]config.RateLimitRule{
+		"login": {Limit: 10, Window: time.Minute, By: ByIP},
+	}})
+	require.NoError(t, err)
+	rule, ok := l.Rule("login")
+	assert.True(t, ok)
+	assert.Equal(t, "login", rule.Name)
+}
+
+func TestAllowWithoutRule(t *testing.T) {
+	// 限流关闭或规则未配置时不访问 Redis
+	l, err := New(nil, "test", config.RateLimitConfig{Enabled: false, Rules: map[string]config.RateLimitRule{
+		"login": {Limit: 1, Window: time.Minute, By: ByIP},
+	}})
+	require.NoError(t, err)
+	res, err := l.Allow(context.Background(), "login", Identity{IP: "1.2.3.4"})
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Nil(t, res.Headers())
+
+	l.enabled = true
+	res, err = l.Allow(context.Background(), "withdraw", Identity{IP: "1.2.3.4"})
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.False(t, res.Limited())
+}
+
+func TestSubject(t *testing.T) {
+	id := Identity{IP: "1.2.3.4", UserID: 42, APIKey: "mk_abc"}
+	assert.Equal(t, "ip:1.2.3.4", subject(ByIP, id))
+	assert.Equal(t, "user:42", subject(ByUser, id))
+	assert.Equal(t, "key:mk_abc", subject(ByAPIKey, id))
+	assert.Equal(t, "all", subject(ByRoute, id))
+
+	// 身份缺失时退化为 IP
+	assert.Equal(t, "ip:1.2.3.4", subject(ByUser, Identity{IP: "1.2.3.4"}))
+	assert.Equal(t, "ip:1.2.3.4", subject(ByAPIKey, Identity{IP: "1.2.3.4"}))
+
+	l := &Limiter{namespace: "gateway"}
+	assert.Equal(t, "ratelimit:gateway:withdraw:user:42", l.key(Rule{Name: "withdraw", By: ByUser}, id))
+}
+
+func TestResultHeaders(t *testing.T) {
+	rule := Rule{Name: "login", Limit: 10, Window: time.Minute} // 每 6s 补充一个令牌
+
+	res := newResult(rule, true, 7.5)
+	assert.Equal(t, 7, res.Remaining)
+	assert.Equal(t, 15*time.Second, res.Reset)
+	assert.Equal(t, map[string]string{
+		HeaderLimit:     "10",
+		HeaderRemaining: "7",
+		HeaderReset:     "15",
+	}, res.Headers())
+
+	res = newResult(rule, false, 0.25)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, 4500*time.Millisecond, res.RetryAfter)
+	h := res.Headers()
+	assert.Equal(t, "5", h[HeaderRetryAfter])
+	assert.Equal(t, "59", h[HeaderReset])
+}
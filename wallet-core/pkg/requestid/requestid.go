@@ -0,0 +1,52 @@
+// Package requestid 请求 ID 的生成与传递
+// HTTP 使用 X-Request-Id 头，gRPC 使用 x-request-id metadata，进程内通过 context 传递
+package requestid
+
+import (
+	"context"
+
+	"wallet-core/pkg/safe_random"
+)
+
+const (
+	Header      = "X-Request-Id"
+	MetadataKey = "x-request-id"
+)
+
+// maxLen 外部传入的请求 ID 超过该长度时重新生成，避免日志被撑爆
+const maxLen = 64
+
+type ctxKey struct{}
+
+// New 生成新的请求 ID (16 字节随机数的 hex)
+func New() string {
+	id, err := safe_random.GenerateRandomHexString(16)
+	if err != nil {
+		return "unknown"
+	}
+	return id
+}
+
+// Ensure 沿用上游传入的请求 ID，缺失或不合法时生成新的
+func Ensure(id string) string {
+	if id == "" || len(id) > maxLen {
+		return New()
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return New()
+		}
+	}
+	return id
+}
+
+// WithContext 把请求 ID 写入 context
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext 取出请求 ID，不存在时返回空串
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
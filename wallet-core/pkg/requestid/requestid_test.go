@@ -0,0 +1,25 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnsure(t *testing.T) {
+	assert.Equal(t, "abc-123", Ensure("abc-123"))
+
+	// 缺失、过长或包含控制字符 / 空格时重新生成
+	for _, in := range []string{"", strings.Repeat("a", 65), "a b", "a\nb"} {
+		got := Ensure(in)
+		assert.Len(t, got, 32, in)
+		assert.NotEqual(t, in, got)
+	}
+}
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, "", FromContext(context.Background()))
+	assert.Equal(t, "rid", FromContext(WithContext(context.Background(), "rid")))
+}
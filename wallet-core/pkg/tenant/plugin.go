@@ -0,0 +1,112 @@
+package tenant
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"wallet-core/pkg/errno"
+)
+
+// Plugin 按 context 中的租户隔离数据 (db.Use(tenant.Plugin{}))
+// 只作用于带 TenantID 字段的模型:
+// - 查询 / Row / Scan / 更新 / 删除: 追加 tenant_id = 当前租户
+// - 创建: TenantID 为 0 时填入当前租户，填了其他租户的拒绝写入
+// 没有 WHERE 条件的更新 / 删除不追加条件，仍由 GORM 按 ErrMissingWhereClause 拦截；
+// 只按主键更新的记录本身就是在租户范围内查出来的
+// Raw / Exec 手写 SQL 不经过这里，需要自己带上 tenant_id
+type Plugin struct{}
+
+func (Plugin) Name() string {
+	return "tenant"
+}
+
+func (Plugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Query().Before("gorm:query").Register("tenant:query", scopeQuery); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("tenant:row", scopeQuery); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:update", scopeWrite); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("tenant:delete", scopeWrite); err != nil {
+		return err
+	}
+	return cb.Create().Before("gorm:create").Register("tenant:create", assignCreate)
+}
+
+// tenantField 当前语句模型的 TenantID 字段，不是租户表时返回 nil
+func tenantField(db *gorm.DB) *schema.Field {
+	if db.Statement.Schema == nil {
+		return nil
+	}
+	return db.Statement.Schema.LookUpField("TenantID")
+}
+
+func scopeQuery(db *gorm.DB) {
+	id, ok := FromContext(db.Statement.Context)
+	if !ok || db.Error != nil || tenantField(db) == nil {
+		return
+	}
+	addCondition(db.Statement, id)
+}
+
+// addCondition 追加 tenant_id 条件
+// 已有的条件先整体包成一组，避免 Where(a).Or(b) 变成 a OR b AND tenant_id = ? 而越过租户
+func addCondition(stmt *gorm.Statement, id uint64) {
+	cond := condition(id)
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			c.Expression = clause.Where{Exprs: []clause.Expression{clause.And(where.Exprs...), cond}}
+			stmt.Clauses["WHERE"] = c
+			return
+		}
+	}
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{cond}})
+}
+
+func scopeWrite(db *gorm.DB) {
+	if _, hasWhere := db.Statement.Clauses["WHERE"]; !hasWhere {
+		return
+	}
+	scopeQuery(db)
+}
+
+func assignCreate(db *gorm.DB) {
+	id, ok := FromContext(db.Statement.Context)
+	field := tenantField(db)
+	if !ok || db.Error != nil || field == nil {
+		return
+	}
+
+	ctx, rv := db.Statement.Context, db.Statement.ReflectValue
+	assign := func(v reflect.Value) {
+		current, zero := field.ValueOf(ctx, v)
+		if zero {
+			if err := field.Set(ctx, v, id); err != nil {
+				db.AddError(err)
+			}
+			return
+		}
+		if current != id {
+			db.AddError(errno.ErrTenantMismatch)
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			v := reflect.Indirect(rv.Index(i))
+			if v.Kind() == reflect.Struct {
+				assign(v)
+			}
+		}
+	case reflect.Struct:
+		assign(rv)
+	}
+}
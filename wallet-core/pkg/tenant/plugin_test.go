@@ -0,0 +1,100 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"wallet-core/pkg/errno"
+)
+
+type scopedRow struct {
+	ID       uint64
+	TenantID uint64
+	Email    string
+}
+
+type plainRow struct {
+	ID    uint64
+	Email string
+}
+
+// dryRunDB 只生成 SQL，不连接数据库
+func dryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(Plugin{}))
+	return db
+}
+
+func TestPluginScopesQueries(t *testing.T) {
+	db := dryRunDB(t)
+	ctx := WithTenant(context.Background(), 7)
+
+	stmt := db.WithContext(ctx).Where("email = ?", "a@example.com").Or("id = ?", 1).Find(&[]scopedRow{}).Statement
+	assert.Equal(t, `SELECT * FROM "scoped_rows" WHERE (email = $1 OR id = $2) AND "scoped_rows"."tenant_id" = $3`, stmt.SQL.String())
+	assert.Equal(t, []interface{}{"a@example.com", 1, uint64(7)}, stmt.Vars)
+
+	// 没有租户字段的表 / 系统调用不加条件
+	stmt = db.WithContext(ctx).Find(&[]plainRow{}).Statement
+	assert.Equal(t, `SELECT * FROM "plain_rows"`, stmt.SQL.String())
+	stmt = db.WithContext(context.Background()).Find(&[]scopedRow{}).Statement
+	assert.Equal(t, `SELECT * FROM "scoped_rows"`, stmt.SQL.String())
+}
+
+func TestPluginScopesUpdates(t *testing.T) {
+	db := dryRunDB(t)
+	ctx := WithTenant(context.Background(), 7)
+
+	stmt := db.WithContext(ctx).Model(&scopedRow{}).Where("email = ?", "a@example.com").Update("email", "b@example.com").Statement
+	assert.Contains(t, stmt.SQL.String(), `WHERE email = $2 AND "scoped_rows"."tenant_id" = $3`)
+
+	// 没有 WHERE 条件时不追加，交给 GORM 拦截
+	err := db.WithContext(ctx).Model(&scopedRow{}).Update("email", "b@example.com").Error
+	assert.ErrorIs(t, err, gorm.ErrMissingWhereClause)
+}
+
+func TestPluginAssignsTenantOnCreate(t *testing.T) {
+	db := dryRunDB(t)
+	ctx := WithTenant(context.Background(), 7)
+
+	row := scopedRow{Email: "a@example.com"}
+	require.NoError(t, db.WithContext(ctx).Create(&row).Error)
+	assert.Equal(t, uint64(7), row.TenantID)
+
+	rows := []scopedRow{{Email: "a@example.com"}, {Email: "b@example.com", TenantID: 7}}
+	require.NoError(t, db.WithContext(ctx).Create(&rows).Error)
+	assert.Equal(t, uint64(7), rows[0].TenantID)
+
+	err := db.WithContext(ctx).Create(&scopedRow{Email: "c@example.com", TenantID: 8}).Error
+	assert.ErrorIs(t, err, errno.ErrTenantMismatch)
+
+	// 系统调用保留原值
+	other := scopedRow{Email: "d@example.com", TenantID: 8}
+	require.NoError(t, db.Create(&other).Error)
+	assert.Equal(t, uint64(8), other.TenantID)
+}
+
+func TestScope(t *testing.T) {
+	db := dryRunDB(t)
+	stmt := db.Scopes(Scope(3)).Find(&[]scopedRow{}).Statement
+	assert.Equal(t, `SELECT * FROM "scoped_rows" WHERE "scoped_rows"."tenant_id" = $1`, stmt.SQL.String())
+}
+
+func TestFromContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+	assert.Equal(t, DefaultID, OrDefault(context.Background()))
+
+	id, ok := FromContext(WithTenant(context.Background(), 5))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), id)
+}
@@ -0,0 +1,60 @@
+// Package tenant 多租户 (品牌) 隔离
+// 租户 ID 随 context 传递: 用户请求由 Access Token 的 tid 声明确定，未登录的接口 (注册 / 登录 / 找回密码) 由 X-Tenant 头确定
+// 带 tenant_id 列的表 (users / addresses / deposits / withdrawals) 由 Plugin 按 context 中的租户自动加条件，
+// context 中没有租户时视为系统调用 (扫块 / 归集 / 后台任务 / 管理后台)，不加限制
+package tenant
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// DefaultID 默认租户，多租户之前的数据全部属于它，未指定租户的请求也落到它
+	DefaultID uint64 = 1
+
+	// Header / MetadataKey 未登录接口指定租户代码的请求头 / gRPC metadata
+	Header      = "X-Tenant"
+	MetadataKey = "x-tenant"
+
+	// Column 租户列名
+	Column = "tenant_id"
+)
+
+type ctxKey struct{}
+
+// WithTenant 把租户 ID 写入 context
+func WithTenant(ctx context.Context, id uint64) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext 取出租户 ID，系统调用 (未设置) 时 ok 为 false
+func FromContext(ctx context.Context) (uint64, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	id, ok := ctx.Value(ctxKey{}).(uint64)
+	return id, ok && id != 0
+}
+
+// OrDefault context 中的租户，未设置时为默认租户
+func OrDefault(ctx context.Context) uint64 {
+	if id, ok := FromContext(ctx); ok {
+		return id
+	}
+	return DefaultID
+}
+
+// Scope 把查询限定在某个租户内，用于系统调用中需要按租户查询的地方 (如 db.Scopes(tenant.Scope(id)))
+func Scope(id uint64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(condition(id))
+	}
+}
+
+// condition tenant_id = ?，列名带上当前表名，联表查询时不会有歧义
+func condition(id uint64) clause.Expression {
+	return clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: Column}, Value: id}
+}
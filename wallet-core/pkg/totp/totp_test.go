@@ -0,0 +1,60 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 附录 B 测试向量 (SHA1, 8 位)
+func TestHOTPVectors(t *testing.T) {
+	key := []byte("12345678901234567890")
+	vectors := map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	}
+	for ts, want := range vectors {
+		assert.Equal(t, want, hotp(key, ts/Period, 8), "t=%d", ts)
+	}
+}
+
+func TestCodeAndValidate(t *testing.T) {
+	secret := b32.EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(59, 0)
+
+	code, err := Code(secret, now)
+	require.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	step, ok := Validate(secret, code, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), step)
+
+	// 允许一个步长的时钟偏差
+	_, ok = Validate(secret, code, now.Add(Period*time.Second), 1)
+	assert.True(t, ok)
+	_, ok = Validate(secret, code, now.Add(3*Period*time.Second), 1)
+	assert.False(t, ok)
+
+	_, ok = Validate(secret, "000000", now, 1)
+	assert.False(t, ok)
+	_, ok = Validate(secret, "12345", now, 1)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecretAndURI(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	uri := ProvisioningURI("WalletCore", "alice@example.com", secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/WalletCore:alice@example.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+}
@@ -0,0 +1,108 @@
+package ethtx
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// 交易类型 (对应配置 chains.<chain>.tx_type)
+const (
+	TxTypeLegacy  = "legacy"  // 单一 GasPrice (EIP-155)
+	TxTypeDynamic = "dynamic" // EIP-1559: MaxFeePerGas + MaxPriorityFeePerGas
+)
+
+var defaultTipCap = big.NewInt(1_000_000_000) // 1 Gwei: 拿不到历史数据时的兜底小费
+
+// Fees 交易的手续费参数
+// Legacy 交易只使用 GasPrice; Dynamic 交易使用 GasTipCap / GasFeeCap
+type Fees struct {
+	Dynamic   bool
+	GasPrice  *big.Int // Legacy: Gas 单价
+	GasTipCap *big.Int // Dynamic: maxPriorityFeePerGas (给矿工/验证者的小费)
+	GasFeeCap *big.Int // Dynamic: maxFeePerGas (愿意支付的最高单价 = BaseFee + 小费)
+}
+
+// MaxPrice 每单位 Gas 最多支付的价格，用于预留手续费 (余额 - GasLimit * MaxPrice)
+func (f Fees) MaxPrice() *big.Int {
+	if f.Dynamic {
+		return f.GasFeeCap
+	}
+	return f.GasPrice
+}
+
+// DynamicFeesFromHistory 根据 eth_feeHistory 结果计算 EIP-1559 手续费
+// - 小费: 各区块小费中位数的中位数
+// - MaxFeePerGas = 2 * 下一个区块 BaseFee + 小费
+// BaseFee 每个区块最多上涨 12.5%，2 倍可以扛住连续 6 个满块
+func DynamicFeesFromHistory(history *ethereum.FeeHistory, fallbackTip *big.Int) (Fees, error) {
+	return DynamicFeesAt(history, 0, fallbackTip)
+}
+
+// DynamicFeesAt 与 DynamicFeesFromHistory 相同，但小费取 rewardPercentiles 中第 idx 个百分位
+// 用于一次 eth_feeHistory 请求同时算出 慢 / 标准 / 快 多档手续费
+func DynamicFeesAt(history *ethereum.FeeHistory, idx int, fallbackTip *big.Int) (Fees, error) {
+	if history == nil || len(history.BaseFee) == 0 {
+		return Fees{}, errors.New("eth_feeHistory 没有返回 BaseFee (节点不支持 EIP-1559?)")
+	}
+	// BaseFee 比 Reward 多一个元素，最后一个是下一个区块的 BaseFee
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	var tips []*big.Int
+	for _, rewards := range history.Reward {
+		if len(rewards) > idx && rewards[idx] != nil && rewards[idx].Sign() > 0 {
+			tips = append(tips, rewards[idx])
+		}
+	}
+
+	tip := fallbackTip
+	if len(tips) > 0 {
+		sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+		tip = tips[len(tips)/2]
+	}
+	if tip == nil {
+		tip = defaultTipCap
+	}
+
+	feeCap := new(big.Int).Mul(baseFee, big.NewInt(2))
+	feeCap.Add(feeCap, tip)
+
+	return Fees{
+		Dynamic:   true,
+		GasTipCap: new(big.Int).Set(tip),
+		GasFeeCap: feeCap,
+	}, nil
+}
+
+// NewTx 按手续费类型构造未签名交易
+func NewTx(chainID *big.Int, nonce uint64, to common.Address, value *big.Int, gasLimit uint64, fees Fees, data []byte) *types.Transaction {
+	if fees.Dynamic {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: fees.GasTipCap,
+			GasFeeCap: fees.GasFeeCap,
+			Gas:       gasLimit,
+			To:        &to,
+			Value:     value,
+			Data:      data,
+		})
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: fees.GasPrice,
+		Gas:      gasLimit,
+		To:       &to,
+		Value:    value,
+		Data:     data,
+	})
+}
+
+// Signer 返回同时支持 Legacy (EIP-155) 和 Dynamic (EIP-1559) 交易的签名器
+func Signer(chainID *big.Int) types.Signer {
+	return types.LatestSignerForChainID(chainID)
+}
@@ -0,0 +1,78 @@
+package ethtx
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1_000_000_000))
+}
+
+func TestDynamicFeesFromHistory(t *testing.T) {
+	history := &ethereum.FeeHistory{
+		Reward: [][]*big.Int{
+			{gwei(1)}, {gwei(3)}, {gwei(2)}, {big.NewInt(0)}, // 空块的 0 小费不参与计算
+		},
+		BaseFee: []*big.Int{gwei(10), gwei(11), gwei(12), gwei(13), gwei(14)},
+	}
+
+	fees, err := DynamicFeesFromHistory(history, gwei(5))
+	require.NoError(t, err)
+	assert.True(t, fees.Dynamic)
+	assert.Equal(t, 0, gwei(2).Cmp(fees.GasTipCap), "tip: %s", fees.GasTipCap)
+	// 2 * 14 (下一个区块 BaseFee) + 2
+	assert.Equal(t, 0, gwei(30).Cmp(fees.GasFeeCap), "feeCap: %s", fees.GasFeeCap)
+	assert.Equal(t, fees.GasFeeCap, fees.MaxPrice())
+}
+
+func TestDynamicFeesFromHistory_Fallback(t *testing.T) {
+	history := &ethereum.FeeHistory{
+		Reward:  [][]*big.Int{{big.NewInt(0)}},
+		BaseFee: []*big.Int{gwei(10), gwei(10)},
+	}
+
+	fees, err := DynamicFeesFromHistory(history, gwei(5))
+	require.NoError(t, err)
+	assert.Equal(t, 0, gwei(5).Cmp(fees.GasTipCap))
+	assert.Equal(t, 0, gwei(25).Cmp(fees.GasFeeCap))
+
+	_, err = DynamicFeesFromHistory(&ethereum.FeeHistory{}, gwei(5))
+	assert.Error(t, err)
+}
+
+func TestNewTxAndSign(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(11155111)
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+	tests := []struct {
+		name     string
+		fees     Fees
+		wantType uint8
+	}{
+		{"legacy", Fees{GasPrice: gwei(20)}, types.LegacyTxType},
+		{"dynamic", Fees{Dynamic: true, GasTipCap: gwei(2), GasFeeCap: gwei(30)}, types.DynamicFeeTxType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := NewTx(chainID, 7, to, big.NewInt(1), 21000, tt.fees, nil)
+			signed, err := types.SignTx(tx, Signer(chainID), key)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantType, signed.Type())
+			sender, err := types.Sender(Signer(chainID), signed)
+			require.NoError(t, err)
+			assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), sender)
+		})
+	}
+}
@@ -9,9 +9,14 @@ type UnsignedTransaction struct {
 	Amount   string `json:"amount"`         // Amount in base unit (Wei, Satoshi)
 	Nonce    uint64 `json:"nonce"`          // Account Nonce (ETH)
 	GasLimit uint64 `json:"gas_limit"`      // Gas Limit (ETH)
-	GasPrice string `json:"gas_price"`      // Gas Price in Wei (ETH)
+	GasPrice string `json:"gas_price"`      // Gas Price in Wei (ETH, legacy only)
 	Data     string `json:"data,omitempty"` // Contract Data (Hex)
 
+	// EIP-1559 (type-2) fields. Type 0 (or omitted) means a legacy transaction.
+	Type                 uint8  `json:"type,omitempty"`                     // 0: Legacy, 2: DynamicFee
+	MaxFeePerGas         string `json:"max_fee_per_gas,omitempty"`          // Wei
+	MaxPriorityFeePerGas string `json:"max_priority_fee_per_gas,omitempty"` // Wei
+
 	// DerivationPath is crucial for the signer to know which key to use
 	// e.g., "m/44'/60'/0'/0/0"
 	DerivationPath string `json:"derivation_path"`